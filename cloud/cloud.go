@@ -8,7 +8,13 @@ import (
 	biproperty "github.com/cloudfoundry/bosh-utils/property"
 )
 
-const MaxCpiApiVersionSupported = 2
+const MaxCpiApiVersionSupported = 3
+
+// CPIs advertising this API version return VM networks from create_vm and avoid the registry
+const RegistrylessCpiApiVersion = 2
+
+// CPIs advertising this API version resize and update detached disks natively
+const DiskUpdateAsOfCpiApiVersion = 3
 
 // The agent on stemcells with version 2
 // will avoid the registry IFF CPI and Director support CPI API v2 (above)
@@ -34,6 +40,10 @@ type Cloud interface {
 	AttachDisk(vmCID, diskCID string) (interface{}, error)
 	DetachDisk(vmCID, diskCID string) error
 	DeleteDisk(diskCID string) error
+	ResizeDisk(diskCID string, newSize int) error
+	UpdateDisk(diskCID string, newSize int, cloudProperties biproperty.Map) (newDiskCID string, err error)
+	SnapshotDisk(diskCID string, metadata DiskMetadata) (snapshotCID string, err error)
+	DeleteSnapshot(snapshotCID string) error
 	Info() (cpiInfo CpiInfo, err error)
 	fmt.Stringer
 }
@@ -397,6 +407,115 @@ func (c cloud) DeleteDisk(diskCID string) error {
 	return nil
 }
 
+func (c cloud) ResizeDisk(diskCID string, newSize int) error {
+	c.logger.Debug(c.logTag, "Resizing disk '%s' to size %d", diskCID, newSize)
+
+	cpiInfo, err := c.Info()
+	if err != nil {
+		return err
+	}
+
+	method := "resize_disk"
+	cmdOutput, err := c.cpiCmdRunner.Run(c.context, method, cpiInfo.ApiVersion, diskCID, newSize)
+	if err != nil {
+		return bosherr.WrapError(err, "Calling CPI 'resize_disk' method")
+	}
+
+	if cmdOutput.Error != nil {
+		return NewCPIError(method, *cmdOutput.Error)
+	}
+
+	return nil
+}
+
+func (c cloud) UpdateDisk(diskCID string, newSize int, cloudProperties biproperty.Map) (string, error) {
+	c.logger.Debug(c.logTag,
+		"Updating disk '%s' to size %d, cloudProperties %#v",
+		diskCID,
+		newSize,
+		cloudProperties,
+	)
+
+	cpiInfo, err := c.Info()
+	if err != nil {
+		return "", err
+	}
+
+	method := "update_disk"
+	cmdOutput, err := c.cpiCmdRunner.Run(
+		c.context,
+		method,
+		cpiInfo.ApiVersion,
+		diskCID,
+		newSize,
+		cloudProperties,
+	)
+	if err != nil {
+		return "", bosherr.WrapError(err, "Calling CPI 'update_disk' method")
+	}
+
+	if cmdOutput.Error != nil {
+		return "", NewCPIError(method, *cmdOutput.Error)
+	}
+
+	// CPIs which update the disk in place do not return a new disk cid
+	if cmdOutput.Result == nil {
+		return diskCID, nil
+	}
+
+	cidString, ok := cmdOutput.Result.(string)
+	if !ok {
+		return "", bosherr.Errorf("Unexpected external CPI command result: '%#v'", cmdOutput.Result)
+	}
+	return cidString, nil
+}
+
+func (c cloud) SnapshotDisk(diskCID string, metadata DiskMetadata) (string, error) {
+	c.logger.Debug(c.logTag, "Snapshotting disk '%s'", diskCID)
+
+	cpiInfo, err := c.Info()
+	if err != nil {
+		return "", err
+	}
+
+	method := "snapshot_disk"
+	cmdOutput, err := c.cpiCmdRunner.Run(c.context, method, cpiInfo.ApiVersion, diskCID, metadata)
+	if err != nil {
+		return "", bosherr.WrapError(err, "Calling CPI 'snapshot_disk' method")
+	}
+
+	if cmdOutput.Error != nil {
+		return "", NewCPIError(method, *cmdOutput.Error)
+	}
+
+	cidString, ok := cmdOutput.Result.(string)
+	if !ok {
+		return "", bosherr.Errorf("Unexpected external CPI command result: '%#v'", cmdOutput.Result)
+	}
+	return cidString, nil
+}
+
+func (c cloud) DeleteSnapshot(snapshotCID string) error {
+	c.logger.Debug(c.logTag, "Deleting snapshot '%s'", snapshotCID)
+
+	cpiInfo, err := c.Info()
+	if err != nil {
+		return err
+	}
+
+	method := "delete_snapshot"
+	cmdOutput, err := c.cpiCmdRunner.Run(c.context, method, cpiInfo.ApiVersion, snapshotCID)
+	if err != nil {
+		return bosherr.WrapError(err, "Calling CPI 'delete_snapshot' method")
+	}
+
+	if cmdOutput.Error != nil {
+		return NewCPIError(method, *cmdOutput.Error)
+	}
+
+	return nil
+}
+
 func (c cloud) Info() (cpiInfo CpiInfo, err error) {
 	c.logger.Debug(c.logTag, "Info")
 
@@ -472,5 +591,5 @@ func (c cloud) String() string {
 }
 
 func (c cloud) shouldInterpretV2Contract(cpiApiVersion int) bool {
-	return cpiApiVersion == MaxCpiApiVersionSupported
+	return cpiApiVersion >= RegistrylessCpiApiVersion
 }
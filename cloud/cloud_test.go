@@ -130,7 +130,7 @@ var _ = Describe("Cloud", func() {
 				})
 			})
 
-			Context("when the cpi version is > 2", func() {
+			Context("when the cpi version is > MaxCpiApiVersionSupported", func() {
 				It("should return MAX supported version by CLI", func() {
 					infoResult = map[string]interface{}{
 						"stemcell_formats": []interface{}{"aws-raw", "aws-light"},
@@ -138,7 +138,7 @@ var _ = Describe("Cloud", func() {
 					}
 					infoParsed := CpiInfo{
 						StemcellFormats: []string{"aws-raw", "aws-light"},
-						ApiVersion:      2,
+						ApiVersion:      MaxCpiApiVersionSupported,
 					}
 					fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{{
 						Result: infoResult,
@@ -892,4 +892,128 @@ var _ = Describe("Cloud", func() {
 			return cloud.DeleteDisk("fake-disk-cid")
 		})
 	})
+
+	Describe("ResizeDisk", func() {
+		It("executes the cpi job script with the correct arguments", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResultWithApiV2},
+				{Result: nil},
+			}
+
+			err := cloud.ResizeDisk("fake-disk-cid", 2048)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeCPICmdRunner.CurrentRunInput).To(HaveLen(2))
+			Expect(fakeCPICmdRunner.CurrentRunInput[1]).To(Equal(fakebicloud.RunInput{
+				Context:    expectedContext,
+				Method:     "resize_disk",
+				Arguments:  []interface{}{"fake-disk-cid", 2048},
+				ApiVersion: 2,
+			}))
+		})
+
+		It("returns a not implemented error when the cpi does not know the method", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResult},
+				{Error: &CmdError{Type: "Bosh::Clouds::CloudError", Message: "Invalid Method: resize_disk"}},
+			}
+
+			err := cloud.ResizeDisk("fake-disk-cid", 2048)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(Error).Type()).To(Equal(NotImplementedError))
+		})
+
+		itHandlesCPIErrors("resize_disk", func() error {
+			return cloud.ResizeDisk("fake-disk-cid", 2048)
+		})
+	})
+
+	Describe("UpdateDisk", func() {
+		var cloudProperties biproperty.Map
+
+		BeforeEach(func() {
+			cloudProperties = biproperty.Map{
+				"fake-cloud-property-key": "fake-cloud-property-value",
+			}
+		})
+
+		It("executes the cpi job script with the correct arguments", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResultWithApiV2},
+				{Result: "fake-new-disk-cid"},
+			}
+
+			cid, err := cloud.UpdateDisk("fake-disk-cid", 2048, cloudProperties)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cid).To(Equal("fake-new-disk-cid"))
+			Expect(fakeCPICmdRunner.CurrentRunInput[1]).To(Equal(fakebicloud.RunInput{
+				Context:    expectedContext,
+				Method:     "update_disk",
+				Arguments:  []interface{}{"fake-disk-cid", 2048, cloudProperties},
+				ApiVersion: 2,
+			}))
+		})
+
+		It("returns the original cid when the cpi updates the disk in place", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResult},
+				{Result: nil},
+			}
+
+			cid, err := cloud.UpdateDisk("fake-disk-cid", 2048, cloudProperties)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cid).To(Equal("fake-disk-cid"))
+		})
+
+		itHandlesCPIErrors("update_disk", func() error {
+			_, err := cloud.UpdateDisk("fake-disk-cid", 2048, cloudProperties)
+			return err
+		})
+	})
+
+	Describe("SnapshotDisk", func() {
+		It("executes the cpi job script with the correct arguments", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResultWithApiV2},
+				{Result: "fake-snapshot-cid"},
+			}
+
+			metadata := DiskMetadata{"director": "bosh-init"}
+			cid, err := cloud.SnapshotDisk("fake-disk-cid", metadata)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cid).To(Equal("fake-snapshot-cid"))
+			Expect(fakeCPICmdRunner.CurrentRunInput[1]).To(Equal(fakebicloud.RunInput{
+				Context:    expectedContext,
+				Method:     "snapshot_disk",
+				Arguments:  []interface{}{"fake-disk-cid", metadata},
+				ApiVersion: 2,
+			}))
+		})
+
+		itHandlesCPIErrors("snapshot_disk", func() error {
+			_, err := cloud.SnapshotDisk("fake-disk-cid", DiskMetadata{})
+			return err
+		})
+	})
+
+	Describe("DeleteSnapshot", func() {
+		It("executes the cpi job script with the correct arguments", func() {
+			fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{
+				{Result: infoResultWithApiV2},
+				{Result: nil},
+			}
+
+			err := cloud.DeleteSnapshot("fake-snapshot-cid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeCPICmdRunner.CurrentRunInput[1]).To(Equal(fakebicloud.RunInput{
+				Context:    expectedContext,
+				Method:     "delete_snapshot",
+				Arguments:  []interface{}{"fake-snapshot-cid"},
+				ApiVersion: 2,
+			}))
+		})
+
+		itHandlesCPIErrors("delete_snapshot", func() error {
+			return cloud.DeleteSnapshot("fake-snapshot-cid")
+		})
+	})
 })
@@ -0,0 +1,172 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+const redactedValue = "<redacted>"
+
+var (
+	secretKeyRegexp   = regexp.MustCompile(`(?i)(password|secret|private_key|token|credential)`)
+	urlUserInfoRegexp = regexp.MustCompile(`://([^:/@\s]+):([^@\s]+)@`)
+
+	// quotedSecretRegexp and plainSecretRegexp find secret looking key/value pairs in logs,
+	// e.g. `"password":"value"`, `"password"=>"value"` or `password: value`
+	quotedSecretRegexp = regexp.MustCompile(`(?i)("[^"]*(?:password|secret|private_key|token|credential)[^"]*"\s*(?::|=>)\s*)"(?:[^"\\]|\\.)*"`)
+	plainSecretRegexp  = regexp.MustCompile(`(?i)(\b\w*(?:password|secret|private_key|token|credential)\w*\s*[:=]\s*)([^\s"',}]+)`)
+)
+
+// CPICall is a single CPI method invocation as sent to and received from the CPI binary
+type CPICall struct {
+	Method  string   `json:"method"`
+	Request CmdInput `json:"request"`
+
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+	Error    string `json:"error,omitempty"`
+
+	StartedAt time.Time `json:"started_at"`
+	Duration  float64   `json:"duration_seconds"`
+}
+
+type CPICallRecorder interface {
+	Record(CPICall) error
+}
+
+type fsCPICallRecorder struct {
+	dir string
+	fs  boshsys.FileSystem
+
+	lock sync.Mutex
+	seq  int
+}
+
+// NewFSCPICallRecorder saves every CPI call into its own JSON file in dir.
+// Secrets in requests and CPI output are redacted so that recordings can be shared.
+func NewFSCPICallRecorder(dir string, fs boshsys.FileSystem) CPICallRecorder {
+	return &fsCPICallRecorder{dir: dir, fs: fs}
+}
+
+func (r *fsCPICallRecorder) Record(call CPICall) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	err := r.fs.MkdirAll(r.dir, os.ModePerm)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating CPI recording directory '%s'", r.dir)
+	}
+
+	call.Request.Arguments = redactArguments(call.Request.Arguments)
+	call.Stdout = redactStdout(call.Stdout)
+	call.Stderr = redactText(call.Stderr)
+	call.Error = redactText(call.Error)
+
+	bytes, err := json.MarshalIndent(call, "", "  ")
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Marshalling CPI call '%s'", call.Method)
+	}
+
+	r.seq++
+
+	path := filepath.Join(r.dir, fmt.Sprintf("%04d-%s.json", r.seq, call.Method))
+
+	err = r.fs.WriteFile(path, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing CPI call recording '%s'", path)
+	}
+
+	return nil
+}
+
+func redactArguments(args []interface{}) []interface{} {
+	redacted, ok := redactSecrets(args).([]interface{})
+	if !ok || redacted == nil {
+		return []interface{}{}
+	}
+	return redacted
+}
+
+// redactStdout redacts CPI response; CPIs may include whole requests in their logs
+func redactStdout(stdout string) string {
+	var output CmdOutput
+
+	err := json.Unmarshal([]byte(stdout), &output)
+	if err != nil {
+		return redactText(stdout)
+	}
+
+	output.Result = redactSecrets(output.Result)
+	output.Log = redactText(output.Log)
+
+	if output.Error != nil {
+		output.Error.Message = redactText(output.Error.Message)
+	}
+
+	bytes, err := json.Marshal(output)
+	if err != nil {
+		return redactText(stdout)
+	}
+
+	return string(bytes)
+}
+
+// redactText redacts secrets from free form text such as CPI logs
+func redactText(text string) string {
+	text = urlUserInfoRegexp.ReplaceAllString(text, "://$1:"+redactedValue+"@")
+	text = quotedSecretRegexp.ReplaceAllString(text, `${1}"`+redactedValue+`"`)
+	return plainSecretRegexp.ReplaceAllString(text, "${1}"+redactedValue)
+}
+
+// redactSecrets returns a copy of JSON-like value with secret looking values replaced
+func redactSecrets(value interface{}) interface{} {
+	// Round trip through JSON to only deal with generic types
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var generic interface{}
+
+	err = json.Unmarshal(bytes, &generic)
+	if err != nil {
+		return value
+	}
+
+	return redactValue(generic)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for k, v := range typedValue {
+			if _, isString := v.(string); isString && secretKeyRegexp.MatchString(k) {
+				typedValue[k] = redactedValue
+			} else {
+				typedValue[k] = redactValue(v)
+			}
+		}
+		return typedValue
+
+	case []interface{}:
+		for i, v := range typedValue {
+			typedValue[i] = redactValue(v)
+		}
+		return typedValue
+
+	case string:
+		return urlUserInfoRegexp.ReplaceAllString(typedValue, "://$1:"+redactedValue+"@")
+
+	default:
+		return value
+	}
+}
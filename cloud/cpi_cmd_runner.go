@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
@@ -67,6 +68,7 @@ type CPICmdRunner interface {
 type cpiCmdRunner struct {
 	cmdRunner boshsys.CmdRunner
 	cpi       CPI
+	recorder  CPICallRecorder
 	logger    boshlog.Logger
 	logTag    string
 }
@@ -75,10 +77,21 @@ func NewCPICmdRunner(
 	cmdRunner boshsys.CmdRunner,
 	cpi CPI,
 	logger boshlog.Logger,
+) CPICmdRunner {
+	return NewRecordingCPICmdRunner(cmdRunner, cpi, nil, logger)
+}
+
+// NewRecordingCPICmdRunner additionally passes every call to recorder (if non-nil)
+func NewRecordingCPICmdRunner(
+	cmdRunner boshsys.CmdRunner,
+	cpi CPI,
+	recorder CPICallRecorder,
+	logger boshlog.Logger,
 ) CPICmdRunner {
 	return &cpiCmdRunner{
 		cmdRunner: cmdRunner,
 		cpi:       cpi,
+		recorder:  recorder,
 		logger:    logger,
 		logTag:    "cpiCmdRunner",
 	}
@@ -109,8 +122,30 @@ func (r *cpiCmdRunner) Run(context CmdContext, method string, apiVersion int, ar
 		Stdin: bytes.NewReader(inputBytes),
 	}
 
+	startedAt := time.Now()
 	stdout, stderr, exitCode, err := r.cmdRunner.RunComplexCommand(cmd)
 	r.logger.Debug(r.logTag, "Exit Code %d when executing external CPI command '%s'\nSTDIN: '%s'\nSTDOUT: '%s'\nSTDERR: '%s'", exitCode, cmdPath, string(inputBytes), stdout, stderr)
+
+	if r.recorder != nil {
+		call := CPICall{
+			Method:    method,
+			Request:   cmdInput,
+			Stdout:    stdout,
+			Stderr:    stderr,
+			ExitCode:  exitCode,
+			StartedAt: startedAt.UTC(),
+			Duration:  time.Since(startedAt).Seconds(),
+		}
+		if err != nil {
+			call.Error = err.Error()
+		}
+
+		recErr := r.recorder.Record(call)
+		if recErr != nil {
+			r.logger.Error(r.logTag, "Failed to record CPI call '%s': %s", method, recErr)
+		}
+	}
+
 	if err != nil {
 		return CmdOutput{}, bosherr.WrapErrorf(err, "Executing external CPI command: '%s'", cmdPath)
 	}
@@ -12,25 +12,47 @@ type Factory interface {
 	NewCloud(installation biinstall.Installation, directorID string, stemcellApiVersion int) (Cloud, error)
 }
 
+type RecordingOpts struct {
+	// RecordDir saves every CPI call into the directory (optional)
+	RecordDir string
+	// ReplayDir serves CPI calls from recordings instead of running the CPI (optional)
+	ReplayDir string
+}
+
 type factory struct {
-	fs        boshsys.FileSystem
-	cmdRunner boshsys.CmdRunner
-	logger    boshlog.Logger
+	fs            boshsys.FileSystem
+	cmdRunner     boshsys.CmdRunner
+	recordingOpts RecordingOpts
+	logger        boshlog.Logger
 }
 
 func NewFactory(
 	fs boshsys.FileSystem,
 	cmdRunner boshsys.CmdRunner,
+	recordingOpts RecordingOpts,
 	logger boshlog.Logger,
 ) Factory {
 	return &factory{
-		fs:        fs,
-		cmdRunner: cmdRunner,
-		logger:    logger,
+		fs:            fs,
+		cmdRunner:     cmdRunner,
+		recordingOpts: recordingOpts,
+		logger:        logger,
 	}
 }
 
 func (f *factory) NewCloud(installation biinstall.Installation, directorID string, stemcellApiVersion int) (Cloud, error) {
+	if len(f.recordingOpts.RecordDir) > 0 && len(f.recordingOpts.ReplayDir) > 0 {
+		return nil, bosherr.Error("Expected CPI calls to be either recorded or replayed, not both")
+	}
+
+	if len(f.recordingOpts.ReplayDir) > 0 {
+		cpiCmdRunner, err := NewReplayingCPICmdRunner(f.recordingOpts.ReplayDir, f.fs, f.logger)
+		if err != nil {
+			return nil, err
+		}
+		return NewCloud(cpiCmdRunner, directorID, stemcellApiVersion, f.logger), nil
+	}
+
 	numberCpiBinariesFound := 0
 	foundCPI := CPI{}
 
@@ -53,6 +75,12 @@ func (f *factory) NewCloud(installation biinstall.Installation, directorID strin
 		return nil, bosherr.Errorf("Found %d Jobs with a 'bin/cpi' binary. Expected 1.", numberCpiBinariesFound)
 	}
 
-	cpiCmdRunner := NewCPICmdRunner(f.cmdRunner, foundCPI, f.logger)
+	var recorder CPICallRecorder
+
+	if len(f.recordingOpts.RecordDir) > 0 {
+		recorder = NewFSCPICallRecorder(f.recordingOpts.RecordDir, f.fs)
+	}
+
+	cpiCmdRunner := NewRecordingCPICmdRunner(f.cmdRunner, foundCPI, recorder, f.logger)
 	return NewCloud(cpiCmdRunner, directorID, stemcellApiVersion, f.logger), nil
 }
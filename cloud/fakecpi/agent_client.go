@@ -0,0 +1,212 @@
+package fakecpi
+
+import (
+	"errors"
+
+	biagentclient "github.com/cloudfoundry/bosh-agent/v2/agentclient"
+	"github.com/cloudfoundry/bosh-agent/v2/agentclient/applyspec"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+type agentClient struct {
+	store     *Store
+	blobstore blobstore
+	logger    boshlog.Logger
+	logTag    string
+}
+
+// NewAgentClient returns an agent client which answers on behalf of the agent
+// running on the most recently created fake VM.
+func NewAgentClient(store *Store, logger boshlog.Logger) biagentclient.AgentClient {
+	return agentClient{
+		store:     store,
+		blobstore: blobstore{store: store, logger: logger},
+		logger:    logger,
+		logTag:    "fakeAgentClient",
+	}
+}
+
+func (c agentClient) Ping() (string, error) {
+	return "pong", c.withAgent(func(*VM) error { return nil })
+}
+
+func (c agentClient) Stop() error {
+	return c.withAgent(func(vm *VM) error {
+		vm.Agent.JobState = "stopped"
+		return nil
+	})
+}
+
+func (c agentClient) Drain(string) (int64, error) {
+	return 0, c.withAgent(func(*VM) error { return nil })
+}
+
+func (c agentClient) Apply(spec applyspec.ApplySpec) error {
+	return c.withAgent(func(vm *VM) error {
+		vm.Agent.Spec = &spec
+		return nil
+	})
+}
+
+func (c agentClient) Start() error {
+	return c.withAgent(func(vm *VM) error {
+		if vm.Agent.Spec == nil {
+			return bosherr.Error("Cannot start jobs before apply")
+		}
+		vm.Agent.JobState = "running"
+		return nil
+	})
+}
+
+func (c agentClient) GetState() (biagentclient.AgentState, error) {
+	var agentState biagentclient.AgentState
+
+	err := c.withAgent(func(vm *VM) error {
+		agentState.JobState = vm.Agent.JobState
+		agentState.NetworkSpecs = map[string]biagentclient.NetworkSpec{}
+
+		if vm.Agent.Spec != nil {
+			for name, network := range vm.Agent.Spec.Networks {
+				networkMap, _ := network.(map[string]interface{})
+				ip, _ := networkMap["ip"].(string)
+				agentState.NetworkSpecs[name] = biagentclient.NetworkSpec{IP: ip}
+			}
+		}
+
+		return nil
+	})
+
+	return agentState, err
+}
+
+func (c agentClient) AddPersistentDisk(diskCID string, _ interface{}) error {
+	return c.withAgent(func(vm *VM) error {
+		if !contains(vm.DiskCIDs, diskCID) {
+			return bosherr.Errorf("Disk '%s' is not attached to VM '%s'", diskCID, vm.CID)
+		}
+		if !contains(vm.Agent.PersistentDisks, diskCID) {
+			vm.Agent.PersistentDisks = append(vm.Agent.PersistentDisks, diskCID)
+		}
+		return nil
+	})
+}
+
+func (c agentClient) RemovePersistentDisk(diskCID string) error {
+	return c.withAgent(func(vm *VM) error {
+		vm.Agent.PersistentDisks = without(vm.Agent.PersistentDisks, diskCID)
+		vm.Agent.MountedDisks = without(vm.Agent.MountedDisks, diskCID)
+		return nil
+	})
+}
+
+func (c agentClient) MountDisk(diskCID string) error {
+	return c.withAgent(func(vm *VM) error {
+		if !contains(vm.DiskCIDs, diskCID) {
+			return bosherr.Errorf("Disk '%s' is not attached to VM '%s'", diskCID, vm.CID)
+		}
+		if !contains(vm.Agent.MountedDisks, diskCID) {
+			vm.Agent.MountedDisks = append(vm.Agent.MountedDisks, diskCID)
+		}
+		return nil
+	})
+}
+
+func (c agentClient) UnmountDisk(diskCID string) error {
+	return c.withAgent(func(vm *VM) error {
+		vm.Agent.MountedDisks = without(vm.Agent.MountedDisks, diskCID)
+		return nil
+	})
+}
+
+func (c agentClient) ListDisk() ([]string, error) {
+	var disks []string
+
+	err := c.withAgent(func(vm *VM) error {
+		disks = append([]string{}, vm.Agent.MountedDisks...)
+		return nil
+	})
+
+	return disks, err
+}
+
+func (c agentClient) MigrateDisk() error {
+	return c.withAgent(func(*VM) error { return nil })
+}
+
+// CompilePackage pretends that the package source is its compiled form
+func (c agentClient) CompilePackage(packageSource biagentclient.BlobRef, _ []biagentclient.BlobRef) (biagentclient.BlobRef, error) {
+	err := c.withAgent(func(*VM) error { return nil })
+	if err != nil {
+		return biagentclient.BlobRef{}, err
+	}
+
+	blobID, sha1, err := c.blobstore.Copy(packageSource.BlobstoreID)
+	if err != nil {
+		return biagentclient.BlobRef{}, bosherr.WrapErrorf(err, "Compiling package '%s'", packageSource.Name)
+	}
+
+	return biagentclient.BlobRef{
+		Name:        packageSource.Name,
+		Version:     packageSource.Version,
+		BlobstoreID: blobID,
+		SHA1:        sha1,
+	}, nil
+}
+
+func (c agentClient) DeleteARPEntries([]string) error {
+	return c.withAgent(func(*VM) error { return nil })
+}
+
+func (c agentClient) SyncDNS(string, string, uint64) (string, error) {
+	return "synced", c.withAgent(func(*VM) error { return nil })
+}
+
+func (c agentClient) RunScript(string, map[string]interface{}) error {
+	return c.withAgent(func(*VM) error { return nil })
+}
+
+func (c agentClient) SetUpSSH(string, string) (biagentclient.SSHResult, error) {
+	return biagentclient.SSHResult{}, errors.New("SSH is not supported by the fake agent")
+}
+
+func (c agentClient) CleanUpSSH(string) (biagentclient.SSHResult, error) {
+	return biagentclient.SSHResult{}, errors.New("SSH is not supported by the fake agent")
+}
+
+func (c agentClient) BundleLogs(string, string, []string) (biagentclient.BundleLogsResult, error) {
+	return biagentclient.BundleLogsResult{}, errors.New("Fetching logs is not supported by the fake agent")
+}
+
+func (c agentClient) RemoveFile(string) error {
+	return c.withAgent(func(*VM) error { return nil })
+}
+
+func (c agentClient) withAgent(fn func(*VM) error) error {
+	return c.store.Update(func(state *State) error {
+		vm, found := state.CurrentVM()
+		if !found {
+			return bosherr.Error("Fake agent is not reachable: no VM exists")
+		}
+		return fn(vm)
+	})
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+func without(items []string, item string) []string {
+	var result []string
+	for _, i := range items {
+		if i != item {
+			result = append(result, i)
+		}
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package fakecpi_test
+
+import (
+	"os"
+	"path/filepath"
+
+	biagentclient "github.com/cloudfoundry/bosh-agent/v2/agentclient"
+	"github.com/cloudfoundry/bosh-agent/v2/agentclient/applyspec"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
+	. "github.com/cloudfoundry/bosh-cli/v7/cloud/fakecpi"
+)
+
+var _ = Describe("AgentClient", func() {
+	var (
+		store       *Store
+		cloud       bicloud.Cloud
+		agentClient biagentclient.AgentClient
+		vmCID       string
+	)
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		fs := boshsys.NewOsFileSystem(logger)
+
+		store = NewStore(filepath.Join(GinkgoT().TempDir(), "fake-cpi"), fs, fakeuuid.NewFakeGenerator())
+		cloud = NewCloud(store, logger)
+		agentClient = NewAgentClient(store, logger)
+	})
+
+	createVM := func() {
+		stemcellCID, err := cloud.CreateStemcell("/image", biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+
+		vmCID, err = cloud.CreateVM("agent-id", stemcellCID, biproperty.Map{}, nil, nil, biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("is not reachable without a VM", func() {
+		_, err := agentClient.Ping()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no VM exists"))
+	})
+
+	It("runs jobs after apply and start", func() {
+		createVM()
+
+		Expect(agentClient.Ping()).To(Equal("pong"))
+		Expect(agentClient.Start()).ToNot(Succeed())
+
+		err := agentClient.Apply(applyspec.ApplySpec{
+			Name:     "env",
+			Networks: map[string]interface{}{"default": map[string]interface{}{"ip": "10.0.0.6"}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(agentClient.Start()).To(Succeed())
+
+		state, err := agentClient.GetState()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state).To(Equal(biagentclient.AgentState{
+			JobState:     "running",
+			NetworkSpecs: map[string]biagentclient.NetworkSpec{"default": {IP: "10.0.0.6"}},
+		}))
+
+		Expect(agentClient.Stop()).To(Succeed())
+
+		state, err = agentClient.GetState()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.JobState).To(Equal("stopped"))
+	})
+
+	It("only mounts disks attached to its VM", func() {
+		createVM()
+
+		diskCID, err := cloud.CreateDisk(1024, biproperty.Map{}, vmCID)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(agentClient.MountDisk(diskCID)).ToNot(Succeed())
+
+		_, err = cloud.AttachDisk(vmCID, diskCID)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(agentClient.AddPersistentDisk(diskCID, "/dev/sdc")).To(Succeed())
+		Expect(agentClient.MountDisk(diskCID)).To(Succeed())
+		Expect(agentClient.ListDisk()).To(Equal([]string{diskCID}))
+
+		Expect(agentClient.UnmountDisk(diskCID)).To(Succeed())
+		Expect(agentClient.ListDisk()).To(BeEmpty())
+	})
+
+	It("compiles packages by copying uploaded sources", func() {
+		createVM()
+
+		srcPath := filepath.Join(GinkgoT().TempDir(), "pkg.tgz")
+		Expect(os.WriteFile(srcPath, []byte("content"), 0644)).To(Succeed())
+
+		blobstore := NewBlobstore(store, boshlog.NewLogger(boshlog.LevelNone))
+
+		blobID, err := blobstore.Add(srcPath)
+		Expect(err).ToNot(HaveOccurred())
+
+		compiled, err := agentClient.CompilePackage(biagentclient.BlobRef{
+			Name: "pkg", Version: "v1", BlobstoreID: blobID, SHA1: "fake-sha1",
+		}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(compiled.Name).To(Equal("pkg"))
+		Expect(compiled.Version).To(Equal("v1"))
+		Expect(compiled.SHA1).To(Equal("040f06fd774092478d450774f5ba30c5da78acc8"))
+
+		localBlob, err := blobstore.Get(compiled.BlobstoreID)
+		Expect(err).ToNot(HaveOccurred())
+
+		defer localBlob.Delete() //nolint:errcheck
+
+		Expect(os.ReadFile(localBlob.Path())).To(Equal([]byte("content")))
+	})
+})
@@ -0,0 +1,95 @@
+package fakecpi
+
+import (
+	"os"
+	"path/filepath"
+
+	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+
+	biblobstore "github.com/cloudfoundry/bosh-cli/v7/blobstore"
+)
+
+type blobstore struct {
+	store  *Store
+	logger boshlog.Logger
+}
+
+// NewBlobstore returns a blobstore which keeps blobs uploaded for the fake agent in store
+func NewBlobstore(store *Store, logger boshlog.Logger) biblobstore.Blobstore {
+	return blobstore{store: store, logger: logger}
+}
+
+func (b blobstore) Get(blobID string) (biblobstore.LocalBlob, error) {
+	file, err := b.store.fs.TempFile("bosh-init-local-blob")
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Creating temp file")
+	}
+
+	destinationPath := file.Name()
+
+	err = file.Close()
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Closing temp file '%s'", destinationPath)
+	}
+
+	err = b.store.fs.CopyFile(b.store.BlobPath(blobID), destinationPath)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Getting blob '%s'", blobID)
+	}
+
+	return biblobstore.NewLocalBlob(destinationPath, b.store.fs, b.logger), nil
+}
+
+func (b blobstore) Add(sourcePath string) (string, error) {
+	blobID, err := b.store.NewID("blob")
+	if err != nil {
+		return "", err
+	}
+
+	err = b.put(sourcePath, blobID)
+	if err != nil {
+		return "", err
+	}
+
+	return blobID, nil
+}
+
+// Copy duplicates an existing blob and returns new blob's ID and SHA1
+func (b blobstore) Copy(srcBlobID string) (string, string, error) {
+	blobID, err := b.Add(b.store.BlobPath(srcBlobID))
+	if err != nil {
+		return "", "", err
+	}
+
+	file, err := b.store.fs.OpenFile(b.store.BlobPath(blobID), os.O_RDONLY, 0)
+	if err != nil {
+		return "", "", bosherr.WrapErrorf(err, "Opening blob '%s'", blobID)
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	digest, err := boshcrypto.DigestAlgorithmSHA1.CreateDigest(file)
+	if err != nil {
+		return "", "", bosherr.WrapErrorf(err, "Calculating digest of blob '%s'", blobID)
+	}
+
+	return blobID, digest.String(), nil
+}
+
+func (b blobstore) put(sourcePath, blobID string) error {
+	dstPath := b.store.BlobPath(blobID)
+
+	err := b.store.fs.MkdirAll(filepath.Dir(dstPath), os.ModePerm)
+	if err != nil {
+		return bosherr.WrapError(err, "Creating blobs directory")
+	}
+
+	err = b.store.fs.CopyFile(sourcePath, dstPath)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Adding blob '%s'", sourcePath)
+	}
+
+	return nil
+}
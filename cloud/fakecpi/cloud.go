@@ -0,0 +1,296 @@
+package fakecpi
+
+import (
+	"fmt"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+
+	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
+)
+
+const StemcellFormat = "fake"
+
+type cloud struct {
+	store  *Store
+	logger boshlog.Logger
+	logTag string
+}
+
+// NewCloud returns an in-process CPI which keeps stemcells, VMs and disks in store
+// instead of creating them in an IaaS.
+func NewCloud(store *Store, logger boshlog.Logger) bicloud.Cloud {
+	return cloud{store: store, logger: logger, logTag: "fakeCloud"}
+}
+
+func (c cloud) Info() (bicloud.CpiInfo, error) {
+	return bicloud.CpiInfo{
+		StemcellFormats: []string{StemcellFormat},
+		ApiVersion:      bicloud.MaxCpiApiVersionSupported,
+	}, nil
+}
+
+func (c cloud) CreateStemcell(imagePath string, cloudProperties biproperty.Map) (string, error) {
+	cid, err := c.store.NewID("stemcell")
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.Debug(c.logTag, "Creating stemcell '%s' from '%s'", cid, imagePath)
+
+	return cid, c.store.Update(func(state *State) error {
+		state.Stemcells = append(state.Stemcells, Stemcell{
+			CID:             cid,
+			ImagePath:       imagePath,
+			CloudProperties: cloudProperties,
+		})
+		return nil
+	})
+}
+
+func (c cloud) DeleteStemcell(stemcellCID string) error {
+	return c.store.Update(func(state *State) error {
+		for i, stemcell := range state.Stemcells {
+			if stemcell.CID == stemcellCID {
+				state.Stemcells = append(state.Stemcells[:i], state.Stemcells[i+1:]...)
+				return nil
+			}
+		}
+		return c.cpiError("delete_stemcell", bicloud.StemcellNotFoundError, "Stemcell '%s' not found", stemcellCID)
+	})
+}
+
+func (c cloud) HasVM(vmCID string) (bool, error) {
+	state, err := c.store.Load()
+	if err != nil {
+		return false, err
+	}
+
+	_, found := state.FindVM(vmCID)
+
+	return found, nil
+}
+
+func (c cloud) CreateVM(
+	agentID string,
+	stemcellCID string,
+	cloudProperties biproperty.Map,
+	diskCIDs []string,
+	networksInterfaces map[string]biproperty.Map,
+	env biproperty.Map,
+) (string, error) {
+	cid, err := c.store.NewID("vm")
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.Debug(c.logTag, "Creating VM '%s' for agent '%s'", cid, agentID)
+
+	return cid, c.store.Update(func(state *State) error {
+		if _, found := state.FindStemcell(stemcellCID); !found {
+			return c.cpiError("create_vm", bicloud.StemcellNotFoundError, "Stemcell '%s' not found", stemcellCID)
+		}
+
+		state.VMs = append(state.VMs, VM{
+			CID:             cid,
+			AgentID:         agentID,
+			StemcellCID:     stemcellCID,
+			CloudProperties: cloudProperties,
+			Networks:        networksInterfaces,
+			Env:             env,
+		})
+
+		return nil
+	})
+}
+
+func (c cloud) SetVMMetadata(vmCID string, metadata bicloud.VMMetadata) error {
+	return c.store.Update(func(state *State) error {
+		vm, found := state.FindVM(vmCID)
+		if !found {
+			return c.cpiError("set_vm_metadata", bicloud.VMNotFoundError, "VM '%s' not found", vmCID)
+		}
+		vm.Metadata = metadata
+		return nil
+	})
+}
+
+func (c cloud) SetDiskMetadata(diskCID string, metadata bicloud.DiskMetadata) error {
+	return c.store.Update(func(state *State) error {
+		disk, found := state.FindDisk(diskCID)
+		if !found {
+			return c.cpiError("set_disk_metadata", bicloud.DiskNotFoundError, "Disk '%s' not found", diskCID)
+		}
+		disk.Metadata = metadata
+		return nil
+	})
+}
+
+func (c cloud) DeleteVM(vmCID string) error {
+	return c.store.Update(func(state *State) error {
+		for i, vm := range state.VMs {
+			if vm.CID == vmCID {
+				state.VMs = append(state.VMs[:i], state.VMs[i+1:]...)
+				return nil
+			}
+		}
+		return c.cpiError("delete_vm", bicloud.VMNotFoundError, "VM '%s' not found", vmCID)
+	})
+}
+
+func (c cloud) CreateDisk(size int, cloudProperties biproperty.Map, vmCID string) (string, error) {
+	cid, err := c.store.NewID("disk")
+	if err != nil {
+		return "", err
+	}
+
+	return cid, c.store.Update(func(state *State) error {
+		state.Disks = append(state.Disks, Disk{
+			CID:             cid,
+			Size:            size,
+			CloudProperties: cloudProperties,
+		})
+		return nil
+	})
+}
+
+func (c cloud) AttachDisk(vmCID, diskCID string) (interface{}, error) {
+	var hint interface{}
+
+	err := c.store.Update(func(state *State) error {
+		vm, found := state.FindVM(vmCID)
+		if !found {
+			return c.cpiError("attach_disk", bicloud.VMNotFoundError, "VM '%s' not found", vmCID)
+		}
+
+		if _, found := state.FindDisk(diskCID); !found {
+			return c.cpiError("attach_disk", bicloud.DiskNotFoundError, "Disk '%s' not found", diskCID)
+		}
+
+		for _, cid := range vm.DiskCIDs {
+			if cid == diskCID {
+				return bosherr.Errorf("Disk '%s' is already attached to VM '%s'", diskCID, vmCID)
+			}
+		}
+
+		vm.DiskCIDs = append(vm.DiskCIDs, diskCID)
+		hint = fmt.Sprintf("/dev/sd%c", 'c'+len(vm.DiskCIDs)-1)
+
+		return nil
+	})
+
+	return hint, err
+}
+
+func (c cloud) DetachDisk(vmCID, diskCID string) error {
+	return c.store.Update(func(state *State) error {
+		vm, found := state.FindVM(vmCID)
+		if !found {
+			return c.cpiError("detach_disk", bicloud.VMNotFoundError, "VM '%s' not found", vmCID)
+		}
+
+		for i, cid := range vm.DiskCIDs {
+			if cid == diskCID {
+				vm.DiskCIDs = append(vm.DiskCIDs[:i], vm.DiskCIDs[i+1:]...)
+				return nil
+			}
+		}
+
+		return c.cpiError("detach_disk", bicloud.DiskNotFoundError, "Disk '%s' is not attached to VM '%s'", diskCID, vmCID)
+	})
+}
+
+func (c cloud) DeleteDisk(diskCID string) error {
+	return c.store.Update(func(state *State) error {
+		if vm, found := state.AttachedVM(diskCID); found {
+			return bosherr.Errorf("Disk '%s' is still attached to VM '%s'", diskCID, vm.CID)
+		}
+
+		for i, disk := range state.Disks {
+			if disk.CID == diskCID {
+				state.Disks = append(state.Disks[:i], state.Disks[i+1:]...)
+				return nil
+			}
+		}
+
+		return c.cpiError("delete_disk", bicloud.DiskNotFoundError, "Disk '%s' not found", diskCID)
+	})
+}
+
+func (c cloud) ResizeDisk(diskCID string, newSize int) error {
+	return c.store.Update(func(state *State) error {
+		disk, err := c.findDetachedDisk(state, "resize_disk", diskCID)
+		if err != nil {
+			return err
+		}
+		if newSize < disk.Size {
+			return bosherr.Errorf("Disk '%s' cannot be shrunk from %d to %d", diskCID, disk.Size, newSize)
+		}
+		disk.Size = newSize
+		return nil
+	})
+}
+
+// UpdateDisk changes the disk in place, so the disk keeps its CID
+func (c cloud) UpdateDisk(diskCID string, newSize int, cloudProperties biproperty.Map) (string, error) {
+	return diskCID, c.store.Update(func(state *State) error {
+		disk, err := c.findDetachedDisk(state, "update_disk", diskCID)
+		if err != nil {
+			return err
+		}
+		disk.Size = newSize
+		disk.CloudProperties = cloudProperties
+		return nil
+	})
+}
+
+func (c cloud) SnapshotDisk(diskCID string, metadata bicloud.DiskMetadata) (string, error) {
+	cid, err := c.store.NewID("snapshot")
+	if err != nil {
+		return "", err
+	}
+
+	return cid, c.store.Update(func(state *State) error {
+		if _, found := state.FindDisk(diskCID); !found {
+			return c.cpiError("snapshot_disk", bicloud.DiskNotFoundError, "Disk '%s' not found", diskCID)
+		}
+		state.Snapshots = append(state.Snapshots, Snapshot{
+			CID:      cid,
+			DiskCID:  diskCID,
+			Metadata: metadata,
+		})
+		return nil
+	})
+}
+
+func (c cloud) DeleteSnapshot(snapshotCID string) error {
+	return c.store.Update(func(state *State) error {
+		for i, snapshot := range state.Snapshots {
+			if snapshot.CID == snapshotCID {
+				state.Snapshots = append(state.Snapshots[:i], state.Snapshots[i+1:]...)
+				return nil
+			}
+		}
+		return bosherr.Errorf("Snapshot '%s' not found", snapshotCID)
+	})
+}
+
+func (c cloud) String() string {
+	return fmt.Sprintf("FakeCloud{Dir=%s}", c.store.Dir())
+}
+
+func (c cloud) findDetachedDisk(state *State, method, diskCID string) (*Disk, error) {
+	disk, found := state.FindDisk(diskCID)
+	if !found {
+		return nil, c.cpiError(method, bicloud.DiskNotFoundError, "Disk '%s' not found", diskCID)
+	}
+	if vm, found := state.AttachedVM(diskCID); found {
+		return nil, bosherr.Errorf("Disk '%s' must be detached from VM '%s' before it can be changed", diskCID, vm.CID)
+	}
+	return disk, nil
+}
+
+func (c cloud) cpiError(method, errType, msg string, args ...interface{}) error {
+	return bicloud.NewCPIError(method, bicloud.CmdError{Type: errType, Message: fmt.Sprintf(msg, args...)})
+}
@@ -0,0 +1,142 @@
+package fakecpi_test
+
+import (
+	"errors"
+	"path/filepath"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
+	. "github.com/cloudfoundry/bosh-cli/v7/cloud/fakecpi"
+)
+
+var _ = Describe("Cloud", func() {
+	var (
+		store *Store
+		cloud bicloud.Cloud
+	)
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		fs := boshsys.NewOsFileSystem(logger)
+		uuidGen := fakeuuid.NewFakeGenerator()
+		uuidGen.GeneratedUUID = "fake-uuid"
+
+		store = NewStore(filepath.Join(GinkgoT().TempDir(), "fake-cpi"), fs, uuidGen)
+		cloud = NewCloud(store, logger)
+	})
+
+	expectCPIError := func(err error, errType string) {
+		var cloudErr bicloud.Error
+		Expect(errors.As(err, &cloudErr)).To(BeTrue())
+		Expect(cloudErr.Type()).To(Equal(errType))
+	}
+
+	It("reports CPI API v2", func() {
+		info, err := cloud.Info()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.ApiVersion).To(Equal(bicloud.MaxCpiApiVersionSupported))
+	})
+
+	It("keeps track of stemcells, VMs and disks", func() {
+		stemcellCID, err := cloud.CreateStemcell("/image", biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stemcellCID).To(Equal("stemcell-fake-uuid"))
+
+		vmCID, err := cloud.CreateVM("agent-id", stemcellCID, biproperty.Map{}, nil,
+			map[string]biproperty.Map{"default": {"ip": "10.0.0.6"}}, biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+
+		found, err := cloud.HasVM(vmCID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		diskCID, err := cloud.CreateDisk(1024, biproperty.Map{}, vmCID)
+		Expect(err).ToNot(HaveOccurred())
+
+		hint, err := cloud.AttachDisk(vmCID, diskCID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hint).To(Equal("/dev/sdc"))
+
+		Expect(cloud.SetDiskMetadata(diskCID, bicloud.DiskMetadata{"director": "bosh-init"})).To(Succeed())
+
+		err = cloud.DeleteDisk(diskCID)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("still attached"))
+
+		state, err := store.Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.VMs).To(HaveLen(1))
+		Expect(state.VMs[0].AgentID).To(Equal("agent-id"))
+		Expect(state.VMs[0].DiskCIDs).To(Equal([]string{diskCID}))
+		Expect(state.Disks[0].Metadata).To(Equal(map[string]string{"director": "bosh-init"}))
+
+		Expect(cloud.DetachDisk(vmCID, diskCID)).To(Succeed())
+		Expect(cloud.DeleteDisk(diskCID)).To(Succeed())
+		Expect(cloud.DeleteVM(vmCID)).To(Succeed())
+		Expect(cloud.DeleteStemcell(stemcellCID)).To(Succeed())
+
+		state, err = store.Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state).To(Equal(State{Stemcells: []Stemcell{}, VMs: []VM{}, Disks: []Disk{}}))
+	})
+
+	It("resizes, updates and snapshots detached disks", func() {
+		stemcellCID, err := cloud.CreateStemcell("/image", biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+		vmCID, err := cloud.CreateVM("agent-id", stemcellCID, biproperty.Map{}, nil, nil, biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+		diskCID, err := cloud.CreateDisk(1024, biproperty.Map{}, vmCID)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = cloud.AttachDisk(vmCID, diskCID)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = cloud.ResizeDisk(diskCID, 2048)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must be detached"))
+
+		Expect(cloud.DetachDisk(vmCID, diskCID)).To(Succeed())
+		Expect(cloud.ResizeDisk(diskCID, 2048)).To(Succeed())
+		Expect(cloud.ResizeDisk(diskCID, 1024)).ToNot(Succeed())
+
+		newDiskCID, err := cloud.UpdateDisk(diskCID, 4096, biproperty.Map{"type": "ssd"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(newDiskCID).To(Equal(diskCID))
+
+		snapshotCID, err := cloud.SnapshotDisk(diskCID, bicloud.DiskMetadata{"director": "bosh-init"})
+		Expect(err).ToNot(HaveOccurred())
+
+		state, err := store.Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.Disks[0].Size).To(Equal(4096))
+		Expect(state.Disks[0].CloudProperties).To(Equal(biproperty.Map{"type": "ssd"}))
+		Expect(state.Snapshots).To(Equal([]Snapshot{{
+			CID:      snapshotCID,
+			DiskCID:  diskCID,
+			Metadata: map[string]string{"director": "bosh-init"},
+		}}))
+
+		Expect(cloud.DeleteSnapshot(snapshotCID)).To(Succeed())
+		Expect(cloud.DeleteSnapshot(snapshotCID)).ToNot(Succeed())
+	})
+
+	It("returns not found CPI errors for missing resources", func() {
+		_, err := cloud.CreateVM("agent-id", "missing", biproperty.Map{}, nil, nil, biproperty.Map{})
+		expectCPIError(err, bicloud.StemcellNotFoundError)
+
+		expectCPIError(cloud.DeleteVM("missing"), bicloud.VMNotFoundError)
+		expectCPIError(cloud.DeleteDisk("missing"), bicloud.DiskNotFoundError)
+		expectCPIError(cloud.DeleteStemcell("missing"), bicloud.StemcellNotFoundError)
+		expectCPIError(cloud.ResizeDisk("missing", 1024), bicloud.DiskNotFoundError)
+
+		found, err := cloud.HasVM("missing")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+})
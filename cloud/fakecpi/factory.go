@@ -0,0 +1,72 @@
+package fakecpi
+
+import (
+	"net/http"
+
+	biagentclient "github.com/cloudfoundry/bosh-agent/v2/agentclient"
+	bihttpagent "github.com/cloudfoundry/bosh-agent/v2/agentclient/http"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+
+	biblobstore "github.com/cloudfoundry/bosh-cli/v7/blobstore"
+	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
+	biinstall "github.com/cloudfoundry/bosh-cli/v7/installation"
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/v7/installation/manifest"
+)
+
+// The factories below use the fake implementations when the installation
+// manifest selects the fake CPI and delegate to the real factories otherwise.
+
+type cloudFactory struct {
+	store   *Store
+	factory bicloud.Factory
+	logger  boshlog.Logger
+}
+
+func NewCloudFactory(store *Store, factory bicloud.Factory, logger boshlog.Logger) bicloud.Factory {
+	return cloudFactory{store: store, factory: factory, logger: logger}
+}
+
+func (f cloudFactory) NewCloud(installation biinstall.Installation, directorID string, stemcellApiVersion int) (bicloud.Cloud, error) {
+	if installation.Manifest().CPI == biinstallmanifest.FakeCPI {
+		return NewCloud(f.store, f.logger), nil
+	}
+	return f.factory.NewCloud(installation, directorID, stemcellApiVersion)
+}
+
+type agentClientFactory struct {
+	store   *Store
+	factory bihttpagent.AgentClientFactory
+	logger  boshlog.Logger
+}
+
+func NewAgentClientFactory(store *Store, factory bihttpagent.AgentClientFactory, logger boshlog.Logger) bihttpagent.AgentClientFactory {
+	return agentClientFactory{store: store, factory: factory, logger: logger}
+}
+
+func (f agentClientFactory) NewAgentClient(directorID, mbusURL, caCert string) (biagentclient.AgentClient, error) {
+	if isFakeMbus(mbusURL) {
+		return NewAgentClient(f.store, f.logger), nil
+	}
+	return f.factory.NewAgentClient(directorID, mbusURL, caCert)
+}
+
+type blobstoreFactory struct {
+	store   *Store
+	factory biblobstore.Factory
+	logger  boshlog.Logger
+}
+
+func NewBlobstoreFactory(store *Store, factory biblobstore.Factory, logger boshlog.Logger) biblobstore.Factory {
+	return blobstoreFactory{store: store, factory: factory, logger: logger}
+}
+
+func (f blobstoreFactory) Create(mbusURL string, httpClient *http.Client) (biblobstore.Blobstore, error) {
+	if isFakeMbus(mbusURL) {
+		return NewBlobstore(f.store, f.logger), nil
+	}
+	return f.factory.Create(mbusURL, httpClient)
+}
+
+func isFakeMbus(mbusURL string) bool {
+	return mbusURL == biinstallmanifest.FakeMbus
+}
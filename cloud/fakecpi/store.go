@@ -0,0 +1,208 @@
+package fakecpi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-agent/v2/agentclient/applyspec"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+)
+
+const stateFileName = "state.json"
+
+type State struct {
+	Stemcells []Stemcell `json:"stemcells"`
+	VMs       []VM       `json:"vms"`
+	Disks     []Disk     `json:"disks"`
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+type Stemcell struct {
+	CID             string         `json:"cid"`
+	ImagePath       string         `json:"image_path"`
+	CloudProperties biproperty.Map `json:"cloud_properties"`
+}
+
+type VM struct {
+	CID             string                    `json:"cid"`
+	AgentID         string                    `json:"agent_id"`
+	StemcellCID     string                    `json:"stemcell_cid"`
+	CloudProperties biproperty.Map            `json:"cloud_properties"`
+	Networks        map[string]biproperty.Map `json:"networks"`
+	Env             biproperty.Map            `json:"env"`
+	Metadata        map[string]string         `json:"metadata"`
+	DiskCIDs        []string                  `json:"disk_cids"`
+
+	Agent Agent `json:"agent"`
+}
+
+// Agent is the state kept by the fake agent running on a VM
+type Agent struct {
+	JobState        string               `json:"job_state"`
+	Spec            *applyspec.ApplySpec `json:"spec,omitempty"`
+	PersistentDisks []string             `json:"persistent_disks"`
+	MountedDisks    []string             `json:"mounted_disks"`
+}
+
+type Disk struct {
+	CID             string            `json:"cid"`
+	Size            int               `json:"size"`
+	CloudProperties biproperty.Map    `json:"cloud_properties"`
+	Metadata        map[string]string `json:"metadata"`
+}
+
+type Snapshot struct {
+	CID      string            `json:"cid"`
+	DiskCID  string            `json:"disk_cid"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+func (s *State) FindStemcell(cid string) (*Stemcell, bool) {
+	for i := range s.Stemcells {
+		if s.Stemcells[i].CID == cid {
+			return &s.Stemcells[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *State) FindVM(cid string) (*VM, bool) {
+	for i := range s.VMs {
+		if s.VMs[i].CID == cid {
+			return &s.VMs[i], true
+		}
+	}
+	return nil, false
+}
+
+// CurrentVM returns the most recently created VM, which is the one the agent runs on
+func (s *State) CurrentVM() (*VM, bool) {
+	if len(s.VMs) == 0 {
+		return nil, false
+	}
+	return &s.VMs[len(s.VMs)-1], true
+}
+
+func (s *State) FindDisk(cid string) (*Disk, bool) {
+	for i := range s.Disks {
+		if s.Disks[i].CID == cid {
+			return &s.Disks[i], true
+		}
+	}
+	return nil, false
+}
+
+// AttachedVM returns the VM which diskCID is attached to, if any
+func (s *State) AttachedVM(diskCID string) (*VM, bool) {
+	for i := range s.VMs {
+		for _, cid := range s.VMs[i].DiskCIDs {
+			if cid == diskCID {
+				return &s.VMs[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Store persists fake cloud, agent and blobstore state in a local directory
+// so that subsequent create-env and delete-env runs see the same environment.
+type Store struct {
+	dir     string
+	fs      boshsys.FileSystem
+	uuidGen boshuuid.Generator
+
+	lock sync.Mutex
+}
+
+func NewStore(dir string, fs boshsys.FileSystem, uuidGen boshuuid.Generator) *Store {
+	return &Store{dir: dir, fs: fs, uuidGen: uuidGen}
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) BlobPath(blobID string) string {
+	return filepath.Join(s.dir, "blobs", blobID)
+}
+
+func (s *Store) NewID(prefix string) (string, error) {
+	id, err := s.uuidGen.Generate()
+	if err != nil {
+		return "", bosherr.WrapError(err, "Generating ID")
+	}
+	return prefix + "-" + id, nil
+}
+
+func (s *Store) Load() (State, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.load()
+}
+
+// Update saves state modified by updateFunc unless it returns an error
+func (s *Store) Update(updateFunc func(*State) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	err = updateFunc(&state)
+	if err != nil {
+		return err
+	}
+
+	return s.save(state)
+}
+
+func (s *Store) load() (State, error) {
+	var state State
+
+	path := filepath.Join(s.dir, stateFileName)
+
+	if !s.fs.FileExists(path) {
+		return state, nil
+	}
+
+	bytes, err := s.fs.ReadFile(path)
+	if err != nil {
+		return state, bosherr.WrapErrorf(err, "Reading fake CPI state '%s'", path)
+	}
+
+	err = json.Unmarshal(bytes, &state)
+	if err != nil {
+		return state, bosherr.WrapErrorf(err, "Unmarshalling fake CPI state '%s'", path)
+	}
+
+	return state, nil
+}
+
+func (s *Store) save(state State) error {
+	err := s.fs.MkdirAll(s.dir, os.ModePerm)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating fake CPI state directory '%s'", s.dir)
+	}
+
+	bytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling fake CPI state")
+	}
+
+	path := filepath.Join(s.dir, stateFileName)
+
+	err = s.fs.WriteFile(path, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing fake CPI state '%s'", path)
+	}
+
+	return nil
+}
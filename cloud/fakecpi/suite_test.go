@@ -0,0 +1,13 @@
+package fakecpi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFakeCPI(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "cloud/fakecpi")
+}
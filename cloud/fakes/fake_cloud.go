@@ -39,6 +39,20 @@ type FakeCloud struct {
 	DeleteStemcellInputs []DeleteStemcellInput
 	DeleteStemcellErr    error
 
+	ResizeDiskInputs []ResizeDiskInput
+	ResizeDiskErr    error
+
+	UpdateDiskInputs []UpdateDiskInput
+	UpdateDiskCID    string
+	UpdateDiskErr    error
+
+	SnapshotDiskInputs []SnapshotDiskInput
+	SnapshotDiskCID    string
+	SnapshotDiskErr    error
+
+	DeleteSnapshotInputs []DeleteSnapshotInput
+	DeleteSnapshotErr    error
+
 	SetVMMetadataCid      string
 	SetVMMetadataMetadata cloud.VMMetadata
 	SetVMMetadataError    error
@@ -97,6 +111,26 @@ type DeleteStemcellInput struct {
 	StemcellCID string
 }
 
+type ResizeDiskInput struct {
+	DiskCID string
+	NewSize int
+}
+
+type UpdateDiskInput struct {
+	DiskCID         string
+	NewSize         int
+	CloudProperties biproperty.Map
+}
+
+type SnapshotDiskInput struct {
+	DiskCID  string
+	Metadata cloud.DiskMetadata
+}
+
+type DeleteSnapshotInput struct {
+	SnapshotCID string
+}
+
 func NewFakeCloud() *FakeCloud {
 	return &FakeCloud{
 		CreateStemcellInputs: []CreateStemcellInput{},
@@ -203,6 +237,38 @@ func (c *FakeCloud) DeleteDisk(diskCID string) error {
 	return c.DeleteDiskErr
 }
 
+func (c *FakeCloud) ResizeDisk(diskCID string, newSize int) error {
+	c.ResizeDiskInputs = append(c.ResizeDiskInputs, ResizeDiskInput{
+		DiskCID: diskCID,
+		NewSize: newSize,
+	})
+	return c.ResizeDiskErr
+}
+
+func (c *FakeCloud) UpdateDisk(diskCID string, newSize int, cloudProperties biproperty.Map) (string, error) {
+	c.UpdateDiskInputs = append(c.UpdateDiskInputs, UpdateDiskInput{
+		DiskCID:         diskCID,
+		NewSize:         newSize,
+		CloudProperties: cloudProperties,
+	})
+	return c.UpdateDiskCID, c.UpdateDiskErr
+}
+
+func (c *FakeCloud) SnapshotDisk(diskCID string, metadata cloud.DiskMetadata) (string, error) {
+	c.SnapshotDiskInputs = append(c.SnapshotDiskInputs, SnapshotDiskInput{
+		DiskCID:  diskCID,
+		Metadata: metadata,
+	})
+	return c.SnapshotDiskCID, c.SnapshotDiskErr
+}
+
+func (c *FakeCloud) DeleteSnapshot(snapshotCID string) error {
+	c.DeleteSnapshotInputs = append(c.DeleteSnapshotInputs, DeleteSnapshotInput{
+		SnapshotCID: snapshotCID,
+	})
+	return c.DeleteSnapshotErr
+}
+
 func (c *FakeCloud) Info() (cpiInfo cloud.CpiInfo, err error) {
 	return c.InfoResult, c.InfoError
 }
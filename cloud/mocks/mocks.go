@@ -110,6 +110,20 @@ func (mr *MockCloudMockRecorder) DeleteDisk(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDisk", reflect.TypeOf((*MockCloud)(nil).DeleteDisk), arg0)
 }
 
+// DeleteSnapshot mocks base method.
+func (m *MockCloud) DeleteSnapshot(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSnapshot", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSnapshot indicates an expected call of DeleteSnapshot.
+func (mr *MockCloudMockRecorder) DeleteSnapshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSnapshot", reflect.TypeOf((*MockCloud)(nil).DeleteSnapshot), arg0)
+}
+
 // DeleteStemcell mocks base method.
 func (m *MockCloud) DeleteStemcell(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -182,6 +196,20 @@ func (mr *MockCloudMockRecorder) Info() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockCloud)(nil).Info))
 }
 
+// ResizeDisk mocks base method.
+func (m *MockCloud) ResizeDisk(arg0 string, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeDisk", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResizeDisk indicates an expected call of ResizeDisk.
+func (mr *MockCloudMockRecorder) ResizeDisk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeDisk", reflect.TypeOf((*MockCloud)(nil).ResizeDisk), arg0, arg1)
+}
+
 // SetDiskMetadata mocks base method.
 func (m *MockCloud) SetDiskMetadata(arg0 string, arg1 cloud.DiskMetadata) error {
 	m.ctrl.T.Helper()
@@ -210,6 +238,21 @@ func (mr *MockCloudMockRecorder) SetVMMetadata(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVMMetadata", reflect.TypeOf((*MockCloud)(nil).SetVMMetadata), arg0, arg1)
 }
 
+// SnapshotDisk mocks base method.
+func (m *MockCloud) SnapshotDisk(arg0 string, arg1 cloud.DiskMetadata) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotDisk", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotDisk indicates an expected call of SnapshotDisk.
+func (mr *MockCloudMockRecorder) SnapshotDisk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotDisk", reflect.TypeOf((*MockCloud)(nil).SnapshotDisk), arg0, arg1)
+}
+
 // String mocks base method.
 func (m *MockCloud) String() string {
 	m.ctrl.T.Helper()
@@ -224,6 +267,21 @@ func (mr *MockCloudMockRecorder) String() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockCloud)(nil).String))
 }
 
+// UpdateDisk mocks base method.
+func (m *MockCloud) UpdateDisk(arg0 string, arg1 int, arg2 property.Map) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDisk", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDisk indicates an expected call of UpdateDisk.
+func (mr *MockCloudMockRecorder) UpdateDisk(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDisk", reflect.TypeOf((*MockCloud)(nil).UpdateDisk), arg0, arg1, arg2)
+}
+
 // MockFactory is a mock of Factory interface.
 type MockFactory struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,109 @@
+package cloud
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+type replayingCPICmdRunner struct {
+	calls []CPICall
+
+	lock sync.Mutex
+	next int
+
+	logger boshlog.Logger
+	logTag string
+}
+
+// NewReplayingCPICmdRunner serves CPI responses previously saved by NewFSCPICallRecorder.
+// Calls are matched by method and order only since arguments such as stemcell image paths,
+// agent IDs and creation times differ between runs. Only CPI calls are replayed;
+// calls to the agent on created VM still require a live agent.
+func NewReplayingCPICmdRunner(dir string, fs boshsys.FileSystem, logger boshlog.Logger) (CPICmdRunner, error) {
+	paths, err := fs.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing CPI recordings in '%s'", dir)
+	}
+
+	if len(paths) == 0 {
+		return nil, bosherr.Errorf("Expected to find CPI recordings in '%s'", dir)
+	}
+
+	// File names start with zero padded sequence numbers
+	sort.Strings(paths)
+
+	var calls []CPICall
+
+	for _, path := range paths {
+		bytes, err := fs.ReadFile(path)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading CPI recording '%s'", path)
+		}
+
+		var call CPICall
+
+		err = json.Unmarshal(bytes, &call)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Unmarshalling CPI recording '%s'", path)
+		}
+
+		calls = append(calls, call)
+	}
+
+	return &replayingCPICmdRunner{
+		calls:  calls,
+		logger: logger,
+		logTag: "replayingCPICmdRunner",
+	}, nil
+}
+
+func (r *replayingCPICmdRunner) Run(context CmdContext, method string, apiVersion int, args ...interface{}) (CmdOutput, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.next >= len(r.calls) {
+		return CmdOutput{}, bosherr.Errorf("Replaying CPI call '%s': no more recorded calls (recorded %d)", method, len(r.calls))
+	}
+
+	call := r.calls[r.next]
+
+	if call.Method != method {
+		return CmdOutput{}, bosherr.Errorf(
+			"Replaying CPI call '%s': expected recorded call #%d to be '%s'", method, r.next+1, call.Method)
+	}
+
+	r.next++
+
+	args = redactArguments(args)
+
+	if !reflect.DeepEqual(args, redactArguments(call.Request.Arguments)) {
+		expectedBytes, _ := json.Marshal(call.Request.Arguments)
+		actualBytes, _ := json.Marshal(args)
+
+		r.logger.Debug(r.logTag, "Replaying CPI call #%d '%s' recorded with arguments '%s' for arguments '%s'",
+			r.next, method, expectedBytes, actualBytes)
+	}
+
+	r.logger.Debug(r.logTag, "Replaying CPI call #%d '%s'\nSTDOUT: '%s'\nSTDERR: '%s'", r.next, method, call.Stdout, call.Stderr)
+
+	if len(call.Error) > 0 {
+		return CmdOutput{}, bosherr.WrapErrorf(errors.New(call.Error), "Executing external CPI command (replayed)")
+	}
+
+	cmdOutput := CmdOutput{}
+
+	err := json.Unmarshal([]byte(call.Stdout), &cmdOutput)
+	if err != nil {
+		return CmdOutput{}, bosherr.WrapErrorf(err, "Unmarshalling replayed CPI command output: STDOUT: '%s', STDERR: '%s'", call.Stdout, call.Stderr)
+	}
+
+	return cmdOutput, nil
+}
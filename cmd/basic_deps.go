@@ -9,6 +9,7 @@ import (
 	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
 
 	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
+	boshtarball "github.com/cloudfoundry/bosh-cli/v7/release/tarball"
 	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
 )
 
@@ -43,7 +44,7 @@ func NewBasicDepsWithFS(ui *boshui.ConfUI, fs boshsys.FileSystem, logger boshlog
 
 		UUIDGen:                  boshuuid.NewGenerator(),
 		CmdRunner:                cmdRunner,
-		Compressor:               boshcmd.NewTarballCompressor(cmdRunner, fs),
+		Compressor:               boshtarball.NewDetectingCompressor(boshcmd.NewTarballCompressor(cmdRunner, fs), fs),
 		DigestCalculator:         digestCalculator,
 		DigestCreationAlgorithms: digestCreationAlgorithms,
 		Time:                     clock.NewClock(),
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	boshsandbox "github.com/cloudfoundry/bosh-cli/v7/releasedir/sandbox"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type BuildPackageCmd struct {
+	blobsDir      boshreldir.BlobsDir
+	releaseReader boshrel.Reader
+	builder       boshsandbox.Builder
+	parallel      int
+	ui            boshui.UI
+}
+
+func NewBuildPackageCmd(
+	blobsDir boshreldir.BlobsDir,
+	releaseReader boshrel.Reader,
+	builder boshsandbox.Builder,
+	parallel int,
+	ui boshui.UI,
+) BuildPackageCmd {
+	return BuildPackageCmd{
+		blobsDir:      blobsDir,
+		releaseReader: releaseReader,
+		builder:       builder,
+		parallel:      parallel,
+		ui:            ui,
+	}
+}
+
+func (c BuildPackageCmd) Run(opts BuildPackageOpts) error {
+	err := c.blobsDir.SyncBlobs(c.parallel)
+	if err != nil {
+		return err
+	}
+
+	release, err := c.releaseReader.Read(opts.Directory.Path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading release from directory '%s'", opts.Directory.Path)
+	}
+
+	defer release.CleanUp() //nolint:errcheck
+
+	for _, pkg := range release.Packages() {
+		if pkg.Name() != opts.Args.Name {
+			continue
+		}
+
+		results, err := c.builder.Build(pkg, uiBlockWriter{c.ui})
+		if err != nil {
+			return err
+		}
+
+		table := boshtbl.Table{
+			Content: "packages",
+
+			Header: []boshtbl.Header{
+				boshtbl.NewHeader("Name"),
+				boshtbl.NewHeader("Fingerprint"),
+				boshtbl.NewHeader("Build"),
+				boshtbl.NewHeader("Install Target"),
+			},
+		}
+
+		for _, result := range results {
+			build := "built"
+			if result.Cached {
+				build = "cached"
+			}
+
+			table.Rows = append(table.Rows, []boshtbl.Value{
+				boshtbl.NewValueString(result.Name),
+				boshtbl.NewValueString(result.Fingerprint),
+				boshtbl.NewValueString(build),
+				boshtbl.NewValueString(result.Path),
+			})
+		}
+
+		c.ui.PrintTable(table)
+
+		return nil
+	}
+
+	return bosherr.Errorf("Expected to find package '%s' in release directory '%s'", opts.Args.Name, opts.Directory.Path)
+}
+
+// uiBlockWriter passes through output of packaging scripts
+type uiBlockWriter struct {
+	ui boshui.UI
+}
+
+func (w uiBlockWriter) Write(p []byte) (int, error) {
+	w.ui.PrintBlock(p)
+	return len(p), nil
+}
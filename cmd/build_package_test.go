@@ -0,0 +1,128 @@
+package cmd_test
+
+import (
+	"errors"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	birelpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
+	birelres "github.com/cloudfoundry/bosh-cli/v7/release/resource"
+	fakereldir "github.com/cloudfoundry/bosh-cli/v7/releasedir/releasedirfakes"
+	boshsandbox "github.com/cloudfoundry/bosh-cli/v7/releasedir/sandbox"
+	fakesandbox "github.com/cloudfoundry/bosh-cli/v7/releasedir/sandbox/sandboxfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("BuildPackageCmd", func() {
+	var (
+		blobsDir      *fakereldir.FakeBlobsDir
+		releaseReader *fakerel.FakeReader
+		release       *fakerel.FakeRelease
+		builder       *fakesandbox.FakeBuilder
+		ui            *fakeui.FakeUI
+		command       BuildPackageCmd
+		opts          BuildPackageOpts
+
+		pkg1, pkg2 *birelpkg.Package
+	)
+
+	BeforeEach(func() {
+		blobsDir = &fakereldir.FakeBlobsDir{}
+		releaseReader = &fakerel.FakeReader{}
+		release = &fakerel.FakeRelease{}
+		builder = &fakesandbox.FakeBuilder{}
+		ui = &fakeui.FakeUI{}
+		command = NewBuildPackageCmd(blobsDir, releaseReader, builder, 3, ui)
+
+		opts = BuildPackageOpts{
+			Args:      BuildPackageArgs{Name: "pkg2"},
+			Directory: DirOrCWDArg{Path: "/dir"},
+		}
+
+		pkg1 = birelpkg.NewPackage(birelres.NewResourceWithBuiltArchive("pkg1", "pkg1-fp", "/pkg1.tgz", ""), nil)
+		pkg2 = birelpkg.NewPackage(birelres.NewResourceWithBuiltArchive("pkg2", "pkg2-fp", "/pkg2.tgz", ""), []string{"pkg1"})
+
+		release.PackagesReturns([]*birelpkg.Package{pkg1, pkg2})
+		releaseReader.ReadReturns(release, nil)
+	})
+
+	It("syncs blobs, builds package and prints install targets", func() {
+		builder.BuildStub = func(pkg *birelpkg.Package, out io.Writer) ([]boshsandbox.Result, error) {
+			_, err := out.Write([]byte("packaging-output"))
+			Expect(err).ToNot(HaveOccurred())
+
+			return []boshsandbox.Result{
+				{Name: "pkg1", Fingerprint: "pkg1-fp", Path: "/sandbox/pkg1", Cached: true},
+				{Name: "pkg2", Fingerprint: "pkg2-fp", Path: "/sandbox/pkg2"},
+			}, nil
+		}
+
+		err := command.Run(opts)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(blobsDir.SyncBlobsArgsForCall(0)).To(Equal(3))
+		Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/dir"))
+
+		pkg, _ := builder.BuildArgsForCall(0)
+		Expect(pkg).To(Equal(pkg2))
+
+		Expect(ui.Blocks).To(Equal([]string{"packaging-output"}))
+
+		Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+			{
+				boshtbl.NewValueString("pkg1"),
+				boshtbl.NewValueString("pkg1-fp"),
+				boshtbl.NewValueString("cached"),
+				boshtbl.NewValueString("/sandbox/pkg1"),
+			},
+			{
+				boshtbl.NewValueString("pkg2"),
+				boshtbl.NewValueString("pkg2-fp"),
+				boshtbl.NewValueString("built"),
+				boshtbl.NewValueString("/sandbox/pkg2"),
+			},
+		}))
+
+		Expect(release.CleanUpCallCount()).To(Equal(1))
+	})
+
+	It("returns error if package is not in release", func() {
+		opts.Args.Name = "unknown"
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected to find package 'unknown' in release directory '/dir'"))
+		Expect(builder.BuildCallCount()).To(Equal(0))
+	})
+
+	It("returns error if syncing blobs fails", func() {
+		blobsDir.SyncBlobsReturns(errors.New("fake-err"))
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+		Expect(releaseReader.ReadCallCount()).To(Equal(0))
+	})
+
+	It("returns error if reading release fails", func() {
+		releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+
+	It("returns error if building fails", func() {
+		builder.BuildReturns(nil, errors.New("fake-err"))
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type CleanReleaseDirCmd struct {
+	cleaner boshreldir.ReleaseDirCleaner
+	ui      boshui.UI
+}
+
+func NewCleanReleaseDirCmd(cleaner boshreldir.ReleaseDirCleaner, ui boshui.UI) CleanReleaseDirCmd {
+	return CleanReleaseDirCmd{cleaner: cleaner, ui: ui}
+}
+
+func (c CleanReleaseDirCmd) Run(opts CleanReleaseDirOpts) error {
+	artifacts, err := c.cleaner.Clean(opts.KeepDev, opts.FinalBlobs, opts.CacheBlobs, true)
+	if err != nil {
+		return err
+	}
+
+	table := boshtbl.Table{
+		Content: "artifacts",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Type"),
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Version"),
+			boshtbl.NewHeader("Blobstore ID"),
+			boshtbl.NewHeader("Cache Size"),
+		},
+	}
+
+	var cacheSize uint64
+
+	for _, artifact := range artifacts {
+		size := boshtbl.Value(boshtbl.NewValueString(""))
+		if artifact.Size > 0 {
+			size = boshtbl.NewValueBytes(artifact.Size)
+		}
+
+		cacheSize += artifact.Size
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(artifact.Type),
+			boshtbl.NewValueString(artifact.Name),
+			boshtbl.NewValueString(artifact.Version),
+			boshtbl.NewValueString(artifact.BlobstoreID),
+			size,
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	// Blobs of removed builds stay in local cache unless explicitly requested
+	if !opts.CacheBlobs && cacheSize > 0 {
+		c.ui.PrintLinef("Local cache will keep %s of removed builds; use --cache-blobs to remove them",
+			boshtbl.NewValueBytes(cacheSize).String())
+	}
+
+	if opts.DryRun || len(artifacts) == 0 {
+		return nil
+	}
+
+	err = c.ui.AskForConfirmation()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.cleaner.Clean(opts.KeepDev, opts.FinalBlobs, opts.CacheBlobs, false)
+	if err != nil {
+		return err
+	}
+
+	c.ui.PrintLinef("Removed %d artifact(s) from release directory", len(artifacts))
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/v7/releasedir/releasedirfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("CleanReleaseDirCmd", func() {
+	var (
+		cleaner *fakereldir.FakeReleaseDirCleaner
+		ui      *fakeui.FakeUI
+		command CleanReleaseDirCmd
+		opts    CleanReleaseDirOpts
+	)
+
+	BeforeEach(func() {
+		cleaner = &fakereldir.FakeReleaseDirCleaner{}
+		ui = &fakeui.FakeUI{}
+		command = NewCleanReleaseDirCmd(cleaner, ui)
+		opts = CleanReleaseDirOpts{KeepDev: 3, FinalBlobs: true}
+
+		cleaner.CleanReturns([]boshreldir.CleanedArtifact{
+			{Type: "dev release", Name: "rel", Version: "1+dev.1"},
+			{Type: "final job", Name: "job", Version: "fp", BlobstoreID: "blob-id", Size: 2048},
+		}, nil)
+	})
+
+	It("prints artifacts and removes them after confirmation", func() {
+		err := command.Run(opts)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cleaner.CleanCallCount()).To(Equal(2))
+
+		keepDev, finalBlobs, cacheBlobs, dryRun := cleaner.CleanArgsForCall(0)
+		Expect(keepDev).To(Equal(3))
+		Expect(finalBlobs).To(BeTrue())
+		Expect(cacheBlobs).To(BeFalse())
+		Expect(dryRun).To(BeTrue())
+
+		keepDev, finalBlobs, cacheBlobs, dryRun = cleaner.CleanArgsForCall(1)
+		Expect(keepDev).To(Equal(3))
+		Expect(finalBlobs).To(BeTrue())
+		Expect(cacheBlobs).To(BeFalse())
+		Expect(dryRun).To(BeFalse())
+
+		Expect(ui.AskedConfirmationCalled).To(BeTrue())
+
+		Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+			{
+				boshtbl.NewValueString("dev release"),
+				boshtbl.NewValueString("rel"),
+				boshtbl.NewValueString("1+dev.1"),
+				boshtbl.NewValueString(""),
+				boshtbl.NewValueString(""),
+			},
+			{
+				boshtbl.NewValueString("final job"),
+				boshtbl.NewValueString("job"),
+				boshtbl.NewValueString("fp"),
+				boshtbl.NewValueString("blob-id"),
+				boshtbl.NewValueBytes(2048),
+			},
+		}))
+
+		Expect(ui.Said).To(ContainElement("Removed 2 artifact(s) from release directory"))
+	})
+
+	It("reports size of cache blobs that are kept", func() {
+		opts.DryRun = true
+
+		err := command.Run(opts)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Said).To(ContainElement("Local cache will keep 2.0 KiB of removed builds; use --cache-blobs to remove them"))
+	})
+
+	It("removes cache blobs when requested", func() {
+		opts.CacheBlobs = true
+
+		err := command.Run(opts)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, cacheBlobs, _ := cleaner.CleanArgsForCall(0)
+		Expect(cacheBlobs).To(BeTrue())
+
+		_, _, cacheBlobs, _ = cleaner.CleanArgsForCall(1)
+		Expect(cacheBlobs).To(BeTrue())
+
+		Expect(ui.Said).ToNot(ContainElement(ContainSubstring("Local cache will keep")))
+	})
+
+	It("only prints artifacts during dry run", func() {
+		opts.DryRun = true
+
+		err := command.Run(opts)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cleaner.CleanCallCount()).To(Equal(1))
+		Expect(ui.AskedConfirmationCalled).To(BeFalse())
+		Expect(ui.Table.Rows).To(HaveLen(2))
+	})
+
+	It("does not ask for confirmation if there is nothing to remove", func() {
+		cleaner.CleanReturns(nil, nil)
+
+		err := command.Run(opts)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cleaner.CleanCallCount()).To(Equal(1))
+		Expect(ui.AskedConfirmationCalled).To(BeFalse())
+	})
+
+	It("does not remove anything if confirmation is rejected", func() {
+		ui.AskedConfirmationErr = errors.New("stop")
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("stop"))
+
+		Expect(cleaner.CleanCallCount()).To(Equal(1))
+	})
+
+	It("returns error if finding artifacts fails", func() {
+		cleaner.CleanReturns(nil, errors.New("fake-err"))
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+		Expect(ui.AskedConfirmationCalled).To(BeFalse())
+	})
+
+	It("returns error if removing artifacts fails", func() {
+		cleaner.CleanReturnsOnCall(1, nil, errors.New("fake-err"))
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
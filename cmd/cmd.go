@@ -8,14 +8,22 @@ import (
 	bihttpagent "github.com/cloudfoundry/bosh-agent/v2/agentclient/http"
 	"github.com/cppforlife/go-patch/patch"
 
+	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
 	cmdconf "github.com/cloudfoundry/bosh-cli/v7/cmd/config"
 	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
 	"github.com/cloudfoundry/bosh-cli/v7/crypto"
 	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
 	boshtpl "github.com/cloudfoundry/bosh-cli/v7/director/template"
+	boshlogs "github.com/cloudfoundry/bosh-cli/v7/logs"
 	"github.com/cloudfoundry/bosh-cli/v7/pcap"
 	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshreldiff "github.com/cloudfoundry/bosh-cli/v7/release/diff"
+	boshsbom "github.com/cloudfoundry/bosh-cli/v7/release/sbom"
+	boshtarball "github.com/cloudfoundry/bosh-cli/v7/release/tarball"
+	boshval "github.com/cloudfoundry/bosh-cli/v7/release/validator"
 	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	boshsandbox "github.com/cloudfoundry/bosh-cli/v7/releasedir/sandbox"
+	boshupstream "github.com/cloudfoundry/bosh-cli/v7/releasedir/upstream"
 	boshssh "github.com/cloudfoundry/bosh-cli/v7/ssh"
 	bistemcell "github.com/cloudfoundry/bosh-cli/v7/stemcell"
 	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
@@ -23,7 +31,9 @@ import (
 	boshuit "github.com/cloudfoundry/bosh-cli/v7/ui/task"
 
 	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshfu "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshhttp "github.com/cloudfoundry/bosh-utils/httpclient"
 )
 
 type Cmd struct {
@@ -60,6 +70,10 @@ func (c Cmd) Execute() (cmdErr error) {
 	c.configureUI()
 	c.configureFS()
 
+	if c.BoshOpts.AllEnvironmentsOpt || IsEnvironmentPattern(c.BoshOpts.EnvironmentOpt) {
+		return c.executeInEnvironments()
+	}
+
 	deps := c.deps
 
 	switch opts := c.Opts.(type) {
@@ -71,7 +85,7 @@ func (c Cmd) Execute() (cmdErr error) {
 
 	case *CreateEnvOpts:
 		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentPreparer {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op, opts.RecreatePersistentDisks, opts.PackageDir).Preparer()
+			return NewEnvFactory(deps, manifestPath, statePath, vars, BuiltInCPIOp(op, opts.CPI), opts.RecreatePersistentDisks, opts.PackageDir, opts.CPIRecordingOpts()).Preparer()
 		}
 
 		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
@@ -79,7 +93,7 @@ func (c Cmd) Execute() (cmdErr error) {
 
 	case *DeleteEnvOpts:
 		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentDeleter {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op, false, opts.PackageDir).Deleter()
+			return NewEnvFactory(deps, manifestPath, statePath, vars, BuiltInCPIOp(op, opts.CPI), false, opts.PackageDir, opts.CPIRecordingOpts()).Deleter()
 		}
 
 		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
@@ -87,7 +101,7 @@ func (c Cmd) Execute() (cmdErr error) {
 
 	case *StopEnvOpts:
 		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentStateManager {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op, false, "").StateManager()
+			return NewEnvFactory(deps, manifestPath, statePath, vars, op, false, "", bicloud.RecordingOpts{}).StateManager()
 		}
 
 		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
@@ -95,7 +109,7 @@ func (c Cmd) Execute() (cmdErr error) {
 
 	case *StartEnvOpts:
 		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentStateManager {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op, false, "").StateManager()
+			return NewEnvFactory(deps, manifestPath, statePath, vars, op, false, "", bicloud.RecordingOpts{}).StateManager()
 		}
 
 		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
@@ -111,6 +125,12 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *UnaliasEnvOpts:
 		return NewUnaliasEnvCmd(c.config()).Run(*opts)
 
+	case *ExportInventoryOpts:
+		return NewExportInventoryCmd(deps.UI, c.director()).Run()
+
+	case *DiffInventoryOpts:
+		return NewDiffInventoryCmd(deps.UI).Run(*opts)
+
 	case *LogInOpts:
 		sessionFactory := func(config cmdconf.Config) Session {
 			return NewSessionFromOpts(c.BoshOpts, config, deps.UI, true, true, deps.FS, deps.Logger)
@@ -156,7 +176,14 @@ func (c Cmd) Execute() (cmdErr error) {
 		return NewDeploymentsCmd(deps.UI, c.director()).Run()
 
 	case *DeleteDeploymentOpts:
-		return NewDeleteDeploymentCmd(deps.UI, c.deployment()).Run(*opts)
+		director, deployment := c.directorAndDeployment()
+
+		err := c.waitForLock(director, deployment, opts.WaitForLockFlags)
+		if err != nil {
+			return err
+		}
+
+		return NewDeleteDeploymentCmd(deps.UI, deployment).Run(*opts)
 
 	case *ReleasesOpts:
 		return NewReleasesCmd(deps.UI, c.director()).Run()
@@ -170,7 +197,7 @@ func (c Cmd) Execute() (cmdErr error) {
 			return releaseReader, releaseDir
 		}
 
-		releaseWriter := relProv.NewArchiveWriter()
+		releaseWriter := c.releaseWriter(relProv)
 
 		releaseArchiveFactory := func(path string) boshdir.ReleaseArchive {
 			return boshdir.NewFSReleaseArchive(path, deps.FS)
@@ -181,6 +208,8 @@ func (c Cmd) Execute() (cmdErr error) {
 			releaseWriter,
 			c.director(),
 			releaseArchiveFactory,
+			c.uploadProgressStore(),
+			crypto.NewTarballSigner(deps.FS),
 			deps.CmdRunner,
 			deps.FS,
 			deps.UI,
@@ -199,7 +228,8 @@ func (c Cmd) Execute() (cmdErr error) {
 			return boshdir.NewFSStemcellArchive(path, deps.FS)
 		}
 
-		return NewUploadStemcellCmd(c.director(), stemcellArchiveFactory, deps.UI).Run(*opts)
+		return NewUploadStemcellCmd(
+			c.director(), stemcellArchiveFactory, c.uploadProgressStore(), crypto.NewTarballSigner(deps.FS), deps.UI).Run(*opts)
 
 	case *DeleteStemcellOpts:
 		return NewDeleteStemcellCmd(deps.UI, c.director()).Run(*opts)
@@ -237,6 +267,11 @@ func (c Cmd) Execute() (cmdErr error) {
 			sess.taskReporter.EnableWithHeartbeat(time.Duration(*opts.WithHeartbeat) * time.Second)
 		}
 
+		err = c.waitForLock(director, deployment, opts.WaitForLockFlags)
+		if err != nil {
+			return err
+		}
+
 		downloader := NewUIDownloader(director, deps.Time, deps.FS, deps.UI)
 		return NewRunErrandCmd(deployment, downloader, deps.UI).Run(*opts)
 
@@ -273,6 +308,9 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *DeleteVMOpts:
 		return NewDeleteVMCmd(deps.UI, c.deployment()).Run(*opts)
 
+	case *DriftCheckOpts:
+		return NewDriftCheckCmd(deps.UI, c.director(), deps.FS).Run(*opts)
+
 	case *InterpolateOpts:
 		return NewInterpolateCmd(deps.UI).Run(*opts)
 
@@ -315,11 +353,18 @@ func (c Cmd) Execute() (cmdErr error) {
 		return NewManifestCmd(deps.UI, c.deployment()).Run()
 
 	case *EventsOpts:
-		return NewEventsCmd(deps.UI, c.director()).Run(*opts)
+		sinkFactory := NewEventSinkFactory(deps.UI, deps.FS, deps.Logger)
+		return NewEventsCmd(deps.UI, c.director(), sinkFactory, deps.Time).Run(*opts)
 
 	case *EventOpts:
 		return NewEventCmd(deps.UI, c.director()).Run(*opts)
 
+	case *UpgradeStemcellsOpts:
+		return NewUpgradeStemcellsCmd(deps.UI, c.director(), deps.FS).Run(*opts)
+
+	case *ReportOpts:
+		return NewReportCmd(deps.UI, c.director(), deps.Time).Run(*opts)
+
 	case *InspectReleaseOpts:
 		return NewInspectReleaseCmd(deps.UI, c.director()).Run(*opts)
 
@@ -328,6 +373,16 @@ func (c Cmd) Execute() (cmdErr error) {
 
 		return NewInspectLocalReleaseCmd(
 			relProv.NewArchiveReader(),
+			crypto.NewTarballSigner(deps.FS),
+			deps.UI,
+		).Run(*opts)
+
+	case *InspectReleaseSBOMOpts:
+		relProv, _ := c.releaseProviders()
+
+		return NewInspectReleaseSBOMCmd(
+			relProv.NewArchiveReader(),
+			c.sbomGenerator(),
 			deps.UI,
 		).Run(*opts)
 
@@ -352,7 +407,8 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *DeployOpts:
 		director, deployment := c.directorAndDeployment()
 		releaseManager := c.releaseManager(director)
-		return NewDeployCmd(deps.UI, deployment, releaseManager, director).Run(*opts)
+		lockWaiter := NewLockWaiter(director, deps.UI, deps.Time)
+		return NewDeployCmd(deps.UI, deployment, releaseManager, director, lockWaiter).Run(*opts)
 
 	case *StartOpts:
 		return NewStartCmd(deps.UI, c.deployment()).Run(*opts)
@@ -361,10 +417,24 @@ func (c Cmd) Execute() (cmdErr error) {
 		return NewStopCmd(deps.UI, c.deployment()).Run(*opts)
 
 	case *RestartOpts:
-		return NewRestartCmd(deps.UI, c.deployment()).Run(*opts)
+		director, deployment := c.directorAndDeployment()
+
+		err := c.waitForLock(director, deployment, opts.WaitForLockFlags)
+		if err != nil {
+			return err
+		}
+
+		return NewRestartCmd(deps.UI, deployment).Run(*opts)
 
 	case *RecreateOpts:
-		return NewRecreateCmd(deps.UI, c.deployment()).Run(*opts)
+		director, deployment := c.directorAndDeployment()
+
+		err := c.waitForLock(director, deployment, opts.WaitForLockFlags)
+		if err != nil {
+			return err
+		}
+
+		return NewRecreateCmd(deps.UI, deployment).Run(*opts)
 
 	case *CloudCheckOpts:
 		return NewCloudCheckCmd(c.deployment(), deps.UI).Run(*opts)
@@ -385,16 +455,27 @@ func (c Cmd) Execute() (cmdErr error) {
 		sshProvider := boshssh.NewProvider(deps.CmdRunner, deps.FS, deps.UI, deps.Logger)
 		nonIntSSHRunner := sshProvider.NewSSHRunner(false)
 
+		mergedSSHRunnerFactory := func(writerOpts boshssh.MergingWriterOpts) boshssh.Runner {
+			return sshProvider.NewMergedSSHRunner(boshssh.NewMergingWriter(deps.UI, deps.Time, writerOpts))
+		}
+
 		if opts.TargetDirector {
 			agentClientFactory := bihttpagent.NewAgentClientFactory(1*time.Second, deps.Logger)
 			scpRunner := sshProvider.NewSCPRunner()
-			return NewEnvLogsCmd(agentClientFactory, nonIntSSHRunner, scpRunner, deps.FS, deps.Time, deps.UI).Run(*opts)
+			return NewEnvLogsCmd(agentClientFactory, nonIntSSHRunner, mergedSSHRunnerFactory, scpRunner, deps.FS, deps.Time, deps.UI).Run(*opts)
+		} else if opts.Extract {
+			director, deployment := c.directorAndDeployment()
+			extractor := NewUILogBundleExtractor(director, deps.Compressor, deps.FS, deps.UI)
+			return NewExtractLogsCmd(deployment, extractor, boshlogs.NewFSIndexer(deps.FS), deps.UI, c.BoshOpts.Parallel).Run(*opts)
 		} else {
 			director, deployment := c.directorAndDeployment()
 			downloader := NewUIDownloader(director, deps.Time, deps.FS, deps.UI)
-			return NewLogsCmd(deployment, downloader, deps.UUIDGen, nonIntSSHRunner).Run(*opts)
+			return NewLogsCmd(deployment, downloader, deps.UUIDGen, nonIntSSHRunner, mergedSSHRunnerFactory).Run(*opts)
 		}
 
+	case *LogsSearchOpts:
+		return NewLogsSearchCmd(boshlogs.NewFSIndexer(deps.FS), boshlogs.NewFSSearcher(deps.FS), deps.UI).Run(*opts)
+
 	case *SSHOpts:
 		sshProvider := boshssh.NewProvider(deps.CmdRunner, deps.FS, deps.UI, deps.Logger)
 		intSSHRunner := sshProvider.NewSSHRunner(true)
@@ -441,11 +522,65 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *VendorPackageOpts:
 		return NewVendorPackageCmd(c.releaseDir, deps.UI).Run(*opts)
 
+	case *BuildPackageOpts:
+		rootfsPath := opts.StemcellRootfs
+		if len(rootfsPath) > 0 {
+			var err error
+			rootfsPath, err = deps.FS.ExpandPath(rootfsPath)
+			if err != nil {
+				return err
+			}
+		}
+		_, relDirProv := c.releaseProviders()
+		releaseReader := relDirProv.NewReleaseReader(opts.Directory.Path, c.BoshOpts.Parallel)
+		builder := boshsandbox.NewBuilder(opts.Directory.Path, rootfsPath, deps.Compressor, deps.CmdRunner, deps.FS, deps.Logger)
+		return NewBuildPackageCmd(c.blobsDir(opts.Directory), releaseReader, builder, c.BoshOpts.Parallel, deps.UI).Run(*opts)
+
 	case *FinalizeReleaseOpts:
 		_, relDirProv := c.releaseProviders()
 		releaseReader := relDirProv.NewReleaseReader(opts.Directory.Path, c.BoshOpts.Parallel)
 		releaseDir := relDirProv.NewFSReleaseDir(opts.Directory.Path, c.BoshOpts.Parallel)
-		return NewFinalizeReleaseCmd(releaseReader, releaseDir, deps.UI).Run(*opts)
+		return NewFinalizeReleaseCmd(releaseReader, releaseDir, c.workspace, deps.UI).Run(*opts)
+
+	case *ValidateReleaseOpts:
+		return NewValidateReleaseCmd(boshval.NewValidator(deps.FS), deps.UI).Run(*opts)
+
+	case *CleanReleaseDirOpts:
+		_, relDirProv := c.releaseProviders()
+		cleaner := relDirProv.NewFSReleaseDirCleaner(opts.Directory.Path)
+		return NewCleanReleaseDirCmd(cleaner, deps.UI).Run(*opts)
+
+	case *DiffReleasesOpts:
+		relProv, relDirProv := c.releaseProviders()
+
+		releaseDirFactory := func(dir DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir, boshreldir.BlobsDir) {
+			releaseReader := relProv.NewMultiReader(dir.Path)
+			releaseDir := relDirProv.NewFSReleaseDir(dir.Path, c.BoshOpts.Parallel)
+			return releaseReader, releaseDir, c.blobsDir(dir)
+		}
+
+		indiciesFactory := func(dir DirOrCWDArg) (boshrel.ArchiveIndicies, boshrel.ArchiveIndicies) {
+			return relDirProv.DevAndFinalIndicies(dir.Path)
+		}
+
+		differ := boshreldiff.NewDiffer(deps.Compressor, deps.FS)
+
+		return NewDiffReleasesCmd(relProv.NewArchiveReader(), releaseDirFactory, indiciesFactory, differ, c.BoshOpts.Parallel, deps.UI).Run(*opts)
+
+	case *VerifyReleaseReproducibleOpts:
+		relProv, relDirProv := c.releaseProviders()
+
+		scratchReaderFactory := func(dir DirOrCWDArg, scratchPath string) boshrel.Reader {
+			return relDirProv.NewScratchReleaseReader(dir.Path, scratchPath, c.BoshOpts.Parallel)
+		}
+
+		return NewVerifyReleaseReproducibleCmd(
+			relProv.NewArchiveReader(),
+			scratchReaderFactory,
+			c.releaseWriter(relProv),
+			deps.FS,
+			deps.UI,
+		).Run(*opts)
 
 	case *CreateReleaseOpts:
 		relProv, relDirProv := c.releaseProviders()
@@ -458,7 +593,10 @@ func (c Cmd) Execute() (cmdErr error) {
 
 		_, err := NewCreateReleaseCmd(
 			releaseDirFactory,
-			relProv.NewArchiveWriter(),
+			c.workspace,
+			c.releaseWriter(relProv),
+			c.sbomGenerator(),
+			crypto.NewTarballSigner(c.deps.FS),
 			c.deps.FS,
 			c.deps.UI,
 		).Run(*opts)
@@ -469,7 +607,7 @@ func (c Cmd) Execute() (cmdErr error) {
 
 		return NewRedigestReleaseCmd(
 			relProv.NewArchiveReader(),
-			relProv.NewArchiveWriter(),
+			c.releaseWriter(relProv),
 			crypto.NewDigestCalculator(c.deps.FS, []boshcrypto.Algorithm{boshcrypto.DigestAlgorithmSHA1}),
 			boshfu.NewFileMover(c.deps.FS),
 			c.deps.FS,
@@ -481,7 +619,7 @@ func (c Cmd) Execute() (cmdErr error) {
 
 		return NewRedigestReleaseCmd(
 			relProv.NewArchiveReader(),
-			relProv.NewArchiveWriter(),
+			c.releaseWriter(relProv),
 			crypto.NewDigestCalculator(c.deps.FS, []boshcrypto.Algorithm{boshcrypto.DigestAlgorithmSHA256}),
 			boshfu.NewFileMover(c.deps.FS),
 			c.deps.FS,
@@ -503,6 +641,11 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *SyncBlobsOpts:
 		return NewSyncBlobsCmd(c.blobsDir(opts.Directory), c.BoshOpts.Parallel).Run()
 
+	case *UpdateBlobsOpts:
+		httpClient := boshhttp.NewHTTPClient(boshhttp.CreateExternalDefaultClient(nil), deps.Logger)
+		updater := boshupstream.NewUpdater(opts.Directory.Path, c.blobsDir(opts.Directory), httpClient, deps.FS)
+		return NewUpdateBlobsCmd(updater, deps.UI).Run(*opts)
+
 	case *CurlOpts:
 		return NewCurlCmd(deps.UI, c.director().(boshdir.DirectorImpl).NewHTTPClientRequest()).Run(*opts)
 
@@ -517,6 +660,64 @@ func (c Cmd) Execute() (cmdErr error) {
 		return fmt.Errorf("Unhandled command: %#v", c.Opts) //nolint:staticcheck
 	}
 }
+
+// executeInEnvironments runs read-only commands against each selected environment
+func (c Cmd) executeInEnvironments() error {
+	var fanOutFunc FanOutFunc
+
+	switch opts := c.Opts.(type) {
+	case *EnvironmentOpts:
+		fanOutFunc = func(director boshdir.Director, ui boshui.UI) error {
+			return NewEnvironmentCmd(ui, director).Run(*opts)
+		}
+
+	case *DeploymentsOpts:
+		fanOutFunc = func(director boshdir.Director, ui boshui.UI) error {
+			return NewDeploymentsCmd(ui, director).Run()
+		}
+
+	case *StemcellsOpts:
+		fanOutFunc = func(director boshdir.Director, ui boshui.UI) error {
+			return NewStemcellsCmd(ui, director).Run()
+		}
+
+	case *ReleasesOpts:
+		fanOutFunc = func(director boshdir.Director, ui boshui.UI) error {
+			return NewReleasesCmd(ui, director).Run()
+		}
+
+	case *VMsOpts:
+		fanOutFunc = func(director boshdir.Director, ui boshui.UI) error {
+			return NewVMsCmd(ui, director, c.BoshOpts.Parallel).Run(*opts)
+		}
+
+	case *TasksOpts:
+		fanOutFunc = func(director boshdir.Director, ui boshui.UI) error {
+			return NewTasksCmd(ui, director).Run(*opts)
+		}
+
+	default:
+		return bosherr.Error("Command does not support running against multiple environments")
+	}
+
+	// Sessions share config so that concurrently refreshed tokens are saved one after another
+	config := cmdconf.NewSyncConfig(c.config())
+
+	environments, err := SelectEnvironments(config, c.BoshOpts.EnvironmentOpt, c.BoshOpts.AllEnvironmentsOpt)
+	if err != nil {
+		return err
+	}
+
+	directorFactory := func(env cmdconf.Environment, ui boshui.UI) (boshdir.Director, error) {
+		opts := c.BoshOpts
+		opts.EnvironmentOpt = env.URL
+
+		return NewSessionFromOpts(opts, config, ui, false, false, c.deps.FS, c.deps.Logger).Director()
+	}
+
+	return NewFanOutCmd(environments, directorFactory, c.deps.UI, c.BoshOpts.Parallel).Run(fanOutFunc)
+}
+
 func (c Cmd) configureUI() {
 	c.deps.UI.EnableTTY(c.BoshOpts.TTYOpt)
 
@@ -590,13 +791,21 @@ func (c Cmd) directorAndDeployment() (boshdir.Director, boshdir.Deployment) {
 	return director, deployment
 }
 
+// waitForLock queues mutating deployment commands behind the current lock holder
+func (c Cmd) waitForLock(director boshdir.Director, deployment boshdir.Deployment, flags WaitForLockFlags) error {
+	return NewLockWaiter(director, c.deps.UI, c.deps.Time).Wait(deployment.Name(), flags)
+}
+
 func (c Cmd) releaseProviders() (boshrel.Provider, boshreldir.Provider) {
 	indexReporter := boshui.NewIndexReporter(c.deps.UI)
 	blobsReporter := boshui.NewBlobsReporter(c.deps.UI)
 	releaseIndexReporter := boshui.NewReleaseIndexReporter(c.deps.UI)
 
+	// Release tarballs and their job and package archives are built reproducibly
+	compressor := boshtarball.NewReproducibleCompressor(c.deps.Compressor, c.compression(), c.deps.FS)
+
 	releaseProvider := boshrel.NewProvider(
-		c.deps.CmdRunner, c.deps.Compressor, c.deps.DigestCalculator, c.deps.FS, c.deps.Logger)
+		c.deps.CmdRunner, compressor, c.deps.DigestCalculator, c.deps.FS, c.deps.Logger)
 
 	releaseDirProvider := boshreldir.NewProvider(
 		indexReporter, releaseIndexReporter, blobsReporter, releaseProvider,
@@ -605,6 +814,27 @@ func (c Cmd) releaseProviders() (boshrel.Provider, boshreldir.Provider) {
 	return releaseProvider, releaseDirProvider
 }
 
+func (c Cmd) compression() boshtarball.Compression {
+	compression, err := boshtarball.NewCompression(c.BoshOpts.Compression, c.BoshOpts.CompressionLevel)
+	c.panicIfErr(err)
+
+	return compression
+}
+
+// releaseWriter returns writer that keeps zstd archives only when zstd was requested
+// so that releases for directors without zstd support are always gzip compressed
+func (c Cmd) releaseWriter(relProv boshrel.Provider) boshrel.Writer {
+	if c.compression().Format == boshtarball.FormatZstd {
+		return relProv.NewArchiveWriter()
+	}
+
+	return relProv.NewGzipArchiveWriter()
+}
+
+func (c Cmd) sbomGenerator() boshsbom.Generator {
+	return boshsbom.NewGenerator(c.deps.Compressor, c.deps.FS, c.deps.Time)
+}
+
 func (c Cmd) releaseManager(director boshdir.Director) ReleaseManager {
 	relProv, relDirProv := c.releaseProviders()
 
@@ -614,11 +844,14 @@ func (c Cmd) releaseManager(director boshdir.Director) ReleaseManager {
 		return releaseReader, releaseDir
 	}
 
-	releaseWriter := relProv.NewArchiveWriter()
+	releaseWriter := c.releaseWriter(relProv)
 
 	createReleaseCmd := NewCreateReleaseCmd(
 		releaseDirFactory,
+		c.workspace,
 		releaseWriter,
+		c.sbomGenerator(),
+		crypto.NewTarballSigner(c.deps.FS),
 		c.deps.FS,
 		c.deps.UI,
 	)
@@ -632,6 +865,8 @@ func (c Cmd) releaseManager(director boshdir.Director) ReleaseManager {
 		releaseWriter,
 		director,
 		releaseArchiveFactory,
+		c.uploadProgressStore(),
+		crypto.NewTarballSigner(c.deps.FS),
 		c.deps.CmdRunner,
 		c.deps.FS,
 		c.deps.UI,
@@ -640,6 +875,13 @@ func (c Cmd) releaseManager(director boshdir.Director) ReleaseManager {
 	return NewReleaseManager(createReleaseCmd, uploadReleaseCmd, c.BoshOpts.Parallel)
 }
 
+func (c Cmd) uploadProgressStore() boshdir.UploadProgressStore {
+	dirPath, err := c.deps.FS.ExpandPath(filepath.Join("~", ".bosh", "uploads"))
+	c.panicIfErr(err)
+
+	return boshdir.NewFSUploadProgressStore(dirPath, c.deps.FS)
+}
+
 func (c Cmd) blobsDir(dir DirOrCWDArg) boshreldir.BlobsDir {
 	_, relDirProv := c.releaseProviders()
 	return relDirProv.NewFSBlobsDir(dir.Path)
@@ -650,6 +892,11 @@ func (c Cmd) releaseDir(dir DirOrCWDArg) boshreldir.ReleaseDir {
 	return relDirProv.NewFSReleaseDir(dir.Path, c.BoshOpts.Parallel)
 }
 
+func (c Cmd) workspace(path string) boshreldir.Workspace {
+	_, relDirProv := c.releaseProviders()
+	return relDirProv.NewFSWorkspace(path, c.BoshOpts.Parallel)
+}
+
 func (c Cmd) panicIfErr(err error) {
 	if err != nil {
 		panic(cmdConveniencePanic{err})
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/cloudfoundry/bosh-cli/v7/installation"
+	"github.com/cloudfoundry/bosh-cli/v7/installation/manifest"
 )
 
 type FakeInstallation struct {
@@ -18,6 +19,16 @@ type FakeInstallation struct {
 	jobsReturnsOnCall map[int]struct {
 		result1 []installation.InstalledJob
 	}
+	ManifestStub        func() manifest.Manifest
+	manifestMutex       sync.RWMutex
+	manifestArgsForCall []struct {
+	}
+	manifestReturns struct {
+		result1 manifest.Manifest
+	}
+	manifestReturnsOnCall map[int]struct {
+		result1 manifest.Manifest
+	}
 	TargetStub        func() installation.Target
 	targetMutex       sync.RWMutex
 	targetArgsForCall []struct {
@@ -85,6 +96,59 @@ func (fake *FakeInstallation) JobsReturnsOnCall(i int, result1 []installation.In
 	}{result1}
 }
 
+func (fake *FakeInstallation) Manifest() manifest.Manifest {
+	fake.manifestMutex.Lock()
+	ret, specificReturn := fake.manifestReturnsOnCall[len(fake.manifestArgsForCall)]
+	fake.manifestArgsForCall = append(fake.manifestArgsForCall, struct {
+	}{})
+	stub := fake.ManifestStub
+	fakeReturns := fake.manifestReturns
+	fake.recordInvocation("Manifest", []interface{}{})
+	fake.manifestMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeInstallation) ManifestCallCount() int {
+	fake.manifestMutex.RLock()
+	defer fake.manifestMutex.RUnlock()
+	return len(fake.manifestArgsForCall)
+}
+
+func (fake *FakeInstallation) ManifestCalls(stub func() manifest.Manifest) {
+	fake.manifestMutex.Lock()
+	defer fake.manifestMutex.Unlock()
+	fake.ManifestStub = stub
+}
+
+func (fake *FakeInstallation) ManifestReturns(result1 manifest.Manifest) {
+	fake.manifestMutex.Lock()
+	defer fake.manifestMutex.Unlock()
+	fake.ManifestStub = nil
+	fake.manifestReturns = struct {
+		result1 manifest.Manifest
+	}{result1}
+}
+
+func (fake *FakeInstallation) ManifestReturnsOnCall(i int, result1 manifest.Manifest) {
+	fake.manifestMutex.Lock()
+	defer fake.manifestMutex.Unlock()
+	fake.ManifestStub = nil
+	if fake.manifestReturnsOnCall == nil {
+		fake.manifestReturnsOnCall = make(map[int]struct {
+			result1 manifest.Manifest
+		})
+	}
+	fake.manifestReturnsOnCall[i] = struct {
+		result1 manifest.Manifest
+	}{result1}
+}
+
 func (fake *FakeInstallation) Target() installation.Target {
 	fake.targetMutex.Lock()
 	ret, specificReturn := fake.targetReturnsOnCall[len(fake.targetArgsForCall)]
@@ -141,10 +205,6 @@ func (fake *FakeInstallation) TargetReturnsOnCall(i int, result1 installation.Ta
 func (fake *FakeInstallation) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.jobsMutex.RLock()
-	defer fake.jobsMutex.RUnlock()
-	fake.targetMutex.RLock()
-	defer fake.targetMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
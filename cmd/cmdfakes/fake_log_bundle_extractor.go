@@ -0,0 +1,113 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package cmdfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/cmd"
+)
+
+type FakeLogBundleExtractor struct {
+	ExtractStub        func(string, string, string) error
+	extractMutex       sync.RWMutex
+	extractArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	extractReturns struct {
+		result1 error
+	}
+	extractReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLogBundleExtractor) Extract(arg1 string, arg2 string, arg3 string) error {
+	fake.extractMutex.Lock()
+	ret, specificReturn := fake.extractReturnsOnCall[len(fake.extractArgsForCall)]
+	fake.extractArgsForCall = append(fake.extractArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ExtractStub
+	fakeReturns := fake.extractReturns
+	fake.recordInvocation("Extract", []interface{}{arg1, arg2, arg3})
+	fake.extractMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLogBundleExtractor) ExtractCallCount() int {
+	fake.extractMutex.RLock()
+	defer fake.extractMutex.RUnlock()
+	return len(fake.extractArgsForCall)
+}
+
+func (fake *FakeLogBundleExtractor) ExtractCalls(stub func(string, string, string) error) {
+	fake.extractMutex.Lock()
+	defer fake.extractMutex.Unlock()
+	fake.ExtractStub = stub
+}
+
+func (fake *FakeLogBundleExtractor) ExtractArgsForCall(i int) (string, string, string) {
+	fake.extractMutex.RLock()
+	defer fake.extractMutex.RUnlock()
+	argsForCall := fake.extractArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLogBundleExtractor) ExtractReturns(result1 error) {
+	fake.extractMutex.Lock()
+	defer fake.extractMutex.Unlock()
+	fake.ExtractStub = nil
+	fake.extractReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLogBundleExtractor) ExtractReturnsOnCall(i int, result1 error) {
+	fake.extractMutex.Lock()
+	defer fake.extractMutex.Unlock()
+	fake.ExtractStub = nil
+	if fake.extractReturnsOnCall == nil {
+		fake.extractReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.extractReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLogBundleExtractor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLogBundleExtractor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ cmd.LogBundleExtractor = new(FakeLogBundleExtractor)
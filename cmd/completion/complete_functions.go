@@ -33,10 +33,13 @@ func NewCompleteFunctionsMap(logger boshlog.Logger, directorQuery DirectorQueryI
 		reflect.TypeOf(opts.AliasEnvArgs{}).Name():                         c.listEnvAliases,
 		reflect.TypeOf(opts.AllOrInstanceGroupOrInstanceSlugArgs{}).Name(): c.listInstanceGroupsOrSlugs,
 		reflect.TypeOf(opts.AttachDiskArgs{}).Name():                       c.listDiskCIDs,
+		reflect.TypeOf(opts.BuildPackageArgs{}).Name():                     c.noFile,
 		reflect.TypeOf(opts.ConfigArgs{}).Name():                           c.listConfigIDs,
 		reflect.TypeOf(opts.CreateEnvArgs{}).Name():                        c.listFiles,
 		reflect.TypeOf(opts.CreateRecoveryPlanArgs{}).Name():               c.listFiles,
 		reflect.TypeOf(opts.CreateReleaseArgs{}).Name():                    c.listFiles,
+		reflect.TypeOf(opts.DiffInventoryArgs{}).Name():                    c.listFiles,
+		reflect.TypeOf(opts.DiffReleasesArgs{}).Name():                     c.listFiles,
 		reflect.TypeOf(opts.CurlArgs{}).Name():                             c.listDirectorApiEndpoints,
 		reflect.TypeOf(opts.DeleteConfigArgs{}).Name():                     c.listConfigIDs,
 		reflect.TypeOf(opts.DeleteDiskArgs{}).Name():                       c.listOrphanedDiskCIDs,
@@ -47,6 +50,7 @@ func NewCompleteFunctionsMap(logger boshlog.Logger, directorQuery DirectorQueryI
 		reflect.TypeOf(opts.DeleteStemcellArgs{}).Name():                   c.listStemcells,
 		reflect.TypeOf(opts.DeleteVMArgs{}).Name():                         c.listVmCIDs,
 		reflect.TypeOf(opts.DeployArgs{}).Name():                           c.listFiles,
+		reflect.TypeOf(opts.DriftCheckArgs{}).Name():                       c.listFiles,
 		reflect.TypeOf(opts.EventArgs{}).Name():                            c.listEventIds,
 		reflect.TypeOf(opts.ExportReleaseArgs{}).Name():                    c.noFile,
 		reflect.TypeOf(opts.FinalizeReleaseArgs{}).Name():                  c.noFile,
@@ -54,6 +58,7 @@ func NewCompleteFunctionsMap(logger boshlog.Logger, directorQuery DirectorQueryI
 		reflect.TypeOf(opts.GeneratePackageArgs{}).Name():                  c.noFile,
 		reflect.TypeOf(opts.InspectLocalReleaseArgs{}).Name():              c.noFile,
 		reflect.TypeOf(opts.InspectReleaseArgs{}).Name():                   c.listReleaseSlugs,
+		reflect.TypeOf(opts.InspectReleaseSBOMArgs{}).Name():               c.listFiles,
 		reflect.TypeOf(opts.InspectStemcellTarballArgs{}).Name():           c.listFiles,
 		reflect.TypeOf(opts.InstanceSlugArgs{}).Name():                     c.listInstanceSlugs,
 		reflect.TypeOf(opts.InterpolateArgs{}).Name():                      c.listFiles,
@@ -76,6 +81,7 @@ func NewCompleteFunctionsMap(logger boshlog.Logger, directorQuery DirectorQueryI
 		reflect.TypeOf(opts.UploadReleaseArgs{}).Name():                    c.listFiles,
 		reflect.TypeOf(opts.UploadStemcellArgs{}).Name():                   c.listFiles,
 		reflect.TypeOf(opts.VendorPackageArgs{}).Name():                    c.listFiles,
+		reflect.TypeOf(opts.VerifyReleaseReproducibleArgs{}).Name():        c.listFiles,
 	}
 	return cfm
 }
@@ -141,10 +141,13 @@ func (tc testCase) check(boshComplete *completion.BoshComplete) {
 }
 
 var globalFlags = []string{
+	"--all-environments\tRun read-only command against all aliased environments; --environment may also be a pattern such as 'prod-*'",
 	"--ca-cert\tDirector CA certificate path or value, env: BOSH_CA_CERT",
 	"--client\tOverride username or UAA client, env: BOSH_CLIENT",
 	"--client-secret\tOverride password or UAA client secret, env: BOSH_CLIENT_SECRET",
 	"--column\tFilter to show only given column(s), use the --column flag for each column you wish to include",
+	"--compression\tCompress job, package and release tarballs with format (gzip, zstd); gzip is readable by all directors, env: BOSH_COMPRESSION",
+	"--compression-level\tCompression level (gzip: 1-9, zstd: 1-22); defaults to format's default level, env: BOSH_COMPRESSION_LEVEL",
 	"--config\tConfig file path, env: BOSH_CONFIG",
 	"--deployment\tDeployment name, env: BOSH_DEPLOYMENT",
 	"-d\tDeployment name, env: BOSH_DEPLOYMENT",
@@ -167,8 +170,10 @@ var globalCommands = []string{
 	"alias-env\tAlias environment to save URL and CA certificate",
 	"attach-disk\tAttach disk to an instance",
 	"blobs\tList blobs",
+	"build-package\tBuild package and its dependencies locally",
 	"cancel-task\tCancel task at its next checkpoint",
 	"cancel-tasks\tCancel tasks at their next checkpoints",
+	"clean-release-dir\tRemove old dev releases and unreferenced builds from release directory",
 	"clean-up\tClean up old unused resources except orphaned disks",
 	"cloud-check\tCloud consistency check and interactive repair",
 	"cloud-config\tShow current cloud config",
@@ -194,12 +199,16 @@ var globalCommands = []string{
 	"deployment\tShow deployment information",
 	"deployments\tList deployments",
 	"diff-config\tDiff two configs by ID or content",
+	"diff-inventory\tCompare two exported environment inventories",
+	"diff-releases\tCompare jobs and packages of two releases",
 	"disks\tList disks",
+	"drift-check\tCheck deployed manifests and configs for drift from local manifests",
 	"environment\tShow environment",
 	"environments\tList environments",
 	"errands\tList errands",
 	"event\tShow event details",
 	"events\tList events",
+	"export-inventory\tExport deployments, releases, stemcells and configs of environment as JSON",
 	"export-release\tExport the compiled release to a tarball",
 	"finalize-release\tCreate final release from dev release tarball",
 	"generate-job\tGenerate job",
@@ -211,6 +220,7 @@ var globalCommands = []string{
 	"inspect-local-release\tDisplay information from release metadata",
 	"inspect-local-stemcell\tDisplay information from stemcell metadata",
 	"inspect-release\tList release contents such as jobs",
+	"inspect-release-sbom\tDisplay software bill of materials of release tarball without package sources",
 	"instances\tList all instances in a deployment",
 	"interpolate\tInterpolates variables into a manifest",
 	"locks\tList current locks",
@@ -226,6 +236,7 @@ var globalCommands = []string{
 	"releases\tList releases",
 	"remove-blob\tRemove blob",
 	"repack-stemcell\tRepack stemcell",
+	"report\tReport deploy statistics per deployment and user based on tasks and events",
 	"reset-release\tReset release",
 	"restart\tRestart instance(s)",
 	"run-errand\tRun errand",
@@ -246,16 +257,20 @@ var globalCommands = []string{
 	"tasks\tList running or recent tasks",
 	"unalias-env\tRemove an aliased environment",
 	"unignore\tUnignore an instance",
+	"update-blobs\tUpdate blobs to latest versions of their upstream sources",
 	"update-cloud-config\tUpdate current cloud config",
 	"update-config\tUpdate config",
 	"update-cpi-config\tUpdate current CPI config",
 	"update-resurrection\tEnable/disable resurrection",
 	"update-runtime-config\tUpdate current runtime config",
+	"upgrade-stemcells\tUpgrade stemcell of deployments using older stemcell versions",
 	"upload-blobs\tUpload blobs",
 	"upload-release\tUpload release",
 	"upload-stemcell\tUpload stemcell",
+	"validate-release\tValidate job specs, package specs and job templates of release",
 	"variables\tList variables",
 	"vendor-package\tVendor package",
+	"verify-release-reproducible\tRebuild release from source and compare it with release tarball",
 	"vms\tList all VMs in all deployments",
 }
 
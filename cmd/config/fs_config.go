@@ -188,6 +188,11 @@ func (c FSConfig) Save() error {
 }
 
 func (c FSConfig) UpdateConfigWithToken(environment string, t uaa.AccessToken) error {
+	config := c.SetCredentials(environment, credsFromToken(t))
+	return config.Save()
+}
+
+func credsFromToken(t uaa.AccessToken) Creds {
 	creds := Creds{
 		AccessToken:     t.Value(),
 		AccessTokenType: t.Type(),
@@ -196,8 +201,8 @@ func (c FSConfig) UpdateConfigWithToken(environment string, t uaa.AccessToken) e
 	if refreshToken, ok := t.(uaa.RefreshableAccessToken); ok {
 		creds.RefreshToken = refreshToken.RefreshValue()
 	}
-	config := c.SetCredentials(environment, creds)
-	return config.Save()
+
+	return creds
 }
 
 func (c *FSConfig) findOrCreateEnvironment(urlOrAlias string) (int, fsConfigSchema_Environment) {
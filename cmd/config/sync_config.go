@@ -0,0 +1,98 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/uaa"
+)
+
+// SyncConfig serializes access to config shared by concurrent sessions
+// so that tokens refreshed for different environments do not overwrite each other
+type SyncConfig struct {
+	config Config
+	mutex  sync.Mutex
+}
+
+func NewSyncConfig(config Config) *SyncConfig {
+	return &SyncConfig{config: config}
+}
+
+func (c *SyncConfig) Environments() []Environment {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.config.Environments()
+}
+
+func (c *SyncConfig) ResolveEnvironment(urlOrAlias string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.config.ResolveEnvironment(urlOrAlias)
+}
+
+func (c *SyncConfig) AliasEnvironment(url, alias, caCert string) (Config, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.config.AliasEnvironment(url, alias, caCert)
+}
+
+func (c *SyncConfig) UnaliasEnvironment(alias string) (Config, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.config.UnaliasEnvironment(alias)
+}
+
+func (c *SyncConfig) CACert(url string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.config.CACert(url)
+}
+
+func (c *SyncConfig) Credentials(url string) Creds {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.config.Credentials(url)
+}
+
+func (c *SyncConfig) SetCredentials(url string, creds Creds) Config {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.config.SetCredentials(url, creds)
+}
+
+func (c *SyncConfig) UnsetCredentials(url string) Config {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.config.UnsetCredentials(url)
+}
+
+// UpdateConfigWithToken keeps updated config so that subsequent updates build on it
+func (c *SyncConfig) UpdateConfigWithToken(environment string, t uaa.AccessToken) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	config := c.config.SetCredentials(environment, credsFromToken(t))
+
+	err := config.Save()
+	if err != nil {
+		return err
+	}
+
+	c.config = config
+
+	return nil
+}
+
+func (c *SyncConfig) Save() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.config.Save()
+}
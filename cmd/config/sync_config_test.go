@@ -0,0 +1,75 @@
+package config_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/config"
+	"github.com/cloudfoundry/bosh-cli/v7/uaa"
+)
+
+var _ = Describe("SyncConfig", func() {
+	var (
+		fs     *fakesys.FakeFileSystem
+		config *SyncConfig
+	)
+
+	readConfig := func() FSConfig {
+		config, err := NewFSConfigFromPath("/config", fs)
+		Expect(err).ToNot(HaveOccurred())
+
+		return config
+	}
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		config = NewSyncConfig(readConfig())
+	})
+
+	Describe("UpdateConfigWithToken", func() {
+		It("keeps tokens of all environments when updated concurrently", func() {
+			var wg sync.WaitGroup
+
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					token := uaa.NewRefreshableAccessToken("bearer", fmt.Sprintf("access-%d", i), fmt.Sprintf("refresh-%d", i))
+					Expect(config.UpdateConfigWithToken(fmt.Sprintf("env-%d", i), token)).To(Succeed())
+				}(i)
+			}
+
+			wg.Wait()
+
+			reloadedConfig := readConfig()
+
+			for i := 0; i < 10; i++ {
+				Expect(reloadedConfig.Credentials(fmt.Sprintf("env-%d", i))).To(Equal(Creds{
+					AccessToken:     fmt.Sprintf("access-%d", i),
+					AccessTokenType: "bearer",
+					RefreshToken:    fmt.Sprintf("refresh-%d", i),
+				}))
+			}
+
+			Expect(config.Credentials("env-0").AccessToken).To(Equal("access-0"))
+		})
+
+		It("returns an error and keeps previous config when save fails", func() {
+			fs.WriteFileError = errors.New("write error")
+
+			err := config.UpdateConfigWithToken("env", uaa.NewAccessToken("bearer", "access"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("write error"))
+
+			Expect(config.Credentials("env")).To(Equal(Creds{}))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"path/filepath"
 	"strings"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
@@ -9,34 +10,67 @@ import (
 	semver "github.com/cppforlife/go-semi-semantic/version"
 
 	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
 	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshsbom "github.com/cloudfoundry/bosh-cli/v7/release/sbom"
 	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
 	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
 )
 
 type CreateReleaseCmd struct {
 	releaseDirFactory func(DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir)
+	workspaceFactory  func(string) boshreldir.Workspace
 	releaseWriter     boshrel.Writer
+	sbomGenerator     boshsbom.Generator
+	tarballSigner     bicrypto.TarballSigner
 	fs                boshsys.FileSystem
 	ui                boshui.UI
 }
 
 func NewCreateReleaseCmd(
 	releaseDirFactory func(DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir),
+	workspaceFactory func(string) boshreldir.Workspace,
 	releaseWriter boshrel.Writer,
+	sbomGenerator boshsbom.Generator,
+	tarballSigner bicrypto.TarballSigner,
 	fs boshsys.FileSystem,
 	ui boshui.UI,
 ) CreateReleaseCmd {
-	return CreateReleaseCmd{releaseDirFactory, releaseWriter, fs, ui}
+	return CreateReleaseCmd{releaseDirFactory, workspaceFactory, releaseWriter, sbomGenerator, tarballSigner, fs, ui}
 }
 
 func (c CreateReleaseCmd) Run(opts CreateReleaseOpts) (boshrel.Release, error) {
+	if len(opts.Workspace.ExpandedPath) > 0 {
+		return nil, c.runWorkspace(opts)
+	}
+
 	releaseManifestReader, releaseDir := c.releaseDirFactory(opts.Directory)
+
+	return c.run(opts, releaseManifestReader, releaseDir)
+}
+
+func (c CreateReleaseCmd) run(opts CreateReleaseOpts, releaseManifestReader boshrel.Reader, releaseDir boshreldir.ReleaseDir) (boshrel.Release, error) {
 	manifestGiven := len(opts.Args.Manifest.Path) > 0
 
 	var release boshrel.Release
+	var sbomFormat boshsbom.Format
 	var err error
 
+	if len(opts.SBOM) > 0 {
+		sbomFormat, err = boshsbom.NewFormat(opts.SBOM)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(opts.SBOMFile.ExpandedPath) == 0 && len(opts.Tarball.ExpandedPath) == 0 {
+			return nil, bosherr.Error("Expected --tarball or --sbom-file to be specified to write software bill of materials")
+		}
+	}
+
+	if len(opts.SignKey.ExpandedPath) > 0 && len(opts.Tarball.ExpandedPath) == 0 {
+		return nil, bosherr.Error("Expected --tarball to be specified to sign release tarball")
+	}
+
 	if manifestGiven {
 		release, err = releaseManifestReader.Read(opts.Args.Manifest.Path)
 		if err != nil {
@@ -48,6 +82,13 @@ func (c CreateReleaseCmd) Run(opts CreateReleaseOpts) (boshrel.Release, error) {
 			return nil, err
 		}
 
+		if len(sbomFormat) > 0 {
+			err = c.sbomGenerator.AnnotateFromDir(release, opts.Directory.Path)
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Collecting software bill of materials")
+			}
+		}
+
 		if opts.Final {
 			err = c.finalizeRelease(releaseDir, release, opts)
 			if err != nil {
@@ -71,6 +112,20 @@ func (c CreateReleaseCmd) Run(opts CreateReleaseOpts) (boshrel.Release, error) {
 		if err != nil {
 			return nil, bosherr.WrapErrorf(err, "Moving release archive to final destination")
 		}
+
+		if len(opts.SignKey.ExpandedPath) > 0 {
+			err = c.signRelease(releaseDir, release, opts.SignKey.ExpandedPath, dstPath, opts.Final && !manifestGiven)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(sbomFormat) > 0 {
+		err = c.writeSBOM(release, sbomFormat, opts.SBOMFile.ExpandedPath, dstPath)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	ReleaseTables{Release: release, ArchivePath: dstPath}.Print(c.ui)
@@ -78,6 +133,94 @@ func (c CreateReleaseCmd) Run(opts CreateReleaseOpts) (boshrel.Release, error) {
 	return release, nil
 }
 
+// runWorkspace creates a release from each release directory of the workspace
+// after vendoring shared packages that the release directory requires;
+// shared packages are only uploaded to release blobstores when release is finalized
+func (c CreateReleaseCmd) runWorkspace(opts CreateReleaseOpts) error {
+	if len(opts.Args.Manifest.Path) > 0 || len(opts.Name) > 0 {
+		return bosherr.Error("Expected release manifest and '--name' not to be specified with '--workspace'")
+	}
+
+	workspace := c.workspaceFactory(opts.Workspace.ExpandedPath)
+
+	paths, err := workspace.ReleaseDirPaths()
+	if err != nil {
+		return err
+	}
+
+	if len(paths) > 1 {
+		if len(opts.Tarball.ExpandedPath) > 0 && !strings.Contains(opts.Tarball.ExpandedPath, "((name))") {
+			return bosherr.Error("Expected '--tarball' to contain '((name))' when workspace has multiple releases")
+		}
+
+		if len(opts.SBOMFile.ExpandedPath) > 0 {
+			return bosherr.Error("Expected '--sbom-file' not to be specified when workspace has multiple releases")
+		}
+	}
+
+	for _, path := range paths {
+		vendored, err := workspace.VendorSharedPackages(path)
+		if err != nil {
+			return err
+		}
+
+		if len(vendored) > 0 {
+			c.ui.PrintLinef("Vendored shared packages '%s' into '%s'", strings.Join(vendored, "', '"), path)
+		}
+
+		releaseOpts := opts
+		releaseOpts.Directory = DirOrCWDArg{Path: path}
+		releaseOpts.Workspace = FileArg{}
+
+		releaseReader, releaseDir := workspace.ReleaseDir(path)
+
+		_, err = c.run(releaseOpts, releaseReader, releaseDir)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Creating release from '%s'", path)
+		}
+	}
+
+	return nil
+}
+
+func (c CreateReleaseCmd) signRelease(releaseDir boshreldir.ReleaseDir, release boshrel.Release, keyPath, tarballPath string, final bool) error {
+	signature, err := c.tarballSigner.Sign(keyPath, tarballPath)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Signing release archive")
+	}
+
+	if final {
+		err = releaseDir.RecordFinalReleaseSignature(release, signature.KeyFingerprint, signature.Value)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Recording release archive signature")
+		}
+	}
+
+	c.ui.PrintLinef("Wrote signature '%s' with key '%s'", signature.Path, signature.KeyFingerprint)
+
+	return nil
+}
+
+func (c CreateReleaseCmd) writeSBOM(release boshrel.Release, format boshsbom.Format, sbomPath, tarballPath string) error {
+	if len(sbomPath) == 0 {
+		sbomPath = strings.TrimSuffix(tarballPath, filepath.Ext(tarballPath)) + format.FileExtension()
+	}
+
+	bytes, err := c.sbomGenerator.Generate(release, format)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Generating software bill of materials")
+	}
+
+	err = c.fs.WriteFile(sbomPath, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing software bill of materials to '%s'", sbomPath)
+	}
+
+	c.ui.PrintLinef("Wrote software bill of materials to '%s'", sbomPath)
+
+	return nil
+}
+
 func (c CreateReleaseCmd) buildRelease(releaseDir boshreldir.ReleaseDir, opts CreateReleaseOpts) (boshrel.Release, error) {
 	var err error
 
@@ -10,8 +10,12 @@ import (
 
 	"github.com/cloudfoundry/bosh-cli/v7/cmd"
 	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
+	fakecrypto "github.com/cloudfoundry/bosh-cli/v7/crypto/fakes"
 	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
 	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
+	boshsbom "github.com/cloudfoundry/bosh-cli/v7/release/sbom"
+	fakesbom "github.com/cloudfoundry/bosh-cli/v7/release/sbom/sbomfakes"
 	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
 	fakereldir "github.com/cloudfoundry/bosh-cli/v7/releasedir/releasedirfakes"
 	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
@@ -22,9 +26,14 @@ var _ = Describe("CreateReleaseCmd", func() {
 	var (
 		releaseReader *fakerel.FakeReader
 		releaseDir    *fakereldir.FakeReleaseDir
+		workspace     *fakereldir.FakeWorkspace
+		apiDir        *fakereldir.FakeReleaseDir
+		workerDir     *fakereldir.FakeReleaseDir
 		ui            *fakeui.FakeUI
 		fakeFS        *fakesys.FakeFileSystem
 		fakeWriter    *fakerel.FakeWriter
+		sbomGenerator *fakesbom.FakeGenerator
+		tarballSigner *fakecrypto.FakeTarballSigner
 		command       cmd.CreateReleaseCmd
 	)
 
@@ -32,15 +41,34 @@ var _ = Describe("CreateReleaseCmd", func() {
 		releaseReader = &fakerel.FakeReader{}
 		releaseDir = &fakereldir.FakeReleaseDir{}
 
+		workspace = &fakereldir.FakeWorkspace{}
+		apiDir = &fakereldir.FakeReleaseDir{}
+		workerDir = &fakereldir.FakeReleaseDir{}
+
 		releaseDirFactory := func(dir opts.DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir) {
 			Expect(dir).To(Equal(opts.DirOrCWDArg{Path: "/dir"}))
 			return releaseReader, releaseDir
 		}
 
+		workspace.ReleaseDirStub = func(path string) (boshrel.Reader, boshreldir.ReleaseDir) {
+			if path == "/ws/api" {
+				return releaseReader, apiDir
+			}
+			Expect(path).To(Equal("/ws/worker"))
+			return releaseReader, workerDir
+		}
+
+		workspaceFactory := func(path string) boshreldir.Workspace {
+			Expect(path).To(Equal("/ws/workspace.yml"))
+			return workspace
+		}
+
 		fakeWriter = &fakerel.FakeWriter{}
 		fakeFS = fakesys.NewFakeFileSystem()
 		ui = &fakeui.FakeUI{}
-		command = cmd.NewCreateReleaseCmd(releaseDirFactory, fakeWriter, fakeFS, ui)
+		sbomGenerator = &fakesbom.FakeGenerator{}
+		tarballSigner = fakecrypto.NewFakeTarballSigner()
+		command = cmd.NewCreateReleaseCmd(releaseDirFactory, workspaceFactory, fakeWriter, sbomGenerator, tarballSigner, fakeFS, ui)
 	})
 
 	Describe("Run", func() {
@@ -502,6 +530,287 @@ var _ = Describe("CreateReleaseCmd", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-err"))
 			})
+
+			Context("when sign key is given", func() {
+				BeforeEach(func() {
+					createReleaseOpts.SignKey = opts.FileArg{ExpandedPath: "/key.pem"}
+					createReleaseOpts.Tarball = opts.FileArg{ExpandedPath: "/rel.tgz"}
+
+					releaseDir.DefaultNameReturns("default-rel-name", nil)
+					releaseDir.NextDevVersionReturns(semver.MustNewVersionFromString("next-dev+ver"), nil)
+					releaseDir.NextFinalVersionReturns(semver.MustNewVersionFromString("1"), nil)
+					releaseDir.BuildReleaseReturns(release, nil)
+
+					fakeWriter.WriteStub = func(rel boshrel.Release, skipPkgs []string) (string, error) {
+						err := fakeFS.WriteFileString("/temp-tarball.tgz", "release content blah")
+						Expect(err).ToNot(HaveOccurred())
+						return "/temp-tarball.tgz", nil
+					}
+
+					tarballSigner.SignSignature = bicrypto.TarballSignature{
+						Path: "/rel.tgz.sig", KeyFingerprint: "SHA256:fp", Value: "sig"}
+				})
+
+				It("signs release tarball at its final destination", func() {
+					err := act()
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(tarballSigner.SignInputs).To(Equal([]fakecrypto.TarballSignerInput{
+						{KeyPath: "/key.pem", TarballPath: "/rel.tgz"},
+					}))
+					Expect(ui.Said).To(ContainElement("Wrote signature '/rel.tgz.sig' with key 'SHA256:fp'"))
+
+					Expect(releaseDir.RecordFinalReleaseSignatureCallCount()).To(Equal(0))
+				})
+
+				It("records signature of final release in final release index", func() {
+					createReleaseOpts.Final = true
+
+					err := act()
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(releaseDir.RecordFinalReleaseSignatureCallCount()).To(Equal(1))
+					signedRel, fingerprint, sig := releaseDir.RecordFinalReleaseSignatureArgsForCall(0)
+					Expect(signedRel).To(Equal(release))
+					Expect(fingerprint).To(Equal("SHA256:fp"))
+					Expect(sig).To(Equal("sig"))
+				})
+
+				It("returns error if tarball is not requested", func() {
+					createReleaseOpts.Tarball = opts.FileArg{}
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("Expected --tarball to be specified to sign release tarball"))
+					Expect(releaseDir.BuildReleaseCallCount()).To(Equal(0))
+				})
+
+				It("returns error if signing fails", func() {
+					tarballSigner.SignErr = errors.New("fake-err")
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("fake-err"))
+				})
+
+				It("returns error if recording signature fails", func() {
+					createReleaseOpts.Final = true
+					releaseDir.RecordFinalReleaseSignatureReturns(errors.New("fake-err"))
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("fake-err"))
+				})
+			})
+
+			Context("when software bill of materials is requested", func() {
+				BeforeEach(func() {
+					createReleaseOpts.SBOM = "cyclonedx"
+					createReleaseOpts.Tarball = opts.FileArg{ExpandedPath: "/rel.tgz"}
+
+					releaseDir.DefaultNameReturns("default-rel-name", nil)
+					releaseDir.NextDevVersionReturns(semver.MustNewVersionFromString("next-dev+ver"), nil)
+					releaseDir.BuildReleaseReturns(release, nil)
+
+					fakeWriter.WriteStub = func(rel boshrel.Release, skipPkgs []string) (string, error) {
+						err := fakeFS.WriteFileString("/temp-tarball.tgz", "release content blah")
+						Expect(err).ToNot(HaveOccurred())
+						return "/temp-tarball.tgz", nil
+					}
+
+					sbomGenerator.GenerateReturns([]byte("sbom-content"), nil)
+				})
+
+				It("annotates release from release directory and writes document next to tarball", func() {
+					err := act()
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(sbomGenerator.AnnotateFromDirCallCount()).To(Equal(1))
+					annotatedRel, dirPath := sbomGenerator.AnnotateFromDirArgsForCall(0)
+					Expect(annotatedRel).To(Equal(release))
+					Expect(dirPath).To(Equal("/dir"))
+
+					Expect(sbomGenerator.GenerateCallCount()).To(Equal(1))
+					generatedRel, format := sbomGenerator.GenerateArgsForCall(0)
+					Expect(generatedRel).To(Equal(release))
+					Expect(format).To(Equal(boshsbom.CycloneDXFormat))
+
+					content, err := fakeFS.ReadFileString("/rel.cdx.json")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(content).To(Equal("sbom-content"))
+				})
+
+				It("annotates release before finalizing it", func() {
+					createReleaseOpts.Final = true
+					releaseDir.NextFinalVersionReturns(semver.MustNewVersionFromString("1"), nil)
+
+					releaseDir.FinalizeReleaseStub = func(boshrel.Release, bool) error {
+						Expect(sbomGenerator.AnnotateFromDirCallCount()).To(Equal(1))
+						return nil
+					}
+
+					err := act()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(releaseDir.FinalizeReleaseCallCount()).To(Equal(1))
+				})
+
+				It("writes document to custom path", func() {
+					createReleaseOpts.SBOMFile = opts.FileArg{ExpandedPath: "/custom/sbom.json"}
+
+					err := act()
+					Expect(err).ToNot(HaveOccurred())
+
+					content, err := fakeFS.ReadFileString("/custom/sbom.json")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(content).To(Equal("sbom-content"))
+				})
+
+				It("returns error for unknown format before building release", func() {
+					createReleaseOpts.SBOM = "unknown"
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("Expected software bill of materials format 'unknown'"))
+					Expect(releaseDir.BuildReleaseCallCount()).To(Equal(0))
+				})
+
+				It("returns error if there is nowhere to write document", func() {
+					createReleaseOpts.Tarball = opts.FileArg{}
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("Expected --tarball or --sbom-file"))
+				})
+
+				It("returns error if annotating release fails", func() {
+					sbomGenerator.AnnotateFromDirReturns(errors.New("fake-err"))
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("fake-err"))
+				})
+
+				It("returns error if generating document fails", func() {
+					sbomGenerator.GenerateReturns(nil, errors.New("fake-err"))
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("fake-err"))
+				})
+			})
+		})
+
+		Context("when workspace is provided", func() {
+			BeforeEach(func() {
+				createReleaseOpts.Workspace = opts.FileArg{ExpandedPath: "/ws/workspace.yml"}
+
+				workspace.ReleaseDirPathsReturns([]string{"/ws/api", "/ws/worker"}, nil)
+				workspace.VendorSharedPackagesStub = func(path string) ([]string, error) {
+					if path == "/ws/api" {
+						return []string{"golang", "openssl"}, nil
+					}
+					return nil, nil
+				}
+
+				for _, dir := range []*fakereldir.FakeReleaseDir{apiDir, workerDir} {
+					dir.NextDevVersionReturns(semver.MustNewVersionFromString("1+dev.1"), nil)
+					dir.BuildReleaseReturns(release, nil)
+				}
+
+				apiDir.DefaultNameReturns("api", nil)
+				workerDir.DefaultNameReturns("worker", nil)
+			})
+
+			It("vendors shared packages and builds release in each release directory", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(workspace.VendorSharedPackagesCallCount()).To(Equal(2))
+				Expect(workspace.VendorSharedPackagesArgsForCall(0)).To(Equal("/ws/api"))
+				Expect(workspace.VendorSharedPackagesArgsForCall(1)).To(Equal("/ws/worker"))
+
+				Expect(ui.Said).To(ContainElement("Vendored shared packages 'golang', 'openssl' into '/ws/api'"))
+
+				Expect(apiDir.BuildReleaseCallCount()).To(Equal(1))
+				name, _, _ := apiDir.BuildReleaseArgsForCall(0)
+				Expect(name).To(Equal("api"))
+
+				Expect(workerDir.BuildReleaseCallCount()).To(Equal(1))
+				name, _, _ = workerDir.BuildReleaseArgsForCall(0)
+				Expect(name).To(Equal("worker"))
+
+				Expect(releaseDir.BuildReleaseCallCount()).To(Equal(0))
+
+				// Dev releases do not upload shared packages into release blobstores
+				Expect(apiDir.VendorPackageCallCount()).To(Equal(0))
+				Expect(apiDir.FinalizeReleaseCallCount()).To(Equal(0))
+				Expect(workerDir.FinalizeReleaseCallCount()).To(Equal(0))
+			})
+
+			It("finalizes release in each release directory", func() {
+				createReleaseOpts.Final = true
+
+				apiDir.NextFinalVersionReturns(semver.MustNewVersionFromString("2"), nil)
+				workerDir.NextFinalVersionReturns(semver.MustNewVersionFromString("3"), nil)
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(apiDir.FinalizeReleaseCallCount()).To(Equal(1))
+				Expect(workerDir.FinalizeReleaseCallCount()).To(Equal(1))
+			})
+
+			It("returns error if manifest path or name is provided", func() {
+				createReleaseOpts.Name = "custom-name"
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected release manifest and '--name' not to be specified with '--workspace'"))
+			})
+
+			It("returns error if tarball path does not distinguish releases", func() {
+				createReleaseOpts.Tarball = opts.FileArg{ExpandedPath: "/release.tgz"}
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected '--tarball' to contain '((name))' when workspace has multiple releases"))
+				Expect(workspace.VendorSharedPackagesCallCount()).To(Equal(0))
+			})
+
+			It("returns error if software bill of materials path does not distinguish releases", func() {
+				createReleaseOpts.SBOMFile = opts.FileArg{ExpandedPath: "/sbom.json"}
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected '--sbom-file' not to be specified when workspace has multiple releases"))
+			})
+
+			It("returns error if reading workspace fails", func() {
+				workspace.ReleaseDirPathsReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+
+			It("returns error if vendoring shared packages fails", func() {
+				workspace.VendorSharedPackagesStub = nil
+				workspace.VendorSharedPackagesReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+				Expect(apiDir.BuildReleaseCallCount()).To(Equal(0))
+			})
+
+			It("returns error if building release fails", func() {
+				apiDir.BuildReleaseReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Creating release from '/ws/api'"))
+				Expect(workerDir.BuildReleaseCallCount()).To(Equal(0))
+			})
 		})
 	})
 })
@@ -15,6 +15,7 @@ type DeployCmd struct {
 	deployment      boshdir.Deployment
 	releaseUploader ReleaseUploader
 	director        boshdir.Director
+	lockWaiter      DeploymentLockWaiter
 }
 
 type ReleaseUploader interface {
@@ -33,8 +34,9 @@ func NewDeployCmd(
 	deployment boshdir.Deployment,
 	releaseUploader ReleaseUploader,
 	director boshdir.Director,
+	lockWaiter DeploymentLockWaiter,
 ) DeployCmd {
-	return DeployCmd{ui, deployment, releaseUploader, director}
+	return DeployCmd{ui, deployment, releaseUploader, director, lockWaiter}
 }
 
 func (c DeployCmd) Run(opts DeployOpts) error {
@@ -118,6 +120,12 @@ func (c DeployCmd) Run(opts DeployOpts) error {
 		ForceLatestVariables:     opts.ForceLatestVariables,
 	}
 
+	// Wait as late as possible since uploading releases may take a while
+	err = c.lockWaiter.Wait(c.deployment.Name(), opts.WaitForLockFlags)
+	if err != nil {
+		return err
+	}
+
 	return c.deployment.Update(bytes, updateOpts)
 }
 
@@ -23,6 +23,7 @@ var _ = Describe("DeployCmd", func() {
 		deployment      *fakedir.FakeDeployment
 		releaseUploader *fakecmd.FakeReleaseUploader
 		director        *fakedir.FakeDirector
+		lockWaiter      *fakecmd.FakeDeploymentLockWaiter
 		command         cmd.DeployCmd
 	)
 
@@ -37,8 +38,9 @@ var _ = Describe("DeployCmd", func() {
 		}
 
 		director = &fakedir.FakeDirector{}
+		lockWaiter = &fakecmd.FakeDeploymentLockWaiter{}
 
-		command = cmd.NewDeployCmd(ui, deployment, releaseUploader, director)
+		command = cmd.NewDeployCmd(ui, deployment, releaseUploader, director, lockWaiter)
 	})
 
 	Describe("Run", func() {
@@ -336,6 +338,39 @@ releases:
 			Expect(updateOptions.Diff).To(Equal(expectedDiff))
 		})
 
+		It("waits for deployment lock after uploading releases and right before deploying", func() {
+			waitForLock := 10 * time.Minute
+			deployOpts.WaitForLockFlags = opts.WaitForLockFlags{WaitForLock: &waitForLock}
+
+			lockWaiter.WaitStub = func(string, opts.WaitForLockFlags) error {
+				Expect(releaseUploader.UploadReleasesCallCount()).To(Equal(1))
+				Expect(ui.AskedConfirmationCalled).To(BeTrue())
+				Expect(deployment.UpdateCallCount()).To(Equal(0))
+				return nil
+			}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(lockWaiter.WaitCallCount()).To(Equal(1))
+
+			name, flags := lockWaiter.WaitArgsForCall(0)
+			Expect(name).To(Equal("dep"))
+			Expect(flags).To(Equal(deployOpts.WaitForLockFlags))
+
+			Expect(deployment.UpdateCallCount()).To(Equal(1))
+		})
+
+		It("returns error without deploying if waiting for lock failed", func() {
+			lockWaiter.WaitReturns(errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+			Expect(deployment.UpdateCallCount()).To(Equal(0))
+		})
+
 		It("returns error if deploying failed", func() {
 			deployment.UpdateReturns(errors.New("fake-err"))
 
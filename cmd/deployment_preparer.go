@@ -215,7 +215,7 @@ func (c *DeploymentPreparer) PrepareDeployment(stage biui.Stage, recreate bool,
 		}
 
 		if stemcellApiVersion >= bicloud.StemcellNoRegistryAsOfVersion &&
-			cpiInfo.ApiVersion == bicloud.MaxCpiApiVersionSupported {
+			cpiInfo.ApiVersion >= bicloud.RegistrylessCpiApiVersion {
 			return deploy()
 		} else {
 			return bosherr.Errorf(
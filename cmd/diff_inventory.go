@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshinv "github.com/cloudfoundry/bosh-cli/v7/director/inventory"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type DiffInventoryCmd struct {
+	ui boshui.UI
+}
+
+func NewDiffInventoryCmd(ui boshui.UI) DiffInventoryCmd {
+	return DiffInventoryCmd{ui: ui}
+}
+
+func (c DiffInventoryCmd) Run(opts DiffInventoryOpts) error {
+	from, err := c.unmarshal(opts.Args.From)
+	if err != nil {
+		return err
+	}
+
+	to, err := c.unmarshal(opts.Args.To)
+	if err != nil {
+		return err
+	}
+
+	c.ui.PrintLinef("Comparing inventory of director '%s' with '%s'", from.Director.Name, to.Director.Name)
+
+	result := boshinv.NewDiffer().Diff(from, to)
+
+	if result.Empty() {
+		c.ui.PrintLinef("No differences")
+		return nil
+	}
+
+	c.printDirector(result.Director)
+	c.printVersions("releases", "Release", result.Releases)
+	c.printVersions("stemcells", "Stemcell", result.Stemcells)
+	c.printConfigs(result.Configs)
+	c.printDeployments(result.Deployments)
+
+	return nil
+}
+
+func (c DiffInventoryCmd) unmarshal(arg FileBytesWithPathArg) (boshinv.Inventory, error) {
+	var inv boshinv.Inventory
+
+	err := json.Unmarshal(arg.Bytes, &inv)
+	if err != nil {
+		return inv, bosherr.WrapErrorf(err, "Unmarshaling inventory '%s'", arg.Path)
+	}
+
+	return inv, nil
+}
+
+func (c DiffInventoryCmd) printDirector(changes []boshinv.DirectorChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	table := boshtbl.Table{
+		Content: "director",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Property"),
+			boshtbl.NewHeader("From"),
+			boshtbl.NewHeader("To"),
+		},
+	}
+
+	for _, change := range changes {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(change.Property),
+			boshtbl.NewValueString(change.From),
+			boshtbl.NewValueString(change.To),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func (c DiffInventoryCmd) printVersions(content, header string, changes []boshinv.VersionChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	table := boshtbl.Table{
+		Content: content,
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader(header),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("From"),
+			boshtbl.NewHeader("To"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	for _, change := range changes {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(change.Name),
+			boshtbl.NewValueString(string(change.Change)),
+			boshtbl.NewValueStrings(change.From),
+			boshtbl.NewValueStrings(change.To),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func (c DiffInventoryCmd) printConfigs(changes []boshinv.ConfigChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	table := boshtbl.Table{
+		Content: "configs",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Type"),
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("From ID"),
+			boshtbl.NewHeader("To ID"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}},
+	}
+
+	for _, change := range changes {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(change.Type),
+			boshtbl.NewValueString(change.Name),
+			boshtbl.NewValueString(string(change.Change)),
+			boshtbl.NewValueString(change.FromID),
+			boshtbl.NewValueString(change.ToID),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func (c DiffInventoryCmd) printDeployments(changes []boshinv.DeploymentChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	table := boshtbl.Table{
+		Content: "deployments",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Deployment"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("Details"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	for _, change := range changes {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(change.Name),
+			boshtbl.NewValueString(string(change.Change)),
+			boshtbl.NewValueString(strings.Join(change.Details, "\n")),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
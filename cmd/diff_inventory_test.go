@@ -0,0 +1,136 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("DiffInventoryCmd", func() {
+	var (
+		ui      *fakeui.FakeUI
+		command DiffInventoryCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		command = NewDiffInventoryCmd(ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			diffInventoryOpts DiffInventoryOpts
+		)
+
+		BeforeEach(func() {
+			diffInventoryOpts = DiffInventoryOpts{
+				Args: DiffInventoryArgs{
+					From: FileBytesWithPathArg{
+						Path: "a.json",
+						Bytes: []byte(`{
+  "director": {"name": "a", "version": "280.0.0"},
+  "deployments": [{"name": "dep", "releases": [{"name": "rel", "version": "1"}]}],
+  "releases": [{"name": "rel", "version": "1"}],
+  "stemcells": [{"name": "stemcell", "version": "1.1"}],
+  "configs": [{"id": "1", "type": "cloud", "name": "default", "content": "vm_types: []"}]
+}`),
+					},
+					To: FileBytesWithPathArg{
+						Path: "b.json",
+						Bytes: []byte(`{
+  "director": {"name": "b", "version": "280.0.0"},
+  "deployments": [{"name": "dep", "releases": [{"name": "rel", "version": "1"}]}],
+  "releases": [{"name": "rel", "version": "1"}],
+  "stemcells": [{"name": "stemcell", "version": "1.1"}],
+  "configs": [{"id": "5", "type": "cloud", "name": "default", "content": "vm_types: []"}]
+}`),
+					},
+				},
+			}
+		})
+
+		act := func() error { return command.Run(diffInventoryOpts) }
+
+		It("prints that there are no differences", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Said).To(Equal([]string{
+				"Comparing inventory of director 'a' with 'b'",
+				"No differences",
+			}))
+			Expect(ui.Tables).To(BeEmpty())
+		})
+
+		It("prints tables with differences", func() {
+			diffInventoryOpts.Args.To.Bytes = []byte(`{
+  "director": {"name": "b", "version": "281.0.0"},
+  "deployments": [{"name": "dep", "releases": [{"name": "rel", "version": "2"}]}, {"name": "other"}],
+  "releases": [{"name": "rel", "version": "2"}],
+  "stemcells": [{"name": "stemcell", "version": "1.1"}],
+  "configs": [{"id": "5", "type": "cloud", "name": "default", "content": "vm_types: [small]"}]
+}`)
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables).To(HaveLen(4))
+
+			Expect(ui.Tables[0].Content).To(Equal("director"))
+			Expect(ui.Tables[0].Rows).To(Equal([][]boshtbl.Value{
+				{boshtbl.NewValueString("version"), boshtbl.NewValueString("280.0.0"), boshtbl.NewValueString("281.0.0")},
+			}))
+
+			Expect(ui.Tables[1]).To(Equal(boshtbl.Table{
+				Content: "releases",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Release"),
+					boshtbl.NewHeader("Change"),
+					boshtbl.NewHeader("From"),
+					boshtbl.NewHeader("To"),
+				},
+
+				SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("rel"),
+						boshtbl.NewValueString("changed"),
+						boshtbl.NewValueStrings([]string{"1"}),
+						boshtbl.NewValueStrings([]string{"2"}),
+					},
+				},
+			}))
+
+			Expect(ui.Tables[2].Content).To(Equal("configs"))
+			Expect(ui.Tables[2].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("cloud"),
+					boshtbl.NewValueString("default"),
+					boshtbl.NewValueString("changed"),
+					boshtbl.NewValueString("1"),
+					boshtbl.NewValueString("5"),
+				},
+			}))
+
+			Expect(ui.Tables[3].Content).To(Equal("deployments"))
+			Expect(ui.Tables[3].Rows).To(Equal([][]boshtbl.Value{
+				{boshtbl.NewValueString("dep"), boshtbl.NewValueString("changed"), boshtbl.NewValueString("release 'rel' 1 -> 2")},
+				{boshtbl.NewValueString("other"), boshtbl.NewValueString("added"), boshtbl.NewValueString("")},
+			}))
+		})
+
+		It("returns error if inventory cannot be unmarshaled", func() {
+			diffInventoryOpts.Args.To.Bytes = []byte("invalid")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unmarshaling inventory 'b.json'"))
+		})
+	})
+})
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	semver "github.com/cppforlife/go-semi-semantic/version"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshreldiff "github.com/cloudfoundry/bosh-cli/v7/release/diff"
+	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type DiffReleasesCmd struct {
+	archiveReader     boshrel.Reader
+	releaseDirFactory func(DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir, boshreldir.BlobsDir)
+	indiciesFactory   func(DirOrCWDArg) (boshrel.ArchiveIndicies, boshrel.ArchiveIndicies)
+	differ            boshreldiff.Differ
+	parallel          int
+	ui                boshui.UI
+}
+
+func NewDiffReleasesCmd(
+	archiveReader boshrel.Reader,
+	releaseDirFactory func(DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir, boshreldir.BlobsDir),
+	indiciesFactory func(DirOrCWDArg) (boshrel.ArchiveIndicies, boshrel.ArchiveIndicies),
+	differ boshreldiff.Differ,
+	parallel int,
+	ui boshui.UI,
+) DiffReleasesCmd {
+	return DiffReleasesCmd{
+		archiveReader:     archiveReader,
+		releaseDirFactory: releaseDirFactory,
+		indiciesFactory:   indiciesFactory,
+		differ:            differ,
+		parallel:          parallel,
+		ui:                ui,
+	}
+}
+
+func (c DiffReleasesCmd) Run(opts DiffReleasesOpts) error {
+	from, to, err := c.readReleases(opts)
+	if err != nil {
+		return err
+	}
+
+	defer from.CleanUp() //nolint:errcheck
+	defer to.CleanUp()   //nolint:errcheck
+
+	c.ui.PrintLinef("Comparing release '%s/%s' with '%s/%s'", from.Name(), from.Version(), to.Name(), to.Version())
+
+	result, err := c.differ.Diff(from, to)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Comparing releases")
+	}
+
+	c.printResources("jobs", result.Jobs)
+	c.printResources("packages", result.Packages)
+	c.printProperties(result.Properties)
+	c.printLinks(result.Links)
+	c.printTemplates(result.Templates)
+	c.printPackageFiles(result.PackageFiles)
+
+	return nil
+}
+
+func (c DiffReleasesCmd) readReleases(opts DiffReleasesOpts) (boshrel.Release, boshrel.Release, error) {
+	if len(opts.FromDir.Path) == 0 {
+		if len(opts.Args.From) == 0 || len(opts.Args.To) == 0 {
+			return nil, nil, bosherr.Error("Expected FROM and TO release tarballs or '--from-dir' to be specified")
+		}
+
+		from, err := c.archiveReader.Read(opts.Args.From)
+		if err != nil {
+			return nil, nil, bosherr.WrapErrorf(err, "Reading release from '%s'", opts.Args.From)
+		}
+
+		to, err := c.archiveReader.Read(opts.Args.To)
+		if err != nil {
+			from.CleanUp() //nolint:errcheck
+			return nil, nil, bosherr.WrapErrorf(err, "Reading release from '%s'", opts.Args.To)
+		}
+
+		return from, to, nil
+	}
+
+	if len(opts.Args.From) > 0 || len(opts.Args.To) > 0 {
+		return nil, nil, bosherr.Error("Expected release tarballs not to be specified with '--from-dir'")
+	}
+
+	releaseReader, releaseDir, blobsDir := c.releaseDirFactory(opts.FromDir)
+
+	from, err := releaseDir.FindRelease("", semver.Version(opts.FromVersion))
+	if err != nil {
+		return nil, nil, bosherr.WrapErrorf(err, "Finding release in directory '%s'", opts.FromDir.Path)
+	}
+
+	err = blobsDir.SyncBlobs(c.parallel)
+	if err != nil {
+		from.CleanUp() //nolint:errcheck
+		return nil, nil, err
+	}
+
+	to, err := releaseReader.Read(opts.FromDir.Path)
+	if err != nil {
+		from.CleanUp() //nolint:errcheck
+		return nil, nil, bosherr.WrapErrorf(err, "Reading release from directory '%s'", opts.FromDir.Path)
+	}
+
+	// Differ extracts archives hence jobs and packages
+	// must be found in (or built into) dev and final indices
+	devIndicies, finalIndicies := c.indiciesFactory(opts.FromDir)
+
+	for _, release := range []boshrel.Release{from, to} {
+		err = release.Build(devIndicies, finalIndicies, c.parallel)
+		if err != nil {
+			from.CleanUp() //nolint:errcheck
+			to.CleanUp()   //nolint:errcheck
+			return nil, nil, bosherr.WrapErrorf(err, "Building release '%s/%s'", release.Name(), release.Version())
+		}
+	}
+
+	return from, to, nil
+}
+
+func (c DiffReleasesCmd) printResources(content string, changes []boshreldiff.ResourceChange) {
+	table := boshtbl.Table{
+		Content: content,
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("From Fingerprint"),
+			boshtbl.NewHeader("To Fingerprint"),
+		},
+	}
+
+	for _, change := range changes {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(change.Name),
+			boshtbl.NewValueString(string(change.Change)),
+			boshtbl.NewValueString(change.FromFingerprint),
+			boshtbl.NewValueString(change.ToFingerprint),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func (c DiffReleasesCmd) printProperties(changes []boshreldiff.PropertyChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	table := boshtbl.Table{
+		Content: "properties",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Property"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("From Default"),
+			boshtbl.NewHeader("To Default"),
+		},
+	}
+
+	for _, change := range changes {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(change.Job),
+			boshtbl.NewValueString(change.Property),
+			boshtbl.NewValueString(string(change.Change)),
+			boshtbl.NewValueString(change.FromDefault),
+			boshtbl.NewValueString(change.ToDefault),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func (c DiffReleasesCmd) printLinks(changes []boshreldiff.LinkChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	table := boshtbl.Table{
+		Content: "links",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Link"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("From"),
+			boshtbl.NewHeader("To"),
+		},
+	}
+
+	for _, change := range changes {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(change.Job),
+			boshtbl.NewValueString(change.Link),
+			boshtbl.NewValueString(string(change.Change)),
+			boshtbl.NewValueString(change.From),
+			boshtbl.NewValueString(change.To),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func (c DiffReleasesCmd) printTemplates(changes []boshreldiff.TemplateChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	table := boshtbl.Table{
+		Content: "templates",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Template"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("Diff"),
+		},
+	}
+
+	for _, change := range changes {
+		var lines [][]interface{}
+
+		for _, line := range change.Lines {
+			lines = append(lines, []interface{}{line.Text, line.Mod})
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(change.Job),
+			boshtbl.NewValueString(change.Template),
+			boshtbl.NewValueString(string(change.Change)),
+			boshtbl.NewValueString(NewDiff(lines).String()),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func (c DiffReleasesCmd) printPackageFiles(changes []boshreldiff.FileChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	table := boshtbl.Table{
+		Content: "package files",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Package"),
+			boshtbl.NewHeader("File"),
+			boshtbl.NewHeader("Change"),
+		},
+	}
+
+	for _, change := range changes {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(change.Package),
+			boshtbl.NewValueString(change.File),
+			boshtbl.NewValueString(string(change.Change)),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
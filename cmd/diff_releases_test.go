@@ -0,0 +1,320 @@
+package cmd_test
+
+import (
+	"errors"
+
+	semver "github.com/cppforlife/go-semi-semantic/version"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshreldiff "github.com/cloudfoundry/bosh-cli/v7/release/diff"
+	fakereldiff "github.com/cloudfoundry/bosh-cli/v7/release/diff/difffakes"
+	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
+	fakeres "github.com/cloudfoundry/bosh-cli/v7/release/resource/resourcefakes"
+	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/v7/releasedir/releasedirfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("DiffReleasesCmd", func() {
+	var (
+		archiveReader *fakerel.FakeReader
+		releaseReader *fakerel.FakeReader
+		releaseDir    *fakereldir.FakeReleaseDir
+		blobsDir      *fakereldir.FakeBlobsDir
+		differ        *fakereldiff.FakeDiffer
+		ui            *fakeui.FakeUI
+		command       DiffReleasesCmd
+
+		devIndicies, finalIndicies boshrel.ArchiveIndicies
+
+		fromRelease, toRelease *fakerel.FakeRelease
+	)
+
+	BeforeEach(func() {
+		archiveReader = &fakerel.FakeReader{}
+		releaseReader = &fakerel.FakeReader{}
+		releaseDir = &fakereldir.FakeReleaseDir{}
+		blobsDir = &fakereldir.FakeBlobsDir{}
+		differ = &fakereldiff.FakeDiffer{}
+		ui = &fakeui.FakeUI{}
+
+		releaseDirFactory := func(dir DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir, boshreldir.BlobsDir) {
+			Expect(dir).To(Equal(DirOrCWDArg{Path: "/dir"}))
+			return releaseReader, releaseDir, blobsDir
+		}
+
+		devIndicies = boshrel.ArchiveIndicies{Jobs: &fakeres.FakeArchiveIndex{}}
+		finalIndicies = boshrel.ArchiveIndicies{Jobs: &fakeres.FakeArchiveIndex{}}
+
+		indiciesFactory := func(dir DirOrCWDArg) (boshrel.ArchiveIndicies, boshrel.ArchiveIndicies) {
+			Expect(dir).To(Equal(DirOrCWDArg{Path: "/dir"}))
+			return devIndicies, finalIndicies
+		}
+
+		command = NewDiffReleasesCmd(archiveReader, releaseDirFactory, indiciesFactory, differ, 3, ui)
+
+		fromRelease = &fakerel.FakeRelease{}
+		fromRelease.NameReturns("rel")
+		fromRelease.VersionReturns("1")
+
+		toRelease = &fakerel.FakeRelease{}
+		toRelease.NameReturns("rel")
+		toRelease.VersionReturns("2")
+	})
+
+	Context("when comparing release tarballs", func() {
+		var opts DiffReleasesOpts
+
+		BeforeEach(func() {
+			opts = DiffReleasesOpts{Args: DiffReleasesArgs{From: "/from.tgz", To: "/to.tgz"}}
+
+			archiveReader.ReadStub = func(path string) (boshrel.Release, error) {
+				if path == "/from.tgz" {
+					return fromRelease, nil
+				}
+				return toRelease, nil
+			}
+		})
+
+		It("prints changed jobs, packages, properties, links, templates and package files", func() {
+			differ.DiffReturns(boshreldiff.Result{
+				Jobs: []boshreldiff.ResourceChange{
+					{Name: "web", Change: boshreldiff.Changed, FromFingerprint: "fp1", ToFingerprint: "fp2"},
+				},
+				Packages: []boshreldiff.ResourceChange{
+					{Name: "ruby", Change: boshreldiff.Added, ToFingerprint: "fp3"},
+				},
+				Properties: []boshreldiff.PropertyChange{
+					{Job: "web", Property: "port", Change: boshreldiff.Changed, FromDefault: "80", ToDefault: "8080"},
+				},
+				Links: []boshreldiff.LinkChange{
+					{Job: "web", Link: "consumes db", Change: boshreldiff.Added, To: "postgres"},
+				},
+				Templates: []boshreldiff.TemplateChange{
+					{Job: "web", Template: "templates/ctl.erb", Change: boshreldiff.Changed, Lines: []boshreldiff.Line{
+						{Text: "a"},
+						{Text: "b", Mod: "removed"},
+						{Text: "c", Mod: "added"},
+					}},
+				},
+				PackageFiles: []boshreldiff.FileChange{
+					{Package: "ruby", File: "ruby/ruby-3.2.tgz", Change: boshreldiff.Added},
+				},
+			}, nil)
+
+			err := command.Run(opts)
+			Expect(err).ToNot(HaveOccurred())
+
+			from, to := differ.DiffArgsForCall(0)
+			Expect(from).To(Equal(fromRelease))
+			Expect(to).To(Equal(toRelease))
+
+			Expect(ui.Said).To(ContainElement("Comparing release 'rel/1' with 'rel/2'"))
+
+			Expect(ui.Tables).To(HaveLen(6))
+
+			Expect(ui.Tables[0].Content).To(Equal("jobs"))
+			Expect(ui.Tables[0].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("changed"),
+					boshtbl.NewValueString("fp1"),
+					boshtbl.NewValueString("fp2"),
+				},
+			}))
+
+			Expect(ui.Tables[1].Content).To(Equal("packages"))
+			Expect(ui.Tables[1].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("ruby"),
+					boshtbl.NewValueString("added"),
+					boshtbl.NewValueString(""),
+					boshtbl.NewValueString("fp3"),
+				},
+			}))
+
+			Expect(ui.Tables[2].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("port"),
+					boshtbl.NewValueString("changed"),
+					boshtbl.NewValueString("80"),
+					boshtbl.NewValueString("8080"),
+				},
+			}))
+
+			Expect(ui.Tables[3].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("consumes db"),
+					boshtbl.NewValueString("added"),
+					boshtbl.NewValueString(""),
+					boshtbl.NewValueString("postgres"),
+				},
+			}))
+
+			Expect(ui.Tables[4].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("templates/ctl.erb"),
+					boshtbl.NewValueString("changed"),
+					boshtbl.NewValueString("  a\n- b\n+ c\n"),
+				},
+			}))
+
+			Expect(ui.Tables[5].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("ruby"),
+					boshtbl.NewValueString("ruby/ruby-3.2.tgz"),
+					boshtbl.NewValueString("added"),
+				},
+			}))
+
+			Expect(fromRelease.CleanUpCallCount()).To(Equal(1))
+			Expect(toRelease.CleanUpCallCount()).To(Equal(1))
+		})
+
+		It("prints only jobs and packages tables if nothing else changed", func() {
+			err := command.Run(opts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables).To(HaveLen(2))
+			Expect(ui.Tables[0].Rows).To(BeEmpty())
+			Expect(ui.Tables[1].Rows).To(BeEmpty())
+		})
+
+		It("returns error if release tarballs are not specified", func() {
+			opts.Args.To = ""
+
+			err := command.Run(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected FROM and TO release tarballs or '--from-dir' to be specified"))
+		})
+
+		It("returns error and cleans up if reading second release fails", func() {
+			archiveReader.ReadStub = func(path string) (boshrel.Release, error) {
+				if path == "/from.tgz" {
+					return fromRelease, nil
+				}
+				return nil, errors.New("fake-err")
+			}
+
+			err := command.Run(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(fromRelease.CleanUpCallCount()).To(Equal(1))
+			Expect(differ.DiffCallCount()).To(Equal(0))
+		})
+
+		It("returns error if comparing fails", func() {
+			differ.DiffReturns(boshreldiff.Result{}, errors.New("fake-err"))
+
+			err := command.Run(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(fromRelease.CleanUpCallCount()).To(Equal(1))
+			Expect(toRelease.CleanUpCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when comparing release directory", func() {
+		var opts DiffReleasesOpts
+
+		BeforeEach(func() {
+			opts = DiffReleasesOpts{FromDir: DirOrCWDArg{Path: "/dir"}}
+
+			releaseDir.FindReleaseReturns(fromRelease, nil)
+			releaseReader.ReadReturns(toRelease, nil)
+		})
+
+		It("compares last version with current release directory", func() {
+			err := command.Run(opts)
+			Expect(err).ToNot(HaveOccurred())
+
+			name, version := releaseDir.FindReleaseArgsForCall(0)
+			Expect(name).To(BeEmpty())
+			Expect(version).To(Equal(semver.Version{}))
+
+			Expect(blobsDir.SyncBlobsArgsForCall(0)).To(Equal(3))
+			Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/dir"))
+
+			for _, release := range []*fakerel.FakeRelease{fromRelease, toRelease} {
+				Expect(release.BuildCallCount()).To(Equal(1))
+
+				dev, final, parallel := release.BuildArgsForCall(0)
+				Expect(dev).To(Equal(devIndicies))
+				Expect(final).To(Equal(finalIndicies))
+				Expect(parallel).To(Equal(3))
+			}
+
+			from, to := differ.DiffArgsForCall(0)
+			Expect(from).To(Equal(fromRelease))
+			Expect(to).To(Equal(toRelease))
+
+			Expect(archiveReader.ReadCallCount()).To(Equal(0))
+		})
+
+		It("compares given version with current release directory", func() {
+			opts.FromVersion = VersionArg(semver.MustNewVersionFromString("1.1"))
+
+			err := command.Run(opts)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, version := releaseDir.FindReleaseArgsForCall(0)
+			Expect(version).To(Equal(semver.MustNewVersionFromString("1.1")))
+		})
+
+		It("returns error if release tarballs are also specified", func() {
+			opts.Args.From = "/from.tgz"
+
+			err := command.Run(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected release tarballs not to be specified with '--from-dir'"))
+		})
+
+		It("returns error if finding release fails", func() {
+			releaseDir.FindReleaseReturns(nil, errors.New("fake-err"))
+
+			err := command.Run(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(releaseReader.ReadCallCount()).To(Equal(0))
+		})
+
+		It("returns error and cleans up if syncing blobs fails", func() {
+			blobsDir.SyncBlobsReturns(errors.New("fake-err"))
+
+			err := command.Run(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(fromRelease.CleanUpCallCount()).To(Equal(1))
+			Expect(releaseReader.ReadCallCount()).To(Equal(0))
+		})
+
+		It("returns error and cleans up if reading release directory fails", func() {
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			err := command.Run(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(fromRelease.CleanUpCallCount()).To(Equal(1))
+		})
+
+		It("returns error and cleans up if building release fails", func() {
+			toRelease.BuildReturns(errors.New("fake-err"))
+
+			err := command.Run(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Building release 'rel/2'"))
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(fromRelease.CleanUpCallCount()).To(Equal(1))
+			Expect(toRelease.CleanUpCallCount()).To(Equal(1))
+			Expect(differ.DiffCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v3"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	boshtpl "github.com/cloudfoundry/bosh-cli/v7/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type DriftCheckCmd struct {
+	ui       boshui.UI
+	director boshdir.Director
+	fs       boshsys.FileSystem
+}
+
+// DriftCheckMapping lists deployments with their manifests, ops and vars files;
+// relative paths are resolved against mapping file's directory
+type DriftCheckMapping struct {
+	Deployments []DriftCheckMappingDeployment `yaml:"deployments"`
+}
+
+type DriftCheckMappingDeployment struct {
+	Name      string                 `yaml:"name"`
+	Manifest  string                 `yaml:"manifest"`
+	OpsFiles  []string               `yaml:"ops_files"`
+	VarsFiles []string               `yaml:"vars_files"`
+	Vars      map[string]interface{} `yaml:"vars"`
+}
+
+type driftCheck struct {
+	name     string
+	manifest []byte
+	varFlags VarFlags
+	opsFlags OpsFlags
+}
+
+type driftCheckResult struct {
+	name            string
+	manifestDrifted bool
+	outdatedConfigs []string
+}
+
+func (r driftCheckResult) drifted() bool {
+	return r.manifestDrifted || len(r.outdatedConfigs) > 0
+}
+
+func NewDriftCheckCmd(ui boshui.UI, director boshdir.Director, fs boshsys.FileSystem) DriftCheckCmd {
+	return DriftCheckCmd{ui: ui, director: director, fs: fs}
+}
+
+func (c DriftCheckCmd) Run(opts DriftCheckOpts) error {
+	checks, err := c.checks(opts)
+	if err != nil {
+		return err
+	}
+
+	var results []driftCheckResult
+	var errs []error
+	var drifted []string
+
+	for _, check := range checks {
+		result, err := c.check(check, opts.NoRedact)
+		if err != nil {
+			errs = append(errs, bosherr.WrapErrorf(err, "Checking deployment '%s' for drift", check.name))
+			continue
+		}
+
+		results = append(results, result)
+
+		if result.drifted() {
+			drifted = append(drifted, check.name)
+		}
+	}
+
+	c.printResults(results)
+
+	if len(drifted) > 0 {
+		errs = append(errs, bosherr.Errorf("Detected drift in deployments: %s", strings.Join(drifted, ", ")))
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	} else if len(errs) > 1 {
+		return bosherr.NewMultiError(errs...)
+	}
+
+	return nil
+}
+
+func (c DriftCheckCmd) checks(opts DriftCheckOpts) ([]driftCheck, error) {
+	if len(opts.Mapping.Path) == 0 {
+		if len(opts.Args.Manifest.Bytes) == 0 {
+			return nil, bosherr.Error("Expected manifest path or mapping file")
+		}
+
+		if len(opts.Deployment) == 0 {
+			return nil, bosherr.Error("Expected deployment name to be specified")
+		}
+
+		check := driftCheck{
+			name:     opts.Deployment,
+			manifest: opts.Args.Manifest.Bytes,
+			varFlags: opts.VarFlags,
+			opsFlags: opts.OpsFlags,
+		}
+
+		return []driftCheck{check}, nil
+	}
+
+	var mapping DriftCheckMapping
+
+	err := yaml.Unmarshal(opts.Mapping.Bytes, &mapping)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Unmarshaling mapping file '%s'", opts.Mapping.Path)
+	}
+
+	if len(mapping.Deployments) == 0 {
+		return nil, bosherr.Errorf("Expected mapping file '%s' to list at least one deployment", opts.Mapping.Path)
+	}
+
+	dir := filepath.Dir(opts.Mapping.Path)
+
+	var checks []driftCheck
+
+	for _, dep := range mapping.Deployments {
+		check, err := c.mappedCheck(dir, dep, opts)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading deployment '%s' from mapping file", dep.Name)
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// mappedCheck loads files listed for the deployment; variables and ops files
+// given on the command line apply to every deployment and take precedence
+func (c DriftCheckCmd) mappedCheck(dir string, dep DriftCheckMappingDeployment, opts DriftCheckOpts) (driftCheck, error) {
+	if len(dep.Name) == 0 || len(dep.Manifest) == 0 {
+		return driftCheck{}, bosherr.Error("Expected deployment name and manifest to be specified")
+	}
+
+	manifestPath := c.resolvePath(dir, dep.Manifest)
+
+	manifest, err := c.fs.ReadFile(manifestPath)
+	if err != nil {
+		return driftCheck{}, bosherr.WrapErrorf(err, "Reading manifest '%s'", manifestPath)
+	}
+
+	varFlags := VarFlags{}
+
+	for _, path := range dep.VarsFiles {
+		arg := boshtpl.VarsFileArg{FS: c.fs}
+
+		err := arg.UnmarshalFlag(c.resolvePath(dir, path))
+		if err != nil {
+			return driftCheck{}, err
+		}
+
+		varFlags.VarsFiles = append(varFlags.VarsFiles, arg)
+	}
+
+	if len(dep.Vars) > 0 {
+		varFlags.VarsFiles = append(varFlags.VarsFiles, boshtpl.VarsFileArg{Vars: dep.Vars})
+	}
+
+	varFlags.VarsFiles = append(varFlags.VarsFiles, opts.VarsFiles...)
+	varFlags.VarsEnvs = opts.VarsEnvs
+	varFlags.VarFiles = opts.VarFiles
+	varFlags.VarKVs = opts.VarKVs
+	varFlags.VarsFSStore = opts.VarsFSStore
+
+	opsFlags := OpsFlags{}
+
+	for _, path := range dep.OpsFiles {
+		arg := OpsFileArg{FS: c.fs}
+
+		err := arg.UnmarshalFlag(c.resolvePath(dir, path))
+		if err != nil {
+			return driftCheck{}, err
+		}
+
+		opsFlags.OpsFiles = append(opsFlags.OpsFiles, arg)
+	}
+
+	opsFlags.OpsFiles = append(opsFlags.OpsFiles, opts.OpsFiles...)
+
+	return driftCheck{name: dep.Name, manifest: manifest, varFlags: varFlags, opsFlags: opsFlags}, nil
+}
+
+func (c DriftCheckCmd) resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func (c DriftCheckCmd) check(check driftCheck, noRedact bool) (driftCheckResult, error) {
+	result := driftCheckResult{name: check.name}
+
+	tpl := boshtpl.NewTemplate(check.manifest)
+
+	bytes, err := tpl.Evaluate(check.varFlags.AsVariables(), check.opsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return result, bosherr.WrapErrorf(err, "Evaluating manifest")
+	}
+
+	deployment, err := c.director.FindDeployment(check.name)
+	if err != nil {
+		return result, err
+	}
+
+	deployedManifest, err := deployment.Manifest()
+	if err != nil {
+		return result, err
+	}
+
+	result.manifestDrifted, err = c.manifestsDiffer(bytes, []byte(deployedManifest))
+	if err != nil {
+		return result, err
+	}
+
+	if result.manifestDrifted {
+		deploymentDiff, err := deployment.Diff(bytes, noRedact)
+		if err != nil {
+			return result, err
+		}
+
+		c.ui.PrintLinef("Deployment '%s' differs from local manifest:", check.name)
+		NewDiff(deploymentDiff.Diff).Print(c.ui)
+		c.ui.PrintLinef("")
+	}
+
+	result.outdatedConfigs, err = c.outdatedConfigs(check.name)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// manifestsDiffer compares manifests semantically ignoring formatting and key order
+func (c DriftCheckCmd) manifestsDiffer(local, deployed []byte) (bool, error) {
+	var localObj, deployedObj interface{}
+
+	err := yaml.Unmarshal(local, &localObj)
+	if err != nil {
+		return false, bosherr.WrapError(err, "Unmarshaling local manifest")
+	}
+
+	err = yaml.Unmarshal(deployed, &deployedObj)
+	if err != nil {
+		return false, bosherr.WrapError(err, "Unmarshaling deployed manifest")
+	}
+
+	return !reflect.DeepEqual(localObj, deployedObj), nil
+}
+
+// outdatedConfigs returns configs that were updated since deployment was last deployed
+func (c DriftCheckCmd) outdatedConfigs(name string) ([]string, error) {
+	configs, err := c.director.ListDeploymentConfigs(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var outdated []string
+
+	for _, config := range configs.GetConfigs() {
+		latest, err := c.director.LatestConfig(config.Type, config.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if latest.ID != strconv.Itoa(config.Id) {
+			outdated = append(outdated, fmt.Sprintf(
+				"%s/%s (deployed: %d, latest: %s)", config.Type, config.Name, config.Id, latest.ID))
+		}
+	}
+
+	return outdated, nil
+}
+
+func (c DriftCheckCmd) printResults(results []driftCheckResult) {
+	table := boshtbl.Table{
+		Content: "deployments",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Deployment"),
+			boshtbl.NewHeader("Manifest"),
+			boshtbl.NewHeader("Outdated Configs"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	for _, result := range results {
+		manifestVal := boshtbl.NewValueString("in sync")
+		if result.manifestDrifted {
+			manifestVal = boshtbl.NewValueString("drifted")
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(result.name),
+			manifestVal,
+			boshtbl.NewValueStrings(result.outdatedConfigs),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
@@ -0,0 +1,234 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-cli/v7/cmd"
+	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+	boshtpl "github.com/cloudfoundry/bosh-cli/v7/director/template"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("DriftCheckCmd", func() {
+	var (
+		ui          *fakeui.FakeUI
+		director    *fakedir.FakeDirector
+		deployments map[string]*fakedir.FakeDeployment
+		fs          *fakesys.FakeFileSystem
+		command     cmd.DriftCheckCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		director = &fakedir.FakeDirector{}
+		fs = fakesys.NewFakeFileSystem()
+
+		deployments = map[string]*fakedir.FakeDeployment{
+			"dep1": {ManifestStub: func() (string, error) { return "name: dep1\nprops: {a: 1, b: 2}\n", nil }},
+			"dep2": {ManifestStub: func() (string, error) { return "name: dep2\n", nil }},
+		}
+
+		director.FindDeploymentStub = func(name string) (boshdir.Deployment, error) {
+			return deployments[name], nil
+		}
+
+		command = cmd.NewDriftCheckCmd(ui, director, fs)
+	})
+
+	Describe("Run", func() {
+		var (
+			driftCheckOpts opts.DriftCheckOpts
+		)
+
+		BeforeEach(func() {
+			driftCheckOpts = opts.DriftCheckOpts{
+				Args: opts.DriftCheckArgs{
+					Manifest: opts.FileBytesArg{Bytes: []byte("name: dep1\nprops:\n  b: ((b))\n  a: 1\n")},
+				},
+				VarFlags: opts.VarFlags{
+					VarKVs: []boshtpl.VarKV{{Name: "b", Value: 2}},
+				},
+				Deployment: "dep1",
+			}
+		})
+
+		act := func() error { return command.Run(driftCheckOpts) }
+
+		It("reports no drift when interpolated manifest matches deployed manifest", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(director.FindDeploymentArgsForCall(0)).To(Equal("dep1"))
+			Expect(deployments["dep1"].DiffCallCount()).To(Equal(0))
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "deployments",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Deployment"),
+					boshtbl.NewHeader("Manifest"),
+					boshtbl.NewHeader("Outdated Configs"),
+				},
+
+				SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("dep1"),
+						boshtbl.NewValueString("in sync"),
+						boshtbl.NewValueStrings(nil),
+					},
+				},
+			}))
+		})
+
+		It("prints director's diff and returns error when manifest drifted", func() {
+			driftCheckOpts.VarFlags.VarKVs = []boshtpl.VarKV{{Name: "b", Value: 3}}
+
+			deployments["dep1"].DiffReturns(boshdir.DeploymentDiff{
+				Diff: [][]interface{}{
+					{"props:", nil},
+					{"  b: 2", "removed"},
+					{"  b: 3", "added"},
+				},
+			}, nil)
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Detected drift in deployments: dep1"))
+
+			bytes, noRedact := deployments["dep1"].DiffArgsForCall(0)
+			Expect(string(bytes)).To(ContainSubstring("b: 3"))
+			Expect(noRedact).To(BeFalse())
+
+			Expect(ui.Said).To(ContainElement("Deployment 'dep1' differs from local manifest:"))
+			Expect(ui.Said).To(ContainElement("-   b: 2\n"))
+			Expect(ui.Table.Rows[0][1]).To(Equal(boshtbl.NewValueString("drifted")))
+		})
+
+		It("reports configs updated since deployment was deployed", func() {
+			director.ListDeploymentConfigsReturns(boshdir.DeploymentConfigs{
+				Configs: []boshdir.DeploymentConfig{
+					{Config: boshdir.DeploymentConfigProperties{Id: 1, Type: "cloud", Name: "default"}},
+					{Config: boshdir.DeploymentConfigProperties{Id: 5, Type: "runtime", Name: "dns"}},
+				},
+			}, nil)
+
+			director.LatestConfigStub = func(configType, name string) (boshdir.Config, error) {
+				if configType == "cloud" {
+					return boshdir.Config{ID: "3"}, nil
+				}
+				return boshdir.Config{ID: "5"}, nil
+			}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Detected drift in deployments: dep1"))
+
+			Expect(ui.Table.Rows[0][2]).To(Equal(boshtbl.NewValueStrings(
+				[]string{"cloud/default (deployed: 1, latest: 3)"})))
+		})
+
+		It("returns error if manifest cannot be interpolated", func() {
+			driftCheckOpts.VarFlags = opts.VarFlags{}
+			driftCheckOpts.Args.Manifest.Bytes = []byte("name: [")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Checking deployment 'dep1' for drift"))
+		})
+
+		It("returns error if deployed manifest cannot be fetched", func() {
+			deployments["dep1"].ManifestReturns("", errors.New("fake-err"))
+			deployments["dep1"].ManifestStub = nil
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns error if neither manifest nor mapping is given", func() {
+			driftCheckOpts.Args.Manifest = opts.FileBytesArg{}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected manifest path or mapping file"))
+		})
+
+		Context("when mapping file is given", func() {
+			BeforeEach(func() {
+				driftCheckOpts.Args.Manifest = opts.FileBytesArg{}
+				driftCheckOpts.VarFlags = opts.VarFlags{}
+				driftCheckOpts.Deployment = ""
+
+				driftCheckOpts.Mapping = opts.FileBytesWithPathArg{
+					Path: "/repo/drift.yml",
+					Bytes: []byte(`
+deployments:
+- name: dep1
+  manifest: dep1/manifest.yml
+  vars_files: [vars/dep1.yml]
+  vars: {a: 1}
+- name: dep2
+  manifest: /abs/dep2.yml
+  ops_files: [ops/dep2.yml]
+`),
+				}
+
+				Expect(fs.WriteFileString("/repo/dep1/manifest.yml", "name: dep1\nprops: {a: ((a)), b: ((b))}\n")).To(Succeed())
+				Expect(fs.WriteFileString("/repo/vars/dep1.yml", "b: 2\n")).To(Succeed())
+				Expect(fs.WriteFileString("/abs/dep2.yml", "name: dep2\n")).To(Succeed())
+				Expect(fs.WriteFileString("/repo/ops/dep2.yml", "- type: replace\n  path: /name\n  value: dep2\n")).To(Succeed())
+			})
+
+			It("checks every listed deployment", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(director.FindDeploymentCallCount()).To(Equal(2))
+				Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+					{boshtbl.NewValueString("dep1"), boshtbl.NewValueString("in sync"), boshtbl.NewValueStrings(nil)},
+					{boshtbl.NewValueString("dep2"), boshtbl.NewValueString("in sync"), boshtbl.NewValueStrings(nil)},
+				}))
+			})
+
+			It("checks remaining deployments and returns error listing drifted ones", func() {
+				Expect(fs.WriteFileString("/repo/vars/dep1.yml", "b: 3\n")).To(Succeed())
+
+				deployments["dep2"].ManifestStub = nil
+				deployments["dep2"].ManifestReturns("", errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Checking deployment 'dep2' for drift: fake-err"))
+				Expect(err.Error()).To(ContainSubstring("Detected drift in deployments: dep1"))
+
+				Expect(ui.Table.Rows).To(HaveLen(1))
+			})
+
+			It("returns error if listed manifest cannot be read", func() {
+				Expect(fs.RemoveAll("/abs/dep2.yml")).To(Succeed())
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Reading deployment 'dep2' from mapping file"))
+				Expect(director.FindDeploymentCallCount()).To(Equal(0))
+			})
+
+			It("returns error if mapping file lists no deployments", func() {
+				driftCheckOpts.Mapping.Bytes = []byte("deployments: []")
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected mapping file '/repo/drift.yml' to list at least one deployment"))
+			})
+		})
+	})
+})
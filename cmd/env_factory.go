@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	bihttpagent "github.com/cloudfoundry/bosh-agent/v2/agentclient/http"
@@ -11,6 +12,7 @@ import (
 
 	biblobstore "github.com/cloudfoundry/bosh-cli/v7/blobstore"
 	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
+	"github.com/cloudfoundry/bosh-cli/v7/cloud/fakecpi"
 	biconfig "github.com/cloudfoundry/bosh-cli/v7/config"
 	bicpirel "github.com/cloudfoundry/bosh-cli/v7/cpi/release"
 	bidepl "github.com/cloudfoundry/bosh-cli/v7/deployment"
@@ -72,6 +74,7 @@ func NewEnvFactory(
 	manifestOp patch.Op,
 	recreatePersistentDisks bool,
 	packageDir string,
+	cpiRecordingOpts bicloud.RecordingOpts,
 ) *envFactory {
 	f := envFactory{
 		deps:         deps,
@@ -111,8 +114,10 @@ func NewEnvFactory(
 		}
 	}
 
+	deploymentStatePath := biconfig.DeploymentStatePath(manifestPath, statePath)
+
 	f.deploymentStateService = biconfig.NewFileSystemDeploymentStateService(
-		deps.FS, deps.UUIDGen, deps.Logger, biconfig.DeploymentStatePath(manifestPath, statePath))
+		deps.FS, deps.UUIDGen, deps.Logger, deploymentStatePath)
 
 	{
 		installerFactory := boshinst.NewInstallerFactory(
@@ -146,10 +151,16 @@ func NewEnvFactory(
 	}
 
 	{
-		f.blobstoreFactory = biblobstore.NewBlobstoreFactory(deps.UUIDGen, deps.FS, deps.Logger)
+		// Fake CPI is only used when selected by the installation manifest
+		fakeCPIStore := fakecpi.NewStore(FakeCPIStateDir(deploymentStatePath), deps.FS, deps.UUIDGen)
+
+		f.blobstoreFactory = fakecpi.NewBlobstoreFactory(fakeCPIStore,
+			biblobstore.NewBlobstoreFactory(deps.UUIDGen, deps.FS, deps.Logger), deps.Logger)
 		f.deploymentFactory = bidepl.NewFactory(10*time.Second, 500*time.Millisecond)
-		f.agentClientFactory = bihttpagent.NewAgentClientFactory(1*time.Second, deps.Logger)
-		f.cloudFactory = bicloud.NewFactory(deps.FS, deps.CmdRunner, deps.Logger)
+		f.agentClientFactory = fakecpi.NewAgentClientFactory(fakeCPIStore,
+			bihttpagent.NewAgentClientFactory(1*time.Second, deps.Logger), deps.Logger)
+		f.cloudFactory = fakecpi.NewCloudFactory(fakeCPIStore,
+			bicloud.NewFactory(deps.FS, deps.CmdRunner, cpiRecordingOpts, deps.Logger), deps.Logger)
 	}
 
 	{
@@ -187,6 +198,23 @@ func NewEnvFactory(
 	return &f
 }
 
+// FakeCPIStateDir keeps fake CPI state next to the deployment state file
+func FakeCPIStateDir(deploymentStatePath string) string {
+	return strings.TrimSuffix(deploymentStatePath, filepath.Ext(deploymentStatePath)) + "-fake-cpi"
+}
+
+// BuiltInCPIOp selects built-in CPI (if any) on top of user provided ops
+func BuiltInCPIOp(op patch.Op, cpi string) patch.Op {
+	if cpi == "" {
+		return op
+	}
+
+	return patch.Ops{op, patch.ReplaceOp{
+		Path:  patch.MustNewPointerFromString("/cloud_provider?/cpi"),
+		Value: cpi,
+	}}
+}
+
 func (f *envFactory) Preparer() DeploymentPreparer {
 	return NewDeploymentPreparer(
 		f.deps.UI,
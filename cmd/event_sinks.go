@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshhttp "github.com/cloudfoundry/bosh-utils/httpclient"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+)
+
+type EventSink interface {
+	Emit(boshdir.Event) error
+}
+
+const eventWebhookRetryDelay = 2 * time.Second
+
+// EventSinkFactory builds sinks from --sink flags
+type EventSinkFactory struct {
+	ui     boshui.UI
+	fs     boshsys.FileSystem
+	logger boshlog.Logger
+}
+
+func NewEventSinkFactory(ui boshui.UI, fs boshsys.FileSystem, logger boshlog.Logger) EventSinkFactory {
+	return EventSinkFactory{ui: ui, fs: fs, logger: logger}
+}
+
+func (f EventSinkFactory) New(arg EventSinkArg, webhookAttempts uint) (EventSink, error) {
+	switch arg.Type {
+	case EventSinkStdout:
+		return NewUIEventSink(f.ui), nil
+	case EventSinkFile:
+		return NewFileEventSink(f.fs, arg.Target), nil
+	case EventSinkWebhook:
+		retryClient := boshhttp.NewRetryClient(
+			boshhttp.CreateExternalDefaultClient(nil), webhookAttempts, eventWebhookRetryDelay, f.logger)
+
+		return NewWebhookEventSink(arg.Target, boshhttp.NewHTTPClient(retryClient, f.logger)), nil
+	default:
+		return nil, bosherr.Errorf("Unknown event sink '%s'", arg.Type)
+	}
+}
+
+// UIEventSink prints each event as a JSON line
+type UIEventSink struct {
+	ui boshui.UI
+}
+
+func NewUIEventSink(ui boshui.UI) UIEventSink {
+	return UIEventSink{ui: ui}
+}
+
+func (s UIEventSink) Emit(event boshdir.Event) error {
+	bytes, err := marshalEvent(event)
+	if err != nil {
+		return err
+	}
+
+	s.ui.PrintBlock(append(bytes, '\n'))
+
+	return nil
+}
+
+// FileEventSink appends each event as a JSON line to a file
+type FileEventSink struct {
+	fs   boshsys.FileSystem
+	path string
+}
+
+func NewFileEventSink(fs boshsys.FileSystem, path string) FileEventSink {
+	return FileEventSink{fs: fs, path: path}
+}
+
+func (s FileEventSink) Emit(event boshdir.Event) error {
+	bytes, err := marshalEvent(event)
+	if err != nil {
+		return err
+	}
+
+	file, err := s.fs.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Opening event sink file '%s'", s.path)
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing event to file '%s'", s.path)
+	}
+
+	return nil
+}
+
+// WebhookEventSink POSTs each event as JSON; retries are done by HTTP client
+type WebhookEventSink struct {
+	url    string
+	client *boshhttp.HTTPClient
+}
+
+func NewWebhookEventSink(url string, client *boshhttp.HTTPClient) WebhookEventSink {
+	return WebhookEventSink{url: url, client: client}
+}
+
+func (s WebhookEventSink) Emit(event boshdir.Event) error {
+	bytes, err := marshalEvent(event)
+	if err != nil {
+		return err
+	}
+
+	setHeaders := func(req *http.Request) {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.PostCustomized(s.url, bytes, setHeaders)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Posting event '%s' to webhook", event.ID())
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return bosherr.Errorf("Posting event '%s' to webhook: unexpected status code '%d'", event.ID(), resp.StatusCode)
+	}
+
+	return nil
+}
+
+// marshalEvent uses Director's own representation of events
+func marshalEvent(event boshdir.Event) ([]byte, error) {
+	resp := boshdir.EventResp{
+		ID:             event.ID(),
+		Timestamp:      event.Timestamp().Unix(),
+		User:           event.User(),
+		Action:         event.Action(),
+		ObjectType:     event.ObjectType(),
+		ObjectName:     event.ObjectName(),
+		TaskID:         event.TaskID(),
+		DeploymentName: event.DeploymentName(),
+		Instance:       event.Instance(),
+		ParentID:       event.ParentID(),
+		Context:        event.Context(),
+		Error:          event.Error(),
+	}
+
+	bytes, err := json.Marshal(resp)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Marshaling event '%s'", event.ID())
+	}
+
+	return bytes, nil
+}
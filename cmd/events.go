@@ -1,19 +1,30 @@
 package cmd
 
 import (
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
 	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
 	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
 	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
 	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
 )
 
+// eventsPageSize is the maximum number of events returned by the Director per request
+const eventsPageSize = 200
+
 type EventsCmd struct {
-	ui       boshui.UI
-	director boshdir.Director
+	ui          boshui.UI
+	director    boshdir.Director
+	sinkFactory EventSinkFactory
+	timeService clock.Clock
 }
 
-func NewEventsCmd(ui boshui.UI, director boshdir.Director) EventsCmd {
-	return EventsCmd{ui: ui, director: director}
+func NewEventsCmd(ui boshui.UI, director boshdir.Director, sinkFactory EventSinkFactory, timeService clock.Clock) EventsCmd {
+	return EventsCmd{ui: ui, director: director, sinkFactory: sinkFactory, timeService: timeService}
 }
 
 func (c EventsCmd) Run(opts EventsOpts) error {
@@ -30,11 +41,120 @@ func (c EventsCmd) Run(opts EventsOpts) error {
 		ObjectName: opts.ObjectName,
 	}
 
+	if opts.Follow && (len(opts.BeforeID) > 0 || len(opts.Before) > 0) {
+		return bosherr.Error("Expected --before and --before-id to not be used with --follow")
+	}
+
+	var sinks []EventSink
+
+	for _, arg := range opts.Sinks {
+		sink, err := c.sinkFactory.New(arg, opts.WebhookAttempts)
+		if err != nil {
+			return err
+		}
+
+		sinks = append(sinks, sink)
+	}
+
 	events, err := c.director.Events(filter)
 	if err != nil {
 		return err
 	}
 
+	err = c.emit(events, sinks)
+	if err != nil {
+		return err
+	}
+
+	if !opts.Follow {
+		return nil
+	}
+
+	return c.follow(filter, events, opts.PollInterval, sinks)
+}
+
+// follow polls for events after the newest seen event. Director only filters
+// by time so events with the same timestamp are de-duplicated by ID.
+func (c EventsCmd) follow(filter boshdir.EventsFilter, events []boshdir.Event, interval time.Duration, sinks []EventSink) error {
+	var last boshdir.Event
+
+	if len(events) > 0 {
+		last = events[0]
+	}
+
+	for {
+		c.timeService.Sleep(interval)
+
+		if last != nil {
+			filter.After = last.Timestamp().UTC().Format("2006-01-02 15:04:05 UTC")
+		}
+
+		events, err := c.newEvents(filter, last)
+		if err != nil {
+			return bosherr.WrapError(err, "Following events")
+		}
+
+		if len(events) == 0 {
+			continue
+		}
+
+		err = c.emit(events, sinks)
+		if err != nil {
+			return bosherr.WrapError(err, "Following events")
+		}
+
+		last = events[0]
+	}
+}
+
+// newEvents returns events newer than the last event, newest first,
+// paging back when more events happened than fit into a single response
+func (c EventsCmd) newEvents(filter boshdir.EventsFilter, last boshdir.Event) ([]boshdir.Event, error) {
+	var events []boshdir.Event
+
+	for {
+		page, err := c.director.Events(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		var newInPage int
+
+		for _, event := range page {
+			if last == nil || eventIDNewer(event.ID(), last.ID()) {
+				events = append(events, event)
+				newInPage++
+			}
+		}
+
+		if len(page) < eventsPageSize || newInPage < len(page) {
+			return events, nil
+		}
+
+		filter.BeforeID = page[len(page)-1].ID()
+	}
+}
+
+func (c EventsCmd) emit(events []boshdir.Event, sinks []EventSink) error {
+	if len(sinks) == 0 {
+		c.printTable(events)
+		return nil
+	}
+
+	// Director returns newest events first
+	for i := len(events) - 1; i >= 0; i-- {
+		for _, sink := range sinks {
+			err := sink.Emit(events[i])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c EventsCmd) printTable(events []boshdir.Event) {
 	table := boshtbl.Table{
 		Content: "events",
 		Header: []boshtbl.Header{
@@ -75,6 +195,19 @@ func (c EventsCmd) Run(opts EventsOpts) error {
 	}
 
 	c.ui.PrintTable(table)
+}
 
-	return nil
+// eventIDNewer compares IDs numerically since Director assigns increasing integer IDs
+func eventIDNewer(id, lastID string) bool {
+	idNum, err := strconv.Atoi(id)
+	if err != nil {
+		return id != lastID
+	}
+
+	lastIDNum, err := strconv.Atoi(lastID)
+	if err != nil {
+		return id != lastID
+	}
+
+	return idNum > lastIDNum
 }
@@ -2,10 +2,20 @@ package cmd_test
 
 import (
 	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
+	boshhttp "github.com/cloudfoundry/bosh-utils/httpclient"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 
 	"github.com/cloudfoundry/bosh-cli/v7/cmd"
 	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
@@ -17,16 +27,20 @@ import (
 
 var _ = Describe("EventsCmd", func() {
 	var (
-		ui       *fakeui.FakeUI
-		director *fakedir.FakeDirector
-		command  cmd.EventsCmd
-		events   []boshdir.Event
+		ui          *fakeui.FakeUI
+		director    *fakedir.FakeDirector
+		timeService *fakeclock.FakeClock
+		command     cmd.EventsCmd
+		events      []boshdir.Event
 	)
 
 	BeforeEach(func() {
 		ui = &fakeui.FakeUI{}
 		director = &fakedir.FakeDirector{}
-		command = cmd.NewEventsCmd(ui, director)
+		timeService = fakeclock.NewFakeClock(time.Date(2009, time.November, 10, 23, 1, 2, 333, time.UTC))
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		sinkFactory := cmd.NewEventSinkFactory(ui, boshsys.NewOsFileSystem(logger), logger)
+		command = cmd.NewEventsCmd(ui, director, sinkFactory, timeService)
 		events = []boshdir.Event{
 			&fakedir.FakeEvent{
 				IDStub:        func() string { return "4" },
@@ -164,5 +178,245 @@ var _ = Describe("EventsCmd", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-err"))
 		})
+
+		It("emits events oldest first as JSON lines to stdout sink", func() {
+			eventsOpts.Sinks = []opts.EventSinkArg{{Type: "stdout"}}
+
+			director.EventsReturns([]boshdir.Event{events[1], events[0]}, nil)
+
+			err := command.Run(eventsOpts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables).To(BeEmpty())
+			Expect(ui.Blocks).To(Equal([]string{
+				`{"id":"4","timestamp":1257894000,"user":"user","action":"action","object_type":"object-type","object_name":"object-name","task":"task","deployment":"deployment","instance":"instance","parent_id":"1","context":{"user":"bosh_z$"},"error":""}` + "\n",
+				`{"id":"5","timestamp":3814038000,"user":"user2","action":"action2","object_type":"object-type2","object_name":"object-name2","task":"task2","deployment":"deployment2","instance":"instance2","context":{},"error":"some-error"}` + "\n",
+			}))
+		})
+
+		It("appends events to file sink", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "events.log")
+			Expect(os.WriteFile(path, []byte("existing\n"), 0600)).To(Succeed())
+
+			eventsOpts.Sinks = []opts.EventSinkArg{{Type: "file", Target: path}}
+
+			director.EventsReturns([]boshdir.Event{events[1], events[0]}, nil)
+
+			err := command.Run(eventsOpts)
+			Expect(err).ToNot(HaveOccurred())
+
+			contents, err := os.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(MatchRegexp(`^existing\n\{"id":"4",.*\}\n\{"id":"5",.*\}\n$`))
+		})
+
+		It("returns error if event cannot be emitted to sink", func() {
+			eventsOpts.Sinks = []opts.EventSinkArg{{Type: "file", Target: filepath.Join(GinkgoT().TempDir(), "missing", "events.log")}}
+
+			director.EventsReturns(events, nil)
+
+			err := command.Run(eventsOpts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Opening event sink file"))
+		})
+
+		Context("when following events", func() {
+			newEvent := func(id int, timestamp time.Time) boshdir.Event {
+				event := &fakedir.FakeEvent{}
+				event.IDReturns(strconv.Itoa(id))
+				event.TimestampReturns(timestamp)
+				return event
+			}
+
+			var (
+				ts       time.Time
+				runErrCh chan error
+			)
+
+			BeforeEach(func() {
+				ts = time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+				eventsOpts.Follow = true
+				eventsOpts.PollInterval = 5 * time.Second
+				eventsOpts.Sinks = []opts.EventSinkArg{{Type: "stdout"}}
+				eventsOpts.Deployment = "dep"
+
+				runErrCh = make(chan error, 1)
+			})
+
+			run := func(polls int) error {
+				go func() { runErrCh <- command.Run(eventsOpts) }()
+
+				for i := 0; i < polls; i++ {
+					timeService.WaitForWatcherAndIncrement(eventsOpts.PollInterval)
+				}
+
+				var err error
+				Eventually(runErrCh).Should(Receive(&err))
+				return err
+			}
+
+			emittedIDs := func() []string {
+				var ids []string
+				for _, block := range ui.Blocks {
+					ids = append(ids, regexpFirstMatch(`"id":"(\d+)"`, block))
+				}
+				return ids
+			}
+
+			It("polls for events after newest seen event and emits only new ones", func() {
+				responses := [][]boshdir.Event{
+					{newEvent(5, ts), newEvent(4, ts)},
+					{newEvent(7, ts.Add(time.Second)), newEvent(6, ts), newEvent(5, ts)},
+					{},
+				}
+
+				director.EventsStub = func(filter boshdir.EventsFilter) ([]boshdir.Event, error) {
+					call := director.EventsCallCount() - 1
+					if call < len(responses) {
+						return responses[call], nil
+					}
+					return nil, errors.New("fake-err")
+				}
+
+				err := run(3)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Following events: fake-err"))
+
+				Expect(emittedIDs()).To(Equal([]string{"4", "5", "6", "7"}))
+
+				Expect(director.EventsArgsForCall(0)).To(Equal(boshdir.EventsFilter{Deployment: "dep"}))
+				Expect(director.EventsArgsForCall(1)).To(Equal(boshdir.EventsFilter{
+					Deployment: "dep", After: "2020-01-01 10:00:00 UTC"}))
+				Expect(director.EventsArgsForCall(2)).To(Equal(boshdir.EventsFilter{
+					Deployment: "dep", After: "2020-01-01 10:00:01 UTC"}))
+				Expect(director.EventsArgsForCall(3)).To(Equal(boshdir.EventsFilter{
+					Deployment: "dep", After: "2020-01-01 10:00:01 UTC"}))
+			})
+
+			It("pages back when more new events happened than fit into single response", func() {
+				var fullPage []boshdir.Event
+				for id := 206; id > 6; id-- {
+					fullPage = append(fullPage, newEvent(id, ts))
+				}
+
+				responses := [][]boshdir.Event{
+					{newEvent(4, ts)},
+					fullPage,
+					{newEvent(6, ts), newEvent(5, ts), newEvent(4, ts)},
+				}
+
+				director.EventsStub = func(filter boshdir.EventsFilter) ([]boshdir.Event, error) {
+					call := director.EventsCallCount() - 1
+					if call < len(responses) {
+						return responses[call], nil
+					}
+					return nil, errors.New("fake-err")
+				}
+
+				err := run(2)
+				Expect(err).To(HaveOccurred())
+
+				ids := emittedIDs()
+				Expect(ids).To(HaveLen(203))
+				Expect(ids[:4]).To(Equal([]string{"4", "5", "6", "7"}))
+				Expect(ids[202]).To(Equal("206"))
+
+				Expect(director.EventsArgsForCall(2).BeforeID).To(Equal("7"))
+			})
+
+			It("prints tables of new events when no sinks are given", func() {
+				eventsOpts.Sinks = nil
+
+				responses := [][]boshdir.Event{
+					{},
+					{},
+					{newEvent(1, ts)},
+				}
+
+				director.EventsStub = func(filter boshdir.EventsFilter) ([]boshdir.Event, error) {
+					call := director.EventsCallCount() - 1
+					if call < len(responses) {
+						return responses[call], nil
+					}
+					return nil, errors.New("fake-err")
+				}
+
+				err := run(3)
+				Expect(err).To(HaveOccurred())
+
+				Expect(ui.Tables).To(HaveLen(2))
+				Expect(ui.Tables[0].Rows).To(BeEmpty())
+				Expect(ui.Tables[1].Rows).To(HaveLen(1))
+			})
+
+			It("returns error if --before-id is given", func() {
+				eventsOpts.BeforeID = "5"
+
+				err := command.Run(eventsOpts)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected --before and --before-id to not be used with --follow"))
+				Expect(director.EventsCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
+
+var _ = Describe("WebhookEventSink", func() {
+	var (
+		server *ghttp.Server
+		sink   cmd.WebhookEventSink
+		event  *fakedir.FakeEvent
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		retryClient := boshhttp.NewRetryClient(boshhttp.CreateDefaultClient(nil), 2, time.Millisecond, logger)
+		sink = cmd.NewWebhookEventSink(server.URL()+"/events", boshhttp.NewHTTPClient(retryClient, logger))
+
+		event = &fakedir.FakeEvent{}
+		event.IDReturns("5")
+		event.ActionReturns("delete")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("posts event as JSON retrying failed requests", func() {
+		server.AppendHandlers(
+			ghttp.RespondWith(http.StatusServiceUnavailable, ""),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/events"),
+				ghttp.VerifyHeader(http.Header{"Content-Type": []string{"application/json"}}),
+				ghttp.VerifyJSON(`{"id":"5","timestamp":-62135596800,"user":"","action":"delete","object_type":"","object_name":"","task":"","deployment":"","instance":"","context":null,"error":""}`),
+				ghttp.RespondWith(http.StatusAccepted, ""),
+			),
+		)
+
+		err := sink.Emit(event)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(server.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("returns error if webhook keeps failing", func() {
+		server.AppendHandlers(
+			ghttp.RespondWith(http.StatusInternalServerError, ""),
+			ghttp.RespondWith(http.StatusInternalServerError, ""),
+		)
+
+		err := sink.Emit(event)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Posting event '5' to webhook: unexpected status code '500'"))
 	})
 })
+
+func regexpFirstMatch(pattern, s string) string {
+	matches := regexp.MustCompile(pattern).FindStringSubmatch(s)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
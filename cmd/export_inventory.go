@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	boshinv "github.com/cloudfoundry/bosh-cli/v7/director/inventory"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+)
+
+type ExportInventoryCmd struct {
+	ui       boshui.UI
+	director boshdir.Director
+}
+
+func NewExportInventoryCmd(ui boshui.UI, director boshdir.Director) ExportInventoryCmd {
+	return ExportInventoryCmd{ui: ui, director: director}
+}
+
+func (c ExportInventoryCmd) Run() error {
+	inv, err := boshinv.NewCollector(c.director).Collect()
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return bosherr.WrapError(err, "Marshaling inventory")
+	}
+
+	c.ui.PrintBlock(append(bytes, '\n'))
+
+	return nil
+}
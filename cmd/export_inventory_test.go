@@ -0,0 +1,59 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+	boshinv "github.com/cloudfoundry/bosh-cli/v7/director/inventory"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+)
+
+var _ = Describe("ExportInventoryCmd", func() {
+	var (
+		ui       *fakeui.FakeUI
+		director *fakedir.FakeDirector
+		command  ExportInventoryCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		director = &fakedir.FakeDirector{}
+		command = NewExportInventoryCmd(ui, director)
+	})
+
+	Describe("Run", func() {
+		act := func() error { return command.Run() }
+
+		It("prints inventory as JSON", func() {
+			director.InfoReturns(boshdir.Info{Name: "fake-director", UUID: "fake-uuid"}, nil)
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Blocks).To(HaveLen(1))
+
+			var inv boshinv.Inventory
+			Expect(json.Unmarshal([]byte(ui.Blocks[0]), &inv)).To(Succeed())
+
+			Expect(inv.Director.Name).To(Equal("fake-director"))
+			Expect(inv.Director.UUID).To(Equal("fake-uuid"))
+			Expect(inv.Deployments).To(BeEmpty())
+		})
+
+		It("returns error if inventory cannot be collected", func() {
+			director.InfoReturns(boshdir.Info{}, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+			Expect(ui.Blocks).To(BeEmpty())
+		})
+	})
+})
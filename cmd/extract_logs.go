@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/workpool"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	boshlogs "github.com/cloudfoundry/bosh-cli/v7/logs"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type ExtractLogsCmd struct {
+	deployment boshdir.Deployment
+	extractor  LogBundleExtractor
+	indexer    boshlogs.Indexer
+	ui         boshui.UI
+	parallel   int
+}
+
+func NewExtractLogsCmd(
+	deployment boshdir.Deployment,
+	extractor LogBundleExtractor,
+	indexer boshlogs.Indexer,
+	ui boshui.UI,
+	parallel int,
+) ExtractLogsCmd {
+	return ExtractLogsCmd{
+		deployment: deployment,
+		extractor:  extractor,
+		indexer:    indexer,
+		ui:         ui,
+		parallel:   parallel,
+	}
+}
+
+func (c ExtractLogsCmd) Run(opts LogsOpts) error {
+	if opts.Follow || opts.Num > 0 || isMergedLogs(opts) {
+		return bosherr.Error("The --extract flag cannot be used with --follow, --num or --merge")
+	}
+
+	vmInfos, err := c.deployment.VMInfos()
+	if err != nil {
+		return err
+	}
+
+	var selected []boshdir.VMInfo
+
+	for _, info := range vmInfos {
+		if logsSlugMatches(opts.Args.Slug, info) {
+			selected = append(selected, info)
+		}
+	}
+
+	if len(selected) == 0 {
+		return bosherr.Errorf("Expected to find at least one instance matching '%s'", opts.Args.Slug)
+	}
+
+	err = c.extractAll(selected, opts)
+	if err != nil {
+		return err
+	}
+
+	index, err := c.indexer.Build(opts.Directory.Path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Indexing logs in '%s'", opts.Directory.Path)
+	}
+
+	c.printIndex(index)
+
+	return nil
+}
+
+func (c ExtractLogsCmd) extractAll(vmInfos []boshdir.VMInfo, opts LogsOpts) error {
+	parallel := c.parallel
+	if parallel == 0 {
+		parallel = 1
+	}
+
+	logType := buildLogTypeArgument(opts)
+
+	var (
+		errs     []error
+		errsLock sync.Mutex
+		wg       sync.WaitGroup
+	)
+
+	works := make([]func(), len(vmInfos))
+
+	for i, info := range vmInfos {
+		info := info
+		wg.Add(1)
+
+		works[i] = func() {
+			defer wg.Done()
+
+			err := c.extract(info, opts, logType)
+			if err != nil {
+				errsLock.Lock()
+				errs = append(errs, bosherr.WrapErrorf(err, "Extracting logs for '%s/%s'", info.JobName, info.ID))
+				errsLock.Unlock()
+			}
+		}
+	}
+
+	throttler, err := workpool.NewThrottler(parallel, works)
+	if err != nil {
+		return err
+	}
+
+	throttler.Work()
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return bosherr.NewMultiError(errs...)
+	}
+
+	return nil
+}
+
+func (c ExtractLogsCmd) extract(info boshdir.VMInfo, opts LogsOpts, logType string) error {
+	slug := boshdir.NewAllOrInstanceGroupOrInstanceSlug(info.JobName, info.ID)
+
+	result, err := c.deployment.FetchLogs(slug, opts.Filters, logType)
+	if err != nil {
+		return err
+	}
+
+	dstDirPath := filepath.Join(opts.Directory.Path, info.JobName, info.ID)
+
+	return c.extractor.Extract(result.BlobstoreID, result.SHA1, dstDirPath)
+}
+
+func (c ExtractLogsCmd) printIndex(index boshlogs.Index) {
+	table := boshtbl.Table{
+		Content: "instances",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Instance"),
+			boshtbl.NewHeader("Files"),
+			boshtbl.NewHeader("Size"),
+			boshtbl.NewHeader("First Timestamp"),
+			boshtbl.NewHeader("Last Timestamp"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	for _, instance := range index.Instances {
+		var (
+			size        uint64
+			first, last *time.Time
+		)
+
+		for _, file := range instance.Files {
+			size += uint64(file.Size)
+
+			if file.FirstTime != nil && (first == nil || file.FirstTime.Before(*first)) {
+				first = file.FirstTime
+			}
+
+			if file.LastTime != nil && (last == nil || file.LastTime.After(*last)) {
+				last = file.LastTime
+			}
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(fmt.Sprintf("%s/%s", instance.Group, instance.ID)),
+			boshtbl.NewValueInt(len(instance.Files)),
+			boshtbl.NewValueBytes(size),
+			logsTimeValue(first),
+			logsTimeValue(last),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func logsTimeValue(t *time.Time) boshtbl.Value {
+	if t == nil {
+		return boshtbl.ValueString{}
+	}
+	return boshtbl.NewValueTime(*t)
+}
+
+func logsSlugMatches(slug boshdir.AllOrInstanceGroupOrInstanceSlug, info boshdir.VMInfo) bool {
+	if len(slug.IP()) > 0 {
+		for _, ip := range info.IPs {
+			if ip == slug.IP() {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(slug.Name()) > 0 && slug.Name() != info.JobName {
+		return false
+	}
+
+	if len(slug.IndexOrID()) > 0 {
+		if slug.IndexOrID() == info.ID {
+			return true
+		}
+		return info.Index != nil && slug.IndexOrID() == strconv.Itoa(*info.Index)
+	}
+
+	return true
+}
@@ -0,0 +1,238 @@
+package cmd_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-cli/v7/cmd"
+	fakecmd "github.com/cloudfoundry/bosh-cli/v7/cmd/cmdfakes"
+	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+	boshlogs "github.com/cloudfoundry/bosh-cli/v7/logs"
+	fakelogs "github.com/cloudfoundry/bosh-cli/v7/logs/logsfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("ExtractLogsCmd", func() {
+	var (
+		deployment *fakedir.FakeDeployment
+		extractor  *fakecmd.FakeLogBundleExtractor
+		indexer    *fakelogs.FakeIndexer
+		ui         *fakeui.FakeUI
+		command    cmd.ExtractLogsCmd
+	)
+
+	BeforeEach(func() {
+		deployment = &fakedir.FakeDeployment{}
+		extractor = &fakecmd.FakeLogBundleExtractor{}
+		indexer = &fakelogs.FakeIndexer{}
+		ui = &fakeui.FakeUI{}
+		command = cmd.NewExtractLogsCmd(deployment, extractor, indexer, ui, 5)
+	})
+
+	Describe("Run", func() {
+		var (
+			logsOpts opts.LogsOpts
+		)
+
+		BeforeEach(func() {
+			logsOpts = opts.LogsOpts{
+				Directory: opts.DirOrCWDArg{Path: "/fake-dir"},
+				Extract:   true,
+			}
+
+			index0, index1 := 0, 1
+
+			deployment.VMInfosReturns([]boshdir.VMInfo{
+				{JobName: "web", ID: "web-id-0", Index: &index0, IPs: []string{"10.0.0.1"}},
+				{JobName: "web", ID: "web-id-1", Index: &index1, IPs: []string{"10.0.0.2"}},
+				{JobName: "worker", ID: "worker-id-0", Index: &index0, IPs: []string{"10.0.0.3"}},
+			}, nil)
+
+			deployment.FetchLogsStub = func(slug boshdir.AllOrInstanceGroupOrInstanceSlug, _ []string, _ string) (boshdir.LogsResult, error) {
+				return boshdir.LogsResult{BlobstoreID: "blob-" + slug.IndexOrID(), SHA1: "sha1-" + slug.IndexOrID()}, nil
+			}
+		})
+
+		act := func() error { return command.Run(logsOpts) }
+
+		extractedDirs := func() map[string]string {
+			dirs := map[string]string{}
+			for i := 0; i < extractor.ExtractCallCount(); i++ {
+				blobID, _, dstDirPath := extractor.ExtractArgsForCall(i)
+				dirs[blobID] = dstDirPath
+			}
+			return dirs
+		}
+
+		It("fetches and extracts logs for every instance into separate directories", func() {
+			Expect(act()).ToNot(HaveOccurred())
+
+			Expect(deployment.FetchLogsCallCount()).To(Equal(3))
+
+			var slugs []string
+			for i := 0; i < deployment.FetchLogsCallCount(); i++ {
+				slug, _, logTypes := deployment.FetchLogsArgsForCall(i)
+				slugs = append(slugs, slug.String())
+				Expect(logTypes).To(Equal("job"))
+			}
+			Expect(slugs).To(ConsistOf("web/web-id-0", "web/web-id-1", "worker/worker-id-0"))
+
+			Expect(extractedDirs()).To(Equal(map[string]string{
+				"blob-web-id-0":    "/fake-dir/web/web-id-0",
+				"blob-web-id-1":    "/fake-dir/web/web-id-1",
+				"blob-worker-id-0": "/fake-dir/worker/worker-id-0",
+			}))
+
+			Expect(indexer.BuildCallCount()).To(Equal(1))
+			Expect(indexer.BuildArgsForCall(0)).To(Equal("/fake-dir"))
+		})
+
+		It("only extracts logs for instances in the instance group", func() {
+			logsOpts.Args.Slug = boshdir.NewAllOrInstanceGroupOrInstanceSlug("web", "")
+
+			Expect(act()).ToNot(HaveOccurred())
+			Expect(extractedDirs()).To(HaveLen(2))
+			Expect(extractedDirs()).To(HaveKey("blob-web-id-0"))
+			Expect(extractedDirs()).To(HaveKey("blob-web-id-1"))
+		})
+
+		It("allows selecting instance by index", func() {
+			logsOpts.Args.Slug = boshdir.NewAllOrInstanceGroupOrInstanceSlug("web", "1")
+
+			Expect(act()).ToNot(HaveOccurred())
+			Expect(extractedDirs()).To(Equal(map[string]string{"blob-web-id-1": "/fake-dir/web/web-id-1"}))
+		})
+
+		It("allows selecting instance by IP", func() {
+			slug, err := boshdir.NewAllOrInstanceGroupOrInstanceSlugFromString("10.0.0.3")
+			Expect(err).ToNot(HaveOccurred())
+			logsOpts.Args.Slug = slug
+
+			Expect(act()).ToNot(HaveOccurred())
+			Expect(extractedDirs()).To(Equal(map[string]string{"blob-worker-id-0": "/fake-dir/worker/worker-id-0"}))
+		})
+
+		It("passes filters and log types", func() {
+			logsOpts.Filters = []string{"filter1"}
+			logsOpts.Agent = true
+
+			Expect(act()).ToNot(HaveOccurred())
+
+			_, filters, logTypes := deployment.FetchLogsArgsForCall(0)
+			Expect(filters).To(Equal([]string{"filter1"}))
+			Expect(logTypes).To(Equal("agent"))
+		})
+
+		It("prints summary of indexed instances", func() {
+			first := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+			last := time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC)
+
+			indexer.BuildReturns(boshlogs.Index{
+				Instances: []boshlogs.IndexInstance{
+					{
+						Group: "web",
+						ID:    "web-id-0",
+						Files: []boshlogs.IndexFile{
+							{Path: "a.log", Size: 10, FirstTime: &first, LastTime: &first},
+							{Path: "b.log", Size: 20, FirstTime: &first, LastTime: &last},
+						},
+					},
+					{
+						Group: "worker",
+						ID:    "worker-id-0",
+						Files: []boshlogs.IndexFile{{Path: "messages", Size: 5}},
+					},
+				},
+			}, nil)
+
+			Expect(act()).ToNot(HaveOccurred())
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "instances",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Instance"),
+					boshtbl.NewHeader("Files"),
+					boshtbl.NewHeader("Size"),
+					boshtbl.NewHeader("First Timestamp"),
+					boshtbl.NewHeader("Last Timestamp"),
+				},
+
+				SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("web/web-id-0"),
+						boshtbl.NewValueInt(2),
+						boshtbl.NewValueBytes(30),
+						boshtbl.NewValueTime(first),
+						boshtbl.NewValueTime(last),
+					},
+					{
+						boshtbl.NewValueString("worker/worker-id-0"),
+						boshtbl.NewValueInt(1),
+						boshtbl.NewValueBytes(5),
+						boshtbl.ValueString{},
+						boshtbl.ValueString{},
+					},
+				},
+			}))
+		})
+
+		It("returns error if tailing is requested", func() {
+			logsOpts.Follow = true
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("The --extract flag cannot be used with --follow, --num or --merge"))
+			Expect(deployment.VMInfosCallCount()).To(Equal(0))
+		})
+
+		It("returns error if no instances match", func() {
+			logsOpts.Args.Slug = boshdir.NewAllOrInstanceGroupOrInstanceSlug("unknown", "")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected to find at least one instance matching 'unknown'"))
+		})
+
+		It("returns error if listing instances fails", func() {
+			deployment.VMInfosReturns(nil, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns errors for each instance that failed, and does not index", func() {
+			extractor.ExtractStub = func(blobID, _, _ string) error {
+				if blobID == "blob-web-id-1" {
+					return errors.New("fake-err")
+				}
+				return nil
+			}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Extracting logs for 'web/web-id-1'"))
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+			Expect(extractor.ExtractCallCount()).To(Equal(3))
+			Expect(indexer.BuildCallCount()).To(Equal(0))
+		})
+
+		It("returns error if indexing fails", func() {
+			indexer.BuildReturns(boshlogs.Index{}, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Indexing logs in '/fake-dir'"))
+		})
+	})
+})
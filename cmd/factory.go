@@ -64,6 +64,14 @@ func (f Factory) New(args []string) (Cmd, error) {
 			opts.Deployment = boshOpts.DeploymentOpt
 		}
 
+		if opts, ok := command.(*DriftCheckOpts); ok {
+			opts.Deployment = boshOpts.DeploymentOpt
+		}
+
+		if opts, ok := command.(*ReportOpts); ok {
+			opts.Deployment = boshOpts.DeploymentOpt
+		}
+
 		if opts, ok := command.(*VMsOpts); ok {
 			opts.Deployment = boshOpts.DeploymentOpt
 		}
@@ -3,6 +3,7 @@ package cmd_test
 import (
 	"fmt"
 	"os"
+	"time"
 
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
@@ -62,6 +63,7 @@ var _ = Describe("Factory", func() {
 			Entry("add-blob", "add-blob", []string{filePlaceholder, "directory"}),
 			Entry("attach-disk", "attach-disk", []string{"instance/abad1dea", "disk-cid-123"}),
 			Entry("blobs", "blobs", []string{}),
+			Entry("build-package", "build-package", []string{"name"}),
 			Entry("interpolate", "interpolate", []string{filePlaceholder}),
 			Entry("cancel-task", "cancel-task", []string{"1234"}),
 			Entry("clean-up", "clean-up", []string{}),
@@ -85,6 +87,8 @@ var _ = Describe("Factory", func() {
 			Entry("alias-env", "alias-env", []string{"alias"}),
 			Entry("environment", "environment", []string{}),
 			Entry("environments", "environments", []string{}),
+			Entry("export-inventory", "export-inventory", []string{}),
+			Entry("diff-inventory", "diff-inventory", []string{filePlaceholder, filePlaceholder}),
 			Entry("errands", "errands", []string{}),
 			Entry("events", "events", []string{}),
 			Entry("export-release", "export-release", []string{"release/version", "os/version"}),
@@ -94,16 +98,19 @@ var _ = Describe("Factory", func() {
 			Entry("init-release", "init-release", []string{}),
 			Entry("inspect-release", "inspect-release", []string{"name/version"}),
 			Entry("inspect-local-release", "inspect-local-release", []string{filePlaceholder}),
+			Entry("inspect-release-sbom", "inspect-release-sbom", []string{filePlaceholder}),
 			Entry("inspect-local-stemcell", "inspect-local-stemcell", []string{filePlaceholder}),
 			Entry("instances", "instances", []string{}),
 			Entry("locks", "locks", []string{}),
 			Entry("log-in", "log-in", []string{}),
 			Entry("log-out", "log-out", []string{}),
 			Entry("logs", "logs", []string{"slug"}),
+			Entry("logs-search", "logs-search", []string{"dir", "pattern"}),
 			Entry("manifest", "manifest", []string{}),
 			Entry("recreate", "recreate", []string{"slug"}),
 			Entry("releases", "releases", []string{}),
 			Entry("remove-blob", "remove-blob", []string{filePlaceholder}),
+			Entry("report", "report", []string{}),
 			Entry("reset-release", "reset-release", []string{}),
 			Entry("restart", "restart", []string{"slug"}),
 			Entry("run-errand", "run-errand", []string{"name"}),
@@ -116,12 +123,19 @@ var _ = Describe("Factory", func() {
 			Entry("take-snapshot", "take-snapshot", []string{"group/id"}),
 			Entry("task", "task", []string{"1234"}),
 			Entry("tasks", "tasks", []string{}),
+			Entry("update-blobs", "update-blobs", []string{}),
 			Entry("update-cloud-config", "update-cloud-config", []string{filePlaceholder}),
 			Entry("update-resurrection", "update-resurrection", []string{"off"}),
 			Entry("update-runtime-config", "update-runtime-config", []string{filePlaceholder}),
+			Entry("upgrade-stemcells", "upgrade-stemcells", []string{"--os", "ubuntu-jammy"}),
 			Entry("upload-blobs", "upload-blobs", []string{}),
 			Entry("upload-release", "upload-release", []string{filePlaceholder}),
 			Entry("upload-stemcell", "upload-stemcell", []string{filePlaceholder}),
+			Entry("validate-release", "validate-release", []string{}),
+			Entry("diff-releases", "diff-releases", []string{"from.tgz", "to.tgz"}),
+			Entry("drift-check", "drift-check", []string{filePlaceholder}),
+			Entry("clean-release-dir", "clean-release-dir", []string{}),
+			Entry("verify-release-reproducible", "verify-release-reproducible", []string{filePlaceholder}),
 			Entry("vms", "vms", []string{}),
 			Entry("curl", "curl", []string{"/"}),
 		)
@@ -196,6 +210,31 @@ var _ = Describe("Factory", func() {
 	})
 
 	Describe("deploy command", func() {
+		It("waits for lock without timeout when --wait-for-lock is given without value", func() {
+			cmd, err := factory.New([]string{"deploy", "--wait-for-lock", tmpFile})
+			Expect(err).ToNot(HaveOccurred())
+
+			deployOpts := cmd.Opts.(*opts.DeployOpts)
+			Expect(deployOpts.WaitForLock).ToNot(BeNil())
+			Expect(*deployOpts.WaitForLock).To(Equal(time.Duration(0)))
+		})
+
+		It("parses --wait-for-lock timeout", func() {
+			cmd, err := factory.New([]string{"deploy", "--wait-for-lock=30m", tmpFile})
+			Expect(err).ToNot(HaveOccurred())
+
+			deployOpts := cmd.Opts.(*opts.DeployOpts)
+			Expect(*deployOpts.WaitForLock).To(Equal(30 * time.Minute))
+		})
+
+		It("does not wait for lock by default", func() {
+			cmd, err := factory.New([]string{"deploy", tmpFile})
+			Expect(err).ToNot(HaveOccurred())
+
+			deployOpts := cmd.Opts.(*opts.DeployOpts)
+			Expect(deployOpts.WaitForLock).To(BeNil())
+		})
+
 		It("parses multiple skip-drain flags", func() {
 			cmd, err := factory.New([]string{"deploy", "--skip-drain=job1", "--skip-drain=job2", tmpFile})
 			Expect(err).ToNot(HaveOccurred())
@@ -274,6 +313,36 @@ var _ = Describe("Factory", func() {
 		})
 	})
 
+	Describe("report command", func() {
+		It("is passed the deployment flag and defaults to last 30 days", func() {
+			cmd, err := factory.New([]string{"report", "--deployment", "deployment"})
+			Expect(err).ToNot(HaveOccurred())
+
+			reportOpts := cmd.Opts.(*opts.ReportOpts)
+			Expect(reportOpts.Deployment).To(Equal("deployment"))
+			Expect(reportOpts.Since.Duration).To(Equal(30 * 24 * time.Hour))
+			Expect(reportOpts.Top).To(Equal(10))
+		})
+
+		It("parses since in days", func() {
+			cmd, err := factory.New([]string{"report", "--since", "7d"})
+			Expect(err).ToNot(HaveOccurred())
+
+			reportOpts := cmd.Opts.(*opts.ReportOpts)
+			Expect(reportOpts.Since.Duration).To(Equal(7 * 24 * time.Hour))
+		})
+	})
+
+	Describe("drift-check command", func() {
+		It("is passed the deployment flag", func() {
+			cmd, err := factory.New([]string{"drift-check", "--deployment", "deployment"})
+			Expect(err).ToNot(HaveOccurred())
+
+			driftCheckOpts := cmd.Opts.(*opts.DriftCheckOpts)
+			Expect(driftCheckOpts.Deployment).To(Equal("deployment"))
+		})
+	})
+
 	Describe("vms command", func() {
 		It("is passed the deployment flag", func() {
 			cmd, err := factory.New([]string{"vms", "--deployment", "deployment"})
@@ -369,23 +438,35 @@ var _ = Describe("Factory", func() {
 			boshOpts.ExportRelease = opts.ExportReleaseOpts{}
 			boshOpts.RunErrand = opts.RunErrandOpts{}
 			boshOpts.Logs = opts.LogsOpts{}
+			boshOpts.Events = opts.EventsOpts{}
+			boshOpts.UpgradeStemcells = opts.UpgradeStemcellsOpts{}
+			boshOpts.Report = opts.ReportOpts{}
 			boshOpts.Interpolate = opts.InterpolateOpts{}
 			boshOpts.InitRelease = opts.InitReleaseOpts{}
 			boshOpts.ResetRelease = opts.ResetReleaseOpts{}
 			boshOpts.GenerateJob = opts.GenerateJobOpts{}
 			boshOpts.GeneratePackage = opts.GeneratePackageOpts{}
 			boshOpts.VendorPackage = opts.VendorPackageOpts{}
+			boshOpts.BuildPackage = opts.BuildPackageOpts{}
 			boshOpts.CreateRelease = opts.CreateReleaseOpts{}
 			boshOpts.FinalizeRelease = opts.FinalizeReleaseOpts{}
+			boshOpts.VerifyReleaseReproducible = opts.VerifyReleaseReproducibleOpts{}
+			boshOpts.ValidateRelease = opts.ValidateReleaseOpts{}
+			boshOpts.DiffReleases = opts.DiffReleasesOpts{}
+			boshOpts.ExportInventory = opts.ExportInventoryOpts{}
+			boshOpts.DiffInventory = opts.DiffInventoryOpts{}
+			boshOpts.CleanReleaseDir = opts.CleanReleaseDirOpts{}
 			boshOpts.Blobs = opts.BlobsOpts{}
 			boshOpts.AddBlob = opts.AddBlobOpts{}
 			boshOpts.RemoveBlob = opts.RemoveBlobOpts{}
 			boshOpts.SyncBlobs = opts.SyncBlobsOpts{}
 			boshOpts.UploadBlobs = opts.UploadBlobsOpts{}
+			boshOpts.UpdateBlobs = opts.UpdateBlobsOpts{}
 			boshOpts.Pcap = opts.PcapOpts{}
 			boshOpts.SSH = opts.SSHOpts{}
 			boshOpts.SCP = opts.SCPOpts{}
 			boshOpts.Deploy = opts.DeployOpts{}
+			boshOpts.DriftCheck = opts.DriftCheckOpts{}
 			boshOpts.UpdateRuntimeConfig = opts.UpdateRuntimeConfigOpts{}
 			boshOpts.VMs = opts.VMsOpts{}
 			boshOpts.Instances = opts.InstancesOpts{}
@@ -394,6 +475,7 @@ var _ = Describe("Factory", func() {
 			boshOpts.UpdateConfig = opts.UpdateConfigOpts{}
 			boshOpts.DeleteConfig = opts.DeleteConfigOpts{}
 			boshOpts.Curl = opts.CurlOpts{}
+			boshOpts.InspectReleaseSBOM = opts.InspectReleaseSBOMOpts{}
 			return boshOpts
 		}
 
@@ -405,6 +487,7 @@ var _ = Describe("Factory", func() {
 			Expect(clearNonGlobalOpts(cmd.BoshOpts)).To(Equal(opts.BoshOpts{
 				ConfigPathOpt: "~/.bosh/config",
 				Parallel:      5,
+				Compression:   "gzip",
 			}))
 		})
 
@@ -421,6 +504,8 @@ var _ = Describe("Factory", func() {
 				"--no-color",
 				"--non-interactive",
 				"--parallel", "123",
+				"--compression", "zstd",
+				"--compression-level", "19",
 				"locks",
 			}
 
@@ -439,6 +524,8 @@ var _ = Describe("Factory", func() {
 				NoColorOpt:        true,
 				NonInteractiveOpt: true,
 				Parallel:          123,
+				Compression:       "zstd",
+				CompressionLevel:  19,
 			}))
 		})
 
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"path"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/workpool"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	cmdconf "github.com/cloudfoundry/bosh-cli/v7/cmd/config"
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+// FanOutFunc runs read-only command against a single environment
+type FanOutFunc func(director boshdir.Director, ui boshui.UI) error
+
+type FanOutDirectorFactory func(env cmdconf.Environment, ui boshui.UI) (boshdir.Director, error)
+
+// FanOutCmd runs read-only command against each selected environment concurrently
+// and prints collected tables once with an additional environment column
+type FanOutCmd struct {
+	environments    []cmdconf.Environment
+	directorFactory FanOutDirectorFactory
+	ui              boshui.UI
+	parallel        int
+}
+
+func NewFanOutCmd(
+	environments []cmdconf.Environment,
+	directorFactory FanOutDirectorFactory,
+	ui boshui.UI,
+	parallel int,
+) FanOutCmd {
+	return FanOutCmd{
+		environments:    environments,
+		directorFactory: directorFactory,
+		ui:              ui,
+		parallel:        parallel,
+	}
+}
+
+// IsEnvironmentPattern returns true if environment option selects multiple environments, e.g. 'prod-*'
+func IsEnvironmentPattern(environment string) bool {
+	return strings.ContainsAny(environment, "*?")
+}
+
+// SelectEnvironments returns aliased environments whose alias or URL matches pattern;
+// all aliased environments are returned when all is set and pattern is not a pattern
+func SelectEnvironments(config cmdconf.Config, pattern string, all bool) ([]cmdconf.Environment, error) {
+	var selected []cmdconf.Environment
+
+	for _, env := range config.Environments() {
+		if len(env.Alias) == 0 {
+			continue
+		}
+
+		if !IsEnvironmentPattern(pattern) {
+			if all {
+				selected = append(selected, env)
+			}
+			continue
+		}
+
+		aliasMatched, err := path.Match(pattern, env.Alias)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Matching environment pattern '%s'", pattern)
+		}
+
+		urlMatched, _ := path.Match(pattern, env.URL) //nolint:errcheck
+
+		if aliasMatched || urlMatched {
+			selected = append(selected, env)
+		}
+	}
+
+	if len(selected) == 0 {
+		if IsEnvironmentPattern(pattern) {
+			return nil, bosherr.Errorf("Expected at least one aliased environment to match '%s'", pattern)
+		}
+		return nil, bosherr.Error("Expected at least one aliased environment")
+	}
+
+	return selected, nil
+}
+
+func (c FanOutCmd) Run(fanOutFunc FanOutFunc) error {
+	parallel := c.parallel
+	if parallel == 0 {
+		parallel = 1
+	}
+
+	var outputLock sync.Mutex
+
+	uis := make([]*tableCollectingUI, len(c.environments))
+	errs := make([]error, len(c.environments))
+	works := make([]func(), len(c.environments))
+
+	for i, env := range c.environments {
+		i, env := i, env
+		uis[i] = &tableCollectingUI{UI: c.ui, lock: &outputLock}
+
+		works[i] = func() {
+			director, err := c.directorFactory(env, uis[i])
+			if err == nil {
+				err = fanOutFunc(director, uis[i])
+			}
+			if err != nil {
+				errs[i] = bosherr.WrapErrorf(err, "Environment '%s'", env.Alias)
+			}
+		}
+	}
+
+	throttler, err := workpool.NewThrottler(parallel, works)
+	if err != nil {
+		return err
+	}
+
+	throttler.Work()
+
+	c.printTables(uis)
+
+	var envErrs []error
+
+	for _, err := range errs {
+		if err != nil {
+			envErrs = append(envErrs, err)
+		}
+	}
+
+	if len(envErrs) > 0 {
+		return bosherr.NewMultiError(envErrs...)
+	}
+
+	return nil
+}
+
+// printTables merges tables with the same title, content and headers
+// across environments keeping the order in which they were first printed
+func (c FanOutCmd) printTables(uis []*tableCollectingUI) {
+	var merged []collectedTable
+
+	mergedIdx := map[string]int{}
+
+	for i, ui := range uis {
+		envVal := boshtbl.NewValueString(c.environments[i].Alias)
+
+		for _, collected := range ui.tables {
+			key := collected.key()
+
+			idx, found := mergedIdx[key]
+			if !found {
+				idx = len(merged)
+				mergedIdx[key] = idx
+				merged = append(merged, collected.withEnvironmentColumn())
+			}
+
+			for _, row := range collected.table.AsRows() {
+				merged[idx].table.Rows = append(merged[idx].table.Rows, append([]boshtbl.Value{envVal}, row...))
+			}
+		}
+	}
+
+	for _, collected := range merged {
+		if collected.filterHeader != nil {
+			c.ui.PrintTableFiltered(collected.table, collected.filterHeader)
+		} else {
+			c.ui.PrintTable(collected.table)
+		}
+	}
+}
+
+type collectedTable struct {
+	table        boshtbl.Table
+	filterHeader []boshtbl.Header
+}
+
+func (t collectedTable) key() string {
+	keys := []string{t.table.Title, t.table.Content}
+
+	for _, header := range t.table.Header {
+		keys = append(keys, header.Key)
+	}
+
+	for _, header := range t.filterHeader {
+		keys = append(keys, "filter:"+header.Key)
+	}
+
+	return strings.Join(keys, "\x00")
+}
+
+// withEnvironmentColumn returns empty copy of the table with environment as its first column
+func (t collectedTable) withEnvironmentColumn() collectedTable {
+	envHeader := boshtbl.NewHeader("Environment")
+
+	table := t.table
+	table.Header = append([]boshtbl.Header{envHeader}, t.table.Header...)
+	table.Sections = nil
+	table.Rows = nil
+	table.SortBy = []boshtbl.ColumnSort{{Column: 0, Asc: true}}
+
+	for _, sort := range t.table.SortBy {
+		table.SortBy = append(table.SortBy, boshtbl.ColumnSort{Column: sort.Column + 1, Asc: sort.Asc})
+	}
+
+	var filterHeader []boshtbl.Header
+
+	if t.filterHeader != nil {
+		filterHeader = append([]boshtbl.Header{envHeader}, t.filterHeader...)
+	}
+
+	return collectedTable{table: table, filterHeader: filterHeader}
+}
+
+// tableCollectingUI keeps tables for printing once all environments finish
+// and serializes remaining output of concurrently running commands
+type tableCollectingUI struct {
+	boshui.UI
+
+	lock   *sync.Mutex
+	tables []collectedTable
+}
+
+func (ui *tableCollectingUI) PrintTable(table boshtbl.Table) {
+	ui.tables = append(ui.tables, collectedTable{table: table})
+}
+
+func (ui *tableCollectingUI) PrintTableFiltered(table boshtbl.Table, filterHeader []boshtbl.Header) {
+	ui.tables = append(ui.tables, collectedTable{table: table, filterHeader: filterHeader})
+}
+
+func (ui *tableCollectingUI) ErrorLinef(pattern string, args ...interface{}) {
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+	ui.UI.ErrorLinef(pattern, args...)
+}
+
+func (ui *tableCollectingUI) PrintLinef(pattern string, args ...interface{}) {
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+	ui.UI.PrintLinef(pattern, args...)
+}
+
+func (ui *tableCollectingUI) BeginLinef(pattern string, args ...interface{}) {
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+	ui.UI.BeginLinef(pattern, args...)
+}
+
+func (ui *tableCollectingUI) EndLinef(pattern string, args ...interface{}) {
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+	ui.UI.EndLinef(pattern, args...)
+}
+
+func (ui *tableCollectingUI) PrintBlock(block []byte) {
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+	ui.UI.PrintBlock(block)
+}
+
+func (ui *tableCollectingUI) PrintErrorBlock(block string) {
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+	ui.UI.PrintErrorBlock(block)
+}
@@ -0,0 +1,185 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-cli/v7/cmd"
+	cmdconf "github.com/cloudfoundry/bosh-cli/v7/cmd/config"
+	fakecmdconf "github.com/cloudfoundry/bosh-cli/v7/cmd/config/configfakes"
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("FanOutCmd", func() {
+	var (
+		ui           *fakeui.FakeUI
+		environments []cmdconf.Environment
+		directors    map[string]*fakedir.FakeDirector
+		command      cmd.FanOutCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+
+		environments = []cmdconf.Environment{
+			{URL: "https://prod-1", Alias: "prod-1"},
+			{URL: "https://prod-2", Alias: "prod-2"},
+		}
+
+		directors = map[string]*fakedir.FakeDirector{
+			"https://prod-1": {},
+			"https://prod-2": {},
+		}
+
+		directorFactory := func(env cmdconf.Environment, _ boshui.UI) (boshdir.Director, error) {
+			return directors[env.URL], nil
+		}
+
+		command = cmd.NewFanOutCmd(environments, directorFactory, ui, 2)
+	})
+
+	printNames := func(director boshdir.Director, ui boshui.UI) error {
+		info, err := director.Info()
+		if err != nil {
+			return err
+		}
+
+		ui.PrintTable(boshtbl.Table{
+			Content: "names",
+			Header:  []boshtbl.Header{boshtbl.NewHeader("Name")},
+			SortBy:  []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+			Sections: []boshtbl.Section{
+				{Rows: [][]boshtbl.Value{{boshtbl.NewValueString(info.Name)}}},
+			},
+		})
+
+		return nil
+	}
+
+	It("prints tables of all environments merged with environment column", func() {
+		directors["https://prod-1"].InfoReturns(boshdir.Info{Name: "director-1"}, nil)
+		directors["https://prod-2"].InfoReturns(boshdir.Info{Name: "director-2"}, nil)
+
+		err := command.Run(printNames)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Tables).To(Equal([]boshtbl.Table{{
+			Content: "names",
+			Header: []boshtbl.Header{
+				boshtbl.NewHeader("Environment"),
+				boshtbl.NewHeader("Name"),
+			},
+			SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}},
+			Rows: [][]boshtbl.Value{
+				{boshtbl.NewValueString("prod-1"), boshtbl.NewValueString("director-1")},
+				{boshtbl.NewValueString("prod-2"), boshtbl.NewValueString("director-2")},
+			},
+		}}))
+	})
+
+	It("keeps tables with different titles separate", func() {
+		printTitled := func(director boshdir.Director, ui boshui.UI) error {
+			info, err := director.Info()
+			if err != nil {
+				return err
+			}
+
+			ui.PrintTable(boshtbl.Table{
+				Title:  info.Name,
+				Header: []boshtbl.Header{boshtbl.NewHeader("Name")},
+				Rows:   [][]boshtbl.Value{{boshtbl.NewValueString(info.Name)}},
+			})
+
+			return nil
+		}
+
+		directors["https://prod-1"].InfoReturns(boshdir.Info{Name: "director-1"}, nil)
+		directors["https://prod-2"].InfoReturns(boshdir.Info{Name: "director-2"}, nil)
+
+		err := command.Run(printTitled)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Tables).To(HaveLen(2))
+		Expect(ui.Tables[0].Title).To(Equal("director-1"))
+		Expect(ui.Tables[1].Title).To(Equal("director-2"))
+	})
+
+	It("prints tables of successful environments and returns errors of failed ones", func() {
+		directors["https://prod-1"].InfoReturns(boshdir.Info{}, errors.New("fake-err"))
+		directors["https://prod-2"].InfoReturns(boshdir.Info{Name: "director-2"}, nil)
+
+		err := command.Run(printNames)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Environment 'prod-1': fake-err"))
+
+		Expect(ui.Tables).To(HaveLen(1))
+		Expect(ui.Tables[0].Rows).To(Equal([][]boshtbl.Value{
+			{boshtbl.NewValueString("prod-2"), boshtbl.NewValueString("director-2")},
+		}))
+	})
+
+	It("returns error if director cannot be built", func() {
+		directorFactory := func(env cmdconf.Environment, _ boshui.UI) (boshdir.Director, error) {
+			return nil, errors.New("fake-err")
+		}
+
+		command = cmd.NewFanOutCmd(environments, directorFactory, ui, 2)
+
+		err := command.Run(printNames)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Environment 'prod-2': fake-err"))
+	})
+})
+
+var _ = Describe("SelectEnvironments", func() {
+	var (
+		config *fakecmdconf.FakeConfig
+	)
+
+	BeforeEach(func() {
+		config = &fakecmdconf.FakeConfig{}
+		config.EnvironmentsReturns([]cmdconf.Environment{
+			{URL: "https://prod-1", Alias: "prod-1"},
+			{URL: "https://staging", Alias: "staging"},
+			{URL: "https://unaliased"},
+			{URL: "https://prod-2", Alias: "prod-2"},
+		})
+	})
+
+	It("selects all aliased environments", func() {
+		envs, err := cmd.SelectEnvironments(config, "staging", true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(envs).To(Equal([]cmdconf.Environment{
+			{URL: "https://prod-1", Alias: "prod-1"},
+			{URL: "https://staging", Alias: "staging"},
+			{URL: "https://prod-2", Alias: "prod-2"},
+		}))
+	})
+
+	It("selects aliased environments matching pattern", func() {
+		envs, err := cmd.SelectEnvironments(config, "prod-*", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(envs).To(Equal([]cmdconf.Environment{
+			{URL: "https://prod-1", Alias: "prod-1"},
+			{URL: "https://prod-2", Alias: "prod-2"},
+		}))
+	})
+
+	It("returns error if no environment matches pattern", func() {
+		_, err := cmd.SelectEnvironments(config, "dev-*", true)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected at least one aliased environment to match 'dev-*'"))
+	})
+
+	It("returns error if pattern is malformed", func() {
+		_, err := cmd.SelectEnvironments(config, "prod-[*", false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Matching environment pattern 'prod-[*'"))
+	})
+})
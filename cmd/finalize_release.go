@@ -10,20 +10,23 @@ import (
 )
 
 type FinalizeReleaseCmd struct {
-	releaseReader boshrel.Reader
-	releaseDir    boshreldir.ReleaseDir
-	ui            boshui.UI
+	releaseReader    boshrel.Reader
+	releaseDir       boshreldir.ReleaseDir
+	workspaceFactory func(string) boshreldir.Workspace
+	ui               boshui.UI
 }
 
 func NewFinalizeReleaseCmd(
 	releaseReader boshrel.Reader,
 	releaseDir boshreldir.ReleaseDir,
+	workspaceFactory func(string) boshreldir.Workspace,
 	ui boshui.UI,
 ) FinalizeReleaseCmd {
 	return FinalizeReleaseCmd{
-		releaseReader: releaseReader,
-		releaseDir:    releaseDir,
-		ui:            ui,
+		releaseReader:    releaseReader,
+		releaseDir:       releaseDir,
+		workspaceFactory: workspaceFactory,
+		ui:               ui,
 	}
 }
 
@@ -37,12 +40,21 @@ func (c FinalizeReleaseCmd) Run(opts FinalizeReleaseOpts) error {
 		release.SetName(opts.Name)
 	}
 
+	releaseDir := c.releaseDir
+
+	if len(opts.Workspace.ExpandedPath) > 0 {
+		releaseDir, err = c.workspaceFactory(opts.Workspace.ExpandedPath).FindReleaseDir(release.Name())
+		if err != nil {
+			return err
+		}
+	}
+
 	version := semver.Version(opts.Version)
 
 	if !version.Empty() {
 		release.SetVersion(version.AsString())
 	} else {
-		version, err := c.releaseDir.NextFinalVersion(release.Name())
+		version, err := releaseDir.NextFinalVersion(release.Name())
 		if err != nil {
 			return err
 		}
@@ -50,7 +62,7 @@ func (c FinalizeReleaseCmd) Run(opts FinalizeReleaseOpts) error {
 		release.SetVersion(version.AsString())
 	}
 
-	err = c.releaseDir.FinalizeRelease(release, opts.Force)
+	err = releaseDir.FinalizeRelease(release, opts.Force)
 	if err != nil {
 		return err
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
 	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
 	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
+	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
 	fakereldir "github.com/cloudfoundry/bosh-cli/v7/releasedir/releasedirfakes"
 	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
 	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
@@ -20,6 +21,7 @@ var _ = Describe("FinalizeReleaseCmd", func() {
 	var (
 		releaseReader *fakerel.FakeReader
 		releaseDir    *fakereldir.FakeReleaseDir
+		workspace     *fakereldir.FakeWorkspace
 		ui            *fakeui.FakeUI
 		command       cmd.FinalizeReleaseCmd
 	)
@@ -27,8 +29,15 @@ var _ = Describe("FinalizeReleaseCmd", func() {
 	BeforeEach(func() {
 		releaseReader = &fakerel.FakeReader{}
 		releaseDir = &fakereldir.FakeReleaseDir{}
+		workspace = &fakereldir.FakeWorkspace{}
 		ui = &fakeui.FakeUI{}
-		command = cmd.NewFinalizeReleaseCmd(releaseReader, releaseDir, ui)
+
+		workspaceFactory := func(path string) boshreldir.Workspace {
+			Expect(path).To(Equal("/workspace.yml"))
+			return workspace
+		}
+
+		command = cmd.NewFinalizeReleaseCmd(releaseReader, releaseDir, workspaceFactory, ui)
 	})
 
 	Describe("Run", func() {
@@ -127,6 +136,51 @@ var _ = Describe("FinalizeReleaseCmd", func() {
 			}))
 		})
 
+		Context("when workspace is specified", func() {
+			var workspaceReleaseDir *fakereldir.FakeReleaseDir
+
+			BeforeEach(func() {
+				finalizeReleaseOpts.Workspace = opts.FileArg{ExpandedPath: "/workspace.yml"}
+
+				releaseReader.ReadReturns(release, nil)
+
+				workspaceReleaseDir = &fakereldir.FakeReleaseDir{}
+				workspaceReleaseDir.NextFinalVersionReturns(semver.MustNewVersionFromString("2"), nil)
+				workspace.FindReleaseDirReturns(workspaceReleaseDir, nil)
+			})
+
+			It("finalizes release in release directory matching release name", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(workspace.FindReleaseDirArgsForCall(0)).To(Equal("rel"))
+
+				Expect(workspaceReleaseDir.FinalizeReleaseCallCount()).To(Equal(1))
+				Expect(release.Version()).To(Equal("2"))
+
+				Expect(releaseDir.NextFinalVersionCallCount()).To(Equal(0))
+				Expect(releaseDir.FinalizeReleaseCallCount()).To(Equal(0))
+			})
+
+			It("matches release directory by custom name", func() {
+				finalizeReleaseOpts.Name = "custom-name"
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(workspace.FindReleaseDirArgsForCall(0)).To(Equal("custom-name"))
+			})
+
+			It("returns error if release directory cannot be found", func() {
+				workspace.FindReleaseDirReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+				Expect(workspaceReleaseDir.FinalizeReleaseCallCount()).To(Equal(0))
+			})
+		})
+
 		It("returns error if reading path fails", func() {
 			releaseReader.ReadReturns(nil, errors.New("fake-err"))
 
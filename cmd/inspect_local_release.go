@@ -1,27 +1,42 @@
 package cmd
 
 import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
 	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
 	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
 	biui "github.com/cloudfoundry/bosh-cli/v7/ui"
 )
 
 type InspectLocalReleaseCmd struct {
-	reader boshrel.Reader
-	ui     biui.UI
+	reader        boshrel.Reader
+	tarballSigner bicrypto.TarballSigner
+	ui            biui.UI
 }
 
 func NewInspectLocalReleaseCmd(
 	reader boshrel.Reader,
+	tarballSigner bicrypto.TarballSigner,
 	ui biui.UI,
 ) InspectLocalReleaseCmd {
 	return InspectLocalReleaseCmd{
-		reader: reader,
-		ui:     ui,
+		reader:        reader,
+		tarballSigner: tarballSigner,
+		ui:            ui,
 	}
 }
 
 func (cmd InspectLocalReleaseCmd) Run(opts InspectLocalReleaseOpts) error {
+	if len(opts.VerifyKey.ExpandedPath) > 0 {
+		signature, err := cmd.tarballSigner.Verify(opts.VerifyKey.ExpandedPath, opts.Args.PathToRelease)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Verifying release signature")
+		}
+
+		cmd.ui.PrintLinef("Verified signature '%s' with key '%s'", signature.Path, signature.KeyFingerprint)
+	}
+
 	release, err := cmd.reader.Read(opts.Args.PathToRelease)
 
 	if err != nil {
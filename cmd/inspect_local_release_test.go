@@ -8,6 +8,8 @@ import (
 
 	"github.com/cloudfoundry/bosh-cli/v7/cmd"
 	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
+	fakecrypto "github.com/cloudfoundry/bosh-cli/v7/crypto/fakes"
 	boshjob "github.com/cloudfoundry/bosh-cli/v7/release/job"
 	boshpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
 	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
@@ -21,6 +23,7 @@ var _ = Describe("InspectLocalReleaseCmd", func() {
 		var (
 			fakeRelease             *fakerel.FakeRelease
 			releaseReader           *fakerel.FakeReader
+			tarballSigner           *fakecrypto.FakeTarballSigner
 			ui                      *fakeui.FakeUI
 			inspectLocalReleaseOpts opts.InspectLocalReleaseOpts
 			command                 cmd.InspectLocalReleaseCmd
@@ -80,9 +83,10 @@ var _ = Describe("InspectLocalReleaseCmd", func() {
 				},
 			}
 
+			tarballSigner = fakecrypto.NewFakeTarballSigner()
 			ui = &fakeui.FakeUI{}
 
-			command = cmd.NewInspectLocalReleaseCmd(releaseReader, ui)
+			command = cmd.NewInspectLocalReleaseCmd(releaseReader, tarballSigner, ui)
 		})
 
 		It("prints tables with release, job and package information", func() {
@@ -172,5 +176,39 @@ var _ = Describe("InspectLocalReleaseCmd", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-err"))
 		})
+
+		It("does not verify signature unless key is given", func() {
+			err := command.Run(inspectLocalReleaseOpts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tarballSigner.VerifyInputs).To(BeEmpty())
+		})
+
+		Context("when verify key is given", func() {
+			BeforeEach(func() {
+				inspectLocalReleaseOpts.VerifyKey = opts.FileArg{ExpandedPath: "/key.pem"}
+			})
+
+			It("verifies release tarball signature", func() {
+				tarballSigner.VerifySignature = bicrypto.TarballSignature{
+					Path: "/some/release.tgz.sig", KeyFingerprint: "SHA256:fp"}
+
+				err := command.Run(inspectLocalReleaseOpts)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(tarballSigner.VerifyInputs).To(Equal([]fakecrypto.TarballSignerInput{
+					{KeyPath: "/key.pem", TarballPath: "/some/release.tgz"},
+				}))
+				Expect(ui.Said).To(ContainElement("Verified signature '/some/release.tgz.sig' with key 'SHA256:fp'"))
+			})
+
+			It("returns error if signature does not match", func() {
+				tarballSigner.VerifyErr = errors.New("fake-err")
+
+				err := command.Run(inspectLocalReleaseOpts)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+				Expect(ui.Tables).To(BeEmpty())
+			})
+		})
 	})
 })
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshsbom "github.com/cloudfoundry/bosh-cli/v7/release/sbom"
+	biui "github.com/cloudfoundry/bosh-cli/v7/ui"
+)
+
+type InspectReleaseSBOMCmd struct {
+	reader    boshrel.Reader
+	generator boshsbom.Generator
+	ui        biui.UI
+}
+
+func NewInspectReleaseSBOMCmd(
+	reader boshrel.Reader,
+	generator boshsbom.Generator,
+	ui biui.UI,
+) InspectReleaseSBOMCmd {
+	return InspectReleaseSBOMCmd{
+		reader:    reader,
+		generator: generator,
+		ui:        ui,
+	}
+}
+
+func (cmd InspectReleaseSBOMCmd) Run(opts InspectReleaseSBOMOpts) error {
+	format, err := boshsbom.NewFormat(opts.Format)
+	if err != nil {
+		return err
+	}
+
+	release, err := cmd.reader.Read(opts.Args.PathToRelease)
+	if err != nil {
+		return err
+	}
+
+	defer release.CleanUp() //nolint:errcheck
+
+	bytes, err := cmd.generator.Generate(release, format)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.PrintBlock(bytes)
+
+	return nil
+}
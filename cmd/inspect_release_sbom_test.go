@@ -0,0 +1,86 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
+	boshsbom "github.com/cloudfoundry/bosh-cli/v7/release/sbom"
+	fakesbom "github.com/cloudfoundry/bosh-cli/v7/release/sbom/sbomfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+)
+
+var _ = Describe("InspectReleaseSBOMCmd", func() {
+	var (
+		releaseReader *fakerel.FakeReader
+		generator     *fakesbom.FakeGenerator
+		ui            *fakeui.FakeUI
+		command       InspectReleaseSBOMCmd
+	)
+
+	BeforeEach(func() {
+		releaseReader = &fakerel.FakeReader{}
+		generator = &fakesbom.FakeGenerator{}
+		ui = &fakeui.FakeUI{}
+		command = NewInspectReleaseSBOMCmd(releaseReader, generator, ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			inspectOpts InspectReleaseSBOMOpts
+			release     *fakerel.FakeRelease
+		)
+
+		BeforeEach(func() {
+			inspectOpts = InspectReleaseSBOMOpts{
+				Args:   InspectReleaseSBOMArgs{PathToRelease: "/release.tgz"},
+				Format: "spdx",
+			}
+
+			release = &fakerel.FakeRelease{}
+			releaseReader.ReadReturns(release, nil)
+			generator.GenerateReturns([]byte("sbom-content"), nil)
+		})
+
+		It("prints document generated from release tarball", func() {
+			err := command.Run(inspectOpts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/release.tgz"))
+
+			generatedRel, format := generator.GenerateArgsForCall(0)
+			Expect(generatedRel).To(Equal(release))
+			Expect(format).To(Equal(boshsbom.SPDXFormat))
+
+			Expect(ui.Blocks).To(Equal([]string{"sbom-content"}))
+			Expect(release.CleanUpCallCount()).To(Equal(1))
+		})
+
+		It("returns error for unknown format", func() {
+			inspectOpts.Format = "unknown"
+
+			err := command.Run(inspectOpts)
+			Expect(err).To(HaveOccurred())
+			Expect(releaseReader.ReadCallCount()).To(Equal(0))
+		})
+
+		It("returns error if reading release fails", func() {
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			err := command.Run(inspectOpts)
+			Expect(err).To(MatchError(ContainSubstring("fake-err")))
+		})
+
+		It("returns error if generating document fails", func() {
+			generator.GenerateReturns(nil, errors.New("fake-err"))
+
+			err := command.Run(inspectOpts)
+			Expect(err).To(MatchError(ContainSubstring("fake-err")))
+			Expect(release.CleanUpCallCount()).To(Equal(1))
+		})
+	})
+})
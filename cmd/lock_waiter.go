@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+)
+
+const lockPollInterval = 5 * time.Second
+
+//counterfeiter:generate . DeploymentLockWaiter
+
+type DeploymentLockWaiter interface {
+	Wait(deploymentName string, flags WaitForLockFlags) error
+}
+
+// LockWaiter waits for deployment lock to be released so that
+// concurrent operations on the same deployment are queued instead of failing
+type LockWaiter struct {
+	director    boshdir.Director
+	ui          boshui.UI
+	timeService clock.Clock
+}
+
+func NewLockWaiter(director boshdir.Director, ui boshui.UI, timeService clock.Clock) LockWaiter {
+	return LockWaiter{director: director, ui: ui, timeService: timeService}
+}
+
+// Wait returns immediately unless --wait-for-lock was given; zero timeout waits indefinitely
+func (w LockWaiter) Wait(deploymentName string, flags WaitForLockFlags) error {
+	if flags.WaitForLock == nil {
+		return nil
+	}
+
+	timeout := *flags.WaitForLock
+	startedAt := w.timeService.Now()
+
+	var reportedTaskID string
+
+	for {
+		lock, found, err := w.deploymentLock(deploymentName)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			if len(reportedTaskID) > 0 {
+				w.ui.PrintLinef("Lock on deployment '%s' was released", deploymentName)
+			}
+			return nil
+		}
+
+		if timeout > 0 && w.timeService.Since(startedAt) >= timeout {
+			return bosherr.Errorf("Timed out after %s waiting for task '%s' to release lock on deployment '%s'",
+				timeout, lock.TaskID, deploymentName)
+		}
+
+		if lock.TaskID != reportedTaskID {
+			err := w.reportHolder(deploymentName, lock)
+			if err != nil {
+				return err
+			}
+
+			reportedTaskID = lock.TaskID
+		}
+
+		w.timeService.Sleep(lockPollInterval)
+	}
+}
+
+func (w LockWaiter) deploymentLock(deploymentName string) (boshdir.Lock, bool, error) {
+	locks, err := w.director.Locks()
+	if err != nil {
+		return boshdir.Lock{}, false, bosherr.WrapError(err, "Checking deployment locks")
+	}
+
+	for _, lock := range locks {
+		if lock.Type != "deployment" {
+			continue
+		}
+
+		for _, resource := range lock.Resource {
+			if resource == deploymentName {
+				return lock, true, nil
+			}
+		}
+	}
+
+	return boshdir.Lock{}, false, nil
+}
+
+// reportHolder shows who holds the lock based on deployment's current tasks
+func (w LockWaiter) reportHolder(deploymentName string, lock boshdir.Lock) error {
+	tasks, err := w.director.CurrentTasks(boshdir.TasksFilter{All: true, Deployment: deploymentName})
+	if err != nil {
+		return bosherr.WrapError(err, "Checking deployment tasks")
+	}
+
+	for _, task := range tasks {
+		if strconv.Itoa(task.ID()) == lock.TaskID {
+			w.ui.PrintLinef("Waiting for task '%s' (%s by '%s', %s) to release lock on deployment '%s'",
+				lock.TaskID, task.Description(), task.User(), task.State(), deploymentName)
+			return nil
+		}
+	}
+
+	w.ui.PrintLinef("Waiting for task '%s' to release lock on deployment '%s'", lock.TaskID, deploymentName)
+
+	return nil
+}
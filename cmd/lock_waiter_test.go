@@ -0,0 +1,128 @@
+package cmd_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+)
+
+var _ = Describe("LockWaiter", func() {
+	var (
+		director    *fakedir.FakeDirector
+		ui          *fakeui.FakeUI
+		timeService *fakeclock.FakeClock
+		waiter      LockWaiter
+		flags       WaitForLockFlags
+	)
+
+	BeforeEach(func() {
+		director = &fakedir.FakeDirector{}
+		ui = &fakeui.FakeUI{}
+		timeService = fakeclock.NewFakeClock(time.Date(2009, time.November, 10, 23, 1, 2, 333, time.UTC))
+		waiter = NewLockWaiter(director, ui, timeService)
+
+		noTimeout := time.Duration(0)
+		flags = WaitForLockFlags{WaitForLock: &noTimeout}
+
+		task := &fakedir.FakeTask{}
+		task.IDReturns(12)
+		task.DescriptionReturns("create deployment")
+		task.UserReturns("pipeline-a")
+		task.StateReturns("processing")
+
+		director.CurrentTasksReturns([]boshdir.Task{task}, nil)
+	})
+
+	lockedFor := func(polls int) {
+		director.LocksStub = func() ([]boshdir.Lock, error) {
+			if director.LocksCallCount() > polls {
+				return []boshdir.Lock{{Type: "deployment", Resource: []string{"other-dep"}, TaskID: "13"}}, nil
+			}
+			return []boshdir.Lock{{Type: "deployment", Resource: []string{"dep"}, TaskID: "12"}}, nil
+		}
+	}
+
+	wait := func(polls int) error {
+		errCh := make(chan error, 1)
+
+		go func() { errCh <- waiter.Wait("dep", flags) }()
+
+		for i := 0; i < polls; i++ {
+			timeService.WaitForWatcherAndIncrement(5 * time.Second)
+		}
+
+		var err error
+		Eventually(errCh).Should(Receive(&err))
+		return err
+	}
+
+	It("does not check locks unless waiting was requested", func() {
+		err := waiter.Wait("dep", WaitForLockFlags{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(director.LocksCallCount()).To(Equal(0))
+	})
+
+	It("returns immediately if deployment is not locked", func() {
+		lockedFor(0)
+
+		err := wait(0)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(director.LocksCallCount()).To(Equal(1))
+		Expect(director.CurrentTasksCallCount()).To(Equal(0))
+		Expect(ui.Said).To(BeEmpty())
+	})
+
+	It("polls until lock is released and reports lock holder once", func() {
+		lockedFor(3)
+
+		err := wait(3)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(director.LocksCallCount()).To(Equal(4))
+		Expect(director.CurrentTasksArgsForCall(0)).To(Equal(boshdir.TasksFilter{All: true, Deployment: "dep"}))
+
+		Expect(ui.Said).To(Equal([]string{
+			"Waiting for task '12' (create deployment by 'pipeline-a', processing) to release lock on deployment 'dep'",
+			"Lock on deployment 'dep' was released",
+		}))
+	})
+
+	It("reports task ID only if lock holding task is not found", func() {
+		lockedFor(1)
+		director.CurrentTasksReturns(nil, nil)
+
+		err := wait(1)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Said[0]).To(Equal("Waiting for task '12' to release lock on deployment 'dep'"))
+	})
+
+	It("returns error when lock is not released within timeout", func() {
+		lockedFor(100)
+
+		timeout := 12 * time.Second
+		flags.WaitForLock = &timeout
+
+		err := wait(3)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Timed out after 12s waiting for task '12' to release lock on deployment 'dep'"))
+	})
+
+	It("returns error if locks cannot be fetched", func() {
+		director.LocksReturns(nil, errors.New("fake-err"))
+
+		err := wait(0)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Checking deployment locks: fake-err"))
+	})
+})
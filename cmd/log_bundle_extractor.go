@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	biui "github.com/cloudfoundry/bosh-cli/v7/ui"
+)
+
+//counterfeiter:generate . LogBundleExtractor
+
+type LogBundleExtractor interface {
+	Extract(blobstoreID, sha1, dstDirPath string) error
+}
+
+type UILogBundleExtractor struct {
+	director   boshdir.Director
+	compressor boshcmd.Compressor
+
+	fs boshsys.FileSystem
+	ui biui.UI
+}
+
+func NewUILogBundleExtractor(
+	director boshdir.Director,
+	compressor boshcmd.Compressor,
+	fs boshsys.FileSystem,
+	ui biui.UI,
+) UILogBundleExtractor {
+	return UILogBundleExtractor{
+		director:   director,
+		compressor: compressor,
+
+		fs: fs,
+		ui: ui,
+	}
+}
+
+func (e UILogBundleExtractor) Extract(blobstoreID, sha1, dstDirPath string) error {
+	tmpFile, err := e.fs.TempFile(fmt.Sprintf("director-resource-%s", blobstoreID))
+	if err != nil {
+		return err
+	}
+
+	defer tmpFile.Close()                //nolint:errcheck
+	defer e.fs.RemoveAll(tmpFile.Name()) //nolint:errcheck
+
+	e.ui.PrintLinef("Downloading resource '%s' and extracting into '%s'...", blobstoreID, dstDirPath)
+
+	err = e.director.DownloadResourceUnchecked(blobstoreID, tmpFile)
+	if err != nil {
+		return err
+	}
+
+	// unfortunate. apparently old directors may not send the digest.
+	if len(sha1) > 0 {
+		expectedMultipleDigest, err := boshcrypto.ParseMultipleDigest(sha1)
+		if err != nil {
+			return err
+		}
+
+		err = expectedMultipleDigest.VerifyFilePath(tmpFile.Name(), e.fs)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		return err
+	}
+
+	err = e.fs.MkdirAll(dstDirPath, os.ModePerm)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating directory '%s'", dstDirPath)
+	}
+
+	err = e.compressor.DecompressFileToDir(tmpFile.Name(), dstDirPath, boshcmd.CompressorOptions{})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Extracting logs into '%s'", dstDirPath)
+	}
+
+	return nil
+}
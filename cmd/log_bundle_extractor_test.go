@@ -0,0 +1,99 @@
+package cmd_test
+
+import (
+	"errors"
+	"io"
+
+	fakecmd "github.com/cloudfoundry/bosh-utils/fileutil/fakes"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-cli/v7/cmd"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+)
+
+var _ = Describe("UILogBundleExtractor", func() {
+	var (
+		director   *fakedir.FakeDirector
+		compressor *fakecmd.FakeCompressor
+		fs         *fakesys.FakeFileSystem
+		ui         *fakeui.FakeUI
+		extractor  cmd.UILogBundleExtractor
+	)
+
+	BeforeEach(func() {
+		director = &fakedir.FakeDirector{}
+		compressor = fakecmd.NewFakeCompressor()
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		extractor = cmd.NewUILogBundleExtractor(director, compressor, fs, ui)
+
+		fs.ReturnTempFile = fakesys.NewFakeFile("/some-tmp-file", fs)
+
+		director.DownloadResourceUncheckedStub = func(_ string, out io.Writer) error {
+			_, err := out.Write([]byte("file-contents"))
+			Expect(err).ToNot(HaveOccurred())
+			return nil
+		}
+	})
+
+	Describe("Extract", func() {
+		act := func() error {
+			return extractor.Extract("fake-blob-id", "a2511842a89119b9da922f9528307b7f8f55b798", "/fake-dst-dir/web/id")
+		}
+
+		It("downloads specified blob and extracts it into destination", func() {
+			Expect(act()).ToNot(HaveOccurred())
+
+			blobID, _ := director.DownloadResourceUncheckedArgsForCall(0)
+			Expect(blobID).To(Equal("fake-blob-id"))
+
+			Expect(fs.FileExists("/fake-dst-dir/web/id")).To(BeTrue())
+			Expect(compressor.DecompressFileToDirTarballPaths).To(Equal([]string{"/some-tmp-file"}))
+			Expect(compressor.DecompressFileToDirDirs).To(Equal([]string{"/fake-dst-dir/web/id"}))
+
+			Expect(fs.FileExists("/some-tmp-file")).To(BeFalse())
+
+			Expect(ui.Said).To(Equal([]string{
+				"Downloading resource 'fake-blob-id' and extracting into '/fake-dst-dir/web/id'..."}))
+		})
+
+		It("returns error if downloading resource fails", func() {
+			director.DownloadResourceUncheckedStub = nil
+			director.DownloadResourceUncheckedReturns(errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(compressor.DecompressFileToDirTarballPaths).To(BeEmpty())
+		})
+
+		It("returns error if sha1 does not match expected sha1", func() {
+			director.DownloadResourceUncheckedStub = func(_ string, out io.Writer) error {
+				_, err := out.Write([]byte("file-contents-that-were-corrupted"))
+				Expect(err).ToNot(HaveOccurred())
+				return nil
+			}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected stream to have digest"))
+			Expect(compressor.DecompressFileToDirTarballPaths).To(BeEmpty())
+		})
+
+		It("does not check sha1 if it is not provided", func() {
+			Expect(extractor.Extract("fake-blob-id", "", "/fake-dst-dir")).To(Succeed())
+			Expect(compressor.DecompressFileToDirDirs).To(Equal([]string{"/fake-dst-dir"}))
+		})
+
+		It("returns error if extracting fails", func() {
+			compressor.DecompressFileToDirErr = errors.New("fake-err")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Extracting logs into '/fake-dst-dir/web/id'"))
+		})
+	})
+})
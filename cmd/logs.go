@@ -23,11 +23,14 @@ import (
 	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
 )
 
+type MergedSSHRunnerFactory func(boshssh.MergingWriterOpts) boshssh.Runner
+
 type LogsCmd struct {
-	deployment      boshdir.Deployment
-	downloader      Downloader
-	uuidGen         boshuuid.Generator
-	nonIntSSHRunner boshssh.Runner
+	deployment             boshdir.Deployment
+	downloader             Downloader
+	uuidGen                boshuuid.Generator
+	nonIntSSHRunner        boshssh.Runner
+	mergedSSHRunnerFactory MergedSSHRunnerFactory
 }
 
 func NewLogsCmd(
@@ -35,12 +38,14 @@ func NewLogsCmd(
 	downloader Downloader,
 	uuidGen boshuuid.Generator,
 	nonIntSSHRunner boshssh.Runner,
+	mergedSSHRunnerFactory MergedSSHRunnerFactory,
 ) LogsCmd {
 	return LogsCmd{
-		deployment:      deployment,
-		downloader:      downloader,
-		uuidGen:         uuidGen,
-		nonIntSSHRunner: nonIntSSHRunner,
+		deployment:             deployment,
+		downloader:             downloader,
+		uuidGen:                uuidGen,
+		nonIntSSHRunner:        nonIntSSHRunner,
+		mergedSSHRunnerFactory: mergedSSHRunnerFactory,
 	}
 }
 
@@ -48,9 +53,34 @@ func (c LogsCmd) Run(opts LogsOpts) error {
 	if opts.Follow || opts.Num > 0 {
 		return c.tail(opts)
 	}
+	if isMergedLogs(opts) {
+		return errMergedLogsWithoutTail
+	}
 	return c.fetch(opts)
 }
 
+var errMergedLogsWithoutTail = errors.New("the --merge, --grep, --since and --json-lines flags can only be used with --follow or --num")
+
+func isMergedLogs(opts LogsOpts) bool {
+	return opts.Merge || opts.Grep.IsSet() || opts.Since > 0 || opts.JSONLines
+}
+
+// tailSSHRunner picks merging runner when lines should be interleaved, filtered or reformatted
+func tailSSHRunner(opts *LogsOpts, nonIntSSHRunner boshssh.Runner, mergedSSHRunnerFactory MergedSSHRunnerFactory) boshssh.Runner {
+	if !isMergedLogs(*opts) {
+		return nonIntSSHRunner
+	}
+
+	// File headers cannot be attributed once lines are interleaved
+	opts.Quiet = true
+
+	return mergedSSHRunnerFactory(boshssh.MergingWriterOpts{
+		Grep:      opts.Grep.Regexp,
+		Since:     opts.Since,
+		JSONLines: opts.JSONLines,
+	})
+}
+
 func (c LogsCmd) tail(opts LogsOpts) error {
 	sshOpts, connOpts, err := opts.GatewayFlags.AsSSHOpts() //nolint:staticcheck
 	if err != nil {
@@ -64,7 +94,9 @@ func (c LogsCmd) tail(opts LogsOpts) error {
 
 	defer c.deployment.CleanUpSSH(opts.Args.Slug, sshOpts) //nolint:errcheck
 
-	err = c.nonIntSSHRunner.Run(connOpts, result, buildTailCmd(opts))
+	runner := tailSSHRunner(&opts, c.nonIntSSHRunner, c.mergedSSHRunnerFactory)
+
+	err = runner.Run(connOpts, result, buildTailCmd(opts))
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Running follow over non-interactive SSH")
 	}
@@ -177,29 +209,32 @@ func (c LogsCmd) fetch(opts LogsOpts) error {
 }
 
 type EnvLogsCmd struct {
-	agentClientFactory bihttpagent.AgentClientFactory
-	nonIntSSHRunner    boshssh.Runner
-	scpRunner          boshssh.SCPRunner
-	fs                 boshsys.FileSystem
-	timeService        clock.Clock
-	ui                 boshui.UI
+	agentClientFactory     bihttpagent.AgentClientFactory
+	nonIntSSHRunner        boshssh.Runner
+	mergedSSHRunnerFactory MergedSSHRunnerFactory
+	scpRunner              boshssh.SCPRunner
+	fs                     boshsys.FileSystem
+	timeService            clock.Clock
+	ui                     boshui.UI
 }
 
 func NewEnvLogsCmd(
 	agentClientFactory bihttpagent.AgentClientFactory,
 	nonIntSSHRunner boshssh.Runner,
+	mergedSSHRunnerFactory MergedSSHRunnerFactory,
 	scpRunner boshssh.SCPRunner,
 	fs boshsys.FileSystem,
 	timeService clock.Clock,
 	ui boshui.UI,
 ) EnvLogsCmd {
 	return EnvLogsCmd{
-		agentClientFactory: agentClientFactory,
-		nonIntSSHRunner:    nonIntSSHRunner,
-		scpRunner:          scpRunner,
-		fs:                 fs,
-		timeService:        timeService,
-		ui:                 ui,
+		agentClientFactory:     agentClientFactory,
+		nonIntSSHRunner:        nonIntSSHRunner,
+		mergedSSHRunnerFactory: mergedSSHRunnerFactory,
+		scpRunner:              scpRunner,
+		fs:                     fs,
+		timeService:            timeService,
+		ui:                     ui,
 	}
 }
 
@@ -208,6 +243,10 @@ func (c EnvLogsCmd) Run(opts LogsOpts) error {
 		return errors.New("the --director flag requires both the --agent-endpoint and --agent-certificate flags to be set")
 	}
 
+	if isMergedLogs(opts) && !opts.Follow && opts.Num == 0 {
+		return errMergedLogsWithoutTail
+	}
+
 	agentClient, err := c.agentClientFactory.NewAgentClient("bosh-cli", opts.Endpoint, opts.Certificate)
 	if err != nil {
 		return err
@@ -246,7 +285,9 @@ func (c EnvLogsCmd) Run(opts LogsOpts) error {
 }
 
 func (c EnvLogsCmd) tail(opts LogsOpts, connOpts boshssh.ConnectionOpts, sshResult boshdir.SSHResult) error {
-	err := c.nonIntSSHRunner.Run(connOpts, sshResult, buildTailCmd(opts))
+	runner := tailSSHRunner(&opts, c.nonIntSSHRunner, c.mergedSSHRunnerFactory)
+
+	err := runner.Run(connOpts, sshResult, buildTailCmd(opts))
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Running follow over non-interactive SSH")
 	}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshlogs "github.com/cloudfoundry/bosh-cli/v7/logs"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type LogsSearchCmd struct {
+	indexer  boshlogs.Indexer
+	searcher boshlogs.Searcher
+	ui       boshui.UI
+}
+
+func NewLogsSearchCmd(indexer boshlogs.Indexer, searcher boshlogs.Searcher, ui boshui.UI) LogsSearchCmd {
+	return LogsSearchCmd{indexer: indexer, searcher: searcher, ui: ui}
+}
+
+func (c LogsSearchCmd) Run(opts LogsSearchOpts) error {
+	dir := opts.Args.Directory.Path
+
+	if opts.Since.IsSet() && opts.Until.IsSet() && opts.Until.Before(opts.Since.Time) {
+		return bosherr.Errorf("Expected --until '%s' to not be before --since '%s'", opts.Until.AsString(), opts.Since.AsString())
+	}
+
+	index, err := c.indexer.Load(dir)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Loading logs index from '%s'", dir)
+	}
+
+	matches, err := c.searcher.Search(dir, index, boshlogs.SearchOpts{
+		Pattern: opts.Args.Pattern.Regexp,
+		Since:   opts.Since.Time,
+		Until:   opts.Until.Time,
+	})
+	if err != nil {
+		return err
+	}
+
+	table := boshtbl.Table{
+		Content: "matching lines",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Instance"),
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("File"),
+			boshtbl.NewHeader("Line"),
+			boshtbl.NewHeader("Text"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{
+			{Column: 0, Asc: true},
+			{Column: 1, Asc: true},
+			{Column: 2, Asc: true},
+			{Column: 3, Asc: true},
+		},
+	}
+
+	for _, match := range matches {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(fmt.Sprintf("%s/%s", match.Group, match.ID)),
+			boshtbl.NewValueString(match.Job),
+			boshtbl.NewValueString(match.Path),
+			boshtbl.NewValueInt(match.LineNum),
+			boshtbl.NewValueString(match.Line),
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package cmd_test
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-cli/v7/cmd"
+	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshlogs "github.com/cloudfoundry/bosh-cli/v7/logs"
+	fakelogs "github.com/cloudfoundry/bosh-cli/v7/logs/logsfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("LogsSearchCmd", func() {
+	var (
+		indexer  *fakelogs.FakeIndexer
+		searcher *fakelogs.FakeSearcher
+		ui       *fakeui.FakeUI
+		command  cmd.LogsSearchCmd
+	)
+
+	BeforeEach(func() {
+		indexer = &fakelogs.FakeIndexer{}
+		searcher = &fakelogs.FakeSearcher{}
+		ui = &fakeui.FakeUI{}
+		command = cmd.NewLogsSearchCmd(indexer, searcher, ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			searchOpts opts.LogsSearchOpts
+		)
+
+		BeforeEach(func() {
+			searchOpts = opts.LogsSearchOpts{
+				Args: opts.LogsSearchArgs{
+					Directory: opts.DirOrCWDArg{Path: "/fake-dir"},
+					Pattern:   opts.RegexpArg{Regexp: regexp.MustCompile("err")},
+				},
+			}
+		})
+
+		act := func() error { return command.Run(searchOpts) }
+
+		It("searches indexed logs and prints matching lines", func() {
+			index := boshlogs.Index{Instances: []boshlogs.IndexInstance{{Group: "web", ID: "id"}}}
+			indexer.LoadReturns(index, nil)
+
+			searcher.SearchReturns([]boshlogs.Match{
+				{Group: "web", ID: "id", Job: "nginx", Path: "nginx/error.log", LineNum: 3, Line: "some err"},
+			}, nil)
+
+			searchOpts.Since = opts.TimeArg{Time: time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)}
+			searchOpts.Until = opts.TimeArg{Time: time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC)}
+
+			Expect(act()).ToNot(HaveOccurred())
+
+			Expect(indexer.LoadArgsForCall(0)).To(Equal("/fake-dir"))
+
+			dir, searchedIndex, searchOpts := searcher.SearchArgsForCall(0)
+			Expect(dir).To(Equal("/fake-dir"))
+			Expect(searchedIndex).To(Equal(index))
+			Expect(searchOpts.Pattern.String()).To(Equal("err"))
+			Expect(searchOpts.Since).To(Equal(time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)))
+			Expect(searchOpts.Until).To(Equal(time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC)))
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "matching lines",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Instance"),
+					boshtbl.NewHeader("Job"),
+					boshtbl.NewHeader("File"),
+					boshtbl.NewHeader("Line"),
+					boshtbl.NewHeader("Text"),
+				},
+
+				SortBy: []boshtbl.ColumnSort{
+					{Column: 0, Asc: true},
+					{Column: 1, Asc: true},
+					{Column: 2, Asc: true},
+					{Column: 3, Asc: true},
+				},
+
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("web/id"),
+						boshtbl.NewValueString("nginx"),
+						boshtbl.NewValueString("nginx/error.log"),
+						boshtbl.NewValueInt(3),
+						boshtbl.NewValueString("some err"),
+					},
+				},
+			}))
+		})
+
+		It("returns error if until is before since", func() {
+			searchOpts.Since = opts.TimeArg{Time: time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC)}
+			searchOpts.Until = opts.TimeArg{Time: time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected --until '2024-01-02T15:00:00Z' to not be before --since '2024-01-02T16:00:00Z'"))
+			Expect(indexer.LoadCallCount()).To(Equal(0))
+		})
+
+		It("returns error if loading index fails", func() {
+			indexer.LoadReturns(boshlogs.Index{}, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Loading logs index from '/fake-dir'"))
+			Expect(searcher.SearchCallCount()).To(Equal(0))
+		})
+
+		It("returns error if searching fails", func() {
+			searcher.SearchReturns(nil, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
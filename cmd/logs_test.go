@@ -36,11 +36,13 @@ var _ = Describe("Logs", func() {
 
 	Describe("LogsCmd", func() {
 		var (
-			deployment      *fakedir.FakeDeployment
-			downloader      *fakecmd.FakeDownloader
-			uuidGen         *fakeuuid.FakeGenerator
-			nonIntSSHRunner *fakessh.FakeRunner
-			command         cmd.LogsCmd
+			deployment       *fakedir.FakeDeployment
+			downloader       *fakecmd.FakeDownloader
+			uuidGen          *fakeuuid.FakeGenerator
+			nonIntSSHRunner  *fakessh.FakeRunner
+			mergedSSHRunner  *fakessh.FakeRunner
+			mergedWriterOpts []boshssh.MergingWriterOpts
+			command          cmd.LogsCmd
 		)
 
 		BeforeEach(func() {
@@ -50,7 +52,13 @@ var _ = Describe("Logs", func() {
 			downloader = &fakecmd.FakeDownloader{}
 			uuidGen = &fakeuuid.FakeGenerator{}
 			nonIntSSHRunner = &fakessh.FakeRunner{}
-			command = cmd.NewLogsCmd(deployment, downloader, uuidGen, nonIntSSHRunner)
+			mergedSSHRunner = &fakessh.FakeRunner{}
+			mergedWriterOpts = nil
+			mergedSSHRunnerFactory := func(opts boshssh.MergingWriterOpts) boshssh.Runner {
+				mergedWriterOpts = append(mergedWriterOpts, opts)
+				return mergedSSHRunner
+			}
+			command = cmd.NewLogsCmd(deployment, downloader, uuidGen, nonIntSSHRunner, mergedSSHRunnerFactory)
 		})
 
 		Describe("Run", func() {
@@ -186,6 +194,15 @@ var _ = Describe("Logs", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(nonIntSSHRunner.RunCallCount()).To(Equal(0))
 				})
+
+				It("returns error if merging is requested", func() {
+					logsOpts.Merge = true
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("can only be used with --follow or --num"))
+					Expect(deployment.FetchLogsCallCount()).To(Equal(0))
+				})
 			})
 
 			Context("when tailing logs (or specifying number of lines)", func() {
@@ -352,6 +369,52 @@ var _ = Describe("Logs", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(deployment.FetchLogsCallCount()).To(Equal(0))
 				})
+
+				Context("when merging logs", func() {
+					BeforeEach(func() {
+						logsOpts.Merge = true
+					})
+
+					It("runs quiet tail command through merged SSH runner", func() {
+						result := boshdir.SSHResult{Hosts: []boshdir.Host{{Host: "ip1"}, {Host: "ip2"}}}
+						deployment.SetUpSSHReturns(result, nil)
+
+						Expect(act()).ToNot(HaveOccurred())
+
+						Expect(nonIntSSHRunner.RunCallCount()).To(Equal(0))
+						Expect(mergedSSHRunner.RunCallCount()).To(Equal(1))
+
+						_, runResult, runCommand := mergedSSHRunner.RunArgsForCall(0)
+						Expect(runResult).To(Equal(result))
+						Expect(runCommand).To(Equal([]string{
+							"sudo", "bash", "-c", "'exec tail -F -q /var/vcap/sys/log/**/*.log $(if [ -f /var/vcap/sys/log/*.log ]; then echo /var/vcap/sys/log/*.log ; fi)'"}))
+
+						Expect(mergedWriterOpts).To(Equal([]boshssh.MergingWriterOpts{{}}))
+					})
+
+					It("passes filters and output format to merging writer", func() {
+						logsOpts.Merge = false
+						Expect((&logsOpts.Grep).UnmarshalFlag("ERROR")).To(Succeed())
+						logsOpts.Since = 10 * time.Minute
+						logsOpts.JSONLines = true
+
+						Expect(act()).ToNot(HaveOccurred())
+						Expect(mergedSSHRunner.RunCallCount()).To(Equal(1))
+
+						Expect(mergedWriterOpts).To(HaveLen(1))
+						Expect(mergedWriterOpts[0].Grep.String()).To(Equal("ERROR"))
+						Expect(mergedWriterOpts[0].Since).To(Equal(10 * time.Minute))
+						Expect(mergedWriterOpts[0].JSONLines).To(BeTrue())
+					})
+
+					It("returns error if merged SSH session errors", func() {
+						mergedSSHRunner.RunReturns(errors.New("fake-err"))
+
+						err := act()
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("fake-err"))
+					})
+				})
 			})
 		})
 	})
@@ -363,6 +426,8 @@ var _ = Describe("Logs", func() {
 			agentClientFactory *mockhttpagent.MockAgentClientFactory
 			agentClient        *mockagentclient.MockAgentClient
 			nonIntSSHRunner    *fakessh.FakeRunner
+			mergedSSHRunner    *fakessh.FakeRunner
+			mergedWriterOpts   []boshssh.MergingWriterOpts
 			scpRunner          *fakessh.FakeSCPRunner
 			fs                 *fakes.FakeFileSystem
 			timeService        clock.Clock
@@ -379,6 +444,12 @@ var _ = Describe("Logs", func() {
 			agentClient = mockagentclient.NewMockAgentClient(mockCtrl)
 			agentClientFactory = mockhttpagent.NewMockAgentClientFactory(mockCtrl)
 			nonIntSSHRunner = &fakessh.FakeRunner{}
+			mergedSSHRunner = &fakessh.FakeRunner{}
+			mergedWriterOpts = nil
+			mergedSSHRunnerFactory := func(opts boshssh.MergingWriterOpts) boshssh.Runner {
+				mergedWriterOpts = append(mergedWriterOpts, opts)
+				return mergedSSHRunner
+			}
 			scpRunner = &fakessh.FakeSCPRunner{}
 			fs = fakes.NewFakeFileSystem()
 			timeService = fakeclock.NewFakeClock(time.Date(2009, time.November, 10, 23, 1, 2, 333, time.UTC))
@@ -386,7 +457,7 @@ var _ = Describe("Logs", func() {
 
 			uuidGen = &fakeuuid.FakeGenerator{}
 
-			command = cmd.NewEnvLogsCmd(agentClientFactory, nonIntSSHRunner, scpRunner, fs, timeService, ui)
+			command = cmd.NewEnvLogsCmd(agentClientFactory, nonIntSSHRunner, mergedSSHRunnerFactory, scpRunner, fs, timeService, ui)
 		})
 
 		AfterEach(func() {
@@ -442,6 +513,23 @@ var _ = Describe("Logs", func() {
 				})
 			})
 
+			Context("merging flags are set without following or number of lines", func() {
+				BeforeEach(func() {
+					logsOpts = opts.LogsOpts{
+						CreateEnvAuthFlags: opts.CreateEnvAuthFlags{
+							TargetDirector: true,
+							Endpoint:       "https:///foo:bar@10.0.0.5",
+							Certificate:    "some-cert",
+						},
+						JSONLines: true,
+					}
+				})
+
+				It("errors without connecting to agent", func() {
+					Expect(command.Run(logsOpts)).To(MatchError("the --merge, --grep, --since and --json-lines flags can only be used with --follow or --num"))
+				})
+			})
+
 			Context("the endpoint and certificate flags are set", func() {
 				BeforeEach(func() {
 					logsOpts = opts.LogsOpts{
@@ -612,6 +700,26 @@ var _ = Describe("Logs", func() {
 
 							Expect(command.Run(logsOpts)).ToNot(HaveOccurred())
 						})
+
+						It("runs quiet tail command through merged SSH runner with filters and output format", func() {
+							Expect((&logsOpts.Grep).UnmarshalFlag("ERROR")).To(Succeed())
+							logsOpts.Since = 10 * time.Minute
+							logsOpts.JSONLines = true
+
+							Expect(command.Run(logsOpts)).ToNot(HaveOccurred())
+
+							Expect(nonIntSSHRunner.RunCallCount()).To(Equal(0))
+							Expect(mergedSSHRunner.RunCallCount()).To(Equal(1))
+
+							_, _, runCommand := mergedSSHRunner.RunArgsForCall(0)
+							Expect(runCommand).To(Equal([]string{
+								"sudo", "bash", "-c", "'exec tail -F -q /var/vcap/sys/log/**/*.log $(if [ -f /var/vcap/sys/log/*.log ]; then echo /var/vcap/sys/log/*.log ; fi)'"}))
+
+							Expect(mergedWriterOpts).To(HaveLen(1))
+							Expect(mergedWriterOpts[0].Grep.String()).To(Equal("ERROR"))
+							Expect(mergedWriterOpts[0].Since).To(Equal(10 * time.Minute))
+							Expect(mergedWriterOpts[0].JSONLines).To(BeTrue())
+						})
 					})
 				})
 
@@ -0,0 +1,36 @@
+package opts
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+// AgeArg is a duration that additionally accepts days, e.g. 30d
+type AgeArg struct {
+	time.Duration
+}
+
+func (a *AgeArg) UnmarshalFlag(data string) error {
+	if days, found := strings.CutSuffix(data, "d"); found {
+		num, err := strconv.Atoi(days)
+		if err != nil || num < 0 {
+			return bosherr.Errorf("Invalid duration '%s': expected e.g. 30d or 12h", data)
+		}
+
+		a.Duration = time.Duration(num) * 24 * time.Hour
+
+		return nil
+	}
+
+	dur, err := time.ParseDuration(data)
+	if err != nil || dur < 0 {
+		return bosherr.Errorf("Invalid duration '%s': expected e.g. 30d or 12h", data)
+	}
+
+	a.Duration = dur
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package opts_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+)
+
+var _ = Describe("AgeArg", func() {
+	Describe("UnmarshalFlag", func() {
+		var (
+			arg AgeArg
+		)
+
+		BeforeEach(func() {
+			arg = AgeArg{}
+		})
+
+		It("parses days", func() {
+			err := (&arg).UnmarshalFlag("30d")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg.Duration).To(Equal(30 * 24 * time.Hour))
+		})
+
+		It("parses go durations", func() {
+			err := (&arg).UnmarshalFlag("12h30m")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg.Duration).To(Equal(12*time.Hour + 30*time.Minute))
+		})
+
+		It("returns error if duration is invalid", func() {
+			for _, data := range []string{"d", "xd", "-1d", "-1h", "1w"} {
+				err := (&arg).UnmarshalFlag(data)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Invalid duration '" + data + "': expected e.g. 30d or 12h"))
+			}
+		})
+	})
+})
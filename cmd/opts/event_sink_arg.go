@@ -0,0 +1,46 @@
+package opts
+
+import (
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+const (
+	EventSinkStdout  = "stdout"
+	EventSinkFile    = "file"
+	EventSinkWebhook = "webhook"
+)
+
+// EventSinkArg is one of 'stdout', 'file:PATH' or 'webhook:URL'
+type EventSinkArg struct {
+	Type   string
+	Target string
+}
+
+func (a *EventSinkArg) UnmarshalFlag(data string) error {
+	sinkType, target, _ := strings.Cut(data, ":")
+
+	switch sinkType {
+	case EventSinkStdout:
+		if len(target) > 0 {
+			return bosherr.Errorf("Expected sink '%s' to not specify target", data)
+		}
+
+	case EventSinkFile, EventSinkWebhook:
+		if len(target) == 0 {
+			return bosherr.Errorf("Expected sink '%s' to specify target, e.g. '%s:...'", data, sinkType)
+		}
+
+		if sinkType == EventSinkWebhook && !URLArg(target).IsRemote() {
+			return bosherr.Errorf("Expected webhook sink '%s' to specify http or https URL", data)
+		}
+
+	default:
+		return bosherr.Errorf("Expected sink '%s' to be one of 'stdout', 'file:PATH' or 'webhook:URL'", data)
+	}
+
+	*a = EventSinkArg{Type: sinkType, Target: target}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package opts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+)
+
+var _ = Describe("EventSinkArg", func() {
+	Describe("UnmarshalFlag", func() {
+		var (
+			arg EventSinkArg
+		)
+
+		BeforeEach(func() {
+			arg = EventSinkArg{}
+		})
+
+		It("parses stdout sink", func() {
+			err := arg.UnmarshalFlag("stdout")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg).To(Equal(EventSinkArg{Type: "stdout"}))
+		})
+
+		It("parses file sink", func() {
+			err := arg.UnmarshalFlag("file:/var/log/events.log")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg).To(Equal(EventSinkArg{Type: "file", Target: "/var/log/events.log"}))
+		})
+
+		It("parses webhook sink keeping URL intact", func() {
+			err := arg.UnmarshalFlag("webhook:https://siem.example.com:8443/events?token=abc")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg).To(Equal(EventSinkArg{Type: "webhook", Target: "https://siem.example.com:8443/events?token=abc"}))
+		})
+
+		It("returns error if file sink does not specify path", func() {
+			err := arg.UnmarshalFlag("file")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected sink 'file' to specify target, e.g. 'file:...'"))
+		})
+
+		It("returns error if webhook sink does not specify http URL", func() {
+			err := arg.UnmarshalFlag("webhook:siem.example.com")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected webhook sink 'webhook:siem.example.com' to specify http or https URL"))
+		})
+
+		It("returns error if stdout sink specifies target", func() {
+			err := arg.UnmarshalFlag("stdout:foo")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected sink 'stdout:foo' to not specify target"))
+		})
+
+		It("returns error for unknown sink", func() {
+			err := arg.UnmarshalFlag("syslog:localhost")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected sink 'syslog:localhost' to be one of 'stdout', 'file:PATH' or 'webhook:URL'"))
+		})
+	})
+})
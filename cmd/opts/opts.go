@@ -6,6 +6,7 @@ import (
 	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
 	"github.com/cppforlife/go-patch/patch"
 
+	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
 	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
 	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
 )
@@ -22,6 +23,13 @@ type BoshOpts struct {
 	Sha2           bool      `long:"sha2"                  description:"Use SHA256 checksums" env:"BOSH_SHA2"`
 	Parallel       int       `long:"parallel" description:"The max number of parallel operations" default:"5"`
 
+	// Run read-only commands against multiple environments
+	AllEnvironmentsOpt bool `long:"all-environments" description:"Run read-only command against all aliased environments; --environment may also be a pattern such as 'prod-*'"`
+
+	// Tarball compression
+	Compression      string `long:"compression"       value-name:"FORMAT" description:"Compress job, package and release tarballs with format (gzip, zstd); gzip is readable by all directors" env:"BOSH_COMPRESSION" default:"gzip"`
+	CompressionLevel int    `long:"compression-level" value-name:"LEVEL"  description:"Compression level (gzip: 1-9, zstd: 1-22); defaults to format's default level" env:"BOSH_COMPRESSION_LEVEL"`
+
 	// Specify client credentials
 	ClientOpt       string `long:"client"        description:"Override username or UAA client"        env:"BOSH_CLIENT"`
 	ClientSecretOpt string `long:"client-secret" description:"Override password or UAA client secret" env:"BOSH_CLIENT_SECRET"`
@@ -50,6 +58,9 @@ type BoshOpts struct {
 	AliasEnv     AliasEnvOpts     `command:"alias-env"                 description:"Alias environment to save URL and CA certificate"`
 	UnaliasEnv   UnaliasEnvOpts   `command:"unalias-env"               description:"Remove an aliased environment"`
 
+	ExportInventory ExportInventoryOpts `command:"export-inventory" description:"Export deployments, releases, stemcells and configs of environment as JSON"`
+	DiffInventory   DiffInventoryOpts   `command:"diff-inventory"   description:"Compare two exported environment inventories"`
+
 	// Authentication
 	LogIn  LogInOpts  `command:"log-in"  alias:"l" alias:"login"  description:"Log in"` //nolint:staticcheck
 	LogOut LogOutOpts `command:"log-out"           alias:"logout" description:"Log out"`
@@ -89,14 +100,16 @@ type BoshOpts struct {
 	Deployments      DeploymentsOpts      `command:"deployments"       alias:"ds" alias:"deps" description:"List deployments"` //nolint:staticcheck
 	DeleteDeployment DeleteDeploymentOpts `command:"delete-deployment" alias:"deld"            description:"Delete deployment"`
 
-	Deploy   DeployOpts   `command:"deploy"   alias:"d"   description:"Update deployment"`
-	Manifest ManifestOpts `command:"manifest" alias:"man" description:"Show deployment manifest"`
+	Deploy     DeployOpts     `command:"deploy"      alias:"d"   description:"Update deployment"`
+	Manifest   ManifestOpts   `command:"manifest"    alias:"man" description:"Show deployment manifest"`
+	DriftCheck DriftCheckOpts `command:"drift-check"             description:"Check deployed manifests and configs for drift from local manifests"`
 
 	Interpolate InterpolateOpts `command:"interpolate" alias:"int" description:"Interpolates variables into a manifest"`
 
 	// Events
 	Events EventsOpts `command:"events" description:"List events"`
 	Event  EventOpts  `command:"event" description:"Show event details"`
+	Report ReportOpts `command:"report" description:"Report deploy statistics per deployment and user based on tasks and events"`
 
 	// Stemcells
 	Stemcells            StemcellsOpts              `command:"stemcells"       alias:"ss"   description:"List stemcells"`
@@ -104,6 +117,7 @@ type BoshOpts struct {
 	UploadStemcell       UploadStemcellOpts         `command:"upload-stemcell" alias:"us"   description:"Upload stemcell"`
 	DeleteStemcell       DeleteStemcellOpts         `command:"delete-stemcell" alias:"dels" description:"Delete stemcell"`
 	RepackStemcell       RepackStemcellOpts         `command:"repack-stemcell"              description:"Repack stemcell"`
+	UpgradeStemcells     UpgradeStemcellsOpts       `command:"upgrade-stemcells"            description:"Upgrade stemcell of deployments using older stemcell versions"`
 
 	// Releases
 	Releases            ReleasesOpts            `command:"releases"        alias:"rs"   description:"List releases"`
@@ -111,6 +125,7 @@ type BoshOpts struct {
 	ExportRelease       ExportReleaseOpts       `command:"export-release"               description:"Export the compiled release to a tarball"`
 	InspectRelease      InspectReleaseOpts      `command:"inspect-release"              description:"List release contents such as jobs"`
 	InspectLocalRelease InspectLocalReleaseOpts `command:"inspect-local-release"     description:"Display information from release metadata"`
+	InspectReleaseSBOM  InspectReleaseSBOMOpts  `command:"inspect-release-sbom"      description:"Display software bill of materials of release tarball without package sources"`
 	DeleteRelease       DeleteReleaseOpts       `command:"delete-release"  alias:"delr" description:"Delete release"`
 
 	// Errands
@@ -145,13 +160,14 @@ type BoshOpts struct {
 	OrphanedVMs        OrphanedVMsOpts        `command:"orphaned-vms"                                   description:"List all the orphaned VMs in all deployments"`
 
 	// Instance management
-	Logs     LogsOpts     `command:"logs"      description:"Fetch logs from instance(s)"`
-	Start    StartOpts    `command:"start"     description:"Start instance(s)"`
-	Stop     StopOpts     `command:"stop"      description:"Stop instance(s)"`
-	Restart  RestartOpts  `command:"restart"   description:"Restart instance(s)"`
-	Recreate RecreateOpts `command:"recreate"  description:"Recreate instance(s)"`
-	DeleteVM DeleteVMOpts `command:"delete-vm" description:"Delete VM"`
-	Pcap     PcapOpts     `command:"pcap"      description:"Capture network packets on instance(s)"`
+	Logs       LogsOpts       `command:"logs"        description:"Fetch logs from instance(s)"`
+	LogsSearch LogsSearchOpts `command:"logs-search" description:"Search logs extracted with 'logs --extract'"`
+	Start      StartOpts      `command:"start"       description:"Start instance(s)"`
+	Stop       StopOpts       `command:"stop"        description:"Stop instance(s)"`
+	Restart    RestartOpts    `command:"restart"     description:"Restart instance(s)"`
+	Recreate   RecreateOpts   `command:"recreate"    description:"Recreate instance(s)"`
+	DeleteVM   DeleteVMOpts   `command:"delete-vm"   description:"Delete VM"`
+	Pcap       PcapOpts       `command:"pcap"        description:"Capture network packets on instance(s)"`
 
 	// SSH instance
 	SSH SSHOpts `command:"ssh" description:"SSH into instance(s)"`
@@ -166,18 +182,28 @@ type BoshOpts struct {
 	GeneratePackage GeneratePackageOpts `command:"generate-package"            description:"Generate package"`
 	CreateRelease   CreateReleaseOpts   `command:"create-release"   alias:"cr" description:"Create release"`
 	VendorPackage   VendorPackageOpts   `command:"vendor-package"              description:"Vendor package"`
+	BuildPackage    BuildPackageOpts    `command:"build-package"               description:"Build package and its dependencies locally"`
 
 	Sha1ifyRelease Sha1ifyReleaseOpts `command:"sha1ify-release"  description:"Convert release tarball to use SHA1"`
 	Sha2ifyRelease Sha2ifyReleaseOpts `command:"sha2ify-release"  description:"Convert release tarball to use SHA256"`
 
 	FinalizeRelease FinalizeReleaseOpts `command:"finalize-release"               description:"Create final release from dev release tarball"`
 
+	VerifyReleaseReproducible VerifyReleaseReproducibleOpts `command:"verify-release-reproducible" description:"Rebuild release from source and compare it with release tarball"`
+
+	ValidateRelease ValidateReleaseOpts `command:"validate-release" description:"Validate job specs, package specs and job templates of release"`
+
+	DiffReleases DiffReleasesOpts `command:"diff-releases" description:"Compare jobs and packages of two releases"`
+
+	CleanReleaseDir CleanReleaseDirOpts `command:"clean-release-dir" description:"Remove old dev releases and unreferenced builds from release directory"`
+
 	// Blob management
 	Blobs       BlobsOpts       `command:"blobs"        description:"List blobs"`
 	AddBlob     AddBlobOpts     `command:"add-blob"     description:"Add blob"`
 	RemoveBlob  RemoveBlobOpts  `command:"remove-blob"  description:"Remove blob"`
 	SyncBlobs   SyncBlobsOpts   `command:"sync-blobs"   description:"Sync blobs"`
 	UploadBlobs UploadBlobsOpts `command:"upload-blobs" description:"Upload blobs"`
+	UpdateBlobs UpdateBlobsOpts `command:"update-blobs" description:"Update blobs to latest versions of their upstream sources"`
 
 	Variables VariablesOpts `command:"variables" alias:"vars" description:"List variables"`
 }
@@ -198,6 +224,8 @@ type CreateEnvOpts struct {
 	Recreate                bool   `long:"recreate" description:"Recreate VM in deployment"`
 	RecreatePersistentDisks bool   `long:"recreate-persistent-disks" description:"Recreate persistent disks in the deployment"`
 	PackageDir              string `long:"package-dir" value-name:"DIR" description:"Package cache location override"`
+	CPI                     string `long:"cpi" value-name:"NAME" description:"Use a built-in CPI instead of the CPI release from the manifest (supported: fake)"`
+	CPIRecordingFlags
 	cmd
 }
 
@@ -205,6 +233,15 @@ type CreateEnvArgs struct {
 	Manifest FileBytesWithPathArg `positional-arg-name:"PATH" description:"Path to a manifest file"`
 }
 
+type CPIRecordingFlags struct {
+	RecordCPI string `long:"record-cpi" value-name:"DIR" description:"Record CPI calls (request, response, stderr and timing) into directory with secrets redacted"`
+	ReplayCPI string `long:"replay-cpi" value-name:"DIR" description:"Serve CPI calls from a directory recorded with --record-cpi instead of running the CPI; calls are matched by order and agent is not replayed"`
+}
+
+func (f CPIRecordingFlags) CPIRecordingOpts() bicloud.RecordingOpts {
+	return bicloud.RecordingOpts{RecordDir: f.RecordCPI, ReplayDir: f.ReplayCPI}
+}
+
 type DeleteEnvOpts struct {
 	Args DeleteEnvArgs `positional-args:"true" required:"true"`
 	VarFlags
@@ -212,6 +249,8 @@ type DeleteEnvOpts struct {
 	SkipDrain  bool   `long:"skip-drain" description:"Skip running drain and pre-stop scripts"`
 	StatePath  string `long:"state" value-name:"PATH" description:"State file path"`
 	PackageDir string `long:"package-dir" value-name:"DIR" description:"Package cache location override"`
+	CPI        string `long:"cpi" value-name:"NAME" description:"Use a built-in CPI instead of the CPI release from the manifest (supported: fake)"`
+	CPIRecordingFlags
 	cmd
 }
 
@@ -251,6 +290,20 @@ type EnvironmentsOpts struct {
 	cmd
 }
 
+type ExportInventoryOpts struct {
+	cmd
+}
+
+type DiffInventoryOpts struct {
+	Args DiffInventoryArgs `positional-args:"true" required:"true"`
+	cmd
+}
+
+type DiffInventoryArgs struct {
+	From FileBytesWithPathArg `positional-arg-name:"FROM" description:"Path to inventory to compare from"`
+	To   FileBytesWithPathArg `positional-arg-name:"TO"   description:"Path to inventory to compare to"`
+}
+
 type AliasEnvOpts struct {
 	Args AliasEnvArgs `positional-args:"true" required:"true"`
 
@@ -517,6 +570,8 @@ type DeployOpts struct {
 	DryRun               bool `long:"dry-run" description:"Renders job templates without altering deployment"`
 	ForceLatestVariables bool `long:"force-latest-variables" description:"Retrieve the latest variable values from the config server regardless of their update strategy"`
 
+	WaitForLockFlags
+
 	cmd
 }
 
@@ -528,11 +583,36 @@ type ManifestOpts struct {
 	cmd
 }
 
+type DriftCheckOpts struct {
+	Args DriftCheckArgs `positional-args:"true"`
+
+	Mapping FileBytesWithPathArg `long:"mapping" value-name:"PATH" description:"Check all deployments listed in mapping file instead of a single deployment"`
+
+	VarFlags
+	OpsFlags
+
+	NoRedact bool `long:"no-redact" description:"Show non-redacted manifest diff"`
+
+	Deployment string
+	cmd
+}
+
+type DriftCheckArgs struct {
+	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a manifest file"`
+}
+
 type DeleteDeploymentOpts struct {
 	Force bool `long:"force" description:"Ignore errors"`
+
+	WaitForLockFlags
+
 	cmd
 }
 
+type WaitForLockFlags struct {
+	WaitForLock *time.Duration `long:"wait-for-lock" value-name:"TIMEOUT" description:"Wait for deployment lock to be released before starting. Use '=' to specify timeout, e.g. --wait-for-lock=30m" optional:"true" optional-value:"0s"`
+}
+
 // Events
 
 type EventsOpts struct {
@@ -547,6 +627,21 @@ type EventsOpts struct {
 	ObjectType string `long:"object-type"  description:"Show events with given object type"`
 	ObjectName string `long:"object-name"  description:"Show events with given object name"`
 
+	Follow          bool           `long:"follow"           description:"Keep polling for new events until interrupted"`
+	PollInterval    time.Duration  `long:"poll-interval"    description:"Interval between polls when following events" default:"5s"`
+	Sinks           []EventSinkArg `long:"sink"             value-name:"SINK" description:"Emit events as JSON to 'stdout', 'file:PATH' or 'webhook:URL' instead of printing a table (can be specified multiple times)"`
+	WebhookAttempts uint           `long:"webhook-attempts" description:"Number of attempts to POST each event to webhook sinks" default:"5"`
+
+	cmd
+}
+
+type ReportOpts struct {
+	Since AgeArg `long:"since" description:"Include tasks and events newer than duration, e.g. 30d or 12h" default:"30d"`
+	Top   int    `long:"top"   description:"Number of most failing instance groups to show" default:"10"`
+	CSV   bool   `long:"csv"   description:"Print report as CSV instead of tables"`
+
+	Deployment string
+
 	cmd
 }
 
@@ -576,6 +671,11 @@ type UploadStemcellOpts struct {
 
 	SHA1 string `long:"sha1" description:"SHA1 of the remote stemcell (is not used with local files)"`
 
+	VerifyKey FileArg `long:"verify-key" description:"Verify signature of local stemcell tarball with PEM encoded public key or certificate at path"`
+
+	Chunked bool `long:"chunked" description:"Upload local stemcell in verified chunks that can be resumed (experimental, requires Director with chunked_uploads or presigned_uploads feature; otherwise uploads regularly)"`
+	Resume  bool `long:"resume"  description:"Resume interrupted chunked upload of local stemcell (experimental)"`
+
 	cmd
 }
 
@@ -595,6 +695,17 @@ type DeleteStemcellArgs struct {
 	Slug boshdir.StemcellSlug `positional-arg-name:"NAME/VERSION"`
 }
 
+type UpgradeStemcellsOpts struct {
+	OS           string  `long:"os"            value-name:"OS"      description:"Operating system of stemcells to upgrade, e.g. ubuntu-jammy" required:"true"`
+	Version      string  `long:"version"       value-name:"VERSION" description:"Stemcell version to upgrade to" default:"latest"`
+	Deployments  string  `long:"deployments"   value-name:"PATTERN" description:"Only upgrade deployments matching pattern, e.g. 'cf-*'"`
+	Concurrency  int     `long:"concurrency"   description:"Number of deployments to update concurrently" default:"1"`
+	ProgressFile FileArg `long:"progress-file" value-name:"PATH"    description:"Record upgraded deployments in file to skip them when resuming interrupted upgrade"`
+	NoRedact     bool    `long:"no-redact"     description:"Show non-redacted manifest diff"`
+
+	cmd
+}
+
 type RepackStemcellOpts struct {
 	Args            RepackStemcellArgs `positional-args:"true" required:"true"`
 	Name            string             `long:"name" description:"Repacked stemcell name"`
@@ -642,6 +753,11 @@ type UploadReleaseOpts struct {
 
 	Stemcell boshdir.OSVersionSlug `long:"stemcell" value-name:"OS/VERSION" description:"Stemcell that the release is compiled against (applies to remote releases)"`
 
+	VerifyKey FileArg `long:"verify-key" description:"Verify signature of local release tarball with PEM encoded public key or certificate at path"`
+
+	Chunked bool `long:"chunked" description:"Upload local release in verified chunks that can be resumed (experimental, requires Director with chunked_uploads or presigned_uploads feature; otherwise uploads regularly)"`
+	Resume  bool `long:"resume"  description:"Resume interrupted chunked upload of local release (experimental)"`
+
 	Release boshrel.Release
 
 	cmd
@@ -688,6 +804,9 @@ type InspectReleaseArgs struct {
 
 type InspectLocalReleaseOpts struct {
 	Args InspectLocalReleaseArgs `positional-args:"true" required:"true"`
+
+	VerifyKey FileArg `long:"verify-key" description:"Verify signature of release tarball with PEM encoded public key or certificate at path"`
+
 	cmd
 }
 
@@ -695,6 +814,18 @@ type InspectLocalReleaseArgs struct {
 	PathToRelease string `positional-arg-name:"PATH-TO-RELEASE" description:"Path to release"`
 }
 
+type InspectReleaseSBOMOpts struct {
+	Args InspectReleaseSBOMArgs `positional-args:"true" required:"true"`
+
+	Format string `long:"format" value-name:"FORMAT" description:"Software bill of materials format (supported: spdx, cyclonedx)" default:"spdx"`
+
+	cmd
+}
+
+type InspectReleaseSBOMArgs struct {
+	PathToRelease string `positional-arg-name:"PATH-TO-RELEASE" description:"Path to release tarball"`
+}
+
 // Errands
 
 type ErrandsOpts struct {
@@ -718,6 +849,8 @@ type RunErrandOpts struct {
 	DownloadLogs  bool        `long:"download-logs" description:"Download logs"`
 	LogsDirectory DirOrCWDArg `long:"logs-dir" description:"Destination directory for logs" default:"."`
 
+	WaitForLockFlags
+
 	cmd
 }
 
@@ -886,6 +1019,13 @@ type LogsOpts struct {
 	System  bool     `long:"system" description:"Include only system logs"`
 	All     bool     `long:"all-logs" description:"Include all logs (agent, system, and job logs)"`
 
+	Merge     bool          `long:"merge"      description:"Interleave lines from all instances by timestamp (used with --follow or --num)"`
+	Grep      RegexpArg     `long:"grep"       description:"Only show lines matching regular expression (implies --merge)"`
+	Since     time.Duration `long:"since"      description:"Only show lines with timestamps newer than duration, e.g. 10m (implies --merge)"`
+	JSONLines bool          `long:"json-lines" description:"Print each line as a JSON object (implies --merge)"`
+
+	Extract bool `long:"extract" description:"Fetch logs of each instance in parallel, extract them into DIR/<group>/<id>/ and index them"`
+
 	GatewayFlags
 
 	CreateEnvAuthFlags
@@ -893,6 +1033,20 @@ type LogsOpts struct {
 	cmd
 }
 
+type LogsSearchOpts struct {
+	Args LogsSearchArgs `positional-args:"true" required:"true"`
+
+	Since TimeArg `long:"since" description:"Only include lines logged at or after this time (RFC 3339 format)"`
+	Until TimeArg `long:"until" description:"Only include lines logged at or before this time (RFC 3339 format)"`
+
+	cmd
+}
+
+type LogsSearchArgs struct {
+	Directory DirOrCWDArg `positional-arg-name:"DIR"     description:"Directory with extracted logs"`
+	Pattern   RegexpArg   `positional-arg-name:"PATTERN" description:"Regular expression to search for"`
+}
+
 type CreateEnvAuthFlags struct {
 	TargetDirector bool   `long:"director"             description:"Target the command at the BOSH director (or other type of VM deployed via create-env)"`
 	Endpoint       string `long:"agent-endpoint"       description:"Address to connect to the agent's HTTPS endpoint (used with --director)"      env:"BOSH_AGENT_ENDPOINT"`
@@ -938,6 +1092,8 @@ type RestartOpts struct {
 	Converge   bool `long:"converge" description:"Converge the deployment state before running action (default)"`
 	NoConverge bool `long:"no-converge" description:"Act only on specified instance"`
 
+	WaitForLockFlags
+
 	cmd
 }
 
@@ -956,6 +1112,8 @@ type RecreateOpts struct {
 	Converge   bool `long:"converge" description:"Converge the deployment state before running action (default)"`
 	NoConverge bool `long:"no-converge" description:"Act only on specified instance"`
 
+	WaitForLockFlags
+
 	cmd
 }
 
@@ -1078,6 +1236,20 @@ type GeneratePackageArgs struct {
 	Name string `positional-arg-name:"NAME"`
 }
 
+type BuildPackageOpts struct {
+	Args BuildPackageArgs `positional-args:"true" required:"true"`
+
+	Directory DirOrCWDArg `long:"dir" description:"Release directory path if not current working directory" default:"."`
+
+	StemcellRootfs string `long:"stemcell-rootfs" value-name:"DIR" description:"Run packaging scripts chrooted into copy of extracted stemcell root filesystem"`
+
+	cmd
+}
+
+type BuildPackageArgs struct {
+	Name string `positional-arg-name:"NAME"`
+}
+
 type VendorPackageOpts struct {
 	Args VendorPackageArgs `positional-args:"true" required:"true"`
 
@@ -1122,6 +1294,13 @@ type CreateReleaseOpts struct {
 	Tarball FileArg `long:"tarball" description:"Create release tarball at path (e.g. /tmp/release.tgz)"`
 	Force   bool    `long:"force"   description:"Ignore Git dirty state check"`
 
+	SBOM     string  `long:"sbom"      value-name:"FORMAT" description:"Generate software bill of materials for the release (supported: spdx, cyclonedx)"`
+	SBOMFile FileArg `long:"sbom-file"                     description:"Write software bill of materials to path (default: next to release tarball)"`
+
+	SignKey FileArg `long:"sign-key" description:"Sign release tarball with PEM encoded Ed25519, ECDSA or RSA private key at path"`
+
+	Workspace FileArg `long:"workspace" description:"Create each release listed in workspace file, vendoring shared packages into it"`
+
 	cmd
 }
 
@@ -1139,6 +1318,8 @@ type FinalizeReleaseOpts struct {
 
 	Force bool `long:"force" description:"Ignore Git dirty state check"`
 
+	Workspace FileArg `long:"workspace" description:"Finalize release in release directory of workspace file matching release name"`
+
 	cmd
 }
 
@@ -1146,6 +1327,48 @@ type FinalizeReleaseArgs struct {
 	Path string `positional-arg-name:"PATH"`
 }
 
+type VerifyReleaseReproducibleOpts struct {
+	Args VerifyReleaseReproducibleArgs `positional-args:"true" required:"true"`
+
+	Directory DirOrCWDArg `long:"dir" description:"Release directory path if not current working directory" default:"."`
+
+	cmd
+}
+
+type VerifyReleaseReproducibleArgs struct {
+	Path string `positional-arg-name:"PATH" description:"Path to release tarball"`
+}
+
+type ValidateReleaseOpts struct {
+	Directory DirOrCWDArg `long:"dir" description:"Release directory path if not current working directory" default:"."`
+	cmd
+}
+
+type DiffReleasesOpts struct {
+	Args DiffReleasesArgs `positional-args:"true"`
+
+	FromDir     DirOrCWDArg `long:"from-dir"     value-name:"DIR" description:"Compare release directory with its last dev or final version"`
+	FromVersion VersionArg  `long:"from-version"                  description:"Release version to compare release directory with"`
+
+	cmd
+}
+
+type DiffReleasesArgs struct {
+	From string `positional-arg-name:"FROM" description:"Release tarball to compare from"`
+	To   string `positional-arg-name:"TO"   description:"Release tarball to compare to"`
+}
+
+type CleanReleaseDirOpts struct {
+	Directory DirOrCWDArg `long:"dir" description:"Release directory path if not current working directory" default:"."`
+
+	KeepDev    int  `long:"keep-dev"    value-name:"N" description:"Number of latest dev releases to keep" default:"2"`
+	FinalBlobs bool `long:"final-blobs"                description:"Remove unreferenced final builds and delete their blobs from release blobstore"`
+	CacheBlobs bool `long:"cache-blobs"                description:"Remove blobs from local cache (~/.bosh/cache) not referenced by builds of this release directory, including blobs of other release directories"`
+	DryRun     bool `long:"dry-run"                    description:"Print out artifacts that will be removed but does not remove anything"`
+
+	cmd
+}
+
 // Blobs
 
 type BlobsOpts struct {
@@ -1188,6 +1411,14 @@ type UploadBlobsOpts struct {
 	cmd
 }
 
+type UpdateBlobsOpts struct {
+	Directory DirOrCWDArg `long:"dir" description:"Release directory path if not current working directory" default:"."`
+
+	Check bool `long:"check" description:"Only check for newer versions and fail if any blob is outdated"`
+
+	cmd
+}
+
 type CurlOpts struct {
 	Args CurlArgs `positional-args:"true" required:"true"`
 
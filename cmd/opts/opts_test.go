@@ -91,6 +91,7 @@ var _ = Describe("Opts", func() {
 			Expect(errs).To(Equal([]string{
 				"Command 'UploadStemcellOpts' shadows global long option 'version'",
 				"Command 'RepackStemcellOpts' shadows global long option 'version'",
+				"Command 'UpgradeStemcellsOpts' shadows global long option 'version'",
 				"Command 'UploadReleaseOpts' shadows global long option 'version'",
 				"Command 'CreateReleaseOpts' shadows global long option 'version'",
 				"Command 'FinalizeReleaseOpts' shadows global long option 'version'",
@@ -137,6 +138,30 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("AllEnvironmentsOpt", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("AllEnvironmentsOpt", opts)).To(Equal(
+					`long:"all-environments" description:"Run read-only command against all aliased environments; --environment may also be a pattern such as 'prod-*'"`,
+				))
+			})
+		})
+
+		Describe("Compression", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Compression", opts)).To(Equal(
+					`long:"compression" value-name:"FORMAT" description:"Compress job, package and release tarballs with format (gzip, zstd); gzip is readable by all directors" env:"BOSH_COMPRESSION" default:"gzip"`,
+				))
+			})
+		})
+
+		Describe("CompressionLevel", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CompressionLevel", opts)).To(Equal(
+					`long:"compression-level" value-name:"LEVEL" description:"Compression level (gzip: 1-9, zstd: 1-22); defaults to format's default level" env:"BOSH_COMPRESSION_LEVEL"`,
+				))
+			})
+		})
+
 		Describe("CACertOpt", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("CACertOpt", opts)).To(Equal(
@@ -249,6 +274,22 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("ExportInventory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ExportInventory", opts)).To(Equal(
+					`command:"export-inventory" description:"Export deployments, releases, stemcells and configs of environment as JSON"`,
+				))
+			})
+		})
+
+		Describe("DiffInventory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DiffInventory", opts)).To(Equal(
+					`command:"diff-inventory" description:"Compare two exported environment inventories"`,
+				))
+			})
+		})
+
 		Describe("LogIn", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("LogIn", opts)).To(Equal(
@@ -441,6 +482,22 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("DriftCheck", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DriftCheck", opts)).To(Equal(
+					`command:"drift-check" description:"Check deployed manifests and configs for drift from local manifests"`,
+				))
+			})
+		})
+
+		Describe("Report", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Report", opts)).To(Equal(
+					`command:"report" description:"Report deploy statistics per deployment and user based on tasks and events"`,
+				))
+			})
+		})
+
 		Describe("Manifest", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Manifest", opts)).To(Equal(
@@ -481,6 +538,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("UpgradeStemcells", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("UpgradeStemcells", opts)).To(Equal(
+					`command:"upgrade-stemcells" description:"Upgrade stemcell of deployments using older stemcell versions"`,
+				))
+			})
+		})
+
 		Describe("Releases", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Releases", opts)).To(Equal(
@@ -521,6 +586,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("InspectReleaseSBOM", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("InspectReleaseSBOM", opts)).To(Equal(
+					`command:"inspect-release-sbom" description:"Display software bill of materials of release tarball without package sources"`,
+				))
+			})
+		})
+
 		Describe("InspectLocalStemcell", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("InspectLocalStemcell", opts)).To(Equal(
@@ -665,6 +738,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("LogsSearch", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("LogsSearch", opts)).To(Equal(
+					`command:"logs-search" description:"Search logs extracted with 'logs --extract'"`,
+				))
+			})
+		})
+
 		Describe("Start", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Start", opts)).To(Equal(
@@ -753,6 +834,30 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("BuildPackage", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("BuildPackage", opts)).To(Equal(
+					`command:"build-package" description:"Build package and its dependencies locally"`,
+				))
+			})
+		})
+
+		Describe("CleanReleaseDir", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CleanReleaseDir", opts)).To(Equal(
+					`command:"clean-release-dir" description:"Remove old dev releases and unreferenced builds from release directory"`,
+				))
+			})
+		})
+
+		Describe("DiffReleases", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DiffReleases", opts)).To(Equal(
+					`command:"diff-releases" description:"Compare jobs and packages of two releases"`,
+				))
+			})
+		})
+
 		Describe("Sha2ifyRelease", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Sha2ifyRelease", opts)).To(Equal(
@@ -809,6 +914,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("UpdateBlobs", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("UpdateBlobs", opts)).To(Equal(
+					`command:"update-blobs" description:"Update blobs to latest versions of their upstream sources"`,
+				))
+			})
+		})
+
 		Describe("AttachDisk", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("AttachDisk", opts)).To(Equal(
@@ -868,6 +981,24 @@ var _ = Describe("Opts", func() {
 				`long:"skip-drain" description:"Skip running drain and pre-stop scripts"`,
 			))
 		})
+
+		It("has --cpi", func() {
+			Expect(getStructTagForName("CPI", opts)).To(Equal(
+				`long:"cpi" value-name:"NAME" description:"Use a built-in CPI instead of the CPI release from the manifest (supported: fake)"`,
+			))
+		})
+
+		It("has --record-cpi", func() {
+			Expect(getStructTagForName("RecordCPI", opts)).To(Equal(
+				`long:"record-cpi" value-name:"DIR" description:"Record CPI calls (request, response, stderr and timing) into directory with secrets redacted"`,
+			))
+		})
+
+		It("has --replay-cpi", func() {
+			Expect(getStructTagForName("ReplayCPI", opts)).To(Equal(
+				`long:"replay-cpi" value-name:"DIR" description:"Serve CPI calls from a directory recorded with --record-cpi instead of running the CPI; calls are matched by order and agent is not replayed"`,
+			))
+		})
 	})
 
 	Describe("CreateEnvArgs", func() {
@@ -916,6 +1047,24 @@ var _ = Describe("Opts", func() {
 				`long:"skip-drain" description:"Skip running drain and pre-stop scripts"`,
 			))
 		})
+
+		It("has --cpi", func() {
+			Expect(getStructTagForName("CPI", opts)).To(Equal(
+				`long:"cpi" value-name:"NAME" description:"Use a built-in CPI instead of the CPI release from the manifest (supported: fake)"`,
+			))
+		})
+
+		It("has --record-cpi", func() {
+			Expect(getStructTagForName("RecordCPI", opts)).To(Equal(
+				`long:"record-cpi" value-name:"DIR" description:"Record CPI calls (request, response, stderr and timing) into directory with secrets redacted"`,
+			))
+		})
+
+		It("has --replay-cpi", func() {
+			Expect(getStructTagForName("ReplayCPI", opts)).To(Equal(
+				`long:"replay-cpi" value-name:"DIR" description:"Serve CPI calls from a directory recorded with --record-cpi instead of running the CPI; calls are matched by order and agent is not replayed"`,
+			))
+		})
 	})
 
 	Describe("DeleteEnvArgs", func() {
@@ -1230,6 +1379,116 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("DiffInventoryOpts", func() {
+		var opts *DiffInventoryOpts
+
+		BeforeEach(func() {
+			opts = &DiffInventoryOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+	})
+
+	Describe("DiffInventoryArgs", func() {
+		var args *DiffInventoryArgs
+
+		BeforeEach(func() {
+			args = &DiffInventoryArgs{}
+		})
+
+		Describe("From", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("From", args)).To(Equal(
+					`positional-arg-name:"FROM" description:"Path to inventory to compare from"`,
+				))
+			})
+		})
+
+		Describe("To", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("To", args)).To(Equal(
+					`positional-arg-name:"TO" description:"Path to inventory to compare to"`,
+				))
+			})
+		})
+	})
+
+	Describe("EventsOpts", func() {
+		var opts *EventsOpts
+
+		BeforeEach(func() {
+			opts = &EventsOpts{}
+		})
+
+		Describe("Follow", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Follow", opts)).To(Equal(
+					`long:"follow" description:"Keep polling for new events until interrupted"`,
+				))
+			})
+		})
+
+		Describe("PollInterval", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("PollInterval", opts)).To(Equal(
+					`long:"poll-interval" description:"Interval between polls when following events" default:"5s"`,
+				))
+			})
+		})
+
+		Describe("Sinks", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Sinks", opts)).To(Equal(
+					`long:"sink" value-name:"SINK" description:"Emit events as JSON to 'stdout', 'file:PATH' or 'webhook:URL' instead of printing a table (can be specified multiple times)"`,
+				))
+			})
+		})
+
+		Describe("WebhookAttempts", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("WebhookAttempts", opts)).To(Equal(
+					`long:"webhook-attempts" description:"Number of attempts to POST each event to webhook sinks" default:"5"`,
+				))
+			})
+		})
+	})
+
+	Describe("ReportOpts", func() {
+		var opts *ReportOpts
+
+		BeforeEach(func() {
+			opts = &ReportOpts{}
+		})
+
+		Describe("Since", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Since", opts)).To(Equal(
+					`long:"since" description:"Include tasks and events newer than duration, e.g. 30d or 12h" default:"30d"`,
+				))
+			})
+		})
+
+		Describe("Top", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Top", opts)).To(Equal(
+					`long:"top" description:"Number of most failing instance groups to show" default:"10"`,
+				))
+			})
+		})
+
+		Describe("CSV", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CSV", opts)).To(Equal(
+					`long:"csv" description:"Print report as CSV instead of tables"`,
+				))
+			})
+		})
+	})
+
 	Describe("TaskOpts", func() {
 		var opts *TaskOpts
 
@@ -1675,6 +1934,52 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("DriftCheckOpts", func() {
+		var opts *DriftCheckOpts
+
+		BeforeEach(func() {
+			opts = &DriftCheckOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true"`))
+			})
+		})
+
+		Describe("Mapping", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Mapping", opts)).To(Equal(
+					`long:"mapping" value-name:"PATH" description:"Check all deployments listed in mapping file instead of a single deployment"`,
+				))
+			})
+		})
+
+		Describe("NoRedact", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("NoRedact", opts)).To(Equal(
+					`long:"no-redact" description:"Show non-redacted manifest diff"`,
+				))
+			})
+		})
+	})
+
+	Describe("DriftCheckArgs", func() {
+		var opts *DriftCheckArgs
+
+		BeforeEach(func() {
+			opts = &DriftCheckArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", opts)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest file"`,
+				))
+			})
+		})
+	})
+
 	Describe("DeleteDeploymentOpts", func() {
 		var opts *DeleteDeploymentOpts
 
@@ -1712,6 +2017,22 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("Chunked", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Chunked", opts)).To(Equal(
+					`long:"chunked" description:"Upload local stemcell in verified chunks that can be resumed (experimental, requires Director with chunked_uploads or presigned_uploads feature; otherwise uploads regularly)"`,
+				))
+			})
+		})
+
+		Describe("Resume", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Resume", opts)).To(Equal(
+					`long:"resume" description:"Resume interrupted chunked upload of local stemcell (experimental)"`,
+				))
+			})
+		})
+
 		Describe("Name", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Name", opts)).To(Equal(
@@ -1735,6 +2056,14 @@ var _ = Describe("Opts", func() {
 				))
 			})
 		})
+
+		Describe("VerifyKey", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("VerifyKey", opts)).To(Equal(
+					`long:"verify-key" description:"Verify signature of local stemcell tarball with PEM encoded public key or certificate at path"`,
+				))
+			})
+		})
 	})
 
 	Describe("UploadStemcellArgs", func() {
@@ -1791,46 +2120,102 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
-	Describe("RepackStemcellOpts", func() {
-		var opts *RepackStemcellOpts
+	Describe("UpgradeStemcellsOpts", func() {
+		var opts *UpgradeStemcellsOpts
 
 		BeforeEach(func() {
-			opts = &RepackStemcellOpts{}
+			opts = &UpgradeStemcellsOpts{}
 		})
 
-		Describe("Args", func() {
+		Describe("OS", func() {
 			It("contains desired values", func() {
-				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+				Expect(getStructTagForName("OS", opts)).To(Equal(
+					`long:"os" value-name:"OS" description:"Operating system of stemcells to upgrade, e.g. ubuntu-jammy" required:"true"`,
+				))
 			})
 		})
 
-		It("has --version", func() {
-			Expect(getStructTagForName("Version", opts)).To(Equal(
-				`long:"version" description:"Repacked stemcell version"`,
-			))
+		Describe("Version", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Version", opts)).To(Equal(
+					`long:"version" value-name:"VERSION" description:"Stemcell version to upgrade to" default:"latest"`,
+				))
+			})
 		})
 
-		It("has --name", func() {
-			Expect(getStructTagForName("Name", opts)).To(Equal(
-				`long:"name" description:"Repacked stemcell name"`,
-			))
+		Describe("Deployments", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Deployments", opts)).To(Equal(
+					`long:"deployments" value-name:"PATTERN" description:"Only upgrade deployments matching pattern, e.g. 'cf-*'"`,
+				))
+			})
 		})
 
-		It("has --cloud-properties", func() {
-			Expect(getStructTagForName("CloudProperties", opts)).To(Equal(
-				`long:"cloud-properties" description:"Repacked stemcell cloud properties"`,
-			))
+		Describe("Concurrency", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Concurrency", opts)).To(Equal(
+					`long:"concurrency" description:"Number of deployments to update concurrently" default:"1"`,
+				))
+			})
 		})
 
-		It("has --empty-image", func() {
-			Expect(getStructTagForName("EmptyImage", opts)).To(Equal(
-				`long:"empty-image" description:"Pack zero byte file instead of image"`,
-			))
+		Describe("ProgressFile", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ProgressFile", opts)).To(Equal(
+					`long:"progress-file" value-name:"PATH" description:"Record upgraded deployments in file to skip them when resuming interrupted upgrade"`,
+				))
+			})
 		})
 
-		It("has --format", func() {
-			Expect(getStructTagForName("Format", opts)).To(Equal(
-				`long:"format" description:"Repacked stemcell formats. Can be used multiple times. Overrides existing formats."`,
+		Describe("NoRedact", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("NoRedact", opts)).To(Equal(
+					`long:"no-redact" description:"Show non-redacted manifest diff"`,
+				))
+			})
+		})
+	})
+
+	Describe("RepackStemcellOpts", func() {
+		var opts *RepackStemcellOpts
+
+		BeforeEach(func() {
+			opts = &RepackStemcellOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		It("has --version", func() {
+			Expect(getStructTagForName("Version", opts)).To(Equal(
+				`long:"version" description:"Repacked stemcell version"`,
+			))
+		})
+
+		It("has --name", func() {
+			Expect(getStructTagForName("Name", opts)).To(Equal(
+				`long:"name" description:"Repacked stemcell name"`,
+			))
+		})
+
+		It("has --cloud-properties", func() {
+			Expect(getStructTagForName("CloudProperties", opts)).To(Equal(
+				`long:"cloud-properties" description:"Repacked stemcell cloud properties"`,
+			))
+		})
+
+		It("has --empty-image", func() {
+			Expect(getStructTagForName("EmptyImage", opts)).To(Equal(
+				`long:"empty-image" description:"Pack zero byte file instead of image"`,
+			))
+		})
+
+		It("has --format", func() {
+			Expect(getStructTagForName("Format", opts)).To(Equal(
+				`long:"format" description:"Repacked stemcell formats. Can be used multiple times. Overrides existing formats."`,
 			))
 		})
 	})
@@ -1903,6 +2288,22 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("Chunked", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Chunked", opts)).To(Equal(
+					`long:"chunked" description:"Upload local release in verified chunks that can be resumed (experimental, requires Director with chunked_uploads or presigned_uploads feature; otherwise uploads regularly)"`,
+				))
+			})
+		})
+
+		Describe("Resume", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Resume", opts)).To(Equal(
+					`long:"resume" description:"Resume interrupted chunked upload of local release (experimental)"`,
+				))
+			})
+		})
+
 		Describe("Name", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Name", opts)).To(Equal(
@@ -1934,6 +2335,14 @@ var _ = Describe("Opts", func() {
 				))
 			})
 		})
+
+		Describe("VerifyKey", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("VerifyKey", opts)).To(Equal(
+					`long:"verify-key" description:"Verify signature of local release tarball with PEM encoded public key or certificate at path"`,
+				))
+			})
+		})
 	})
 
 	Describe("UploadReleaseArgs", func() {
@@ -2082,6 +2491,14 @@ var _ = Describe("Opts", func() {
 			opts = &InspectLocalReleaseOpts{}
 		})
 
+		Describe("VerifyKey", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("VerifyKey", opts)).To(Equal(
+					`long:"verify-key" description:"Verify signature of release tarball with PEM encoded public key or certificate at path"`,
+				))
+			})
+		})
+
 		Describe("Args", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
@@ -2105,6 +2522,44 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("InspectReleaseSBOMOpts", func() {
+		var opts *InspectReleaseSBOMOpts
+
+		BeforeEach(func() {
+			opts = &InspectReleaseSBOMOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("Format", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Format", opts)).To(Equal(
+					`long:"format" value-name:"FORMAT" description:"Software bill of materials format (supported: spdx, cyclonedx)" default:"spdx"`,
+				))
+			})
+		})
+	})
+
+	Describe("WaitForLockFlags", func() {
+		var opts *WaitForLockFlags
+
+		BeforeEach(func() {
+			opts = &WaitForLockFlags{}
+		})
+
+		Describe("WaitForLock", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("WaitForLock", opts)).To(Equal(
+					`long:"wait-for-lock" value-name:"TIMEOUT" description:"Wait for deployment lock to be released before starting. Use '=' to specify timeout, e.g. --wait-for-lock=30m" optional:"true" optional-value:"0s"`,
+				))
+			})
+		})
+	})
+
 	Describe("InstanceGroupOrInstanceSlugFlags", func() {
 		var opts *InstanceGroupOrInstanceSlugFlags
 
@@ -2673,6 +3128,100 @@ var _ = Describe("Opts", func() {
 				))
 			})
 		})
+
+		Describe("Merge", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Merge", opts)).To(Equal(
+					`long:"merge" description:"Interleave lines from all instances by timestamp (used with --follow or --num)"`,
+				))
+			})
+		})
+
+		Describe("Grep", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Grep", opts)).To(Equal(
+					`long:"grep" description:"Only show lines matching regular expression (implies --merge)"`,
+				))
+			})
+		})
+
+		Describe("Since", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Since", opts)).To(Equal(
+					`long:"since" description:"Only show lines with timestamps newer than duration, e.g. 10m (implies --merge)"`,
+				))
+			})
+		})
+
+		Describe("JSONLines", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("JSONLines", opts)).To(Equal(
+					`long:"json-lines" description:"Print each line as a JSON object (implies --merge)"`,
+				))
+			})
+		})
+
+		Describe("Extract", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Extract", opts)).To(Equal(
+					`long:"extract" description:"Fetch logs of each instance in parallel, extract them into DIR/<group>/<id>/ and index them"`,
+				))
+			})
+		})
+	})
+
+	Describe("LogsSearchOpts", func() {
+		var opts *LogsSearchOpts
+
+		BeforeEach(func() {
+			opts = &LogsSearchOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("Since", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Since", opts)).To(Equal(
+					`long:"since" description:"Only include lines logged at or after this time (RFC 3339 format)"`,
+				))
+			})
+		})
+
+		Describe("Until", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Until", opts)).To(Equal(
+					`long:"until" description:"Only include lines logged at or before this time (RFC 3339 format)"`,
+				))
+			})
+		})
+	})
+
+	Describe("LogsSearchArgs", func() {
+		var opts *LogsSearchArgs
+
+		BeforeEach(func() {
+			opts = &LogsSearchArgs{}
+		})
+
+		Describe("Directory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Directory", opts)).To(Equal(
+					`positional-arg-name:"DIR" description:"Directory with extracted logs"`,
+				))
+			})
+		})
+
+		Describe("Pattern", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Pattern", opts)).To(Equal(
+					`positional-arg-name:"PATTERN" description:"Regular expression to search for"`,
+				))
+			})
+		})
 	})
 
 	Describe("StartOpts", func() {
@@ -3105,6 +3654,54 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("BuildPackageOpts", func() {
+		var opts *BuildPackageOpts
+
+		BeforeEach(func() {
+			opts = &BuildPackageOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(
+					`positional-args:"true" required:"true"`,
+				))
+			})
+		})
+
+		Describe("Directory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Directory", opts)).To(Equal(
+					`long:"dir" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+
+		Describe("StemcellRootfs", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("StemcellRootfs", opts)).To(Equal(
+					`long:"stemcell-rootfs" value-name:"DIR" description:"Run packaging scripts chrooted into copy of extracted stemcell root filesystem"`,
+				))
+			})
+		})
+	})
+
+	Describe("BuildPackageArgs", func() {
+		var opts *BuildPackageArgs
+
+		BeforeEach(func() {
+			opts = &BuildPackageArgs{}
+		})
+
+		Describe("Name", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Name", opts)).To(Equal(
+					`positional-arg-name:"NAME"`,
+				))
+			})
+		})
+	})
+
 	Describe("VendorPackageOpts", func() {
 		var opts *VendorPackageOpts
 
@@ -3226,6 +3823,38 @@ var _ = Describe("Opts", func() {
 				))
 			})
 		})
+
+		Describe("SBOM", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("SBOM", opts)).To(Equal(
+					`long:"sbom" value-name:"FORMAT" description:"Generate software bill of materials for the release (supported: spdx, cyclonedx)"`,
+				))
+			})
+		})
+
+		Describe("SBOMFile", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("SBOMFile", opts)).To(Equal(
+					`long:"sbom-file" description:"Write software bill of materials to path (default: next to release tarball)"`,
+				))
+			})
+		})
+
+		Describe("SignKey", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("SignKey", opts)).To(Equal(
+					`long:"sign-key" description:"Sign release tarball with PEM encoded Ed25519, ECDSA or RSA private key at path"`,
+				))
+			})
+		})
+
+		Describe("Workspace", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Workspace", opts)).To(Equal(
+					`long:"workspace" description:"Create each release listed in workspace file, vendoring shared packages into it"`,
+				))
+			})
+		})
 	})
 
 	Describe("Sha2ifyReleaseOpts", func() {
@@ -3322,6 +3951,14 @@ var _ = Describe("Opts", func() {
 				))
 			})
 		})
+
+		Describe("Workspace", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Workspace", opts)).To(Equal(
+					`long:"workspace" description:"Finalize release in release directory of workspace file matching release name"`,
+				))
+			})
+		})
 	})
 
 	Describe("FinalizeReleaseArgs", func() {
@@ -3338,6 +3975,156 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("VerifyReleaseReproducibleOpts", func() {
+		var opts *VerifyReleaseReproducibleOpts
+
+		BeforeEach(func() {
+			opts = &VerifyReleaseReproducibleOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("Directory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Directory", opts)).To(Equal(
+					`long:"dir" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+	})
+
+	Describe("VerifyReleaseReproducibleArgs", func() {
+		var opts *VerifyReleaseReproducibleArgs
+
+		BeforeEach(func() {
+			opts = &VerifyReleaseReproducibleArgs{}
+		})
+
+		Describe("Path", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Path", opts)).To(Equal(`positional-arg-name:"PATH" description:"Path to release tarball"`))
+			})
+		})
+	})
+
+	Describe("ValidateReleaseOpts", func() {
+		var opts *ValidateReleaseOpts
+
+		BeforeEach(func() {
+			opts = &ValidateReleaseOpts{}
+		})
+
+		Describe("Directory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Directory", opts)).To(Equal(
+					`long:"dir" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+	})
+
+	Describe("CleanReleaseDirOpts", func() {
+		var opts *CleanReleaseDirOpts
+
+		BeforeEach(func() {
+			opts = &CleanReleaseDirOpts{}
+		})
+
+		Describe("Directory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Directory", opts)).To(Equal(
+					`long:"dir" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+
+		Describe("KeepDev", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("KeepDev", opts)).To(Equal(
+					`long:"keep-dev" value-name:"N" description:"Number of latest dev releases to keep" default:"2"`,
+				))
+			})
+		})
+
+		Describe("FinalBlobs", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("FinalBlobs", opts)).To(Equal(
+					`long:"final-blobs" description:"Remove unreferenced final builds and delete their blobs from release blobstore"`,
+				))
+			})
+		})
+
+		Describe("CacheBlobs", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CacheBlobs", opts)).To(Equal(
+					`long:"cache-blobs" description:"Remove blobs from local cache (~/.bosh/cache) not referenced by builds of this release directory, including blobs of other release directories"`,
+				))
+			})
+		})
+
+		Describe("DryRun", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DryRun", opts)).To(Equal(
+					`long:"dry-run" description:"Print out artifacts that will be removed but does not remove anything"`,
+				))
+			})
+		})
+	})
+
+	Describe("DiffReleasesOpts", func() {
+		var opts *DiffReleasesOpts
+
+		BeforeEach(func() {
+			opts = &DiffReleasesOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true"`))
+			})
+		})
+
+		Describe("FromDir", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("FromDir", opts)).To(Equal(
+					`long:"from-dir" value-name:"DIR" description:"Compare release directory with its last dev or final version"`,
+				))
+			})
+		})
+
+		Describe("FromVersion", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("FromVersion", opts)).To(Equal(
+					`long:"from-version" description:"Release version to compare release directory with"`,
+				))
+			})
+		})
+	})
+
+	Describe("DiffReleasesArgs", func() {
+		var opts *DiffReleasesArgs
+
+		BeforeEach(func() {
+			opts = &DiffReleasesArgs{}
+		})
+
+		Describe("From", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("From", opts)).To(Equal(`positional-arg-name:"FROM" description:"Release tarball to compare from"`))
+			})
+		})
+
+		Describe("To", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("To", opts)).To(Equal(`positional-arg-name:"TO" description:"Release tarball to compare to"`))
+			})
+		})
+	})
+
 	Describe("BlobsOpts", func() {
 		var opts *BlobsOpts
 
@@ -3446,6 +4233,30 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("UpdateBlobsOpts", func() {
+		var opts *UpdateBlobsOpts
+
+		BeforeEach(func() {
+			opts = &UpdateBlobsOpts{}
+		})
+
+		Describe("Directory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Directory", opts)).To(Equal(
+					`long:"dir" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+
+		Describe("Check", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Check", opts)).To(Equal(
+					`long:"check" description:"Only check for newer versions and fail if any blob is outdated"`,
+				))
+			})
+		})
+	})
+
 	Describe("CurlOpts", func() {
 		var opts *CurlOpts
 
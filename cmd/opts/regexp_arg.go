@@ -0,0 +1,26 @@
+package opts
+
+import (
+	"regexp"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+type RegexpArg struct {
+	*regexp.Regexp
+}
+
+func (a *RegexpArg) UnmarshalFlag(data string) error {
+	re, err := regexp.Compile(data)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Invalid regular expression '%s'", data)
+	}
+
+	a.Regexp = re
+
+	return nil
+}
+
+func (a RegexpArg) IsSet() bool {
+	return a.Regexp != nil
+}
@@ -0,0 +1,35 @@
+package opts_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+)
+
+var _ = Describe("RegexpArg", func() {
+	Describe("UnmarshalFlag", func() {
+		var (
+			arg RegexpArg
+		)
+
+		BeforeEach(func() {
+			arg = RegexpArg{}
+		})
+
+		It("compiles regular expression", func() {
+			err := (&arg).UnmarshalFlag("err(or)?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg.IsSet()).To(BeTrue())
+			Expect(arg.MatchString("some error")).To(BeTrue())
+			Expect(arg.MatchString("some info")).To(BeFalse())
+		})
+
+		It("returns error if regular expression is invalid", func() {
+			err := (&arg).UnmarshalFlag("err(")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Invalid regular expression 'err('"))
+			Expect(arg.IsSet()).To(BeFalse())
+		})
+	})
+})
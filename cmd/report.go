@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+// reportTasksLimit is the maximum number of recent tasks considered by report
+const reportTasksLimit = 10000
+
+type ReportCmd struct {
+	ui          boshui.UI
+	director    boshdir.Director
+	timeService clock.Clock
+}
+
+type deployStats struct {
+	deploys   int
+	failed    int
+	durations []time.Duration
+	recreates int
+}
+
+type instanceGroupFailures struct {
+	deployment    string
+	instanceGroup string
+	failures      int
+}
+
+func NewReportCmd(ui boshui.UI, director boshdir.Director, timeService clock.Clock) ReportCmd {
+	return ReportCmd{ui: ui, director: director, timeService: timeService}
+}
+
+func (c ReportCmd) Run(opts ReportOpts) error {
+	since := c.timeService.Now().Add(-opts.Since.Duration).UTC()
+
+	tasks, err := c.director.RecentTasks(reportTasksLimit, boshdir.TasksFilter{Deployment: opts.Deployment})
+	if err != nil {
+		return bosherr.WrapError(err, "Fetching recent tasks")
+	}
+
+	events, err := c.events(since, opts.Deployment)
+	if err != nil {
+		return bosherr.WrapError(err, "Fetching events")
+	}
+
+	deploymentStats := map[string]*deployStats{}
+	userStats := map[string]*deployStats{}
+
+	for _, task := range tasks {
+		if task.StartedAt().Before(since) || task.Description() != "create deployment" {
+			continue
+		}
+
+		for _, stats := range []*deployStats{
+			statsFor(deploymentStats, task.DeploymentName()),
+			statsFor(userStats, task.User()),
+		} {
+			stats.add(task)
+		}
+	}
+
+	failures := map[string]*instanceGroupFailures{}
+
+	for _, event := range events {
+		if event.Action() == "recreate" && event.ObjectType() == "instance" {
+			statsFor(deploymentStats, event.DeploymentName()).recreates++
+		}
+
+		if len(event.Error()) > 0 && len(event.Instance()) > 0 {
+			instanceGroup := strings.SplitN(event.Instance(), "/", 2)[0]
+			key := event.DeploymentName() + "/" + instanceGroup
+
+			if _, found := failures[key]; !found {
+				failures[key] = &instanceGroupFailures{deployment: event.DeploymentName(), instanceGroup: instanceGroup}
+			}
+
+			failures[key].failures++
+		}
+	}
+
+	tables := []boshtbl.Table{
+		c.deploymentsTable(deploymentStats),
+		c.usersTable(userStats),
+		c.instanceGroupsTable(failures, opts.Top),
+	}
+
+	if opts.CSV {
+		return c.printCSV(tables)
+	}
+
+	c.ui.PrintLinef("Report since %s", since.Format(time.RFC3339))
+
+	for _, table := range tables {
+		c.ui.PrintTable(table)
+	}
+
+	return nil
+}
+
+// events returns all events after given time paging back through Director's responses
+func (c ReportCmd) events(since time.Time, deployment string) ([]boshdir.Event, error) {
+	filter := boshdir.EventsFilter{
+		After:      since.Format("2006-01-02 15:04:05 UTC"),
+		Deployment: deployment,
+	}
+
+	var events []boshdir.Event
+
+	for {
+		page, err := c.director.Events(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, page...)
+
+		if len(page) < eventsPageSize {
+			return events, nil
+		}
+
+		filter.BeforeID = page[len(page)-1].ID()
+	}
+}
+
+func statsFor(stats map[string]*deployStats, key string) *deployStats {
+	if _, found := stats[key]; !found {
+		stats[key] = &deployStats{}
+	}
+	return stats[key]
+}
+
+// add counts only finished tasks since running ones have no outcome yet
+func (s *deployStats) add(task boshdir.Task) {
+	switch task.State() {
+	case "done":
+		s.durations = append(s.durations, task.FinishedAt().Sub(task.StartedAt()))
+	case "error", "timeout", "cancelled":
+		s.failed++
+	default:
+		return
+	}
+
+	s.deploys++
+}
+
+func (s *deployStats) values() []boshtbl.Value {
+	successRate := ""
+	if s.deploys > 0 {
+		successRate = fmt.Sprintf("%.1f%%", float64(s.deploys-s.failed)*100/float64(s.deploys))
+	}
+
+	return []boshtbl.Value{
+		boshtbl.NewValueInt(s.deploys),
+		boshtbl.NewValueInt(s.failed),
+		boshtbl.NewValueString(successRate),
+		boshtbl.NewValueString(percentile(s.durations, 50)),
+		boshtbl.NewValueString(percentile(s.durations, 95)),
+	}
+}
+
+// percentile uses nearest-rank method over durations of successful deploys
+func percentile(durations []time.Duration, p float64) string {
+	if len(durations) == 0 {
+		return ""
+	}
+
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1].Round(time.Second).String()
+}
+
+func (c ReportCmd) deploymentsTable(stats map[string]*deployStats) boshtbl.Table {
+	table := boshtbl.Table{
+		Content: "deployments",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Deployment"),
+			boshtbl.NewHeader("Deploys"),
+			boshtbl.NewHeader("Failed"),
+			boshtbl.NewHeader("Success Rate"),
+			boshtbl.NewHeader("Duration p50"),
+			boshtbl.NewHeader("Duration p95"),
+			boshtbl.NewHeader("Recreates"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	for name, s := range stats {
+		row := append([]boshtbl.Value{boshtbl.NewValueString(name)}, s.values()...)
+		table.Rows = append(table.Rows, append(row, boshtbl.NewValueInt(s.recreates)))
+	}
+
+	return table
+}
+
+func (c ReportCmd) usersTable(stats map[string]*deployStats) boshtbl.Table {
+	table := boshtbl.Table{
+		Content: "users",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("User"),
+			boshtbl.NewHeader("Deploys"),
+			boshtbl.NewHeader("Failed"),
+			boshtbl.NewHeader("Success Rate"),
+			boshtbl.NewHeader("Duration p50"),
+			boshtbl.NewHeader("Duration p95"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	for name, s := range stats {
+		table.Rows = append(table.Rows, append([]boshtbl.Value{boshtbl.NewValueString(name)}, s.values()...))
+	}
+
+	return table
+}
+
+func (c ReportCmd) instanceGroupsTable(failures map[string]*instanceGroupFailures, top int) boshtbl.Table {
+	table := boshtbl.Table{
+		Content: "failing instance groups",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Deployment"),
+			boshtbl.NewHeader("Instance Group"),
+			boshtbl.NewHeader("Failures"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 2, Asc: false}, {Column: 0, Asc: true}, {Column: 1, Asc: true}},
+	}
+
+	var sorted []*instanceGroupFailures
+
+	for _, f := range failures {
+		sorted = append(sorted, f)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].failures != sorted[j].failures {
+			return sorted[i].failures > sorted[j].failures
+		}
+		return sorted[i].deployment+"/"+sorted[i].instanceGroup < sorted[j].deployment+"/"+sorted[j].instanceGroup
+	})
+
+	if top >= 0 && len(sorted) > top {
+		sorted = sorted[:top]
+	}
+
+	for _, f := range sorted {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(f.deployment),
+			boshtbl.NewValueString(f.instanceGroup),
+			boshtbl.NewValueInt(f.failures),
+		})
+	}
+
+	return table
+}
+
+// printCSV prints each table as CSV with a header row; tables are separated by an empty line
+func (c ReportCmd) printCSV(tables []boshtbl.Table) error {
+	var buf bytes.Buffer
+
+	for i, table := range tables {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		writer := csv.NewWriter(&buf)
+
+		var header []string
+		for _, h := range table.Header {
+			header = append(header, h.Title)
+		}
+
+		records := [][]string{header}
+
+		rows := append([][]boshtbl.Value{}, table.Rows...)
+		sort.Stable(boshtbl.Sorting{SortBy: table.SortBy, Rows: rows})
+
+		for _, row := range rows {
+			var record []string
+			for _, val := range row {
+				record = append(record, val.String())
+			}
+			records = append(records, record)
+		}
+
+		err := writer.WriteAll(records)
+		if err != nil {
+			return bosherr.WrapError(err, "Writing CSV")
+		}
+	}
+
+	c.ui.PrintBlock(buf.Bytes())
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package cmd_test
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-cli/v7/cmd"
+	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("ReportCmd", func() {
+	var (
+		ui          *fakeui.FakeUI
+		director    *fakedir.FakeDirector
+		timeService *fakeclock.FakeClock
+		command     cmd.ReportCmd
+		reportOpts  opts.ReportOpts
+	)
+
+	newTask := func(deployment, user, description, state string, startedAt time.Time, duration time.Duration) boshdir.Task {
+		return &fakedir.FakeTask{
+			DeploymentNameStub: func() string { return deployment },
+			UserStub:           func() string { return user },
+			DescriptionStub:    func() string { return description },
+			StateStub:          func() string { return state },
+			StartedAtStub:      func() time.Time { return startedAt },
+			FinishedAtStub:     func() time.Time { return startedAt.Add(duration) },
+		}
+	}
+
+	newEvent := func(id, deployment, action, objectType, instance, error string) boshdir.Event {
+		return &fakedir.FakeEvent{
+			IDStub:             func() string { return id },
+			DeploymentNameStub: func() string { return deployment },
+			ActionStub:         func() string { return action },
+			ObjectTypeStub:     func() string { return objectType },
+			InstanceStub:       func() string { return instance },
+			ErrorStub:          func() string { return error },
+		}
+	}
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		director = &fakedir.FakeDirector{}
+		timeService = fakeclock.NewFakeClock(time.Date(2020, time.June, 30, 12, 0, 0, 0, time.UTC))
+		command = cmd.NewReportCmd(ui, director, timeService)
+
+		reportOpts = opts.ReportOpts{Since: opts.AgeArg{Duration: 30 * 24 * time.Hour}, Top: 10}
+
+		june := func(day int) time.Time { return time.Date(2020, time.June, day, 10, 0, 0, 0, time.UTC) }
+
+		director.RecentTasksReturns([]boshdir.Task{
+			newTask("dep2", "bob", "create deployment", "processing", june(29), 0),
+			newTask("dep2", "bob", "run errand smoke-tests", "done", june(20), time.Minute),
+			newTask("dep2", "bob", "create deployment", "done", june(15), 5*time.Minute),
+			newTask("dep1", "alice", "create deployment", "done", june(12), 20*time.Minute),
+			newTask("dep1", "bob", "create deployment", "error", june(11), time.Minute),
+			newTask("dep1", "alice", "create deployment", "done", june(10), 10*time.Minute),
+			newTask("dep1", "alice", "create deployment", "done", time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC), time.Hour),
+		}, nil)
+
+		director.EventsReturns([]boshdir.Event{
+			newEvent("9", "dep1", "recreate", "instance", "zookeeper/uuid-1", ""),
+			newEvent("8", "dep1", "recreate", "instance", "zookeeper/uuid-2", ""),
+			newEvent("7", "dep1", "update", "instance", "zookeeper/uuid-1", "fake-err"),
+			newEvent("6", "dep1", "update", "instance", "zookeeper/uuid-2", "fake-err"),
+			newEvent("5", "dep2", "update", "instance", "web/uuid-3", "fake-err"),
+			newEvent("4", "dep2", "update", "deployment", "", "fake-err"),
+		}, nil)
+	})
+
+	act := func() error { return command.Run(reportOpts) }
+
+	It("reports statistics of deploys per deployment and user", func() {
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, filter := director.RecentTasksArgsForCall(0)
+		Expect(filter).To(Equal(boshdir.TasksFilter{}))
+
+		Expect(director.EventsArgsForCall(0)).To(Equal(boshdir.EventsFilter{After: "2020-05-31 12:00:00 UTC"}))
+
+		Expect(ui.Said).To(Equal([]string{"Report since 2020-05-31T12:00:00Z"}))
+		Expect(ui.Tables).To(HaveLen(3))
+
+		deployments := ui.Tables[0]
+		Expect(deployments.Content).To(Equal("deployments"))
+		Expect(deployments.Header).To(Equal([]boshtbl.Header{
+			boshtbl.NewHeader("Deployment"),
+			boshtbl.NewHeader("Deploys"),
+			boshtbl.NewHeader("Failed"),
+			boshtbl.NewHeader("Success Rate"),
+			boshtbl.NewHeader("Duration p50"),
+			boshtbl.NewHeader("Duration p95"),
+			boshtbl.NewHeader("Recreates"),
+		}))
+		Expect(deployments.SortBy).To(Equal([]boshtbl.ColumnSort{{Column: 0, Asc: true}}))
+		Expect(deployments.Rows).To(ConsistOf(
+			[]boshtbl.Value{
+				boshtbl.NewValueString("dep1"),
+				boshtbl.NewValueInt(3),
+				boshtbl.NewValueInt(1),
+				boshtbl.NewValueString("66.7%"),
+				boshtbl.NewValueString("10m0s"),
+				boshtbl.NewValueString("20m0s"),
+				boshtbl.NewValueInt(2),
+			},
+			[]boshtbl.Value{
+				boshtbl.NewValueString("dep2"),
+				boshtbl.NewValueInt(1),
+				boshtbl.NewValueInt(0),
+				boshtbl.NewValueString("100.0%"),
+				boshtbl.NewValueString("5m0s"),
+				boshtbl.NewValueString("5m0s"),
+				boshtbl.NewValueInt(0),
+			},
+		))
+
+		users := ui.Tables[1]
+		Expect(users.Content).To(Equal("users"))
+		Expect(users.Rows).To(ConsistOf(
+			[]boshtbl.Value{
+				boshtbl.NewValueString("alice"),
+				boshtbl.NewValueInt(2),
+				boshtbl.NewValueInt(0),
+				boshtbl.NewValueString("100.0%"),
+				boshtbl.NewValueString("10m0s"),
+				boshtbl.NewValueString("20m0s"),
+			},
+			[]boshtbl.Value{
+				boshtbl.NewValueString("bob"),
+				boshtbl.NewValueInt(2),
+				boshtbl.NewValueInt(1),
+				boshtbl.NewValueString("50.0%"),
+				boshtbl.NewValueString("5m0s"),
+				boshtbl.NewValueString("5m0s"),
+			},
+		))
+
+		instanceGroups := ui.Tables[2]
+		Expect(instanceGroups.Content).To(Equal("failing instance groups"))
+		Expect(instanceGroups.Rows).To(Equal([][]boshtbl.Value{
+			{boshtbl.NewValueString("dep1"), boshtbl.NewValueString("zookeeper"), boshtbl.NewValueInt(2)},
+			{boshtbl.NewValueString("dep2"), boshtbl.NewValueString("web"), boshtbl.NewValueInt(1)},
+		}))
+	})
+
+	It("limits number of failing instance groups", func() {
+		reportOpts.Top = 1
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Tables[2].Rows).To(Equal([][]boshtbl.Value{
+			{boshtbl.NewValueString("dep1"), boshtbl.NewValueString("zookeeper"), boshtbl.NewValueInt(2)},
+		}))
+	})
+
+	It("restricts report to deployment", func() {
+		reportOpts.Deployment = "dep1"
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, filter := director.RecentTasksArgsForCall(0)
+		Expect(filter).To(Equal(boshdir.TasksFilter{Deployment: "dep1"}))
+		Expect(director.EventsArgsForCall(0).Deployment).To(Equal("dep1"))
+	})
+
+	It("pages through events when more events happened than fit into a response", func() {
+		var page []boshdir.Event
+		for i := 1000; i > 800; i-- {
+			page = append(page, newEvent(strconv.Itoa(i), "dep1", "recreate", "instance", "zookeeper/uuid", ""))
+		}
+
+		director.EventsReturnsOnCall(0, page, nil)
+		director.EventsReturnsOnCall(1, []boshdir.Event{
+			newEvent("800", "dep1", "recreate", "instance", "zookeeper/uuid", ""),
+		}, nil)
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(director.EventsCallCount()).To(Equal(2))
+		Expect(director.EventsArgsForCall(1)).To(Equal(boshdir.EventsFilter{
+			After: "2020-05-31 12:00:00 UTC", BeforeID: "801",
+		}))
+
+		Expect(ui.Tables[0].Rows).To(ContainElement(ContainElement(boshtbl.NewValueInt(201))))
+	})
+
+	It("prints tables as CSV", func() {
+		reportOpts.CSV = true
+
+		director.RecentTasksReturns([]boshdir.Task{
+			newTask("dep2", "bob", "create deployment", "done", time.Date(2020, time.June, 15, 0, 0, 0, 0, time.UTC), 90*time.Second),
+		}, nil)
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Said).To(BeEmpty())
+		Expect(ui.Tables).To(BeEmpty())
+		Expect(ui.Blocks).To(Equal([]string{
+			"Deployment,Deploys,Failed,Success Rate,Duration p50,Duration p95,Recreates\n" +
+				"dep1,0,0,,,,2\n" +
+				"dep2,1,0,100.0%,1m30s,1m30s,0\n" +
+				"\n" +
+				"User,Deploys,Failed,Success Rate,Duration p50,Duration p95\n" +
+				"bob,1,0,100.0%,1m30s,1m30s\n" +
+				"\n" +
+				"Deployment,Instance Group,Failures\n" +
+				"dep1,zookeeper,2\n" +
+				"dep2,web,1\n",
+		}))
+	})
+
+	It("returns error if tasks cannot be fetched", func() {
+		director.RecentTasksReturns(nil, errors.New("fake-err"))
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Fetching recent tasks: fake-err"))
+	})
+
+	It("returns error if events cannot be fetched", func() {
+		director.EventsReturns(nil, errors.New("fake-err"))
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Fetching events: fake-err"))
+	})
+})
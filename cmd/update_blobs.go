@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshupstream "github.com/cloudfoundry/bosh-cli/v7/releasedir/upstream"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type UpdateBlobsCmd struct {
+	updater boshupstream.Updater
+	ui      boshui.UI
+}
+
+func NewUpdateBlobsCmd(updater boshupstream.Updater, ui boshui.UI) UpdateBlobsCmd {
+	return UpdateBlobsCmd{updater: updater, ui: ui}
+}
+
+func (c UpdateBlobsCmd) Run(opts UpdateBlobsOpts) error {
+	statuses, err := c.updater.Check()
+	if err != nil {
+		return bosherr.WrapError(err, "Checking blob sources")
+	}
+
+	table := boshtbl.Table{
+		Content: "blob sources",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Source"),
+			boshtbl.NewHeader("Current"),
+			boshtbl.NewHeader("Latest"),
+			boshtbl.NewHeader("Blob"),
+			boshtbl.NewHeader("Status"),
+		},
+	}
+
+	var outdated []boshupstream.Status
+
+	for _, status := range statuses {
+		statusStr := "up to date"
+
+		if !status.UpToDate() {
+			statusStr = "outdated"
+			outdated = append(outdated, status)
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(status.Source.Name),
+			boshtbl.NewValueString(status.CurrentVersion),
+			boshtbl.NewValueString(status.LatestVersion),
+			boshtbl.NewValueString(status.Path),
+			boshtbl.NewValueFmt(boshtbl.NewValueString(statusStr), !status.UpToDate()),
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	if opts.Check {
+		if len(outdated) > 0 {
+			return bosherr.Errorf("Expected blobs to be up to date but found %d outdated source(s)", len(outdated))
+		}
+		return nil
+	}
+
+	for _, status := range outdated {
+		result, err := c.updater.Update(status)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Updating blob source '%s'", status.Source.Name)
+		}
+
+		if len(result.Blob.Path) > 0 {
+			c.ui.PrintLinef("Added blob '%s'", result.Blob.Path)
+		}
+
+		for _, path := range result.RemovedPaths {
+			c.ui.PrintLinef("Removed blob '%s'", path)
+		}
+
+		for _, path := range result.SpecPaths {
+			c.ui.PrintLinef("Updated package spec '%s'", path)
+		}
+	}
+
+	if len(outdated) > 0 {
+		c.ui.PrintLinef("Run 'bosh upload-blobs' to upload new blobs")
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	boshupstream "github.com/cloudfoundry/bosh-cli/v7/releasedir/upstream"
+	fakeupstream "github.com/cloudfoundry/bosh-cli/v7/releasedir/upstream/upstreamfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("UpdateBlobsCmd", func() {
+	var (
+		updater *fakeupstream.FakeUpdater
+		ui      *fakeui.FakeUI
+		command UpdateBlobsCmd
+		opts    UpdateBlobsOpts
+
+		upToDate, outdated boshupstream.Status
+	)
+
+	BeforeEach(func() {
+		updater = &fakeupstream.FakeUpdater{}
+		ui = &fakeui.FakeUI{}
+		command = NewUpdateBlobsCmd(updater, ui)
+		opts = UpdateBlobsOpts{}
+
+		upToDate = boshupstream.Status{
+			Source:         boshupstream.Source{Name: "ruby"},
+			CurrentVersion: "3.2.0",
+			LatestVersion:  "3.2.0",
+			Path:           "ruby/ruby-3.2.0.tgz",
+			Tracked:        true,
+		}
+
+		outdated = boshupstream.Status{
+			Source:         boshupstream.Source{Name: "golang"},
+			CurrentVersion: "1.20.0",
+			LatestVersion:  "1.21.0",
+			Path:           "golang/go1.21.0.tgz",
+			StalePaths:     []string{"golang/go1.20.0.tgz"},
+		}
+
+		updater.CheckReturns([]boshupstream.Status{outdated, upToDate}, nil)
+	})
+
+	It("prints status of blob sources and updates outdated blobs", func() {
+		updater.UpdateReturns(boshupstream.Result{
+			Blob:         boshreldir.Blob{Path: "golang/go1.21.0.tgz"},
+			RemovedPaths: []string{"golang/go1.20.0.tgz"},
+			SpecPaths:    []string{"packages/golang/spec"},
+		}, nil)
+
+		err := command.Run(opts)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+			{
+				boshtbl.NewValueString("golang"),
+				boshtbl.NewValueString("1.20.0"),
+				boshtbl.NewValueString("1.21.0"),
+				boshtbl.NewValueString("golang/go1.21.0.tgz"),
+				boshtbl.NewValueFmt(boshtbl.NewValueString("outdated"), true),
+			},
+			{
+				boshtbl.NewValueString("ruby"),
+				boshtbl.NewValueString("3.2.0"),
+				boshtbl.NewValueString("3.2.0"),
+				boshtbl.NewValueString("ruby/ruby-3.2.0.tgz"),
+				boshtbl.NewValueFmt(boshtbl.NewValueString("up to date"), false),
+			},
+		}))
+
+		Expect(updater.UpdateCallCount()).To(Equal(1))
+		Expect(updater.UpdateArgsForCall(0)).To(Equal(outdated))
+
+		Expect(ui.Said).To(Equal([]string{
+			"Added blob 'golang/go1.21.0.tgz'",
+			"Removed blob 'golang/go1.20.0.tgz'",
+			"Updated package spec 'packages/golang/spec'",
+			"Run 'bosh upload-blobs' to upload new blobs",
+		}))
+	})
+
+	It("does not update blobs when checking", func() {
+		opts.Check = true
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected blobs to be up to date but found 1 outdated source(s)"))
+
+		Expect(ui.Table.Rows).To(HaveLen(2))
+		Expect(updater.UpdateCallCount()).To(Equal(0))
+	})
+
+	It("succeeds when checking up to date blobs", func() {
+		updater.CheckReturns([]boshupstream.Status{upToDate}, nil)
+		opts.Check = true
+
+		err := command.Run(opts)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("returns error if checking fails", func() {
+		updater.CheckReturns(nil, errors.New("fake-err"))
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+
+	It("returns error if updating fails", func() {
+		updater.UpdateReturns(boshupstream.Result{}, errors.New("fake-err"))
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Updating blob source 'golang'"))
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
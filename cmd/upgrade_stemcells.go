@@ -0,0 +1,372 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/workpool"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"github.com/cppforlife/go-patch/patch"
+	semver "github.com/cppforlife/go-semi-semantic/version"
+	"gopkg.in/yaml.v3"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	boshtpl "github.com/cloudfoundry/bosh-cli/v7/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type UpgradeStemcellsCmd struct {
+	ui       boshui.UI
+	director boshdir.Director
+	fs       boshsys.FileSystem
+}
+
+// UpgradeStemcellsProgress is kept in progress file so that
+// deployments upgraded before interruption are skipped
+type UpgradeStemcellsProgress struct {
+	OS       string   `json:"os"`
+	Version  string   `json:"version"`
+	Upgraded []string `json:"upgraded"`
+}
+
+type stemcellUpgrade struct {
+	deployment boshdir.Deployment
+	manifest   []byte
+	from       []string
+	diff       boshdir.DeploymentDiff
+	err        error
+}
+
+func NewUpgradeStemcellsCmd(ui boshui.UI, director boshdir.Director, fs boshsys.FileSystem) UpgradeStemcellsCmd {
+	return UpgradeStemcellsCmd{ui: ui, director: director, fs: fs}
+}
+
+func (c UpgradeStemcellsCmd) Run(opts UpgradeStemcellsOpts) error {
+	target, names, err := c.targetVersion(opts.OS, opts.Version)
+	if err != nil {
+		return err
+	}
+
+	progress, err := c.readProgress(opts.ProgressFile.ExpandedPath, opts.OS, target.AsString())
+	if err != nil {
+		return err
+	}
+
+	upgrades, err := c.upgrades(opts, target, names, progress)
+	if err != nil {
+		return err
+	}
+
+	if len(upgrades) == 0 {
+		c.ui.PrintLinef("All deployments use stemcell '%s/%s'", opts.OS, target.AsString())
+		return nil
+	}
+
+	for _, upgrade := range upgrades {
+		upgrade.diff, err = upgrade.deployment.Diff(upgrade.manifest, opts.NoRedact)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Diffing deployment '%s'", upgrade.deployment.Name())
+		}
+
+		c.ui.PrintLinef("Deployment '%s':", upgrade.deployment.Name())
+		NewDiff(upgrade.diff.Diff).Print(c.ui)
+		c.ui.PrintLinef("")
+	}
+
+	err = c.ui.AskForConfirmation()
+	if err != nil {
+		return err
+	}
+
+	c.update(upgrades, opts, progress)
+
+	c.printResults(upgrades, target.AsString())
+
+	var errs []error
+
+	for _, upgrade := range upgrades {
+		if upgrade.err != nil {
+			errs = append(errs, bosherr.WrapErrorf(upgrade.err, "Upgrading deployment '%s'", upgrade.deployment.Name()))
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	} else if len(errs) > 1 {
+		return bosherr.NewMultiError(errs...)
+	}
+
+	return nil
+}
+
+// targetVersion resolves version to upgrade to and returns names of uploaded stemcells with given OS
+func (c UpgradeStemcellsCmd) targetVersion(os, version string) (semver.Version, map[string]bool, error) {
+	stemcells, err := c.director.Stemcells()
+	if err != nil {
+		return semver.Version{}, nil, bosherr.WrapError(err, "Listing stemcells")
+	}
+
+	var target semver.Version
+
+	names := map[string]bool{}
+
+	for _, stemcell := range stemcells {
+		if stemcell.OSName() != os {
+			continue
+		}
+
+		names[stemcell.Name()] = true
+
+		if version == "latest" {
+			if target.Empty() || stemcell.Version().IsGt(target) {
+				target = stemcell.Version()
+			}
+		} else if stemcell.Version().AsString() == version {
+			target = stemcell.Version()
+		}
+	}
+
+	if target.Empty() {
+		if version == "latest" {
+			return target, nil, bosherr.Errorf("Expected stemcell with OS '%s' to be uploaded", os)
+		}
+		return target, nil, bosherr.Errorf("Expected stemcell '%s/%s' to be uploaded", os, version)
+	}
+
+	return target, names, nil
+}
+
+// upgrades returns deployments using older versions of stemcells with given names
+func (c UpgradeStemcellsCmd) upgrades(
+	opts UpgradeStemcellsOpts,
+	target semver.Version,
+	names map[string]bool,
+	progress *UpgradeStemcellsProgress,
+) ([]*stemcellUpgrade, error) {
+	deployments, err := c.director.Deployments()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Listing deployments")
+	}
+
+	var upgrades []*stemcellUpgrade
+
+	for _, deployment := range deployments {
+		if len(opts.Deployments) > 0 {
+			matched, err := path.Match(opts.Deployments, deployment.Name())
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Matching deployment pattern '%s'", opts.Deployments)
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		if progress.upgraded(deployment.Name()) {
+			c.ui.PrintLinef("Skipping deployment '%s' since it was upgraded according to progress file", deployment.Name())
+			continue
+		}
+
+		stemcells, err := deployment.Stemcells()
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Listing stemcells of deployment '%s'", deployment.Name())
+		}
+
+		var from []string
+
+		for _, stemcell := range stemcells {
+			if names[stemcell.Name()] && stemcell.Version().IsLt(target) {
+				from = append(from, stemcell.Version().AsString())
+			}
+		}
+
+		if len(from) == 0 {
+			continue
+		}
+
+		manifest, err := deployment.Manifest()
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Fetching manifest of deployment '%s'", deployment.Name())
+		}
+
+		bytes, err := c.upgradeManifest([]byte(manifest), opts.OS, target.AsString(), names)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Upgrading manifest of deployment '%s'", deployment.Name())
+		}
+
+		upgrades = append(upgrades, &stemcellUpgrade{deployment: deployment, manifest: bytes, from: from})
+	}
+
+	return upgrades, nil
+}
+
+// upgradeManifest replaces stemcell versions in manifest's stemcells block;
+// 'latest' aliases are kept since Director resolves them when deploying
+func (c UpgradeStemcellsCmd) upgradeManifest(manifest []byte, os, version string, names map[string]bool) ([]byte, error) {
+	var parsed struct {
+		Stemcells []struct {
+			OS      string `yaml:"os"`
+			Name    string `yaml:"name"`
+			Version string `yaml:"version"`
+		} `yaml:"stemcells"`
+	}
+
+	err := yaml.Unmarshal(manifest, &parsed)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Unmarshaling manifest")
+	}
+
+	var ops patch.Ops
+
+	for i, stemcell := range parsed.Stemcells {
+		if stemcell.OS != os && !names[stemcell.Name] {
+			continue
+		}
+
+		if stemcell.Version == "latest" || strings.HasSuffix(stemcell.Version, ".latest") || stemcell.Version == version {
+			continue
+		}
+
+		ops = append(ops, patch.ReplaceOp{
+			Path:  patch.MustNewPointerFromString(fmt.Sprintf("/stemcells/%d/version", i)),
+			Value: version,
+		})
+	}
+
+	if len(ops) == 0 {
+		return manifest, nil
+	}
+
+	return boshtpl.NewTemplate(manifest).Evaluate(boshtpl.StaticVariables{}, ops, boshtpl.EvaluateOpts{})
+}
+
+func (c UpgradeStemcellsCmd) update(upgrades []*stemcellUpgrade, opts UpgradeStemcellsOpts, progress *UpgradeStemcellsProgress) {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var progressLock sync.Mutex
+
+	works := make([]func(), len(upgrades))
+
+	for i, upgrade := range upgrades {
+		upgrade := upgrade
+
+		works[i] = func() {
+			upgrade.err = upgrade.deployment.Update(upgrade.manifest, boshdir.UpdateOpts{Diff: upgrade.diff})
+			if upgrade.err != nil {
+				return
+			}
+
+			progressLock.Lock()
+			defer progressLock.Unlock()
+
+			progress.Upgraded = append(progress.Upgraded, upgrade.deployment.Name())
+
+			upgrade.err = c.writeProgress(opts.ProgressFile.ExpandedPath, progress)
+		}
+	}
+
+	throttler, err := workpool.NewThrottler(concurrency, works)
+	if err != nil {
+		for _, upgrade := range upgrades {
+			upgrade.err = err
+		}
+		return
+	}
+
+	throttler.Work()
+}
+
+func (c UpgradeStemcellsCmd) readProgress(path, os, version string) (*UpgradeStemcellsProgress, error) {
+	progress := &UpgradeStemcellsProgress{OS: os, Version: version, Upgraded: []string{}}
+
+	if len(path) == 0 || !c.fs.FileExists(path) {
+		return progress, nil
+	}
+
+	bytes, err := c.fs.ReadFile(path)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Reading progress file '%s'", path)
+	}
+
+	var recorded UpgradeStemcellsProgress
+
+	err = json.Unmarshal(bytes, &recorded)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Unmarshaling progress file '%s'", path)
+	}
+
+	if recorded.OS != os || recorded.Version != version {
+		return nil, bosherr.Errorf("Expected progress file '%s' to be for stemcell '%s/%s' but it is for '%s/%s'",
+			path, os, version, recorded.OS, recorded.Version)
+	}
+
+	progress.Upgraded = append(progress.Upgraded, recorded.Upgraded...)
+
+	return progress, nil
+}
+
+func (c UpgradeStemcellsCmd) writeProgress(path string, progress *UpgradeStemcellsProgress) error {
+	if len(path) == 0 {
+		return nil
+	}
+
+	bytes, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return bosherr.WrapError(err, "Marshaling progress")
+	}
+
+	err = c.fs.WriteFile(path, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing progress file '%s'", path)
+	}
+
+	return nil
+}
+
+func (p *UpgradeStemcellsProgress) upgraded(name string) bool {
+	for _, upgraded := range p.Upgraded {
+		if upgraded == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c UpgradeStemcellsCmd) printResults(upgrades []*stemcellUpgrade, version string) {
+	table := boshtbl.Table{
+		Content: "deployments",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Deployment"),
+			boshtbl.NewHeader("From"),
+			boshtbl.NewHeader("To"),
+			boshtbl.NewHeader("Status"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	for _, upgrade := range upgrades {
+		status := boshtbl.NewValueString("upgraded")
+		if upgrade.err != nil {
+			status = boshtbl.NewValueString("failed")
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(upgrade.deployment.Name()),
+			boshtbl.NewValueStrings(upgrade.from),
+			boshtbl.NewValueString(version),
+			boshtbl.NewValueFmt(status, upgrade.err != nil),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
@@ -0,0 +1,255 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	semver "github.com/cppforlife/go-semi-semantic/version"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-cli/v7/cmd"
+	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("UpgradeStemcellsCmd", func() {
+	var (
+		ui          *fakeui.FakeUI
+		director    *fakedir.FakeDirector
+		fs          *fakesys.FakeFileSystem
+		deployments map[string]*fakedir.FakeDeployment
+		command     cmd.UpgradeStemcellsCmd
+		upgradeOpts opts.UpgradeStemcellsOpts
+	)
+
+	newStemcell := func(name, os, version string) boshdir.Stemcell {
+		return &fakedir.FakeStemcell{
+			NameStub:    func() string { return name },
+			OSNameStub:  func() string { return os },
+			VersionStub: func() semver.Version { return semver.MustNewVersionFromString(version) },
+		}
+	}
+
+	newDeployment := func(name, manifest string, stemcells ...boshdir.Stemcell) *fakedir.FakeDeployment {
+		dep := &fakedir.FakeDeployment{}
+		dep.NameReturns(name)
+		dep.ManifestReturns(manifest, nil)
+		dep.StemcellsReturns(stemcells, nil)
+		dep.DiffReturns(boshdir.DeploymentDiff{Diff: [][]interface{}{{"stemcells:", nil}}}, nil)
+		return dep
+	}
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		director = &fakedir.FakeDirector{}
+		fs = fakesys.NewFakeFileSystem()
+		command = cmd.NewUpgradeStemcellsCmd(ui, director, fs)
+
+		upgradeOpts = opts.UpgradeStemcellsOpts{OS: "ubuntu-jammy", Version: "latest", Concurrency: 1}
+
+		director.StemcellsReturns([]boshdir.Stemcell{
+			newStemcell("bosh-jammy", "ubuntu-jammy", "1.10"),
+			newStemcell("bosh-jammy", "ubuntu-jammy", "1.20"),
+			newStemcell("bosh-jammy", "ubuntu-jammy", "1.9"),
+			newStemcell("bosh-bionic", "ubuntu-bionic", "2.0"),
+		}, nil)
+
+		deployments = map[string]*fakedir.FakeDeployment{
+			"cf-1": newDeployment("cf-1",
+				"name: cf-1\nstemcells:\n- alias: default\n  os: ubuntu-jammy\n  version: \"1.10\"\n- alias: other\n  os: ubuntu-bionic\n  version: \"1.0\"\n",
+				newStemcell("bosh-jammy", "", "1.10"), newStemcell("bosh-bionic", "", "1.0")),
+			"cf-2": newDeployment("cf-2",
+				"name: cf-2\nstemcells:\n- alias: default\n  name: bosh-jammy\n  version: latest\n",
+				newStemcell("bosh-jammy", "", "1.9")),
+			"current": newDeployment("current",
+				"name: current\nstemcells:\n- alias: default\n  os: ubuntu-jammy\n  version: \"1.20\"\n",
+				newStemcell("bosh-jammy", "", "1.20")),
+			"other": newDeployment("other",
+				"name: other\nstemcells:\n- alias: default\n  os: ubuntu-jammy\n  version: \"1.9\"\n",
+				newStemcell("bosh-jammy", "", "1.9")),
+		}
+
+		director.DeploymentsReturns([]boshdir.Deployment{
+			deployments["cf-1"], deployments["cf-2"], deployments["current"], deployments["other"],
+		}, nil)
+	})
+
+	act := func() error { return command.Run(upgradeOpts) }
+
+	It("upgrades deployments using older stemcells to latest version keeping latest aliases", func() {
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.AskedConfirmationCalled).To(BeTrue())
+
+		manifest, updateOpts := deployments["cf-1"].UpdateArgsForCall(0)
+		Expect(string(manifest)).To(ContainSubstring("os: ubuntu-jammy\n  version: \"1.20\""))
+		Expect(string(manifest)).To(ContainSubstring("os: ubuntu-bionic\n  version: \"1.0\""))
+		Expect(updateOpts.Diff.Diff).To(Equal([][]interface{}{{"stemcells:", nil}}))
+
+		manifest, _ = deployments["cf-2"].UpdateArgsForCall(0)
+		Expect(string(manifest)).To(ContainSubstring("version: latest"))
+
+		manifest, _ = deployments["other"].UpdateArgsForCall(0)
+		Expect(string(manifest)).To(ContainSubstring("version: \"1.20\""))
+
+		Expect(deployments["current"].UpdateCallCount()).To(Equal(0))
+		Expect(deployments["current"].ManifestCallCount()).To(Equal(0))
+
+		bytes, noRedact := deployments["cf-1"].DiffArgsForCall(0)
+		Expect(bytes).To(Equal(manifestArg(deployments["cf-1"])))
+		Expect(noRedact).To(BeFalse())
+		Expect(ui.Said).To(ContainElement("Deployment 'cf-1':"))
+
+		Expect(ui.Table.Rows).To(ConsistOf(
+			[]boshtbl.Value{
+				boshtbl.NewValueString("cf-1"),
+				boshtbl.NewValueStrings([]string{"1.10"}),
+				boshtbl.NewValueString("1.20"),
+				boshtbl.NewValueFmt(boshtbl.NewValueString("upgraded"), false),
+			},
+			[]boshtbl.Value{
+				boshtbl.NewValueString("cf-2"),
+				boshtbl.NewValueStrings([]string{"1.9"}),
+				boshtbl.NewValueString("1.20"),
+				boshtbl.NewValueFmt(boshtbl.NewValueString("upgraded"), false),
+			},
+			[]boshtbl.Value{
+				boshtbl.NewValueString("other"),
+				boshtbl.NewValueStrings([]string{"1.9"}),
+				boshtbl.NewValueString("1.20"),
+				boshtbl.NewValueFmt(boshtbl.NewValueString("upgraded"), false),
+			},
+		))
+	})
+
+	It("upgrades to given version and only deployments matching pattern", func() {
+		upgradeOpts.Version = "1.10"
+		upgradeOpts.Deployments = "cf-*"
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(deployments["cf-1"].UpdateCallCount()).To(Equal(0))
+		Expect(deployments["other"].UpdateCallCount()).To(Equal(0))
+
+		manifest, _ := deployments["cf-2"].UpdateArgsForCall(0)
+		Expect(string(manifest)).To(ContainSubstring("version: latest"))
+	})
+
+	It("does not update deployments if not confirmed", func() {
+		ui.AskedConfirmationErr = errors.New("stop")
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("stop"))
+
+		Expect(deployments["cf-1"].UpdateCallCount()).To(Equal(0))
+	})
+
+	It("reports when all deployments use target stemcell", func() {
+		upgradeOpts.Deployments = "current"
+
+		err := act()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Said).To(Equal([]string{"All deployments use stemcell 'ubuntu-jammy/1.20'"}))
+		Expect(ui.AskedConfirmationCalled).To(BeFalse())
+	})
+
+	It("continues upgrading remaining deployments and returns errors of failed ones", func() {
+		upgradeOpts.Concurrency = 2
+		deployments["cf-2"].UpdateReturns(errors.New("fake-err"))
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Upgrading deployment 'cf-2': fake-err"))
+
+		Expect(deployments["cf-1"].UpdateCallCount()).To(Equal(1))
+		Expect(deployments["other"].UpdateCallCount()).To(Equal(1))
+
+		Expect(ui.Table.Rows).To(ContainElement([]boshtbl.Value{
+			boshtbl.NewValueString("cf-2"),
+			boshtbl.NewValueStrings([]string{"1.9"}),
+			boshtbl.NewValueString("1.20"),
+			boshtbl.NewValueFmt(boshtbl.NewValueString("failed"), true),
+		}))
+	})
+
+	Context("when progress file is given", func() {
+		BeforeEach(func() {
+			upgradeOpts.ProgressFile = opts.FileArg{ExpandedPath: "/progress.json"}
+		})
+
+		It("records upgraded deployments", func() {
+			deployments["other"].UpdateReturns(errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+
+			progress, err := fs.ReadFileString("/progress.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(progress).To(MatchJSON(`{"os":"ubuntu-jammy","version":"1.20","upgraded":["cf-1","cf-2"]}`))
+		})
+
+		It("skips deployments upgraded in previous run", func() {
+			err := fs.WriteFileString("/progress.json", `{"os":"ubuntu-jammy","version":"1.20","upgraded":["cf-2"]}`)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deployments["cf-2"].UpdateCallCount()).To(Equal(0))
+			Expect(deployments["cf-2"].StemcellsCallCount()).To(Equal(0))
+			Expect(ui.Said).To(ContainElement("Skipping deployment 'cf-2' since it was upgraded according to progress file"))
+
+			progress, err := fs.ReadFileString("/progress.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(progress).To(MatchJSON(`{"os":"ubuntu-jammy","version":"1.20","upgraded":["cf-2","cf-1","other"]}`))
+		})
+
+		It("returns error if progress file is for another stemcell", func() {
+			err := fs.WriteFileString("/progress.json", `{"os":"ubuntu-jammy","version":"1.10","upgraded":[]}`)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(
+				"Expected progress file '/progress.json' to be for stemcell 'ubuntu-jammy/1.20' but it is for 'ubuntu-jammy/1.10'"))
+		})
+	})
+
+	It("returns error if stemcell with OS is not uploaded", func() {
+		upgradeOpts.OS = "windows"
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected stemcell with OS 'windows' to be uploaded"))
+	})
+
+	It("returns error if given stemcell version is not uploaded", func() {
+		upgradeOpts.Version = "1.30"
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected stemcell 'ubuntu-jammy/1.30' to be uploaded"))
+	})
+
+	It("returns error if diff cannot be fetched", func() {
+		deployments["cf-1"].DiffReturns(boshdir.DeploymentDiff{}, errors.New("fake-err"))
+
+		err := act()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Diffing deployment 'cf-1': fake-err"))
+		Expect(ui.AskedConfirmationCalled).To(BeFalse())
+	})
+})
+
+func manifestArg(deployment *fakedir.FakeDeployment) []byte {
+	manifest, _ := deployment.UpdateArgsForCall(0)
+	return manifest
+}
@@ -6,6 +6,7 @@ import (
 	semver "github.com/cppforlife/go-semi-semantic/version"
 
 	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
 	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
 	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
 	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
@@ -18,6 +19,9 @@ type UploadReleaseCmd struct {
 
 	director              boshdir.Director
 	releaseArchiveFactory func(string) boshdir.ReleaseArchive
+	uploadProgressStore   boshdir.UploadProgressStore
+
+	tarballSigner bicrypto.TarballSigner
 
 	cmdRunner boshsys.CmdRunner
 	fs        boshsys.FileSystem
@@ -29,6 +33,8 @@ func NewUploadReleaseCmd(
 	releaseArchiveWriter boshrel.Writer,
 	director boshdir.Director,
 	releaseArchiveFactory func(string) boshdir.ReleaseArchive,
+	uploadProgressStore boshdir.UploadProgressStore,
+	tarballSigner bicrypto.TarballSigner,
 	cmdRunner boshsys.CmdRunner,
 	fs boshsys.FileSystem,
 	ui boshui.UI,
@@ -39,6 +45,9 @@ func NewUploadReleaseCmd(
 
 		director:              director,
 		releaseArchiveFactory: releaseArchiveFactory,
+		uploadProgressStore:   uploadProgressStore,
+
+		tarballSigner: tarballSigner,
 
 		cmdRunner: cmdRunner,
 		fs:        fs,
@@ -47,6 +56,13 @@ func NewUploadReleaseCmd(
 }
 
 func (c UploadReleaseCmd) Run(opts UploadReleaseOpts) error {
+	if len(opts.VerifyKey.ExpandedPath) > 0 {
+		err := c.verifySignature(opts)
+		if err != nil {
+			return err
+		}
+	}
+
 	switch {
 	case opts.Args.URL.IsRemote():
 		return c.uploadIfNecessary(opts, c.uploadRemote)
@@ -57,6 +73,23 @@ func (c UploadReleaseCmd) Run(opts UploadReleaseOpts) error {
 	}
 }
 
+func (c UploadReleaseCmd) verifySignature(opts UploadReleaseOpts) error {
+	path := opts.Args.URL.FilePath()
+
+	if opts.Args.URL.IsRemote() || opts.Args.URL.IsGit() || len(path) == 0 {
+		return bosherr.Error("Expected local release tarball to verify its signature")
+	}
+
+	signature, err := c.tarballSigner.Verify(opts.VerifyKey.ExpandedPath, path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Verifying release signature")
+	}
+
+	c.ui.PrintLinef("Verified signature '%s' with key '%s'", signature.Path, signature.KeyFingerprint)
+
+	return nil
+}
+
 func (c UploadReleaseCmd) uploadRemote(opts UploadReleaseOpts) error {
 	return c.director.UploadReleaseURL(string(opts.Args.URL), opts.SHA1, opts.Rebase, opts.Fix)
 }
@@ -79,6 +112,8 @@ func (c UploadReleaseCmd) uploadGit(opts UploadReleaseOpts) error {
 		Name:      opts.Name,
 		Version:   opts.Version,
 		Fix:       opts.Fix,
+		Chunked:   opts.Chunked,
+		Resume:    opts.Resume,
 	}
 
 	return c.uploadFile(newOpts)
@@ -137,6 +172,11 @@ func (c UploadReleaseCmd) uploadRelease(release boshrel.Release, opts UploadRele
 		return bosherr.WrapErrorf(err, "Opening release")
 	}
 
+	if opts.Chunked || opts.Resume {
+		uploadOpts := boshdir.ResumableUploadOpts{Resume: opts.Resume, Progress: c.uploadProgressStore}
+		return c.director.UploadReleaseFileResumable(file, opts.Rebase, opts.Fix, uploadOpts)
+	}
+
 	return c.director.UploadReleaseFile(file, opts.Rebase, opts.Fix)
 }
 
@@ -10,6 +10,8 @@ import (
 
 	"github.com/cloudfoundry/bosh-cli/v7/cmd"
 	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
+	fakecrypto "github.com/cloudfoundry/bosh-cli/v7/crypto/fakes"
 	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
 	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
 	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
@@ -29,8 +31,11 @@ var _ = Describe("UploadReleaseCmd", func() {
 		cmdRunner     *fakesys.FakeCmdRunner
 		fs            *fakesys.FakeFileSystem
 		archive       *fakedir.FakeReleaseArchive
+		tarballSigner *fakecrypto.FakeTarballSigner
 		ui            *fakeui.FakeUI
 		command       cmd.UploadReleaseCmd
+
+		uploadProgressStore *fakedir.FakeUploadProgressStore
 	)
 
 	BeforeEach(func() {
@@ -60,9 +65,11 @@ var _ = Describe("UploadReleaseCmd", func() {
 			return archive
 		}
 
+		uploadProgressStore = &fakedir.FakeUploadProgressStore{}
+		tarballSigner = fakecrypto.NewFakeTarballSigner()
 		ui = &fakeui.FakeUI{}
 
-		command = cmd.NewUploadReleaseCmd(releaseDirFactory, releaseWriter, director, releaseArchiveFactory, cmdRunner, fs, ui)
+		command = cmd.NewUploadReleaseCmd(releaseDirFactory, releaseWriter, director, releaseArchiveFactory, uploadProgressStore, tarballSigner, cmdRunner, fs, ui)
 	})
 
 	Describe("Run", func() {
@@ -96,8 +103,17 @@ var _ = Describe("UploadReleaseCmd", func() {
 				Expect(fix).To(BeFalse())
 			})
 
+			It("returns error if signature verification is requested", func() {
+				uploadReleaseOpts.VerifyKey = opts.FileArg{ExpandedPath: "/key.pem"}
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected local release tarball to verify its signature"))
+				Expect(director.UploadReleaseURLCallCount()).To(Equal(0))
+			})
+
 			It("uploads given release even if reader is nil", func() {
-				command = cmd.NewUploadReleaseCmd(nil, nil, director, nil, nil, nil, ui)
+				command = cmd.NewUploadReleaseCmd(nil, nil, director, nil, nil, nil, nil, nil, ui)
 
 				err := command.Run(uploadReleaseOpts)
 				Expect(err).ToNot(HaveOccurred())
@@ -239,8 +255,40 @@ var _ = Describe("UploadReleaseCmd", func() {
 				}
 			})
 
+			Context("when signature verification is requested", func() {
+				BeforeEach(func() {
+					uploadReleaseOpts.VerifyKey = opts.FileArg{ExpandedPath: "/key.pem"}
+					releaseReader.ReadReturns(release, nil)
+				})
+
+				It("verifies release tarball signature before uploading", func() {
+					tarballSigner.VerifySignature = bicrypto.TarballSignature{
+						Path: "./some-file.tgz.sig", KeyFingerprint: "SHA256:fp"}
+
+					err := act()
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(tarballSigner.VerifyInputs).To(Equal([]fakecrypto.TarballSignerInput{
+						{KeyPath: "/key.pem", TarballPath: "./some-file.tgz"},
+					}))
+					Expect(ui.Said).To(ContainElement("Verified signature './some-file.tgz.sig' with key 'SHA256:fp'"))
+					Expect(director.UploadReleaseFileCallCount()).To(Equal(1))
+				})
+
+				It("does not upload release if signature does not match", func() {
+					tarballSigner.VerifyErr = errors.New("fake-err")
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+					Expect(releaseReader.ReadCallCount()).To(Equal(0))
+					Expect(director.UploadReleaseFileCallCount()).To(Equal(0))
+				})
+			})
+
 			It("returns an error if reader is nil", func() {
-				command = cmd.NewUploadReleaseCmd(nil, nil, director, nil, nil, nil, ui)
+				command = cmd.NewUploadReleaseCmd(nil, nil, director, nil, nil, nil, nil, nil, ui)
 
 				err := command.Run(uploadReleaseOpts)
 				Expect(err).To(HaveOccurred())
@@ -276,6 +324,51 @@ var _ = Describe("UploadReleaseCmd", func() {
 				Expect(rebase).To(BeFalse())
 				Expect(fix).To(BeFalse())
 			})
+
+			It("uploads given release in chunks when requested", func() {
+				releaseReader.ReadReturns(release, nil)
+
+				uploadReleaseOpts.Chunked = true
+				uploadReleaseOpts.Rebase = true
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(director.UploadReleaseFileCallCount()).To(Equal(0))
+				Expect(director.UploadReleaseFileResumableCallCount()).To(Equal(1))
+
+				file, rebase, fix, uploadOpts := director.UploadReleaseFileResumableArgsForCall(0)
+				Expect(file.(*fakesys.FakeFile).Name()).To(Equal("/archive-path"))
+				Expect(rebase).To(BeTrue())
+				Expect(fix).To(BeFalse())
+				Expect(uploadOpts).To(Equal(boshdir.ResumableUploadOpts{Progress: uploadProgressStore}))
+			})
+
+			It("resumes chunked upload of given release", func() {
+				releaseReader.ReadReturns(release, nil)
+
+				uploadReleaseOpts.Resume = true
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(director.UploadReleaseFileResumableCallCount()).To(Equal(1))
+
+				_, _, _, uploadOpts := director.UploadReleaseFileResumableArgsForCall(0)
+				Expect(uploadOpts).To(Equal(boshdir.ResumableUploadOpts{Resume: true, Progress: uploadProgressStore}))
+			})
+
+			It("returns error if chunked upload fails", func() {
+				releaseReader.ReadReturns(release, nil)
+				director.UploadReleaseFileResumableReturns(errors.New("fake-err"))
+
+				uploadReleaseOpts.Chunked = true
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+
 			It("does not upload release if name and version match existing release", func() {
 				releaseReader.ReadStub = func(path string) (boshrel.Release, error) {
 					Expect(path).To(Equal("./some-file.tgz"))
@@ -430,7 +523,7 @@ var _ = Describe("UploadReleaseCmd", func() {
 			})
 
 			It("returns an error if reader is nil", func() {
-				command = cmd.NewUploadReleaseCmd(nil, nil, director, nil, cmdRunner, fs, ui)
+				command = cmd.NewUploadReleaseCmd(nil, nil, director, nil, nil, nil, cmdRunner, fs, ui)
 
 				err := command.Run(uploadReleaseOpts)
 				Expect(err).To(HaveOccurred())
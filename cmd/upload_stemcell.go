@@ -5,6 +5,7 @@ import (
 	semver "github.com/cppforlife/go-semi-semantic/version"
 
 	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
 	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
 	biui "github.com/cloudfoundry/bosh-cli/v7/ui"
 )
@@ -12,6 +13,8 @@ import (
 type UploadStemcellCmd struct {
 	director               boshdir.Director
 	stemcellArchiveFactory func(string) boshdir.StemcellArchive
+	uploadProgressStore    boshdir.UploadProgressStore
+	tarballSigner          bicrypto.TarballSigner
 
 	ui biui.UI
 }
@@ -19,21 +22,38 @@ type UploadStemcellCmd struct {
 func NewUploadStemcellCmd(
 	director boshdir.Director,
 	stemcellArchiveFactory func(string) boshdir.StemcellArchive,
+	uploadProgressStore boshdir.UploadProgressStore,
+	tarballSigner bicrypto.TarballSigner,
 	ui biui.UI,
 ) UploadStemcellCmd {
 	return UploadStemcellCmd{
 		director:               director,
 		stemcellArchiveFactory: stemcellArchiveFactory,
+		uploadProgressStore:    uploadProgressStore,
+		tarballSigner:          tarballSigner,
 		ui:                     ui,
 	}
 }
 
 func (c UploadStemcellCmd) Run(opts UploadStemcellOpts) error {
 	if opts.Args.URL.IsRemote() {
+		if len(opts.VerifyKey.ExpandedPath) > 0 {
+			return bosherr.Error("Expected local stemcell tarball to verify its signature")
+		}
+
 		return c.uploadRemote(string(opts.Args.URL), opts)
 	}
 
-	return c.uploadFile(opts.Args.URL.FilePath(), opts.Fix)
+	if len(opts.VerifyKey.ExpandedPath) > 0 {
+		signature, err := c.tarballSigner.Verify(opts.VerifyKey.ExpandedPath, opts.Args.URL.FilePath())
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Verifying stemcell signature")
+		}
+
+		c.ui.PrintLinef("Verified signature '%s' with key '%s'", signature.Path, signature.KeyFingerprint)
+	}
+
+	return c.uploadFile(opts.Args.URL.FilePath(), opts)
 }
 
 func (c UploadStemcellCmd) uploadRemote(url string, opts UploadStemcellOpts) error {
@@ -47,7 +67,7 @@ func (c UploadStemcellCmd) uploadRemote(url string, opts UploadStemcellOpts) err
 	return c.director.UploadStemcellURL(url, opts.SHA1, opts.Fix)
 }
 
-func (c UploadStemcellCmd) uploadFile(path string, fix bool) error {
+func (c UploadStemcellCmd) uploadFile(path string, opts UploadStemcellOpts) error {
 	archive := c.stemcellArchiveFactory(path)
 
 	stemcellMetadata, err := archive.Info()
@@ -55,7 +75,7 @@ func (c UploadStemcellCmd) uploadFile(path string, fix bool) error {
 		return bosherr.WrapErrorf(err, "Retrieving stemcell info")
 	}
 
-	necessary, err := c.needToUpload(stemcellMetadata.Name, stemcellMetadata.Version, fix)
+	necessary, err := c.needToUpload(stemcellMetadata.Name, stemcellMetadata.Version, opts.Fix)
 	if err != nil || !necessary {
 		return err
 	}
@@ -65,7 +85,12 @@ func (c UploadStemcellCmd) uploadFile(path string, fix bool) error {
 		return bosherr.WrapErrorf(err, "Opening stemcell")
 	}
 
-	return c.director.UploadStemcellFile(file, fix)
+	if opts.Chunked || opts.Resume {
+		uploadOpts := boshdir.ResumableUploadOpts{Resume: opts.Resume, Progress: c.uploadProgressStore}
+		return c.director.UploadStemcellFileResumable(file, opts.Fix, uploadOpts)
+	}
+
+	return c.director.UploadStemcellFile(file, opts.Fix)
 }
 
 func (c UploadStemcellCmd) needToUpload(name, version string, fix bool) (bool, error) {
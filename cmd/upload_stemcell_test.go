@@ -10,6 +10,8 @@ import (
 
 	"github.com/cloudfoundry/bosh-cli/v7/cmd"
 	"github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
+	fakecrypto "github.com/cloudfoundry/bosh-cli/v7/crypto/fakes"
 	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
 	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
 	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
@@ -20,16 +22,21 @@ var _ = Describe("UploadStemcellCmd", func() {
 		director         *fakedir.FakeDirector
 		fs               *fakesys.FakeFileSystem
 		archive          *fakedir.FakeStemcellArchive
+		tarballSigner    *fakecrypto.FakeTarballSigner
 		ui               *fakeui.FakeUI
 		command          cmd.UploadStemcellCmd
 		existingInfo     boshdir.StemcellInfo
 		existingMetadata boshdir.StemcellMetadata
+
+		uploadProgressStore *fakedir.FakeUploadProgressStore
 	)
 
 	BeforeEach(func() {
 		director = &fakedir.FakeDirector{}
 		fs = fakesys.NewFakeFileSystem()
 		archive = &fakedir.FakeStemcellArchive{}
+		tarballSigner = fakecrypto.NewFakeTarballSigner()
+		uploadProgressStore = &fakedir.FakeUploadProgressStore{}
 		ui = &fakeui.FakeUI{}
 		existingInfo = boshdir.StemcellInfo{Name: "existing-name", Version: "existing-ver"}
 		existingMetadata = boshdir.StemcellMetadata{Name: "existing-name", Version: "existing-ver"}
@@ -43,7 +50,7 @@ var _ = Describe("UploadStemcellCmd", func() {
 			return archive
 		}
 
-		command = cmd.NewUploadStemcellCmd(director, stemcellArchiveFactory, ui)
+		command = cmd.NewUploadStemcellCmd(director, stemcellArchiveFactory, uploadProgressStore, tarballSigner, ui)
 	})
 
 	Describe("Run", func() {
@@ -169,6 +176,15 @@ var _ = Describe("UploadStemcellCmd", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-err"))
 			})
+
+			It("returns error if signature verification is requested", func() {
+				uploadStemcellOpts.VerifyKey = opts.FileArg{ExpandedPath: "/key.pem"}
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected local stemcell tarball to verify its signature"))
+				Expect(director.UploadStemcellURLCallCount()).To(Equal(0))
+			})
 		})
 
 		Context("when url is a local file (file or no prefix)", func() {
@@ -188,6 +204,71 @@ var _ = Describe("UploadStemcellCmd", func() {
 				Expect(fix).To(BeFalse())
 			})
 
+			It("uploads given stemcell in chunks when requested", func() {
+				director.StemcellNeedsUploadReturns(true, nil)
+				uploadStemcellOpts.Chunked = true
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(director.UploadStemcellFileCallCount()).To(Equal(0))
+				Expect(director.UploadStemcellFileResumableCallCount()).To(Equal(1))
+
+				file, fix, uploadOpts := director.UploadStemcellFileResumableArgsForCall(0)
+				Expect(file.(*fakesys.FakeFile).Name()).To(Equal("./some-file.tgz"))
+				Expect(fix).To(BeFalse())
+				Expect(uploadOpts).To(Equal(boshdir.ResumableUploadOpts{Progress: uploadProgressStore}))
+			})
+
+			It("resumes chunked upload of given stemcell", func() {
+				director.StemcellNeedsUploadReturns(true, nil)
+				uploadStemcellOpts.Resume = true
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(director.UploadStemcellFileResumableCallCount()).To(Equal(1))
+
+				_, _, uploadOpts := director.UploadStemcellFileResumableArgsForCall(0)
+				Expect(uploadOpts).To(Equal(boshdir.ResumableUploadOpts{Resume: true, Progress: uploadProgressStore}))
+			})
+
+			It("returns error if chunked upload fails", func() {
+				director.StemcellNeedsUploadReturns(true, nil)
+				director.UploadStemcellFileResumableReturns(errors.New("fake-err"))
+				uploadStemcellOpts.Chunked = true
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+
+			It("verifies stemcell tarball signature before uploading", func() {
+				uploadStemcellOpts.VerifyKey = opts.FileArg{ExpandedPath: "/key.pem"}
+				tarballSigner.VerifySignature = bicrypto.TarballSignature{
+					Path: "./some-file.tgz.sig", KeyFingerprint: "SHA256:fp"}
+				director.StemcellNeedsUploadReturns(true, nil)
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(tarballSigner.VerifyInputs).To(Equal([]fakecrypto.TarballSignerInput{
+					{KeyPath: "/key.pem", TarballPath: "./some-file.tgz"},
+				}))
+				Expect(ui.Said).To(ContainElement("Verified signature './some-file.tgz.sig' with key 'SHA256:fp'"))
+				Expect(director.UploadStemcellFileCallCount()).To(Equal(1))
+			})
+
+			It("does not upload stemcell if signature does not match", func() {
+				uploadStemcellOpts.VerifyKey = opts.FileArg{ExpandedPath: "/key.pem"}
+				tarballSigner.VerifyErr = errors.New("fake-err")
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+				Expect(director.UploadStemcellFileCallCount()).To(Equal(0))
+			})
+
 			It("uploads given stemcell with a fix flag without checking if stemcell exists", func() {
 				director.StemcellNeedsUploadReturns(true, nil)
 				uploadStemcellOpts.Fix = true
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshval "github.com/cloudfoundry/bosh-cli/v7/release/validator"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type ValidateReleaseCmd struct {
+	validator boshval.Validator
+	ui        boshui.UI
+}
+
+func NewValidateReleaseCmd(validator boshval.Validator, ui boshui.UI) ValidateReleaseCmd {
+	return ValidateReleaseCmd{validator: validator, ui: ui}
+}
+
+func (c ValidateReleaseCmd) Run(opts ValidateReleaseOpts) error {
+	result, err := c.validator.Validate(opts.Directory.Path)
+	if err != nil {
+		return err
+	}
+
+	table := boshtbl.Table{
+		Content: "problems",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Position"),
+			boshtbl.NewHeader("Severity"),
+			boshtbl.NewHeader("Message"),
+		},
+	}
+
+	for _, problem := range result.Problems {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(problem.Position()),
+			boshtbl.NewValueFmt(boshtbl.NewValueString(string(problem.Severity)), problem.Severity == boshval.ErrorSeverity),
+			boshtbl.NewValueString(problem.Message),
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	errors := result.Count(boshval.ErrorSeverity)
+	warnings := result.Count(boshval.WarningSeverity)
+
+	if errors > 0 {
+		return bosherr.Errorf("Expected release '%s' to be valid but found %d error(s) and %d warning(s)",
+			opts.Directory.Path, errors, warnings)
+	}
+
+	return nil
+}
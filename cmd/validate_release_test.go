@@ -0,0 +1,75 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshval "github.com/cloudfoundry/bosh-cli/v7/release/validator"
+	fakeval "github.com/cloudfoundry/bosh-cli/v7/release/validator/validatorfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("ValidateReleaseCmd", func() {
+	var (
+		validator *fakeval.FakeValidator
+		ui        *fakeui.FakeUI
+		command   ValidateReleaseCmd
+		opts      ValidateReleaseOpts
+	)
+
+	BeforeEach(func() {
+		validator = &fakeval.FakeValidator{}
+		ui = &fakeui.FakeUI{}
+		command = NewValidateReleaseCmd(validator, ui)
+		opts = ValidateReleaseOpts{Directory: DirOrCWDArg{Path: "/dir"}}
+	})
+
+	It("prints problems found in release directory", func() {
+		validator.ValidateReturns(boshval.Result{Problems: []boshval.Problem{
+			{Severity: boshval.WarningSeverity, Path: "jobs/web/spec", Line: 3, Message: "warning-msg"},
+			{Severity: boshval.ErrorSeverity, Path: "jobs/web/templates/a.erb", Message: "error-msg"},
+		}}, nil)
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected release '/dir' to be valid but found 1 error(s) and 1 warning(s)"))
+
+		Expect(validator.ValidateArgsForCall(0)).To(Equal("/dir"))
+
+		Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+			{
+				boshtbl.NewValueString("jobs/web/spec:3"),
+				boshtbl.NewValueFmt(boshtbl.NewValueString("warning"), false),
+				boshtbl.NewValueString("warning-msg"),
+			},
+			{
+				boshtbl.NewValueString("jobs/web/templates/a.erb"),
+				boshtbl.NewValueFmt(boshtbl.NewValueString("error"), true),
+				boshtbl.NewValueString("error-msg"),
+			},
+		}))
+	})
+
+	It("succeeds when only warnings are found", func() {
+		validator.ValidateReturns(boshval.Result{Problems: []boshval.Problem{
+			{Severity: boshval.WarningSeverity, Path: "jobs/web/spec", Line: 3, Message: "warning-msg"},
+		}}, nil)
+
+		err := command.Run(opts)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ui.Table.Rows).To(HaveLen(1))
+	})
+
+	It("returns error if validation fails", func() {
+		validator.ValidateReturns(boshval.Result{}, errors.New("fake-err"))
+
+		err := command.Run(opts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
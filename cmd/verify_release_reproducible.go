@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts" //nolint:staticcheck
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshman "github.com/cloudfoundry/bosh-cli/v7/release/manifest"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+type VerifyReleaseReproducibleCmd struct {
+	releaseReader        boshrel.Reader
+	scratchReaderFactory func(dir DirOrCWDArg, scratchPath string) boshrel.Reader
+	releaseWriter        boshrel.Writer
+	fs                   boshsys.FileSystem
+	ui                   boshui.UI
+}
+
+func NewVerifyReleaseReproducibleCmd(
+	releaseReader boshrel.Reader,
+	scratchReaderFactory func(dir DirOrCWDArg, scratchPath string) boshrel.Reader,
+	releaseWriter boshrel.Writer,
+	fs boshsys.FileSystem,
+	ui boshui.UI,
+) VerifyReleaseReproducibleCmd {
+	return VerifyReleaseReproducibleCmd{
+		releaseReader:        releaseReader,
+		scratchReaderFactory: scratchReaderFactory,
+		releaseWriter:        releaseWriter,
+		fs:                   fs,
+		ui:                   ui,
+	}
+}
+
+func (c VerifyReleaseReproducibleCmd) Run(opts VerifyReleaseReproducibleOpts) error {
+	expected, err := c.releaseReader.Read(opts.Args.Path)
+	if err != nil {
+		return err
+	}
+
+	defer expected.CleanUp() //nolint:errcheck
+
+	if expected.IsCompiled() {
+		return bosherr.Errorf("Expected release tarball '%s' to not be compiled", opts.Args.Path)
+	}
+
+	scratchPath, err := c.fs.TempDir("bosh-verify-release-reproducible")
+	if err != nil {
+		return bosherr.WrapError(err, "Creating scratch directory")
+	}
+
+	defer c.fs.RemoveAll(scratchPath) //nolint:errcheck
+
+	rebuilt, err := c.scratchReaderFactory(opts.Directory, scratchPath).Read(opts.Directory.Path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Rebuilding release from directory '%s'", opts.Directory.Path)
+	}
+
+	defer rebuilt.CleanUp() //nolint:errcheck
+
+	expectedMan := expected.Manifest()
+
+	rebuilt.SetName(expectedMan.Name)
+	rebuilt.SetVersion(expectedMan.Version)
+	rebuilt.SetCommitHash(expectedMan.CommitHash)
+	rebuilt.SetUncommittedChanges(expectedMan.UncommittedChanges)
+	rebuilt.SetNoCompression(expectedMan.NoCompression)
+
+	table := boshtbl.Table{
+		Content: "resources",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Type"),
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Fingerprint"),
+			boshtbl.NewHeader("Result"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{
+			{Column: 0, Asc: true},
+			{Column: 1, Asc: true},
+		},
+	}
+
+	matches := true
+
+	addRow := func(typ, name, fingerprint, result string) {
+		matches = matches && result == "match"
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(typ),
+			boshtbl.NewValueString(name),
+			boshtbl.NewValueString(fingerprint),
+			boshtbl.NewValueString(result),
+		})
+	}
+
+	expectedJobs := map[string]boshman.JobRef{}
+	for _, ref := range expectedMan.Jobs {
+		expectedJobs[ref.Name] = ref
+	}
+
+	for _, job := range rebuilt.Jobs() {
+		ref, found := expectedJobs[job.Name()]
+		delete(expectedJobs, job.Name())
+		addRow("job", job.Name(), job.Fingerprint(), c.compare(found, ref.Fingerprint, ref.SHA1, job.Fingerprint(), job.ArchivePath()))
+	}
+
+	for name, ref := range expectedJobs {
+		addRow("job", name, ref.Fingerprint, "missing in release directory")
+	}
+
+	expectedPkgs := map[string]boshman.PackageRef{}
+	for _, ref := range expectedMan.Packages {
+		expectedPkgs[ref.Name] = ref
+	}
+
+	for _, pkg := range rebuilt.Packages() {
+		ref, found := expectedPkgs[pkg.Name()]
+		delete(expectedPkgs, pkg.Name())
+		addRow("package", pkg.Name(), pkg.Fingerprint(), c.compare(found, ref.Fingerprint, ref.SHA1, pkg.Fingerprint(), pkg.ArchivePath()))
+	}
+
+	for name, ref := range expectedPkgs {
+		addRow("package", name, ref.Fingerprint, "missing in release directory")
+	}
+
+	if lic := rebuilt.License(); lic != nil {
+		ref := expectedMan.License
+		if ref == nil {
+			ref = &boshman.LicenseRef{}
+		}
+		addRow("license", lic.Name(), lic.Fingerprint(), c.compare(expectedMan.License != nil, ref.Fingerprint, ref.SHA1, lic.Fingerprint(), lic.ArchivePath()))
+	} else if expectedMan.License != nil {
+		addRow("license", "license", expectedMan.License.Fingerprint, "missing in release directory")
+	}
+
+	c.ui.PrintTable(table)
+
+	if !matches {
+		return bosherr.Errorf("Expected release tarball '%s' to be reproducible from '%s'", opts.Args.Path, opts.Directory.Path)
+	}
+
+	rebuiltPath, err := c.releaseWriter.Write(rebuilt, nil)
+	if err != nil {
+		return bosherr.WrapError(err, "Writing rebuilt release tarball")
+	}
+
+	defer c.fs.RemoveAll(rebuiltPath) //nolint:errcheck
+
+	expectedDigest, err := c.digest(opts.Args.Path)
+	if err != nil {
+		return err
+	}
+
+	rebuiltDigest, err := c.digest(rebuiltPath)
+	if err != nil {
+		return err
+	}
+
+	if expectedDigest.String() != rebuiltDigest.String() {
+		return bosherr.Errorf("Expected rebuilt release tarball digest '%s' to match '%s' of '%s'",
+			rebuiltDigest.String(), expectedDigest.String(), opts.Args.Path)
+	}
+
+	c.ui.PrintLinef("Release tarball '%s' is reproducible (%s)", opts.Args.Path, expectedDigest.String())
+
+	return nil
+}
+
+func (c VerifyReleaseReproducibleCmd) compare(found bool, expectedFp, expectedDigest, rebuiltFp, rebuiltPath string) string {
+	if !found {
+		return "missing in release tarball"
+	}
+
+	if expectedFp != rebuiltFp {
+		return "fingerprint differs"
+	}
+
+	digest, err := boshcrypto.ParseMultipleDigest(expectedDigest)
+	if err != nil {
+		return "invalid digest in release tarball"
+	}
+
+	if digest.VerifyFilePath(rebuiltPath, c.fs) != nil {
+		return "archive differs"
+	}
+
+	return "match"
+}
+
+func (c VerifyReleaseReproducibleCmd) digest(path string) (boshcrypto.MultipleDigest, error) {
+	digest, err := boshcrypto.NewMultipleDigestFromPath(path, c.fs, []boshcrypto.Algorithm{boshcrypto.DigestAlgorithmSHA256})
+	if err != nil {
+		return boshcrypto.MultipleDigest{}, bosherr.WrapErrorf(err, "Calculating digest of '%s'", path)
+	}
+
+	return digest, nil
+}
@@ -0,0 +1,233 @@
+package cmd_test
+
+import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd"
+	. "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/v7/release/job"
+	boshman "github.com/cloudfoundry/bosh-cli/v7/release/manifest"
+	boshpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
+	. "github.com/cloudfoundry/bosh-cli/v7/release/resource"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("VerifyReleaseReproducibleCmd", func() {
+	var (
+		releaseReader *fakerel.FakeReader
+		scratchReader *fakerel.FakeReader
+		releaseWriter *fakerel.FakeWriter
+		fs            *fakesys.FakeFileSystem
+		ui            *fakeui.FakeUI
+		command       VerifyReleaseReproducibleCmd
+
+		scratchDirs []DirOrCWDArg
+		scratchPath string
+
+		expected *fakerel.FakeRelease
+		rebuilt  *fakerel.FakeRelease
+		pkg      *boshpkg.Package
+
+		verifyOpts VerifyReleaseReproducibleOpts
+	)
+
+	sha1Of := func(content string) string {
+		return fmt.Sprintf("%x", sha1.Sum([]byte(content)))
+	}
+
+	BeforeEach(func() {
+		releaseReader = &fakerel.FakeReader{}
+		scratchReader = &fakerel.FakeReader{}
+		releaseWriter = &fakerel.FakeWriter{}
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+
+		scratchDirs = nil
+		scratchPath = ""
+
+		scratchReaderFactory := func(dir DirOrCWDArg, path string) boshrel.Reader {
+			scratchDirs = append(scratchDirs, dir)
+			scratchPath = path
+			return scratchReader
+		}
+
+		command = NewVerifyReleaseReproducibleCmd(releaseReader, scratchReaderFactory, releaseWriter, fs, ui)
+
+		Expect(fs.WriteFileString("/job.tgz", "job-archive")).To(Succeed())
+		Expect(fs.WriteFileString("/pkg.tgz", "pkg-archive")).To(Succeed())
+		Expect(fs.WriteFileString("/release.tgz", "release-archive")).To(Succeed())
+		Expect(fs.WriteFileString("/rebuilt.tgz", "release-archive")).To(Succeed())
+
+		expected = &fakerel.FakeRelease{}
+		expected.ManifestReturns(boshman.Manifest{
+			Name:               "rel",
+			Version:            "1.0",
+			CommitHash:         "abc123",
+			UncommittedChanges: true,
+			NoCompression:      true,
+			Jobs: []boshman.JobRef{
+				{Name: "job", Fingerprint: "job-fp", SHA1: sha1Of("job-archive")},
+			},
+			Packages: []boshman.PackageRef{
+				{
+					Name:        "pkg",
+					Fingerprint: "pkg-fp",
+					SHA1:        sha1Of("pkg-archive"),
+				},
+			},
+		})
+		releaseReader.ReadReturns(expected, nil)
+
+		pkg = boshpkg.NewPackage(NewResourceWithBuiltArchive("pkg", "pkg-fp", "/pkg.tgz", "rebuilt-pkg-sha1"), nil)
+
+		rebuilt = &fakerel.FakeRelease{}
+		rebuilt.JobsReturns([]*boshjob.Job{
+			boshjob.NewJob(NewResourceWithBuiltArchive("job", "job-fp", "/job.tgz", "rebuilt-job-sha1")),
+		})
+		rebuilt.PackagesReturns([]*boshpkg.Package{pkg})
+		scratchReader.ReadReturns(rebuilt, nil)
+
+		releaseWriter.WriteReturns("/rebuilt.tgz", nil)
+
+		verifyOpts = VerifyReleaseReproducibleOpts{
+			Args:      VerifyReleaseReproducibleArgs{Path: "/release.tgz"},
+			Directory: DirOrCWDArg{Path: "/dir"},
+		}
+	})
+
+	It("rebuilds release from source with release tarball metadata and compares tarballs", func() {
+		err := command.Run(verifyOpts)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/release.tgz"))
+
+		Expect(scratchDirs).To(Equal([]DirOrCWDArg{{Path: "/dir"}}))
+		Expect(scratchPath).ToNot(BeEmpty())
+		Expect(scratchReader.ReadArgsForCall(0)).To(Equal("/dir"))
+
+		Expect(rebuilt.SetNameArgsForCall(0)).To(Equal("rel"))
+		Expect(rebuilt.SetVersionArgsForCall(0)).To(Equal("1.0"))
+		Expect(rebuilt.SetCommitHashArgsForCall(0)).To(Equal("abc123"))
+		Expect(rebuilt.SetUncommittedChangesArgsForCall(0)).To(BeTrue())
+		Expect(rebuilt.SetNoCompressionArgsForCall(0)).To(BeTrue())
+
+		writtenRel, skipped := releaseWriter.WriteArgsForCall(0)
+		Expect(writtenRel).To(Equal(rebuilt))
+		Expect(skipped).To(BeEmpty())
+
+		Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+			{
+				boshtbl.NewValueString("job"),
+				boshtbl.NewValueString("job"),
+				boshtbl.NewValueString("job-fp"),
+				boshtbl.NewValueString("match"),
+			},
+			{
+				boshtbl.NewValueString("package"),
+				boshtbl.NewValueString("pkg"),
+				boshtbl.NewValueString("pkg-fp"),
+				boshtbl.NewValueString("match"),
+			},
+		}))
+
+		Expect(ui.Said).To(ContainElement(ContainSubstring("Release tarball '/release.tgz' is reproducible (sha256:")))
+
+		Expect(fs.FileExists(scratchPath)).To(BeFalse())
+		Expect(fs.FileExists("/rebuilt.tgz")).To(BeFalse())
+		Expect(expected.CleanUpCallCount()).To(Equal(1))
+		Expect(rebuilt.CleanUpCallCount()).To(Equal(1))
+	})
+
+	It("returns error if rebuilt release tarball differs", func() {
+		Expect(fs.WriteFileString("/rebuilt.tgz", "other-archive")).To(Succeed())
+
+		err := command.Run(verifyOpts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Expected rebuilt release tarball digest 'sha256:"))
+		Expect(err.Error()).To(ContainSubstring("of '/release.tgz'"))
+	})
+
+	It("reports differing archives and fingerprints without writing release tarball", func() {
+		Expect(fs.WriteFileString("/job.tgz", "other-job-archive")).To(Succeed())
+
+		rebuilt.PackagesReturns([]*boshpkg.Package{
+			boshpkg.NewPackage(NewResourceWithBuiltArchive("pkg", "other-pkg-fp", "/pkg.tgz", "sha1"), nil),
+		})
+
+		err := command.Run(verifyOpts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected release tarball '/release.tgz' to be reproducible from '/dir'"))
+
+		Expect(ui.Table.Rows[0][3]).To(Equal(boshtbl.NewValueString("archive differs")))
+		Expect(ui.Table.Rows[1][3]).To(Equal(boshtbl.NewValueString("fingerprint differs")))
+
+		Expect(releaseWriter.WriteCallCount()).To(Equal(0))
+	})
+
+	It("reports jobs and packages that are only present on one side", func() {
+		rebuilt.JobsReturns([]*boshjob.Job{
+			boshjob.NewJob(NewResourceWithBuiltArchive("other-job", "other-job-fp", "/job.tgz", "sha1")),
+		})
+
+		err := command.Run(verifyOpts)
+		Expect(err).To(HaveOccurred())
+
+		Expect(ui.Table.Rows).To(ContainElement([]boshtbl.Value{
+			boshtbl.NewValueString("job"),
+			boshtbl.NewValueString("other-job"),
+			boshtbl.NewValueString("other-job-fp"),
+			boshtbl.NewValueString("missing in release tarball"),
+		}))
+
+		Expect(ui.Table.Rows).To(ContainElement([]boshtbl.Value{
+			boshtbl.NewValueString("job"),
+			boshtbl.NewValueString("job"),
+			boshtbl.NewValueString("job-fp"),
+			boshtbl.NewValueString("missing in release directory"),
+		}))
+	})
+
+	It("returns error if release tarball is compiled", func() {
+		expected.IsCompiledReturns(true)
+
+		err := command.Run(verifyOpts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected release tarball '/release.tgz' to not be compiled"))
+
+		Expect(scratchReader.ReadCallCount()).To(Equal(0))
+	})
+
+	It("returns error if reading release tarball fails", func() {
+		releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+		err := command.Run(verifyOpts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+
+	It("returns error if rebuilding release fails", func() {
+		scratchReader.ReadReturns(nil, errors.New("fake-err"))
+
+		err := command.Run(verifyOpts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Rebuilding release from directory '/dir'"))
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+
+	It("returns error if writing rebuilt release fails", func() {
+		releaseWriter.WriteReturns("", errors.New("fake-err"))
+
+		err := command.Run(verifyOpts)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
@@ -11,6 +11,7 @@ type DiskRepo interface {
 	FindCurrent() (DiskRecord, bool, error)
 	ClearCurrent() error
 	Save(cid string, size int, cloudProperties biproperty.Map) (DiskRecord, error)
+	Update(DiskRecord) error
 	Find(cid string) (DiskRecord, bool, error)
 	All() ([]DiskRecord, error)
 	Delete(DiskRecord) error
@@ -59,6 +60,33 @@ func (r diskRepo) Save(cid string, size int, cloudProperties biproperty.Map) (Di
 	return newRecord, nil
 }
 
+// Update replaces the record which has the same ID as diskRecord
+func (r diskRepo) Update(diskRecord DiskRecord) error {
+	config, records, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, record := range records {
+		if record.ID == diskRecord.ID {
+			records[i] = diskRecord
+			found = true
+		}
+	}
+	if !found {
+		return bosherr.Errorf("Verifying disk record exists with id '%s'", diskRecord.ID)
+	}
+
+	config.Disks = records
+
+	err = r.deploymentStateService.Save(config)
+	if err != nil {
+		return bosherr.WrapError(err, "Saving new config")
+	}
+	return nil
+}
+
 func (r diskRepo) FindCurrent() (DiskRecord, bool, error) {
 	deploymentState, err := r.deploymentStateService.Load()
 	if err != nil {
@@ -60,6 +60,28 @@ var _ = Describe("DiskRepo", func() {
 		})
 	})
 
+	Describe("Update", func() {
+		It("replaces the disk record with the same ID", func() {
+			record, err := repo.Save("fake-cid", 1024, cloudProperties)
+			Expect(err).ToNot(HaveOccurred())
+
+			record.CID = "fake-new-cid"
+			record.Size = 2048
+			err = repo.Update(record)
+			Expect(err).ToNot(HaveOccurred())
+
+			disks, err := repo.All()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(disks).To(Equal([]DiskRecord{record}))
+		})
+
+		It("returns an error when the disk record does not exist", func() {
+			err := repo.Update(DiskRecord{ID: "fake-unknown-id"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Verifying disk record exists with id 'fake-unknown-id'"))
+		})
+	})
+
 	Describe("Find", func() {
 		It("finds existing disk records", func() {
 			savedRecord, err := repo.Save("fake-cid", 1024, cloudProperties)
@@ -15,6 +15,9 @@ type FakeDiskRepo struct {
 	SaveInputs []DiskRepoSaveInput
 	saveOutput diskRepoSaveOutput
 
+	UpdateInputs []DiskRepoUpdateInput
+	UpdateErr    error
+
 	findOutput map[string]diskRepoFindOutput
 
 	DeleteInputs []DiskRepoDeleteInput
@@ -44,6 +47,10 @@ type diskRepoSaveOutput struct {
 	err        error
 }
 
+type DiskRepoUpdateInput struct {
+	DiskRecord biconfig.DiskRecord
+}
+
 type DiskRepoDeleteInput struct {
 	DiskRecord biconfig.DiskRecord
 }
@@ -63,6 +70,7 @@ func NewFakeDiskRepo() *FakeDiskRepo {
 	return &FakeDiskRepo{
 		UpdateCurrentInputs: []DiskRepoUpdateCurrentInput{},
 		SaveInputs:          []DiskRepoSaveInput{},
+		UpdateInputs:        []DiskRepoUpdateInput{},
 		DeleteInputs:        []DiskRepoDeleteInput{},
 		findOutput:          map[string]diskRepoFindOutput{},
 	}
@@ -93,6 +101,14 @@ func (r *FakeDiskRepo) Save(cid string, size int, cloudProperties biproperty.Map
 	return r.saveOutput.diskRecord, r.saveOutput.err
 }
 
+func (r *FakeDiskRepo) Update(diskRecord biconfig.DiskRecord) error {
+	r.UpdateInputs = append(r.UpdateInputs, DiskRepoUpdateInput{
+		DiskRecord: diskRecord,
+	})
+
+	return r.UpdateErr
+}
+
 func (r *FakeDiskRepo) Find(cid string) (biconfig.DiskRecord, bool, error) {
 	return r.findOutput[cid].diskRecord, r.findOutput[cid].found, r.findOutput[cid].err
 }
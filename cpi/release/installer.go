@@ -19,6 +19,10 @@ type CpiInstaller struct {
 }
 
 func (i CpiInstaller) ValidateCpiRelease(installationManifest biinstallmanifest.Manifest, stage biui.Stage) error {
+	if installationManifest.CPI != "" {
+		return nil
+	}
+
 	return stage.Perform("Validating cpi release", func() error {
 		var (
 			errs                   []error
@@ -76,6 +80,11 @@ func (i CpiInstaller) installCpiRelease(installer biinstall.Installer, installat
 }
 
 func (i CpiInstaller) WithInstalledCpiRelease(installationManifest biinstallmanifest.Manifest, target biinstall.Target, stage biui.Stage, fn func(biinstall.Installation) error) (errToReturn error) {
+	if installationManifest.CPI != "" {
+		// Built-in CPIs run in-process so there is nothing to install
+		return fn(biinstall.NewInstallation(target, nil, installationManifest))
+	}
+
 	installer := i.InstallerFactory.NewInstaller(target)
 
 	installation, err := i.installCpiRelease(installer, installationManifest, target, stage)
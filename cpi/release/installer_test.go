@@ -16,6 +16,49 @@ import (
 )
 
 var _ = Describe("Installer", func() {
+	Context("when built-in CPI is used", func() {
+		var (
+			mockCtrl             *gomock.Controller
+			mockInstallerFactory *mocks.MockInstallerFactory
+			installationManifest biinstallationmanifest.Manifest
+			cpiInstaller         release.CpiInstaller
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockInstallerFactory = mocks.NewMockInstallerFactory(mockCtrl)
+			installationManifest = biinstallationmanifest.Manifest{CPI: biinstallationmanifest.FakeCPI}
+			cpiInstaller = release.CpiInstaller{InstallerFactory: mockInstallerFactory}
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("does not validate CPI release", func() {
+			stage := fakeui.NewFakeStage()
+
+			err := cpiInstaller.ValidateCpiRelease(installationManifest, stage)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stage.PerformCalls).To(BeEmpty())
+		})
+
+		It("calls the passed in function without installing CPI release", func() {
+			target := biinstallation.NewTarget("fake-installation-path", "")
+
+			var installation biinstallation.Installation
+			err := cpiInstaller.WithInstalledCpiRelease(installationManifest, target, fakeui.NewFakeStage(), func(i biinstallation.Installation) error {
+				installation = i
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(installation.Target()).To(Equal(target))
+			Expect(installation.Jobs()).To(BeEmpty())
+			Expect(installation.Manifest()).To(Equal(installationManifest))
+		})
+	})
+
 	Describe("WithInstalledCpiRelease", func() {
 		var (
 			mockCtrl             *gomock.Controller
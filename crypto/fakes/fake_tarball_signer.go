@@ -0,0 +1,34 @@
+package fakes
+
+import (
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
+)
+
+type FakeTarballSigner struct {
+	SignInputs    []TarballSignerInput
+	SignSignature bicrypto.TarballSignature
+	SignErr       error
+
+	VerifyInputs    []TarballSignerInput
+	VerifySignature bicrypto.TarballSignature
+	VerifyErr       error
+}
+
+type TarballSignerInput struct {
+	KeyPath     string
+	TarballPath string
+}
+
+func NewFakeTarballSigner() *FakeTarballSigner {
+	return &FakeTarballSigner{}
+}
+
+func (s *FakeTarballSigner) Sign(privateKeyPath, tarballPath string) (bicrypto.TarballSignature, error) {
+	s.SignInputs = append(s.SignInputs, TarballSignerInput{KeyPath: privateKeyPath, TarballPath: tarballPath})
+	return s.SignSignature, s.SignErr
+}
+
+func (s *FakeTarballSigner) Verify(publicKeyPath, tarballPath string) (bicrypto.TarballSignature, error) {
+	s.VerifyInputs = append(s.VerifyInputs, TarballSignerInput{KeyPath: publicKeyPath, TarballPath: tarballPath})
+	return s.VerifySignature, s.VerifyErr
+}
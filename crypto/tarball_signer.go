@@ -0,0 +1,248 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+const signatureExtension = ".sig"
+
+// TarballSigner produces and checks detached signatures of release and stemcell tarballs.
+// Signatures are base64 encoded and kept next to the tarball in a file with .sig extension.
+// ECDSA signatures are made over SHA256 digest (same as cosign sign-blob),
+// RSA signatures use PKCS1v15 over SHA256 digest and Ed25519 signatures use Ed25519ph.
+type TarballSigner interface {
+	Sign(privateKeyPath, tarballPath string) (TarballSignature, error)
+	Verify(publicKeyPath, tarballPath string) (TarballSignature, error)
+}
+
+type TarballSignature struct {
+	Path           string
+	KeyFingerprint string
+	Value          string
+}
+
+func SignaturePath(tarballPath string) string {
+	return tarballPath + signatureExtension
+}
+
+type tarballSigner struct {
+	fs boshsys.FileSystem
+}
+
+func NewTarballSigner(fs boshsys.FileSystem) TarballSigner {
+	return tarballSigner{fs: fs}
+}
+
+func (s tarballSigner) Sign(privateKeyPath, tarballPath string) (TarballSignature, error) {
+	key, err := s.readPrivateKey(privateKeyPath)
+	if err != nil {
+		return TarballSignature{}, err
+	}
+
+	fingerprint, err := keyFingerprint(key.Public())
+	if err != nil {
+		return TarballSignature{}, err
+	}
+
+	digest, opts, err := s.digest(key.Public(), tarballPath)
+	if err != nil {
+		return TarballSignature{}, err
+	}
+
+	sig, err := key.Sign(rand.Reader, digest, opts)
+	if err != nil {
+		return TarballSignature{}, bosherr.WrapErrorf(err, "Signing '%s'", tarballPath)
+	}
+
+	signature := TarballSignature{
+		Path:           SignaturePath(tarballPath),
+		KeyFingerprint: fingerprint,
+		Value:          base64.StdEncoding.EncodeToString(sig),
+	}
+
+	err = s.fs.WriteFileString(signature.Path, signature.Value+"\n")
+	if err != nil {
+		return TarballSignature{}, bosherr.WrapErrorf(err, "Writing signature '%s'", signature.Path)
+	}
+
+	return signature, nil
+}
+
+func (s tarballSigner) Verify(publicKeyPath, tarballPath string) (TarballSignature, error) {
+	key, err := s.readPublicKey(publicKeyPath)
+	if err != nil {
+		return TarballSignature{}, err
+	}
+
+	fingerprint, err := keyFingerprint(key)
+	if err != nil {
+		return TarballSignature{}, err
+	}
+
+	signature := TarballSignature{
+		Path:           SignaturePath(tarballPath),
+		KeyFingerprint: fingerprint,
+	}
+
+	if !s.fs.FileExists(signature.Path) {
+		return signature, bosherr.Errorf("Expected signature '%s' to exist for '%s'", signature.Path, tarballPath)
+	}
+
+	contents, err := s.fs.ReadFileString(signature.Path)
+	if err != nil {
+		return signature, bosherr.WrapErrorf(err, "Reading signature '%s'", signature.Path)
+	}
+
+	signature.Value = strings.TrimSpace(contents)
+
+	sig, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil {
+		return signature, bosherr.WrapErrorf(err, "Decoding signature '%s'", signature.Path)
+	}
+
+	digest, opts, err := s.digest(key, tarballPath)
+	if err != nil {
+		return signature, err
+	}
+
+	var valid bool
+
+	switch pub := key.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.VerifyWithOptions(pub, digest, sig, opts.(*ed25519.Options)) == nil
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(pub, digest, sig)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest, sig) == nil
+	}
+
+	if !valid {
+		return signature, bosherr.Errorf(
+			"Signature '%s' does not match '%s' for key '%s'", signature.Path, tarballPath, fingerprint)
+	}
+
+	return signature, nil
+}
+
+func (s tarballSigner) digest(key crypto.PublicKey, path string) ([]byte, crypto.SignerOpts, error) {
+	var h hash.Hash
+	var opts crypto.SignerOpts
+
+	switch key.(type) {
+	case ed25519.PublicKey:
+		h, opts = crypto.SHA512.New(), &ed25519.Options{Hash: crypto.SHA512}
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		h, opts = sha256.New(), crypto.SHA256
+	default:
+		return nil, nil, bosherr.Errorf("Unsupported key type '%T'", key)
+	}
+
+	file, err := s.fs.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, nil, bosherr.WrapErrorf(err, "Opening '%s'", path)
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	_, err = io.Copy(h, file)
+	if err != nil {
+		return nil, nil, bosherr.WrapErrorf(err, "Reading '%s'", path)
+	}
+
+	return h.Sum(nil), opts, nil
+}
+
+func (s tarballSigner) readPrivateKey(path string) (crypto.Signer, error) {
+	block, err := s.readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var key interface{}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, bosherr.Errorf("Expected private key in '%s' but found '%s'", path, block.Type)
+	}
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Parsing private key '%s'", path)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, bosherr.Errorf("Unsupported private key type '%T' in '%s'", key, path)
+	}
+
+	return signer, nil
+}
+
+func (s tarballSigner) readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := s.readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Parsing public key '%s'", path)
+		}
+		return key, nil
+
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Parsing certificate '%s'", path)
+		}
+		return cert.PublicKey, nil
+
+	default:
+		return nil, bosherr.Errorf("Expected public key or certificate in '%s' but found '%s'", path, block.Type)
+	}
+}
+
+func (s tarballSigner) readPEM(path string) (*pem.Block, error) {
+	bytes, err := s.fs.ReadFile(path)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Reading key '%s'", path)
+	}
+
+	block, _ := pem.Decode(bytes)
+	if block == nil {
+		return nil, bosherr.Errorf("Expected key '%s' to be PEM encoded", path)
+	}
+
+	return block, nil
+}
+
+func keyFingerprint(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", bosherr.WrapError(err, "Marshalling public key")
+	}
+
+	return fmt.Sprintf("SHA256:%x", sha256.Sum256(der)), nil
+}
@@ -0,0 +1,189 @@
+package crypto_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/crypto"
+)
+
+var _ = Describe("TarballSigner", func() {
+	var (
+		fs     *fakesys.FakeFileSystem
+		signer TarballSigner
+	)
+
+	writeKeys := func(key crypto.Signer) {
+		privDER, err := x509.MarshalPKCS8PrivateKey(key)
+		Expect(err).ToNot(HaveOccurred())
+
+		pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+		Expect(err).ToNot(HaveOccurred())
+
+		err = fs.WriteFile("/private.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = fs.WriteFile("/public.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	fingerprint := func(key crypto.Signer) string {
+		pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+		Expect(err).ToNot(HaveOccurred())
+		return fmt.Sprintf("SHA256:%x", sha256.Sum256(pubDER))
+	}
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		signer = NewTarballSigner(fs)
+
+		err := fs.WriteFileString("/release.tgz", "release-contents")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	ed25519Key := func() crypto.Signer {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		return key
+	}
+
+	ecdsaKey := func() crypto.Signer {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		return key
+	}
+
+	rsaKey := func() crypto.Signer {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+		return key
+	}
+
+	DescribeTable("signs and verifies tarballs",
+		func(keyFunc func() crypto.Signer) {
+			key := keyFunc()
+			writeKeys(key)
+
+			signature, err := signer.Sign("/private.pem", "/release.tgz")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(signature.Path).To(Equal("/release.tgz.sig"))
+			Expect(signature.KeyFingerprint).To(Equal(fingerprint(key)))
+			Expect(fs.ReadFileString("/release.tgz.sig")).To(Equal(signature.Value + "\n"))
+
+			verified, err := signer.Verify("/public.pem", "/release.tgz")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(verified).To(Equal(signature))
+
+			err = fs.WriteFileString("/release.tgz", "tampered-contents")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = signer.Verify("/public.pem", "/release.tgz")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Signature '/release.tgz.sig' does not match '/release.tgz'"))
+		},
+		Entry("ed25519", ed25519Key),
+		Entry("ecdsa", ecdsaKey),
+		Entry("rsa", rsaKey),
+	)
+
+	It("verifies ECDSA signatures made over SHA256 digest of tarball", func() {
+		key := ecdsaKey()
+		writeKeys(key)
+
+		digest := sha256.Sum256([]byte("release-contents"))
+		sig, err := ecdsa.SignASN1(rand.Reader, key.(*ecdsa.PrivateKey), digest[:])
+		Expect(err).ToNot(HaveOccurred())
+
+		err = fs.WriteFileString("/release.tgz.sig", base64.StdEncoding.EncodeToString(sig))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = signer.Verify("/public.pem", "/release.tgz")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("verifies signatures with public key from certificate", func() {
+		key := ecdsaKey()
+		writeKeys(key)
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "release-signer"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+
+		certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = fs.WriteFile("/cert.pem", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = signer.Sign("/private.pem", "/release.tgz")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = signer.Verify("/cert.pem", "/release.tgz")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("returns error if signature was made with another key", func() {
+		writeKeys(ed25519Key())
+
+		_, err := signer.Sign("/private.pem", "/release.tgz")
+		Expect(err).ToNot(HaveOccurred())
+
+		writeKeys(ed25519Key())
+
+		_, err = signer.Verify("/public.pem", "/release.tgz")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("does not match"))
+	})
+
+	It("returns error if signature file is missing", func() {
+		writeKeys(ed25519Key())
+
+		_, err := signer.Verify("/public.pem", "/release.tgz")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected signature '/release.tgz.sig' to exist for '/release.tgz'"))
+	})
+
+	It("returns error if key is not PEM encoded", func() {
+		err := fs.WriteFileString("/private.pem", "not-a-key")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = signer.Sign("/private.pem", "/release.tgz")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected key '/private.pem' to be PEM encoded"))
+	})
+
+	It("returns error if public key is given for signing", func() {
+		writeKeys(ed25519Key())
+
+		_, err := signer.Sign("/public.pem", "/release.tgz")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected private key in '/public.pem' but found 'PUBLIC KEY'"))
+	})
+
+	It("returns error if private key is given for verification", func() {
+		writeKeys(ed25519Key())
+
+		_, err := signer.Verify("/private.pem", "/release.tgz")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected public key or certificate in '/private.pem' but found 'PRIVATE KEY'"))
+	})
+})
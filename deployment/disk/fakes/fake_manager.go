@@ -11,6 +11,11 @@ type FakeManager struct {
 	CreateDisk   bidisk.Disk
 	CreateErr    error
 
+	UpdateInputs  []UpdateInput
+	UpdateDisk    bidisk.Disk
+	UpdateUpdated bool
+	UpdateErr     error
+
 	findCurrentOutput findCurrentOutput
 
 	DeleteUnusedCalledTimes int
@@ -24,6 +29,11 @@ type CreateInput struct {
 	InstanceID string
 }
 
+type UpdateInput struct {
+	Disk     bidisk.Disk
+	DiskPool bideplmanifest.DiskPool
+}
+
 type findCurrentOutput struct {
 	Disks []bidisk.Disk
 	Err   error
@@ -48,6 +58,15 @@ func (m *FakeManager) Create(diskPool bideplmanifest.DiskPool, instanceID string
 	return m.CreateDisk, m.CreateErr
 }
 
+func (m *FakeManager) Update(disk bidisk.Disk, diskPool bideplmanifest.DiskPool) (bidisk.Disk, bool, error) {
+	m.UpdateInputs = append(m.UpdateInputs, UpdateInput{
+		Disk:     disk,
+		DiskPool: diskPool,
+	})
+
+	return m.UpdateDisk, m.UpdateUpdated, m.UpdateErr
+}
+
 func (m *FakeManager) FindCurrent() ([]bidisk.Disk, error) {
 	return m.findCurrentOutput.Disks, m.findCurrentOutput.Err
 }
@@ -1,11 +1,13 @@
 package disk
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
 
 	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
 	biconfig "github.com/cloudfoundry/bosh-cli/v7/config"
@@ -16,6 +18,7 @@ import (
 type Manager interface {
 	FindCurrent() ([]Disk, error)
 	Create(bideplmanifest.DiskPool, string) (Disk, error)
+	Update(Disk, bideplmanifest.DiskPool) (Disk, bool, error)
 	FindUnused() ([]Disk, error)
 	DeleteUnused(biui.Stage) error
 }
@@ -79,6 +82,58 @@ func (m *manager) Create(diskPool bideplmanifest.DiskPool, vmCID string) (Disk,
 	return disk, nil
 }
 
+// Update changes the size and cloud properties of a detached disk using the CPI's
+// resize_disk or update_disk method. It returns false when the CPI does not
+// implement the needed method, so that the caller can migrate the data instead.
+func (m *manager) Update(disk Disk, diskPool bideplmanifest.DiskPool) (Disk, bool, error) {
+	diskRecord, found, err := m.diskRepo.Find(disk.CID())
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Finding disk record (cid=%s)", disk.CID())
+	}
+	if !found {
+		return nil, false, bosherr.Errorf("Disk record for disk '%s' not found", disk.CID())
+	}
+
+	// IaaS disks can not be shrunk in place
+	if diskPool.DiskSize < diskRecord.Size {
+		return disk, false, nil
+	}
+
+	cloudPropertiesChanged, err := m.cloudPropertiesChanged(diskRecord.CloudProperties, diskPool.CloudProperties)
+	if err != nil {
+		return nil, false, err
+	}
+
+	newCID := diskRecord.CID
+
+	if !cloudPropertiesChanged {
+		m.logger.Debug(m.logTag, "Resizing disk '%s'", diskRecord.CID)
+		err = m.cloud.ResizeDisk(diskRecord.CID, diskPool.DiskSize)
+	} else {
+		m.logger.Debug(m.logTag, "Updating disk '%s'", diskRecord.CID)
+		newCID, err = m.cloud.UpdateDisk(diskRecord.CID, diskPool.DiskSize, diskPool.CloudProperties)
+	}
+	if err != nil {
+		var cloudErr bicloud.Error
+		if errors.As(err, &cloudErr) && cloudErr.Type() == bicloud.NotImplementedError {
+			m.logger.Info(m.logTag, "CPI does not support changing disk '%s' natively: %s", diskRecord.CID, err)
+			return disk, false, nil
+		}
+		return nil, false, bosherr.WrapErrorf(err, "Updating disk '%s'", diskRecord.CID)
+	}
+
+	diskRecord.CID = newCID
+	diskRecord.Size = diskPool.DiskSize
+	diskRecord.CloudProperties = diskPool.CloudProperties
+
+	err = m.diskRepo.Update(diskRecord)
+	if err != nil {
+		return nil, false, bosherr.WrapError(err, "Updating deployment disk record")
+	}
+
+	return NewDisk(diskRecord, m.cloud, m.diskRepo), true, nil
+}
+
 func (m *manager) FindUnused() ([]Disk, error) {
 	disks := []Disk{}
 
@@ -125,3 +180,16 @@ func (m *manager) DeleteUnused(eventLoggerStage biui.Stage) error {
 
 	return nil
 }
+
+func (m *manager) cloudPropertiesChanged(current, desired biproperty.Map) (bool, error) {
+	currentBytes, err := json.Marshal(current)
+	if err != nil {
+		return false, err
+	}
+	desiredBytes, err := json.Marshal(desired)
+	if err != nil {
+		return false, err
+	}
+
+	return string(currentBytes) != string(desiredBytes), nil
+}
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
 	fakebicloud "github.com/cloudfoundry/bosh-cli/v7/cloud/fakes"
 	biconfig "github.com/cloudfoundry/bosh-cli/v7/config"
 	bidisk "github.com/cloudfoundry/bosh-cli/v7/deployment/disk"
@@ -109,6 +110,119 @@ var _ = Describe("Manager", func() {
 		})
 	})
 
+	Describe("Update", func() {
+		var (
+			disk            bidisk.Disk
+			diskPool        bideplmanifest.DiskPool
+			cloudProperties biproperty.Map
+		)
+
+		BeforeEach(func() {
+			cloudProperties = biproperty.Map{
+				"fake-cloud-property-key": "fake-cloud-property-value",
+			}
+			diskRecord, err := diskRepo.Save("fake-disk-cid", 1024, cloudProperties)
+			Expect(err).ToNot(HaveOccurred())
+			disk = bidisk.NewDisk(diskRecord, fakeCloud, diskRepo)
+
+			diskPool = bideplmanifest.DiskPool{
+				Name:            "fake-disk-pool-name",
+				DiskSize:        2048,
+				CloudProperties: cloudProperties,
+			}
+		})
+
+		Context("when only the disk size grows", func() {
+			It("resizes the disk and updates its record", func() {
+				updatedDisk, updated, err := manager.Update(disk, diskPool)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated).To(BeTrue())
+				Expect(updatedDisk.CID()).To(Equal("fake-disk-cid"))
+
+				Expect(fakeCloud.ResizeDiskInputs).To(Equal([]fakebicloud.ResizeDiskInput{
+					{DiskCID: "fake-disk-cid", NewSize: 2048},
+				}))
+				Expect(fakeCloud.UpdateDiskInputs).To(BeEmpty())
+
+				diskRecord, found, err := diskRepo.Find("fake-disk-cid")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(diskRecord.Size).To(Equal(2048))
+			})
+		})
+
+		Context("when the cloud properties change", func() {
+			BeforeEach(func() {
+				diskPool.CloudProperties = biproperty.Map{"type": "ssd"}
+				fakeCloud.UpdateDiskCID = "fake-new-disk-cid"
+			})
+
+			It("updates the disk and records its new cid", func() {
+				updatedDisk, updated, err := manager.Update(disk, diskPool)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated).To(BeTrue())
+				Expect(updatedDisk.CID()).To(Equal("fake-new-disk-cid"))
+
+				Expect(fakeCloud.UpdateDiskInputs).To(Equal([]fakebicloud.UpdateDiskInput{
+					{DiskCID: "fake-disk-cid", NewSize: 2048, CloudProperties: biproperty.Map{"type": "ssd"}},
+				}))
+				Expect(fakeCloud.ResizeDiskInputs).To(BeEmpty())
+
+				diskRecord, found, err := diskRepo.Find("fake-new-disk-cid")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(diskRecord).To(Equal(biconfig.DiskRecord{
+					ID:              "fake-uuid",
+					CID:             "fake-new-disk-cid",
+					Size:            2048,
+					CloudProperties: biproperty.Map{"type": "ssd"},
+				}))
+			})
+		})
+
+		Context("when the disk shrinks", func() {
+			It("does not change the disk", func() {
+				diskPool.DiskSize = 512
+
+				_, updated, err := manager.Update(disk, diskPool)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated).To(BeFalse())
+				Expect(fakeCloud.ResizeDiskInputs).To(BeEmpty())
+				Expect(fakeCloud.UpdateDiskInputs).To(BeEmpty())
+			})
+		})
+
+		Context("when the CPI does not implement the method", func() {
+			BeforeEach(func() {
+				fakeCloud.ResizeDiskErr = bicloud.NewCPIError("resize_disk", bicloud.CmdError{
+					Type: bicloud.NotImplementedError,
+				})
+			})
+
+			It("returns that the disk was not updated", func() {
+				_, updated, err := manager.Update(disk, diskPool)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated).To(BeFalse())
+
+				diskRecord, _, err := diskRepo.Find("fake-disk-cid")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(diskRecord.Size).To(Equal(1024))
+			})
+		})
+
+		Context("when the CPI fails to resize the disk", func() {
+			BeforeEach(func() {
+				fakeCloud.ResizeDiskErr = errors.New("fake-resize-error")
+			})
+
+			It("returns an error", func() {
+				_, _, err := manager.Update(disk, diskPool)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-resize-error"))
+			})
+		})
+	})
+
 	Describe("FindCurrent", func() {
 		Context("when disk already exists in disk repo", func() {
 			BeforeEach(func() {
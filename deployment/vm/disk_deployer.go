@@ -52,7 +52,7 @@ func (d *diskDeployer) Deploy(diskPool bideplmanifest.DiskPool, cloud bicloud.Cl
 		return disks, bosherr.WrapError(err, "Multiple current disks not supported")
 
 	} else if len(disks) == 1 {
-		disks, err = d.deployExistingDisk(disks[0], diskPool, vm, stage)
+		disks, err = d.deployExistingDisk(disks[0], diskPool, cloud, vm, stage)
 		if err != nil {
 			return disks, err
 		}
@@ -72,22 +72,41 @@ func (d *diskDeployer) Deploy(diskPool bideplmanifest.DiskPool, cloud bicloud.Cl
 	return disks, nil
 }
 
-func (d *diskDeployer) deployExistingDisk(disk bidisk.Disk, diskPool bideplmanifest.DiskPool, vm VM, stage biui.Stage) ([]bidisk.Disk, error) {
+func (d *diskDeployer) deployExistingDisk(disk bidisk.Disk, diskPool bideplmanifest.DiskPool, cloud bicloud.Cloud, vm VM, stage biui.Stage) ([]bidisk.Disk, error) {
 	disks := []bidisk.Disk{}
 
+	diskNeedsMigration, err := disk.NeedsMigration(diskPool.DiskSize, diskPool.CloudProperties)
+	if err != nil {
+		return disks, err
+	}
+
+	// the disk is still detached from the new VM, so the CPI may be able to change it in place
+	if diskNeedsMigration && !d.recreatePersistentDisk {
+		cpiInfo, err := cloud.Info()
+		if err != nil {
+			return disks, bosherr.WrapError(err, "Getting CPI info")
+		}
+
+		// only CPIs advertising native disk updates are asked to change the disk
+		if cpiInfo.ApiVersion >= bicloud.DiskUpdateAsOfCpiApiVersion {
+			var updated bool
+			disk, updated, err = d.updateDisk(disk, diskPool, stage)
+			if err != nil {
+				return disks, err
+			}
+			diskNeedsMigration = !updated
+		}
+	}
+
 	// the disk is already part of the deployment, and should already be attached
 	disks = append(disks, disk)
 
 	// attach is idempotent
-	err := d.attachDisk(disk, vm, stage)
+	err = d.attachDisk(disk, vm, stage)
 	if err != nil {
 		return disks, err
 	}
 
-	diskNeedsMigration, err := disk.NeedsMigration(diskPool.DiskSize, diskPool.CloudProperties)
-	if err != nil {
-		return disks, err
-	}
 	if d.recreatePersistentDisk || diskNeedsMigration {
 		disk, err = d.migrateDisk(disk, diskPool, vm, stage)
 		if err != nil {
@@ -101,6 +120,35 @@ func (d *diskDeployer) deployExistingDisk(disk bidisk.Disk, diskPool bideplmanif
 	return disks, nil
 }
 
+func (d *diskDeployer) updateDisk(disk bidisk.Disk, diskPool bideplmanifest.DiskPool, stage biui.Stage) (bidisk.Disk, bool, error) {
+	var (
+		updatedDisk bidisk.Disk
+		updated     bool
+	)
+
+	stageName := fmt.Sprintf("Updating disk '%s'", disk.CID())
+	err := stage.Perform(stageName, func() error {
+		var err error
+		updatedDisk, updated, err = d.diskManager.Update(disk, diskPool)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return biui.NewSkipStageError(bosherr.Error("Disk can not be updated in place"), "Migrating disk instead")
+		}
+		return nil
+	})
+	if err != nil {
+		return disk, false, err
+	}
+
+	if !updated {
+		return disk, false, nil
+	}
+
+	return updatedDisk, true, nil
+}
+
 func (d *diskDeployer) deployNewDisk(diskPool bideplmanifest.DiskPool, vm VM, stage biui.Stage) ([]bidisk.Disk, error) {
 	disks := []bidisk.Disk{}
 
@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	bicloud "github.com/cloudfoundry/bosh-cli/v7/cloud"
 	fakebicloud "github.com/cloudfoundry/bosh-cli/v7/cloud/fakes"
 	biconfig "github.com/cloudfoundry/bosh-cli/v7/config"
 	fakebiconfig "github.com/cloudfoundry/bosh-cli/v7/config/fakes"
@@ -301,6 +302,81 @@ var _ = Describe("DiskDeployer", func() {
 					fakeDiskRepo.SetFindBehavior("fake-secondary-disk-cid", secondaryDiskRecord, true, nil)
 				})
 
+				It("does not try to update the disk in place if CPI does not advertise disk updates", func() {
+					_, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(fakeDiskManager.UpdateInputs).To(BeEmpty())
+					Expect(fakeStage.PerformCalls[0].Name).To(Equal("Attaching disk 'fake-existing-disk-cid' to VM 'fake-vm-cid'"))
+				})
+
+				It("returns error if getting CPI info fails", func() {
+					cloud.InfoError = bosherr.Error("fake-info-error")
+
+					_, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("fake-info-error"))
+					Expect(fakeVM.AttachDiskInputs).To(BeEmpty())
+				})
+
+				Context("when CPI advertises disk updates", func() {
+					BeforeEach(func() {
+						cloud.InfoResult = bicloud.CpiInfo{ApiVersion: bicloud.DiskUpdateAsOfCpiApiVersion}
+					})
+
+					It("tries to update the disk in place first", func() {
+						_, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(fakeDiskManager.UpdateInputs).To(Equal([]fakebidisk.UpdateInput{
+							{Disk: existingDisk, DiskPool: diskPool},
+						}))
+						Expect(fakeStage.PerformCalls[0].Name).To(Equal("Updating disk 'fake-existing-disk-cid'"))
+						Expect(fakeStage.PerformCalls[0].SkipError).To(HaveOccurred())
+						Expect(fakeVM.MigrateDiskCalledTimes).To(Equal(1))
+					})
+
+					Context("when the disk can be updated in place", func() {
+						var updatedDisk *fakebidisk.FakeDisk
+
+						BeforeEach(func() {
+							updatedDisk = fakebidisk.NewFakeDisk("fake-updated-disk-cid")
+							fakeDiskManager.UpdateDisk = updatedDisk
+							fakeDiskManager.UpdateUpdated = true
+							fakeVM.SetAttachDiskBehavior(updatedDisk, nil)
+						})
+
+						It("attaches the updated disk without migrating", func() {
+							disks, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(disks).To(Equal([]bidisk.Disk{updatedDisk}))
+
+							Expect(fakeDiskManager.CreateInputs).To(BeEmpty())
+							Expect(fakeVM.MigrateDiskCalledTimes).To(Equal(0))
+							Expect(fakeVM.AttachDiskInputs).To(Equal([]fakebivm.AttachDiskInput{
+								{Disk: updatedDisk},
+							}))
+							Expect(fakeStage.PerformCalls).To(Equal([]*fakebiui.PerformCall{
+								{Name: "Updating disk 'fake-existing-disk-cid'"},
+								{Name: "Attaching disk 'fake-updated-disk-cid' to VM 'fake-vm-cid'"},
+							}))
+						})
+					})
+
+					Context("when updating the disk in place fails", func() {
+						BeforeEach(func() {
+							fakeDiskManager.UpdateErr = bosherr.Error("fake-update-disk-error")
+						})
+
+						It("returns error without attaching the disk", func() {
+							_, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
+							Expect(err).To(HaveOccurred())
+							Expect(err.Error()).To(ContainSubstring("fake-update-disk-error"))
+							Expect(fakeVM.AttachDiskInputs).To(BeEmpty())
+						})
+					})
+				})
+
 				It("creates secondary disk", func() {
 					disks, err := diskDeployer.Deploy(diskPool, cloud, fakeVM, fakeStage)
 					Expect(err).ToNot(HaveOccurred())
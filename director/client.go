@@ -1,6 +1,8 @@
 package director
 
 import (
+	"context"
+	"net/http"
 	"time"
 
 	"github.com/cloudfoundry/bosh-utils/httpclient"
@@ -10,6 +12,9 @@ import (
 type Client struct {
 	clientRequest     ClientRequest
 	taskClientRequest TaskClientRequest
+
+	// blobstoreClient stages uploads via presigned URLs without Director's auth
+	blobstoreClient *http.Client
 }
 
 func NewClient(
@@ -21,7 +26,7 @@ func NewClient(
 ) Client {
 	clientRequest := NewClientRequest(endpoint, httpClient, fileReporter, logger)
 	taskClientRequest := NewTaskClientRequest(clientRequest, taskReporter, 500*time.Millisecond)
-	return Client{clientRequest, taskClientRequest}
+	return Client{clientRequest: clientRequest, taskClientRequest: taskClientRequest}
 }
 
 func (c Client) WithContext(contextId string) Client {
@@ -30,5 +35,17 @@ func (c Client) WithContext(contextId string) Client {
 	taskClientRequest := c.taskClientRequest
 	taskClientRequest.clientRequest = clientRequest
 
-	return Client{clientRequest, taskClientRequest}
+	c.clientRequest = clientRequest
+	c.taskClientRequest = taskClientRequest
+
+	return c
+}
+
+// WithRequestContext returns a copy of the Client whose requests and task waits
+// stop when ctx is done; tasks being waited on are cancelled if opts ask for it
+func (c Client) WithRequestContext(ctx context.Context, opts RequestContextOpts) Client {
+	c.clientRequest = c.clientRequest.WithRequestContext(ctx)
+	c.taskClientRequest = c.taskClientRequest.WithRequestContext(ctx, opts)
+
+	return c
 }
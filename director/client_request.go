@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 type ClientRequest struct {
 	endpoint     string
 	contextId    string
+	ctx          context.Context
 	httpClient   *httpclient.HTTPClient
 	fileReporter FileReporter
 	logger       boshlog.Logger
@@ -40,6 +42,21 @@ func (r ClientRequest) WithContext(contextId string) ClientRequest {
 	return r
 }
 
+// WithRequestContext returns a copy of the ClientRequest
+// whose requests are cancelled when ctx is done
+func (r ClientRequest) WithRequestContext(ctx context.Context) ClientRequest {
+	r.ctx = ctx
+	return r
+}
+
+// RequestContext returns context of requests; never nil
+func (r ClientRequest) RequestContext() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
 func (r ClientRequest) Get(path string, response interface{}) error {
 	respBody, _, err := r.RawGet(path, nil, nil)
 	if err != nil {
@@ -171,6 +188,10 @@ func (r ClientRequest) setContextIDHeader(f func(*http.Request)) func(*http.Requ
 		if r.contextId != "" {
 			req.Header.Set("X-Bosh-Context-Id", r.contextId)
 		}
+		if r.ctx != nil {
+			// HTTP client only hands out request pointer hence replace request in place
+			*req = *req.WithContext(r.ctx)
+		}
 	}
 }
 
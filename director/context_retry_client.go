@@ -0,0 +1,86 @@
+package director
+
+import (
+	"net/http"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cloudfoundry/bosh-utils/httpclient"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+// ContextRetryClient retries requests on network errors and on
+// gateway errors of GET and HEAD requests like httpclient's network safe
+// retry client, but stops retrying once request's context is done.
+type ContextRetryClient struct {
+	delegate    AdjustedClient
+	maxAttempts uint
+	retryDelay  time.Duration
+
+	logTag string
+	logger boshlog.Logger
+}
+
+func NewContextRetryClient(
+	delegate AdjustedClient,
+	maxAttempts uint,
+	retryDelay time.Duration,
+	logger boshlog.Logger,
+) ContextRetryClient {
+	return ContextRetryClient{
+		delegate:    delegate,
+		maxAttempts: maxAttempts,
+		retryDelay:  retryDelay,
+
+		logTag: "director.ContextRetryClient",
+		logger: logger,
+	}
+}
+
+func (c ContextRetryClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	retryable := httpclient.NewRequestRetryable(req, c.delegate, c.logger, c.isAttemptable)
+
+	var err error
+	var shouldRetry bool
+
+	for i := uint(0); i < c.maxAttempts; i++ {
+		c.logger.Debug(c.logTag, "Making attempt #%d", i)
+
+		shouldRetry, err = retryable.Attempt()
+		if !shouldRetry {
+			return retryable.Response(), err
+		}
+
+		if ctx.Err() != nil {
+			return retryable.Response(), bosherr.WrapError(ctx.Err(), "Retrying request")
+		}
+
+		select {
+		case <-ctx.Done():
+			return retryable.Response(), bosherr.WrapError(ctx.Err(), "Retrying request")
+		case <-time.After(c.retryDelay):
+		}
+	}
+
+	return retryable.Response(), err
+}
+
+func (c ContextRetryClient) isAttemptable(resp *http.Response, err error) (bool, error) {
+	if err != nil {
+		return true, bosherr.WrapError(err, "Retry")
+	}
+
+	isIdempotent := resp.Request.Method == "GET" || resp.Request.Method == "HEAD"
+
+	isGatewayErr := resp.StatusCode == http.StatusGatewayTimeout ||
+		resp.StatusCode == http.StatusServiceUnavailable ||
+		resp.StatusCode == http.StatusBadGateway
+
+	if isIdempotent && isGatewayErr {
+		return true, bosherr.WrapError(err, "Retry")
+	}
+
+	return false, nil
+}
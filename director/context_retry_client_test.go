@@ -0,0 +1,127 @@
+package director_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+)
+
+var _ = Describe("ContextRetryClient", func() {
+	var (
+		innerClient *fakedir.FakeAdjustedClient
+		client      ContextRetryClient
+	)
+
+	BeforeEach(func() {
+		innerClient = &fakedir.FakeAdjustedClient{}
+		client = NewContextRetryClient(innerClient, 3, time.Millisecond, boshlog.NewLogger(boshlog.LevelNone))
+	})
+
+	respondWith := func(req *http.Request, status int) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+	}
+
+	It("returns response without retrying when request succeeds", func() {
+		req, err := http.NewRequest("GET", "http://host/path", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		innerClient.DoReturns(respondWith(req, http.StatusOK), nil)
+
+		resp, err := client.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		Expect(innerClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("retries network errors until max attempts", func() {
+		req, err := http.NewRequest("POST", "http://host/path", strings.NewReader("body"))
+		Expect(err).ToNot(HaveOccurred())
+
+		innerClient.DoReturns(nil, errors.New("fake-err"))
+
+		_, err = client.Do(req)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+		Expect(innerClient.DoCallCount()).To(Equal(3))
+	})
+
+	It("retries gateway errors of GET requests", func() {
+		req, err := http.NewRequest("GET", "http://host/path", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		innerClient.DoReturnsOnCall(0, respondWith(req, http.StatusBadGateway), nil)
+		innerClient.DoReturnsOnCall(1, respondWith(req, http.StatusOK), nil)
+
+		resp, err := client.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		Expect(innerClient.DoCallCount()).To(Equal(2))
+	})
+
+	It("does not retry gateway errors of POST requests", func() {
+		req, err := http.NewRequest("POST", "http://host/path", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		innerClient.DoReturns(respondWith(req, http.StatusBadGateway), nil)
+
+		resp, err := client.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+
+		Expect(innerClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("stops retrying once request context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "http://host/path", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		innerClient.DoStub = func(*http.Request) (*http.Response, error) {
+			cancel()
+			return nil, errors.New("fake-err")
+		}
+
+		_, err = client.Do(req)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Retrying request: context canceled"))
+
+		Expect(innerClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("stops waiting between retries once request context is done", func() {
+		client = NewContextRetryClient(innerClient, 3, time.Hour, boshlog.NewLogger(boshlog.LevelNone))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "http://host/path", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		innerClient.DoReturns(nil, errors.New("fake-err"))
+
+		_, err = client.Do(req)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("context deadline exceeded"))
+
+		Expect(innerClient.DoCallCount()).To(Equal(1))
+	})
+})
@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -47,6 +48,10 @@ type VariableResult struct {
 
 func (d DeploymentImpl) Name() string { return d.name }
 
+func (d DeploymentImpl) WithRequestContext(ctx context.Context, opts RequestContextOpts) Deployment {
+	return &DeploymentImpl{client: d.client.WithRequestContext(ctx, opts), name: d.name}
+}
+
 func (d *DeploymentImpl) CloudConfig() (string, error) {
 	d.fetch()
 	return d.cloudConfig, d.fetchErr
@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,6 +27,10 @@ func (d DirectorImpl) WithContext(id string) Director {
 	return DirectorImpl{client: d.client.WithContext(id)}
 }
 
+func (d DirectorImpl) WithRequestContext(ctx context.Context, opts RequestContextOpts) Director {
+	return DirectorImpl{client: d.client.WithRequestContext(ctx, opts)}
+}
+
 func (c Client) OrphanedVMs() ([]OrphanedVM, error) {
 	var resps []OrphanedVMResponse
 
@@ -2,6 +2,7 @@ package director_test
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 	"time"
 
@@ -242,6 +243,53 @@ var _ = Describe("Director", func() {
 		})
 	})
 
+	Describe("With Request Context", func() {
+		It("does not make requests once context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := dir.WithRequestContext(ctx, director.RequestContextOpts{}).DownloadResourceUnchecked("blob-id", bytes.NewBufferString(""))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("context canceled"))
+
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("cancels tasks of found deployments and tasks when waiting for them is cancelled", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123"),
+					ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"processing"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123"),
+					ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"processing"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123/output", "type=event"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/task/123"),
+					ghttp.VerifyBasicAuth("username", "password"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			task, err := dir.FindTask(123)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = task.WithRequestContext(ctx, director.RequestContextOpts{CancelTasks: true}).EventOutput(director.NewNoopTaskReporter())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("context deadline exceeded"))
+
+			Expect(server.ReceivedRequests()).To(HaveLen(4))
+		})
+	})
+
 	Describe("Certificate Expiry info", func() {
 		It("Returns the director's certificates expiry info", func() {
 			server.AppendHandlers(
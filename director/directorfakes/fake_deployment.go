@@ -2,6 +2,7 @@
 package directorfakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry/bosh-cli/v7/director"
@@ -421,6 +422,18 @@ type FakeDeployment struct {
 		result1 []director.VariableResult
 		result2 error
 	}
+	WithRequestContextStub        func(context.Context, director.RequestContextOpts) director.Deployment
+	withRequestContextMutex       sync.RWMutex
+	withRequestContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 director.RequestContextOpts
+	}
+	withRequestContextReturns struct {
+		result1 director.Deployment
+	}
+	withRequestContextReturnsOnCall map[int]struct {
+		result1 director.Deployment
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2480,6 +2493,68 @@ func (fake *FakeDeployment) VariablesReturnsOnCall(i int, result1 []director.Var
 	}{result1, result2}
 }
 
+func (fake *FakeDeployment) WithRequestContext(arg1 context.Context, arg2 director.RequestContextOpts) director.Deployment {
+	fake.withRequestContextMutex.Lock()
+	ret, specificReturn := fake.withRequestContextReturnsOnCall[len(fake.withRequestContextArgsForCall)]
+	fake.withRequestContextArgsForCall = append(fake.withRequestContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 director.RequestContextOpts
+	}{arg1, arg2})
+	stub := fake.WithRequestContextStub
+	fakeReturns := fake.withRequestContextReturns
+	fake.recordInvocation("WithRequestContext", []interface{}{arg1, arg2})
+	fake.withRequestContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDeployment) WithRequestContextCallCount() int {
+	fake.withRequestContextMutex.RLock()
+	defer fake.withRequestContextMutex.RUnlock()
+	return len(fake.withRequestContextArgsForCall)
+}
+
+func (fake *FakeDeployment) WithRequestContextCalls(stub func(context.Context, director.RequestContextOpts) director.Deployment) {
+	fake.withRequestContextMutex.Lock()
+	defer fake.withRequestContextMutex.Unlock()
+	fake.WithRequestContextStub = stub
+}
+
+func (fake *FakeDeployment) WithRequestContextArgsForCall(i int) (context.Context, director.RequestContextOpts) {
+	fake.withRequestContextMutex.RLock()
+	defer fake.withRequestContextMutex.RUnlock()
+	argsForCall := fake.withRequestContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDeployment) WithRequestContextReturns(result1 director.Deployment) {
+	fake.withRequestContextMutex.Lock()
+	defer fake.withRequestContextMutex.Unlock()
+	fake.WithRequestContextStub = nil
+	fake.withRequestContextReturns = struct {
+		result1 director.Deployment
+	}{result1}
+}
+
+func (fake *FakeDeployment) WithRequestContextReturnsOnCall(i int, result1 director.Deployment) {
+	fake.withRequestContextMutex.Lock()
+	defer fake.withRequestContextMutex.Unlock()
+	fake.WithRequestContextStub = nil
+	if fake.withRequestContextReturnsOnCall == nil {
+		fake.withRequestContextReturnsOnCall = make(map[int]struct {
+			result1 director.Deployment
+		})
+	}
+	fake.withRequestContextReturnsOnCall[i] = struct {
+		result1 director.Deployment
+	}{result1}
+}
+
 func (fake *FakeDeployment) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
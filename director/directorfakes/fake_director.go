@@ -2,6 +2,7 @@
 package directorfakes
 
 import (
+	"context"
 	"io"
 	"sync"
 
@@ -671,6 +672,20 @@ type FakeDirector struct {
 	uploadReleaseFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UploadReleaseFileResumableStub        func(director.UploadFile, bool, bool, director.ResumableUploadOpts) error
+	uploadReleaseFileResumableMutex       sync.RWMutex
+	uploadReleaseFileResumableArgsForCall []struct {
+		arg1 director.UploadFile
+		arg2 bool
+		arg3 bool
+		arg4 director.ResumableUploadOpts
+	}
+	uploadReleaseFileResumableReturns struct {
+		result1 error
+	}
+	uploadReleaseFileResumableReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UploadReleaseURLStub        func(string, string, bool, bool) error
 	uploadReleaseURLMutex       sync.RWMutex
 	uploadReleaseURLArgsForCall []struct {
@@ -697,6 +712,19 @@ type FakeDirector struct {
 	uploadStemcellFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UploadStemcellFileResumableStub        func(director.UploadFile, bool, director.ResumableUploadOpts) error
+	uploadStemcellFileResumableMutex       sync.RWMutex
+	uploadStemcellFileResumableArgsForCall []struct {
+		arg1 director.UploadFile
+		arg2 bool
+		arg3 director.ResumableUploadOpts
+	}
+	uploadStemcellFileResumableReturns struct {
+		result1 error
+	}
+	uploadStemcellFileResumableReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UploadStemcellURLStub        func(string, string, bool) error
 	uploadStemcellURLMutex       sync.RWMutex
 	uploadStemcellURLArgsForCall []struct {
@@ -721,6 +749,18 @@ type FakeDirector struct {
 	withContextReturnsOnCall map[int]struct {
 		result1 director.Director
 	}
+	WithRequestContextStub        func(context.Context, director.RequestContextOpts) director.Director
+	withRequestContextMutex       sync.RWMutex
+	withRequestContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 director.RequestContextOpts
+	}
+	withRequestContextReturns struct {
+		result1 director.Director
+	}
+	withRequestContextReturnsOnCall map[int]struct {
+		result1 director.Director
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -3946,6 +3986,70 @@ func (fake *FakeDirector) UploadReleaseFileReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDirector) UploadReleaseFileResumable(arg1 director.UploadFile, arg2 bool, arg3 bool, arg4 director.ResumableUploadOpts) error {
+	fake.uploadReleaseFileResumableMutex.Lock()
+	ret, specificReturn := fake.uploadReleaseFileResumableReturnsOnCall[len(fake.uploadReleaseFileResumableArgsForCall)]
+	fake.uploadReleaseFileResumableArgsForCall = append(fake.uploadReleaseFileResumableArgsForCall, struct {
+		arg1 director.UploadFile
+		arg2 bool
+		arg3 bool
+		arg4 director.ResumableUploadOpts
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UploadReleaseFileResumableStub
+	fakeReturns := fake.uploadReleaseFileResumableReturns
+	fake.recordInvocation("UploadReleaseFileResumable", []interface{}{arg1, arg2, arg3, arg4})
+	fake.uploadReleaseFileResumableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDirector) UploadReleaseFileResumableCallCount() int {
+	fake.uploadReleaseFileResumableMutex.RLock()
+	defer fake.uploadReleaseFileResumableMutex.RUnlock()
+	return len(fake.uploadReleaseFileResumableArgsForCall)
+}
+
+func (fake *FakeDirector) UploadReleaseFileResumableCalls(stub func(director.UploadFile, bool, bool, director.ResumableUploadOpts) error) {
+	fake.uploadReleaseFileResumableMutex.Lock()
+	defer fake.uploadReleaseFileResumableMutex.Unlock()
+	fake.UploadReleaseFileResumableStub = stub
+}
+
+func (fake *FakeDirector) UploadReleaseFileResumableArgsForCall(i int) (director.UploadFile, bool, bool, director.ResumableUploadOpts) {
+	fake.uploadReleaseFileResumableMutex.RLock()
+	defer fake.uploadReleaseFileResumableMutex.RUnlock()
+	argsForCall := fake.uploadReleaseFileResumableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDirector) UploadReleaseFileResumableReturns(result1 error) {
+	fake.uploadReleaseFileResumableMutex.Lock()
+	defer fake.uploadReleaseFileResumableMutex.Unlock()
+	fake.UploadReleaseFileResumableStub = nil
+	fake.uploadReleaseFileResumableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDirector) UploadReleaseFileResumableReturnsOnCall(i int, result1 error) {
+	fake.uploadReleaseFileResumableMutex.Lock()
+	defer fake.uploadReleaseFileResumableMutex.Unlock()
+	fake.UploadReleaseFileResumableStub = nil
+	if fake.uploadReleaseFileResumableReturnsOnCall == nil {
+		fake.uploadReleaseFileResumableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uploadReleaseFileResumableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeDirector) UploadReleaseURL(arg1 string, arg2 string, arg3 bool, arg4 bool) error {
 	fake.uploadReleaseURLMutex.Lock()
 	ret, specificReturn := fake.uploadReleaseURLReturnsOnCall[len(fake.uploadReleaseURLArgsForCall)]
@@ -4072,6 +4176,69 @@ func (fake *FakeDirector) UploadStemcellFileReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeDirector) UploadStemcellFileResumable(arg1 director.UploadFile, arg2 bool, arg3 director.ResumableUploadOpts) error {
+	fake.uploadStemcellFileResumableMutex.Lock()
+	ret, specificReturn := fake.uploadStemcellFileResumableReturnsOnCall[len(fake.uploadStemcellFileResumableArgsForCall)]
+	fake.uploadStemcellFileResumableArgsForCall = append(fake.uploadStemcellFileResumableArgsForCall, struct {
+		arg1 director.UploadFile
+		arg2 bool
+		arg3 director.ResumableUploadOpts
+	}{arg1, arg2, arg3})
+	stub := fake.UploadStemcellFileResumableStub
+	fakeReturns := fake.uploadStemcellFileResumableReturns
+	fake.recordInvocation("UploadStemcellFileResumable", []interface{}{arg1, arg2, arg3})
+	fake.uploadStemcellFileResumableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDirector) UploadStemcellFileResumableCallCount() int {
+	fake.uploadStemcellFileResumableMutex.RLock()
+	defer fake.uploadStemcellFileResumableMutex.RUnlock()
+	return len(fake.uploadStemcellFileResumableArgsForCall)
+}
+
+func (fake *FakeDirector) UploadStemcellFileResumableCalls(stub func(director.UploadFile, bool, director.ResumableUploadOpts) error) {
+	fake.uploadStemcellFileResumableMutex.Lock()
+	defer fake.uploadStemcellFileResumableMutex.Unlock()
+	fake.UploadStemcellFileResumableStub = stub
+}
+
+func (fake *FakeDirector) UploadStemcellFileResumableArgsForCall(i int) (director.UploadFile, bool, director.ResumableUploadOpts) {
+	fake.uploadStemcellFileResumableMutex.RLock()
+	defer fake.uploadStemcellFileResumableMutex.RUnlock()
+	argsForCall := fake.uploadStemcellFileResumableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDirector) UploadStemcellFileResumableReturns(result1 error) {
+	fake.uploadStemcellFileResumableMutex.Lock()
+	defer fake.uploadStemcellFileResumableMutex.Unlock()
+	fake.UploadStemcellFileResumableStub = nil
+	fake.uploadStemcellFileResumableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDirector) UploadStemcellFileResumableReturnsOnCall(i int, result1 error) {
+	fake.uploadStemcellFileResumableMutex.Lock()
+	defer fake.uploadStemcellFileResumableMutex.Unlock()
+	fake.UploadStemcellFileResumableStub = nil
+	if fake.uploadStemcellFileResumableReturnsOnCall == nil {
+		fake.uploadStemcellFileResumableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uploadStemcellFileResumableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeDirector) UploadStemcellURL(arg1 string, arg2 string, arg3 bool) error {
 	fake.uploadStemcellURLMutex.Lock()
 	ret, specificReturn := fake.uploadStemcellURLReturnsOnCall[len(fake.uploadStemcellURLArgsForCall)]
@@ -4196,6 +4363,68 @@ func (fake *FakeDirector) WithContextReturnsOnCall(i int, result1 director.Direc
 	}{result1}
 }
 
+func (fake *FakeDirector) WithRequestContext(arg1 context.Context, arg2 director.RequestContextOpts) director.Director {
+	fake.withRequestContextMutex.Lock()
+	ret, specificReturn := fake.withRequestContextReturnsOnCall[len(fake.withRequestContextArgsForCall)]
+	fake.withRequestContextArgsForCall = append(fake.withRequestContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 director.RequestContextOpts
+	}{arg1, arg2})
+	stub := fake.WithRequestContextStub
+	fakeReturns := fake.withRequestContextReturns
+	fake.recordInvocation("WithRequestContext", []interface{}{arg1, arg2})
+	fake.withRequestContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDirector) WithRequestContextCallCount() int {
+	fake.withRequestContextMutex.RLock()
+	defer fake.withRequestContextMutex.RUnlock()
+	return len(fake.withRequestContextArgsForCall)
+}
+
+func (fake *FakeDirector) WithRequestContextCalls(stub func(context.Context, director.RequestContextOpts) director.Director) {
+	fake.withRequestContextMutex.Lock()
+	defer fake.withRequestContextMutex.Unlock()
+	fake.WithRequestContextStub = stub
+}
+
+func (fake *FakeDirector) WithRequestContextArgsForCall(i int) (context.Context, director.RequestContextOpts) {
+	fake.withRequestContextMutex.RLock()
+	defer fake.withRequestContextMutex.RUnlock()
+	argsForCall := fake.withRequestContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDirector) WithRequestContextReturns(result1 director.Director) {
+	fake.withRequestContextMutex.Lock()
+	defer fake.withRequestContextMutex.Unlock()
+	fake.WithRequestContextStub = nil
+	fake.withRequestContextReturns = struct {
+		result1 director.Director
+	}{result1}
+}
+
+func (fake *FakeDirector) WithRequestContextReturnsOnCall(i int, result1 director.Director) {
+	fake.withRequestContextMutex.Lock()
+	defer fake.withRequestContextMutex.Unlock()
+	fake.WithRequestContextStub = nil
+	if fake.withRequestContextReturnsOnCall == nil {
+		fake.withRequestContextReturnsOnCall = make(map[int]struct {
+			result1 director.Director
+		})
+	}
+	fake.withRequestContextReturnsOnCall[i] = struct {
+		result1 director.Director
+	}{result1}
+}
+
 func (fake *FakeDirector) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
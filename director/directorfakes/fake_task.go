@@ -2,6 +2,7 @@
 package directorfakes
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -163,6 +164,18 @@ type FakeTask struct {
 	userReturnsOnCall map[int]struct {
 		result1 string
 	}
+	WithRequestContextStub        func(context.Context, director.RequestContextOpts) director.Task
+	withRequestContextMutex       sync.RWMutex
+	withRequestContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 director.RequestContextOpts
+	}
+	withRequestContextReturns struct {
+		result1 director.Task
+	}
+	withRequestContextReturnsOnCall map[int]struct {
+		result1 director.Task
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -994,39 +1007,71 @@ func (fake *FakeTask) UserReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeTask) WithRequestContext(arg1 context.Context, arg2 director.RequestContextOpts) director.Task {
+	fake.withRequestContextMutex.Lock()
+	ret, specificReturn := fake.withRequestContextReturnsOnCall[len(fake.withRequestContextArgsForCall)]
+	fake.withRequestContextArgsForCall = append(fake.withRequestContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 director.RequestContextOpts
+	}{arg1, arg2})
+	stub := fake.WithRequestContextStub
+	fakeReturns := fake.withRequestContextReturns
+	fake.recordInvocation("WithRequestContext", []interface{}{arg1, arg2})
+	fake.withRequestContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTask) WithRequestContextCallCount() int {
+	fake.withRequestContextMutex.RLock()
+	defer fake.withRequestContextMutex.RUnlock()
+	return len(fake.withRequestContextArgsForCall)
+}
+
+func (fake *FakeTask) WithRequestContextCalls(stub func(context.Context, director.RequestContextOpts) director.Task) {
+	fake.withRequestContextMutex.Lock()
+	defer fake.withRequestContextMutex.Unlock()
+	fake.WithRequestContextStub = stub
+}
+
+func (fake *FakeTask) WithRequestContextArgsForCall(i int) (context.Context, director.RequestContextOpts) {
+	fake.withRequestContextMutex.RLock()
+	defer fake.withRequestContextMutex.RUnlock()
+	argsForCall := fake.withRequestContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTask) WithRequestContextReturns(result1 director.Task) {
+	fake.withRequestContextMutex.Lock()
+	defer fake.withRequestContextMutex.Unlock()
+	fake.WithRequestContextStub = nil
+	fake.withRequestContextReturns = struct {
+		result1 director.Task
+	}{result1}
+}
+
+func (fake *FakeTask) WithRequestContextReturnsOnCall(i int, result1 director.Task) {
+	fake.withRequestContextMutex.Lock()
+	defer fake.withRequestContextMutex.Unlock()
+	fake.WithRequestContextStub = nil
+	if fake.withRequestContextReturnsOnCall == nil {
+		fake.withRequestContextReturnsOnCall = make(map[int]struct {
+			result1 director.Task
+		})
+	}
+	fake.withRequestContextReturnsOnCall[i] = struct {
+		result1 director.Task
+	}{result1}
+}
+
 func (fake *FakeTask) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.cPIOutputMutex.RLock()
-	defer fake.cPIOutputMutex.RUnlock()
-	fake.cancelMutex.RLock()
-	defer fake.cancelMutex.RUnlock()
-	fake.contextIDMutex.RLock()
-	defer fake.contextIDMutex.RUnlock()
-	fake.debugOutputMutex.RLock()
-	defer fake.debugOutputMutex.RUnlock()
-	fake.deploymentNameMutex.RLock()
-	defer fake.deploymentNameMutex.RUnlock()
-	fake.descriptionMutex.RLock()
-	defer fake.descriptionMutex.RUnlock()
-	fake.eventOutputMutex.RLock()
-	defer fake.eventOutputMutex.RUnlock()
-	fake.finishedAtMutex.RLock()
-	defer fake.finishedAtMutex.RUnlock()
-	fake.iDMutex.RLock()
-	defer fake.iDMutex.RUnlock()
-	fake.isErrorMutex.RLock()
-	defer fake.isErrorMutex.RUnlock()
-	fake.resultMutex.RLock()
-	defer fake.resultMutex.RUnlock()
-	fake.resultOutputMutex.RLock()
-	defer fake.resultOutputMutex.RUnlock()
-	fake.startedAtMutex.RLock()
-	defer fake.startedAtMutex.RUnlock()
-	fake.stateMutex.RLock()
-	defer fake.stateMutex.RUnlock()
-	fake.userMutex.RLock()
-	defer fake.userMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,558 @@
+// Package directortest provides an in-memory Director for testing code
+// that talks to the Director API via the director package.
+package directortest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+)
+
+// Server serves Director API endpoints used by the director package
+// (info, deployments, tasks, configs, releases, stemcells and locks)
+// from state kept in memory. It is safe for concurrent use.
+type Server struct {
+	server *httptest.Server
+
+	mu sync.Mutex
+
+	info boshdir.InfoResp
+
+	username string
+	password string
+
+	uaaClients map[string]string
+	uaaUsers   map[string]string
+	tokens     map[string]bool
+
+	refreshTokens map[string]string
+	issuedTokens  int
+
+	deployments map[string]boshdir.DeploymentResp
+	releases    []boshdir.ReleaseSeriesResp
+	stemcells   []boshdir.StemcellResp
+	configs     []boshdir.Config
+	locks       []boshdir.LockResp
+
+	tasks    []*task
+	outcomes []TaskOutcome
+
+	requests []string
+}
+
+// NewServer starts Director on a random local port serving HTTPS;
+// clients have to trust CACert. Server must be closed after use.
+func NewServer() *Server {
+	s := &Server{
+		info: boshdir.InfoResp{
+			Name:    "directortest",
+			UUID:    "directortest-uuid",
+			Version: "0.0.0 (00000000)",
+			User:    "admin",
+			CPI:     "directortest_cpi",
+			Auth:    boshdir.UserAuthenticationResp{Type: "basic", Options: map[string]interface{}{}},
+		},
+		uaaClients: map[string]string{},
+		uaaUsers:   map[string]string{},
+		tokens:     map[string]bool{},
+
+		refreshTokens: map[string]string{},
+		deployments:   map[string]boshdir.DeploymentResp{},
+	}
+
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+func (s *Server) URL() string { return s.server.URL }
+
+// CACert returns PEM encoded certificate that Server presents
+func (s *Server) CACert() string {
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: s.server.Certificate().Raw}
+	return string(pem.EncodeToMemory(block))
+}
+
+func (s *Server) Close() { s.server.Close() }
+
+// SetInfo replaces Director info; authentication details are
+// always filled in by Server based on SetBasicAuth and AddUAAClient
+func (s *Server) SetInfo(info boshdir.InfoResp) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info.Auth = s.info.Auth
+	s.info = info
+}
+
+// SetBasicAuth makes Director require basic auth with given credentials.
+// Without it any credentials are accepted.
+func (s *Server) SetBasicAuth(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.username = username
+	s.password = password
+}
+
+func (s *Server) AddDeployment(dep boshdir.DeploymentResp) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deployments[dep.Name] = dep
+}
+
+func (s *Server) Deployments() []boshdir.DeploymentResp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sortedDeployments()
+}
+
+func (s *Server) AddRelease(name string, versions ...boshdir.ReleaseVersionResp) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, rel := range s.releases {
+		if rel.Name == name {
+			s.releases[i].Versions = append(s.releases[i].Versions, versions...)
+			return
+		}
+	}
+
+	s.releases = append(s.releases, boshdir.ReleaseSeriesResp{Name: name, Versions: versions})
+}
+
+func (s *Server) AddStemcell(stemcell boshdir.StemcellResp) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stemcells = append(s.stemcells, stemcell)
+}
+
+// AddConfig stores new version of config with given type and name
+// and returns its ID, similarly to update-config
+func (s *Server) AddConfig(configType, name, content string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addConfig(configType, name, content).ID
+}
+
+func (s *Server) AddLock(lock boshdir.LockResp) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locks = append(s.locks, lock)
+}
+
+// RemoveLocks removes locks added via AddLock
+func (s *Server) RemoveLocks() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locks = nil
+}
+
+// ReceivedRequests returns method and path of every request, e.g. "GET /info"
+func (s *Server) ReceivedRequests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req.Method+" "+req.URL.Path)
+
+	switch {
+	case req.URL.Path == "/oauth/token":
+		s.serveToken(w, req)
+		return
+	case req.URL.Path == "/login":
+		s.serveLogin(w)
+		return
+	case req.URL.Path == "/info":
+		s.serveInfo(w)
+		return
+	}
+
+	if !s.authorized(req) {
+		s.respondError(w, http.StatusUnauthorized, "Not authorized")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	switch segments[0] {
+	case "deployments":
+		s.serveDeployments(w, req, segments[1:])
+	case "deployment_configs":
+		s.respondJSON(w, http.StatusOK, []interface{}{})
+	case "tasks", "task":
+		s.serveTasks(w, req, segments[1:])
+	case "configs":
+		s.serveConfigs(w, req, segments[1:])
+	case "releases":
+		s.serveReleases(w, req)
+	case "stemcells":
+		s.serveStemcells(w, req)
+	case "locks":
+		s.serveLocks(w, req)
+	default:
+		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Unknown endpoint '%s %s'", req.Method, req.URL.Path))
+	}
+}
+
+func (s *Server) authorized(req *http.Request) bool {
+	header := req.Header.Get("Authorization")
+
+	if s.info.Auth.Type == "uaa" {
+		parts := strings.Fields(header)
+		return len(parts) == 2 && strings.EqualFold(parts[0], "bearer") && s.tokens[parts[1]]
+	}
+
+	if len(s.username) == 0 {
+		return true
+	}
+
+	username, password, ok := req.BasicAuth()
+
+	return ok && username == s.username && password == s.password
+}
+
+func (s *Server) serveInfo(w http.ResponseWriter) {
+	s.respondJSON(w, http.StatusOK, s.info)
+}
+
+func (s *Server) serveDeployments(w http.ResponseWriter, req *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && req.Method == http.MethodGet:
+		s.respondJSON(w, http.StatusOK, s.sortedDeployments())
+
+	case len(segments) == 0 && req.Method == http.MethodPost:
+		s.deploy(w, req)
+
+	case len(segments) == 1 && req.Method == http.MethodGet:
+		dep, found := s.deployments[segments[0]]
+		if !found {
+			s.respondError(w, http.StatusNotFound, fmt.Sprintf("Deployment '%s' doesn't exist", segments[0]))
+			return
+		}
+		s.respondJSON(w, http.StatusOK, map[string]string{"manifest": dep.Manifest})
+
+	case len(segments) == 1 && req.Method == http.MethodDelete:
+		name := segments[0]
+
+		_, found := s.deployments[name]
+		if !found && req.URL.Query().Get("force") != "true" {
+			s.respondError(w, http.StatusNotFound, fmt.Sprintf("Deployment '%s' doesn't exist", name))
+			return
+		}
+
+		s.startTask(w, "delete deployment "+name, name, func() { delete(s.deployments, name) })
+
+	case len(segments) == 2 && segments[1] == "diff" && req.Method == http.MethodPost:
+		s.diff(w, req, segments[0])
+
+	default:
+		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Unknown endpoint '%s %s'", req.Method, req.URL.Path))
+	}
+}
+
+type manifest struct {
+	Name     string `yaml:"name"`
+	Releases []struct {
+		Name    string `yaml:"name"`
+		Version string `yaml:"version"`
+	} `yaml:"releases"`
+	Stemcells []struct {
+		Name    string `yaml:"name"`
+		OS      string `yaml:"os"`
+		Version string `yaml:"version"`
+	} `yaml:"stemcells"`
+}
+
+func (s *Server) readManifest(w http.ResponseWriter, req *http.Request) ([]byte, manifest, bool) {
+	var man manifest
+
+	bytes, err := readBody(req)
+	if err == nil {
+		err = yaml.Unmarshal(bytes, &man)
+	}
+	if err != nil || len(man.Name) == 0 {
+		s.respondError(w, http.StatusBadRequest, "Manifest should specify deployment name")
+		return nil, man, false
+	}
+
+	return bytes, man, true
+}
+
+// deploy replaces deployment with given manifest once its task succeeds
+func (s *Server) deploy(w http.ResponseWriter, req *http.Request) {
+	bytes, man, ok := s.readManifest(w, req)
+	if !ok {
+		return
+	}
+
+	dep := boshdir.DeploymentResp{
+		Name:      man.Name,
+		Manifest:  string(bytes),
+		Releases:  []boshdir.DeploymentReleaseResp{},
+		Stemcells: []boshdir.DeploymentStemcellResp{},
+		Teams:     []string{},
+	}
+
+	for _, rel := range man.Releases {
+		dep.Releases = append(dep.Releases, boshdir.DeploymentReleaseResp{Name: rel.Name, Version: rel.Version})
+	}
+
+	for _, stemcell := range man.Stemcells {
+		name := stemcell.Name
+		if len(name) == 0 {
+			name = stemcell.OS
+		}
+		dep.Stemcells = append(dep.Stemcells, boshdir.DeploymentStemcellResp{Name: name, Version: stemcell.Version})
+	}
+
+	s.startTask(w, "create deployment", man.Name, func() { s.deployments[man.Name] = dep })
+}
+
+// diff marks manifest lines that are not present in deployed manifest as added
+// and deployed manifest lines that are not present anymore as removed
+func (s *Server) diff(w http.ResponseWriter, req *http.Request, name string) {
+	bytes, _, ok := s.readManifest(w, req)
+	if !ok {
+		return
+	}
+
+	oldLines := manifestLines(s.deployments[name].Manifest)
+	newLines := manifestLines(string(bytes))
+
+	resp := boshdir.DeploymentDiffResponse{Context: map[string]interface{}{}, Diff: [][]interface{}{}}
+
+	for _, line := range newLines {
+		if !contains(oldLines, line) {
+			resp.Diff = append(resp.Diff, []interface{}{line, "added"})
+		}
+	}
+
+	for _, line := range oldLines {
+		if !contains(newLines, line) {
+			resp.Diff = append(resp.Diff, []interface{}{line, "removed"})
+		}
+	}
+
+	s.respondJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) serveConfigs(w http.ResponseWriter, req *http.Request, segments []string) {
+	query := req.URL.Query()
+
+	switch {
+	case len(segments) == 0 && req.Method == http.MethodGet:
+		configs := []boshdir.Config{}
+
+		for i := len(s.configs) - 1; i >= 0; i-- {
+			config := s.configs[i]
+
+			if query.Get("latest") == "true" && !config.Current {
+				continue
+			}
+			if (len(query.Get("type")) > 0 && query.Get("type") != config.Type) ||
+				(len(query.Get("name")) > 0 && query.Get("name") != config.Name) {
+				continue
+			}
+
+			configs = append(configs, config)
+		}
+
+		s.respondJSON(w, http.StatusOK, configs)
+
+	case len(segments) == 0 && req.Method == http.MethodPost:
+		var body boshdir.UpdateConfigBody
+
+		bytes, err := readBody(req)
+		if err == nil {
+			err = json.Unmarshal(bytes, &body)
+		}
+		if err != nil {
+			s.respondError(w, http.StatusBadRequest, "Malformed config")
+			return
+		}
+
+		s.respondJSON(w, http.StatusCreated, s.addConfig(body.Type, body.Name, body.Content))
+
+	case len(segments) == 0 && req.Method == http.MethodDelete:
+		found := false
+
+		for i, config := range s.configs {
+			if config.Current && config.Type == query.Get("type") && config.Name == query.Get("name") {
+				s.configs[i].Current = false
+				found = true
+			}
+		}
+
+		if !found {
+			s.respondError(w, http.StatusNotFound, "No configs to delete")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(segments) == 1 && req.Method == http.MethodGet:
+		for _, config := range s.configs {
+			if config.ID == segments[0] {
+				s.respondJSON(w, http.StatusOK, config)
+				return
+			}
+		}
+
+		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Config '%s' not found", segments[0]))
+
+	default:
+		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Unknown endpoint '%s %s'", req.Method, req.URL.Path))
+	}
+}
+
+func (s *Server) addConfig(configType, name, content string) boshdir.Config {
+	for i, config := range s.configs {
+		if config.Type == configType && config.Name == name {
+			s.configs[i].Current = false
+		}
+	}
+
+	config := boshdir.Config{
+		ID:        strconv.Itoa(len(s.configs) + 1),
+		Type:      configType,
+		Name:      name,
+		CreatedAt: "2000-01-01 00:00:00 UTC",
+		Content:   content,
+		Current:   true,
+	}
+
+	s.configs = append(s.configs, config)
+
+	return config
+}
+
+func (s *Server) serveReleases(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Unknown endpoint '%s %s'", req.Method, req.URL.Path))
+		return
+	}
+
+	releases := append([]boshdir.ReleaseSeriesResp{}, s.releases...)
+
+	s.respondJSON(w, http.StatusOK, releases)
+}
+
+func (s *Server) serveStemcells(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Unknown endpoint '%s %s'", req.Method, req.URL.Path))
+		return
+	}
+
+	stemcells := append([]boshdir.StemcellResp{}, s.stemcells...)
+
+	s.respondJSON(w, http.StatusOK, stemcells)
+}
+
+// serveLocks includes locks held by running deployment tasks
+func (s *Server) serveLocks(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Unknown endpoint '%s %s'", req.Method, req.URL.Path))
+		return
+	}
+
+	locks := append([]boshdir.LockResp{}, s.locks...)
+
+	for _, t := range s.tasks {
+		if t.running() && len(t.resp.Deployment) > 0 {
+			locks = append(locks, boshdir.LockResp{
+				Type:     "deployment",
+				Resource: []string{t.resp.Deployment},
+				Timeout:  strconv.FormatInt(t.resp.StartedAt+3600, 10),
+				TaskID:   strconv.Itoa(t.resp.ID),
+			})
+		}
+	}
+
+	s.respondJSON(w, http.StatusOK, locks)
+}
+
+func (s *Server) sortedDeployments() []boshdir.DeploymentResp {
+	deps := []boshdir.DeploymentResp{}
+
+	for _, dep := range s.deployments {
+		deps = append(deps, dep)
+	}
+
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+
+	return deps
+}
+
+func (s *Server) respondJSON(w http.ResponseWriter, status int, obj interface{}) {
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(bytes) //nolint:errcheck
+}
+
+// respondError responds similarly to Director's errors
+func (s *Server) respondError(w http.ResponseWriter, status int, description string) {
+	s.respondJSON(w, status, map[string]interface{}{"code": 10000, "description": description})
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	defer req.Body.Close() //nolint:errcheck
+
+	return io.ReadAll(req.Body)
+}
+
+func manifestLines(manifest string) []string {
+	var lines []string
+
+	for _, line := range strings.Split(manifest, "\n") {
+		if len(strings.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeSegment(obj interface{}) string {
+	bytes, _ := json.Marshal(obj) //nolint:errcheck
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
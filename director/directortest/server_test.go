@@ -0,0 +1,371 @@
+package directortest_test
+
+import (
+	"strings"
+	"sync"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	"github.com/cloudfoundry/bosh-cli/v7/director/directortest"
+	boshuaa "github.com/cloudfoundry/bosh-cli/v7/uaa"
+)
+
+type recordingTaskReporter struct {
+	mu     sync.Mutex
+	output []string
+	states []string
+}
+
+func (r *recordingTaskReporter) TaskStarted(int) {}
+
+func (r *recordingTaskReporter) TaskFinished(_ int, state string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states = append(r.states, state)
+}
+
+func (r *recordingTaskReporter) TaskOutputChunk(_ int, chunk []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.output = append(r.output, string(chunk))
+}
+
+func (r *recordingTaskReporter) TaskHeartbeat(_ int, state string, _ int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states = append(r.states, state)
+}
+
+var _ = Describe("Server", func() {
+	var (
+		server   *directortest.Server
+		director boshdir.Director
+	)
+
+	buildDirector := func(client, clientSecret string, tokenFunc func(bool) (string, error)) boshdir.Director {
+		config, err := boshdir.NewConfigFromURL(server.URL())
+		Expect(err).ToNot(HaveOccurred())
+
+		config.Client = client
+		config.ClientSecret = clientSecret
+		config.TokenFunc = tokenFunc
+		config.CACert = server.CACert()
+
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+
+		dir, err := boshdir.NewFactory(logger).New(config, boshdir.NewNoopTaskReporter(), boshdir.NewNoopFileReporter())
+		Expect(err).ToNot(HaveOccurred())
+
+		return dir
+	}
+
+	BeforeEach(func() {
+		server = directortest.NewServer()
+		server.SetBasicAuth("admin", "admin-password")
+
+		director = buildDirector("admin", "admin-password", nil)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("info", func() {
+		It("serves director info with basic authentication", func() {
+			server.SetInfo(boshdir.InfoResp{Name: "fake-director", Version: "280.0.0", CPI: "fake-cpi"})
+
+			info, err := director.Info()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Name).To(Equal("fake-director"))
+			Expect(info.Version).To(Equal("280.0.0"))
+			Expect(info.CPI).To(Equal("fake-cpi"))
+			Expect(info.Auth.Type).To(Equal("basic"))
+		})
+
+		It("rejects requests with wrong credentials", func() {
+			director = buildDirector("admin", "wrong", nil)
+
+			_, err := director.ListDeployments()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'401'"))
+		})
+	})
+
+	Describe("deployments", func() {
+		It("lists deployments and their manifests", func() {
+			server.AddDeployment(boshdir.DeploymentResp{
+				Name:     "dep2",
+				Manifest: "name: dep2\n",
+				Releases: []boshdir.DeploymentReleaseResp{{Name: "rel", Version: "1"}},
+			})
+			server.AddDeployment(boshdir.DeploymentResp{Name: "dep1", Manifest: "name: dep1\n"})
+
+			deps, err := director.ListDeployments()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deps).To(HaveLen(2))
+			Expect(deps[0].Name).To(Equal("dep1"))
+			Expect(deps[1].Releases).To(Equal([]boshdir.DeploymentReleaseResp{{Name: "rel", Version: "1"}}))
+
+			dep, err := director.FindDeployment("dep2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dep.Manifest()).To(Equal("name: dep2\n"))
+		})
+
+		It("deploys manifest via successful task", func() {
+			dep, err := director.FindDeployment("dep")
+			Expect(err).ToNot(HaveOccurred())
+
+			manifest := []byte("name: dep\nreleases:\n- name: rel\n  version: 2\n")
+
+			diff, err := dep.Diff(manifest, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Diff).To(ContainElement([]interface{}{"name: dep", "added"}))
+
+			err = dep.Update(manifest, boshdir.UpdateOpts{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(server.Deployments()).To(HaveLen(1))
+			Expect(server.Deployments()[0].Manifest).To(Equal(string(manifest)))
+			Expect(server.Deployments()[0].Releases).To(Equal([]boshdir.DeploymentReleaseResp{{Name: "rel", Version: "2"}}))
+
+			Expect(server.Tasks()).To(HaveLen(1))
+			Expect(server.Tasks()[0].State).To(Equal("done"))
+			Expect(server.Tasks()[0].Deployment).To(Equal("dep"))
+
+			Expect(server.ReceivedRequests()).To(ContainElement("POST /deployments"))
+		})
+
+		It("does not change deployment if task fails", func() {
+			server.QueueTaskOutcome(directortest.TaskOutcome{State: "error", Polls: 1})
+
+			dep, err := director.FindDeployment("dep")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = dep.Update([]byte("name: dep\n"), boshdir.UpdateOpts{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected task '1' to succeed but state is 'error'"))
+
+			Expect(server.Deployments()).To(BeEmpty())
+		})
+
+		It("deletes deployment", func() {
+			server.AddDeployment(boshdir.DeploymentResp{Name: "dep"})
+
+			dep, err := director.FindDeployment("dep")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = dep.Delete(false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(server.Deployments()).To(BeEmpty())
+		})
+	})
+
+	Describe("tasks", func() {
+		It("streams event output of running task", func() {
+			id := server.AddTask("fake-task", "dep", directortest.TaskOutcome{
+				Polls:  2,
+				Events: []string{`{"time":1,"stage":"stage-one"}`, `{"time":2,"stage":"stage-two"}`},
+				Result: "fake-result",
+			})
+
+			task, err := director.FindTask(id)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(task.State()).To(Equal("processing"))
+
+			reporter := &recordingTaskReporter{}
+
+			err = task.EventOutput(reporter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(strings.Join(reporter.output, "")).To(Equal(
+				`{"time":1,"stage":"stage-one"}` + "\n" + `{"time":2,"stage":"stage-two"}` + "\n"))
+			Expect(reporter.states).To(Equal([]string{"processing", "done"}))
+
+			reporter = &recordingTaskReporter{}
+
+			err = task.ResultOutput(reporter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(strings.Join(reporter.output, "")).To(Equal("fake-result"))
+		})
+
+		It("lists current and recent tasks newest first", func() {
+			server.AddTask("task-1", "dep1", directortest.TaskOutcome{})
+			server.AddTask("task-2", "dep2", directortest.TaskOutcome{Polls: 1})
+			server.AddTask("task-3", "dep1", directortest.TaskOutcome{Polls: 1})
+
+			tasks, err := director.CurrentTasks(boshdir.TasksFilter{Deployment: "dep1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tasks).To(HaveLen(2))
+			Expect(tasks[0].Description()).To(Equal("task-3"))
+
+			tasks, err = director.RecentTasks(1, boshdir.TasksFilter{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tasks).To(HaveLen(1))
+			Expect(tasks[0].ID()).To(Equal(3))
+		})
+
+		It("cancels running task", func() {
+			id := server.AddTask("fake-task", "", directortest.TaskOutcome{Polls: 5})
+
+			task, err := director.FindTask(id)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = task.Cancel()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(server.Tasks()[0].State).To(Equal("cancelled"))
+		})
+	})
+
+	Describe("locks", func() {
+		It("lists added locks and locks held by running deployment tasks", func() {
+			server.AddLock(boshdir.LockResp{Type: "release", Resource: []string{"rel"}, Timeout: "1443889622", TaskID: "7"})
+			id := server.AddTask("fake-task", "dep", directortest.TaskOutcome{Polls: 1})
+
+			locks, err := director.Locks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(locks).To(HaveLen(2))
+			Expect(locks[0].Resource).To(Equal([]string{"rel"}))
+			Expect(locks[1].Type).To(Equal("deployment"))
+			Expect(locks[1].Resource).To(Equal([]string{"dep"}))
+			Expect(locks[1].TaskID).To(Equal("1"))
+
+			task, err := director.FindTask(id)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(task.EventOutput(&recordingTaskReporter{})).To(Succeed())
+
+			server.RemoveLocks()
+
+			locks, err = director.Locks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(locks).To(BeEmpty())
+		})
+	})
+
+	Describe("configs", func() {
+		It("updates, lists and deletes configs", func() {
+			server.AddConfig("cloud", "default", "azs: []\n")
+
+			_, err := director.UpdateConfig("cloud", "default", "", []byte("azs: [z1]\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			latest, err := director.LatestConfig("cloud", "default")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(latest.ID).To(Equal("2"))
+			Expect(latest.Content).To(Equal("azs: [z1]\n"))
+
+			configs, err := director.ListConfigs(10, boshdir.ConfigsFilter{Type: "cloud"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configs).To(HaveLen(2))
+
+			config, err := director.LatestConfigByID("1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Content).To(Equal("azs: []\n"))
+
+			deleted, err := director.DeleteConfig("cloud", "default")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			_, err = director.LatestConfig("cloud", "default")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("releases and stemcells", func() {
+		It("lists releases and stemcells", func() {
+			server.AddRelease("rel", boshdir.ReleaseVersionResp{Version: "1", CommitHash: "abc"})
+			server.AddRelease("rel", boshdir.ReleaseVersionResp{Version: "2", CurrentlyDeployed: true})
+			server.AddStemcell(boshdir.StemcellResp{Name: "stemcell", Version: "1.1", OperatingSystem: "ubuntu-jammy"})
+
+			releases, err := director.Releases()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(releases).To(HaveLen(2))
+			Expect(releases[0].Name()).To(Equal("rel"))
+			Expect(releases[1].VersionMark("*")).To(Equal("*"))
+
+			stemcells, err := director.Stemcells()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stemcells).To(HaveLen(1))
+			Expect(stemcells[0].OSName()).To(Equal("ubuntu-jammy"))
+		})
+	})
+
+	Describe("UAA", func() {
+		var (
+			uaa boshuaa.UAA
+		)
+
+		BeforeEach(func() {
+			server.AddUAAClient("ci", "ci-secret")
+			server.AddUAAUser("admin", "admin-password")
+
+			info, err := director.Info()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Auth.Type).To(Equal("uaa"))
+
+			config, err := boshuaa.NewConfigFromURL(info.Auth.Options["url"].(string))
+			Expect(err).ToNot(HaveOccurred())
+
+			config.Client = "ci"
+			config.ClientSecret = "ci-secret"
+			config.CACert = server.CACert()
+
+			uaa, err = boshuaa.NewFactory(boshlog.NewLogger(boshlog.LevelNone)).New(config)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("accepts tokens issued via client credentials grant", func() {
+			token, err := uaa.ClientCredentialsGrant()
+			Expect(err).ToNot(HaveOccurred())
+
+			tokenInfo, err := boshuaa.NewTokenInfoFromValue(token.Value())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tokenInfo.Username).To(Equal("ci"))
+
+			director = buildDirector("", "", func(bool) (string, error) {
+				return token.Type() + " " + token.Value(), nil
+			})
+
+			_, err = director.ListDeployments()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("refreshes tokens issued via password grant", func() {
+			token, err := uaa.OwnerPasswordCredentialsGrant([]boshuaa.PromptAnswer{
+				{Key: "username", Value: "admin"},
+				{Key: "password", Value: "admin-password"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			server.RevokeTokens()
+
+			director = buildDirector("", "", func(bool) (string, error) {
+				return token.Type() + " " + token.Value(), nil
+			})
+
+			_, err = director.ListDeployments()
+			Expect(err).To(HaveOccurred())
+
+			refreshable, ok := token.(boshuaa.RefreshableAccessToken)
+			Expect(ok).To(BeTrue())
+
+			token, err = uaa.RefreshTokenGrant(refreshable.RefreshValue())
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = director.ListDeployments()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("rejects unknown tokens", func() {
+			director = buildDirector("", "", func(bool) (string, error) { return "bearer unknown", nil })
+
+			_, err := director.ListDeployments()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'401'"))
+		})
+	})
+})
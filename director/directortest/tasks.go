@@ -0,0 +1,258 @@
+package directortest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+)
+
+// TaskOutcome scripts how task started by Director finishes
+type TaskOutcome struct {
+	// State is final task state, e.g. "done" (default), "error" or "cancelled";
+	// changes requested by task are only applied when it's "done"
+	State string
+
+	// Polls is number of times task is reported as processing before it finishes
+	Polls int
+
+	// Events are lines of event output, e.g. `{"time":1,"stage":"Updating","state":"started"}`
+	Events []string
+
+	// Result is task's result output
+	Result string
+}
+
+type task struct {
+	resp    boshdir.TaskResp
+	outcome TaskOutcome
+	polls   int
+
+	// effect applies changes requested by task once it succeeds
+	effect func()
+}
+
+func (t *task) running() bool {
+	return t.resp.State == "queued" || t.resp.State == "processing" || t.resp.State == "cancelling"
+}
+
+func (t *task) finish() {
+	t.resp.State = t.outcome.State
+	t.resp.FinishedAt = time.Now().Unix()
+
+	if t.resp.State == "done" && t.effect != nil {
+		t.effect()
+	}
+}
+
+func (t *task) output(type_ string) string {
+	switch type_ {
+	case "event":
+		if len(t.outcome.Events) == 0 {
+			return ""
+		}
+		return strings.Join(t.outcome.Events, "\n") + "\n"
+	case "result":
+		return t.outcome.Result
+	default:
+		return ""
+	}
+}
+
+// QueueTaskOutcome scripts outcome of next task started via API;
+// tasks started without queued outcome succeed immediately
+func (s *Server) QueueTaskOutcome(outcome TaskOutcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outcomes = append(s.outcomes, outcome)
+}
+
+// AddTask adds task as if it was started by another client and returns its ID
+func (s *Server) AddTask(description, deployment string, outcome TaskOutcome) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addTask(description, deployment, outcome, nil).resp.ID
+}
+
+// Tasks returns all tasks, oldest first
+func (s *Server) Tasks() []boshdir.TaskResp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var resps []boshdir.TaskResp
+
+	for _, t := range s.tasks {
+		resps = append(resps, t.resp)
+	}
+
+	return resps
+}
+
+func (s *Server) addTask(description, deployment string, outcome TaskOutcome, effect func()) *task {
+	if len(outcome.State) == 0 {
+		outcome.State = "done"
+	}
+
+	t := &task{
+		resp: boshdir.TaskResp{
+			ID:          len(s.tasks) + 1,
+			StartedAt:   time.Now().Unix(),
+			State:       "queued",
+			User:        s.info.User,
+			Deployment:  deployment,
+			Description: description,
+		},
+		outcome: outcome,
+		polls:   outcome.Polls,
+		effect:  effect,
+	}
+
+	s.tasks = append(s.tasks, t)
+
+	return t
+}
+
+// startTask responds with redirect to task similarly to Director
+func (s *Server) startTask(w http.ResponseWriter, description, deployment string, effect func()) {
+	var outcome TaskOutcome
+
+	if len(s.outcomes) > 0 {
+		outcome = s.outcomes[0]
+		s.outcomes = s.outcomes[1:]
+	}
+
+	t := s.addTask(description, deployment, outcome, effect)
+
+	w.Header().Set("Location", fmt.Sprintf("%s/tasks/%d", s.server.URL, t.resp.ID))
+	w.WriteHeader(http.StatusFound)
+}
+
+func (s *Server) findTask(w http.ResponseWriter, idStr string) (*task, bool) {
+	id, err := strconv.Atoi(idStr)
+	if err == nil && id > 0 && id <= len(s.tasks) {
+		return s.tasks[id-1], true
+	}
+
+	s.respondError(w, http.StatusNotFound, fmt.Sprintf("Task %s not found", idStr))
+
+	return nil, false
+}
+
+func (s *Server) serveTasks(w http.ResponseWriter, req *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && req.Method == http.MethodGet:
+		s.respondJSON(w, http.StatusOK, s.filteredTasks(req))
+
+	case len(segments) == 1 && req.Method == http.MethodGet:
+		t, found := s.findTask(w, segments[0])
+		if !found {
+			return
+		}
+
+		if t.running() {
+			if t.polls == 0 {
+				t.finish()
+			} else {
+				t.polls--
+				t.resp.State = "processing"
+			}
+		}
+
+		s.respondJSON(w, http.StatusOK, t.resp)
+
+	case len(segments) == 1 && req.Method == http.MethodDelete:
+		t, found := s.findTask(w, segments[0])
+		if !found {
+			return
+		}
+
+		if !t.running() {
+			s.respondError(w, http.StatusBadRequest, fmt.Sprintf("Task %d is not running", t.resp.ID))
+			return
+		}
+
+		t.resp.State = "cancelled"
+		t.resp.FinishedAt = time.Now().Unix()
+
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(segments) == 2 && segments[1] == "output" && req.Method == http.MethodGet:
+		t, found := s.findTask(w, segments[0])
+		if !found {
+			return
+		}
+
+		s.serveTaskOutput(w, req, t.output(req.URL.Query().Get("type")))
+
+	default:
+		s.respondError(w, http.StatusNotFound, fmt.Sprintf("Unknown endpoint '%s %s'", req.Method, req.URL.Path))
+	}
+}
+
+// serveTaskOutput honors 'Range: bytes=N-' header used to stream task output
+func (s *Server) serveTaskOutput(w http.ResponseWriter, req *http.Request, output string) {
+	rangeHeader := req.Header.Get("Range")
+
+	if len(rangeHeader) == 0 {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(output)) //nolint:errcheck
+		return
+	}
+
+	offset, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+	if err != nil || offset < 0 {
+		s.respondError(w, http.StatusBadRequest, fmt.Sprintf("Malformed range '%s'", rangeHeader))
+		return
+	}
+
+	if offset >= len(output) {
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		w.Write([]byte("Byte range unsatisfiable\n")) //nolint:errcheck
+		return
+	}
+
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(output)-1, len(output)))
+	w.WriteHeader(http.StatusPartialContent)
+	w.Write([]byte(output[offset:])) //nolint:errcheck
+}
+
+// filteredTasks returns newest tasks first similarly to Director
+func (s *Server) filteredTasks(req *http.Request) []boshdir.TaskResp {
+	query := req.URL.Query()
+
+	var states []string
+	if len(query.Get("state")) > 0 {
+		states = strings.Split(query.Get("state"), ",")
+	}
+
+	resps := []boshdir.TaskResp{}
+
+	for _, t := range s.tasks {
+		if len(states) > 0 && !contains(states, t.resp.State) {
+			continue
+		}
+		if len(query.Get("deployment")) > 0 && query.Get("deployment") != t.resp.Deployment {
+			continue
+		}
+		if len(query.Get("context_id")) > 0 && query.Get("context_id") != t.resp.ContextId {
+			continue
+		}
+
+		resps = append(resps, t.resp)
+	}
+
+	sort.Slice(resps, func(i, j int) bool { return resps[i].ID > resps[j].ID })
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err == nil && limit > 0 && limit < len(resps) {
+		resps = resps[:limit]
+	}
+
+	return resps
+}
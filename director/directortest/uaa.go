@@ -0,0 +1,123 @@
+package directortest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AddUAAClient makes Director advertise UAA, which is served by the same server,
+// and accept tokens issued via client credentials grant for given client
+func (s *Server) AddUAAClient(client, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.enableUAA()
+	s.uaaClients[client] = secret
+}
+
+// AddUAAUser makes Director advertise UAA, which is served by the same server,
+// and accept tokens issued via password grant for given user
+func (s *Server) AddUAAUser(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.enableUAA()
+	s.uaaUsers[username] = password
+}
+
+// RevokeTokens makes Director reject all issued access tokens
+// so that clients have to refresh them
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]bool{}
+}
+
+func (s *Server) enableUAA() {
+	s.info.Auth.Type = "uaa"
+	s.info.Auth.Options = map[string]interface{}{"url": s.server.URL}
+}
+
+func (s *Server) serveLogin(w http.ResponseWriter) {
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"prompts": map[string][]string{
+			"username": {"text", "Email"},
+			"password": {"password", "Password"},
+		},
+	})
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "Malformed token request")
+		return
+	}
+
+	var username string
+
+	switch req.PostForm.Get("grant_type") {
+	case "client_credentials":
+		client, secret, ok := req.BasicAuth()
+
+		expectedSecret, found := s.uaaClients[client]
+		if !ok || !found || secret != expectedSecret {
+			s.respondError(w, http.StatusUnauthorized, "Bad credentials")
+			return
+		}
+
+		username = client
+
+	case "password":
+		username = req.PostForm.Get("username")
+
+		expectedPassword, found := s.uaaUsers[username]
+		if !found || req.PostForm.Get("password") != expectedPassword {
+			s.respondError(w, http.StatusUnauthorized, "Bad credentials")
+			return
+		}
+
+	case "refresh_token":
+		var found bool
+
+		username, found = s.refreshTokens[req.PostForm.Get("refresh_token")]
+		if !found {
+			s.respondError(w, http.StatusUnauthorized, "Invalid refresh token")
+			return
+		}
+
+	default:
+		s.respondError(w, http.StatusBadRequest, "Unsupported grant type")
+		return
+	}
+
+	accessToken := s.issueToken(username)
+	refreshToken := s.issueToken(username)
+
+	s.tokens[accessToken] = true
+	s.refreshTokens[refreshToken] = username
+
+	s.respondJSON(w, http.StatusOK, map[string]string{
+		"token_type":    "bearer",
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+	})
+}
+
+// issueToken returns JWT shaped token since clients inspect its claims
+func (s *Server) issueToken(username string) string {
+	s.issuedTokens++
+
+	header := encodeSegment(map[string]string{"alg": "none", "typ": "JWT"})
+
+	payload := encodeSegment(map[string]interface{}{
+		"user_name": username,
+		"scope":     []string{"bosh.admin"},
+		"exp":       time.Now().Add(time.Hour).Unix(),
+		"jti":       fmt.Sprintf("token-%d", s.issuedTokens),
+	})
+
+	return header + "." + payload + ".signature"
+}
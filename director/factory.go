@@ -78,7 +78,7 @@ func (f Factory) httpClient(factoryConfig FactoryConfig, taskReporter TaskReport
 		return nil
 	}
 
-	retryClient := httpclient.NewNetworkSafeRetryClient(rawClient, 5, 500*time.Millisecond, f.logger)
+	retryClient := NewContextRetryClient(rawClient, 5, 500*time.Millisecond, f.logger)
 
 	authedClient := NewAdjustableClient(retryClient, authAdjustment)
 
@@ -90,7 +90,16 @@ func (f Factory) httpClient(factoryConfig FactoryConfig, taskReporter TaskReport
 		Host:   net.JoinHostPort(factoryConfig.Host, fmt.Sprintf("%d", factoryConfig.Port)),
 	}
 
-	return NewClient(endpoint.String(), httpClient, taskReporter, fileReporter, f.logger), nil
+	// Blobstore may not be signed by Director CA, hence system root CAs are also trusted
+	blobstoreCertPool, err := factoryConfig.SystemAndCACertPool()
+	if err != nil {
+		return Client{}, err
+	}
+
+	client := NewClient(endpoint.String(), httpClient, taskReporter, fileReporter, f.logger)
+	client.blobstoreClient = httpclient.CreateDefaultClient(blobstoreCertPool)
+
+	return client, nil
 }
 
 func clearBody(req *http.Request) {
@@ -89,3 +89,18 @@ func (c FactoryConfig) CACertPool() (*x509.CertPool, error) {
 
 	return crypto.CertPoolFromPEM([]byte(c.CACert))
 }
+
+// SystemAndCACertPool returns system root CAs with Director CA appended
+// since blobstore URLs handed out by Director may point to external blobstores
+func (c FactoryConfig) SystemAndCACertPool() (*x509.CertPool, error) {
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Loading system root CAs")
+	}
+
+	if len(c.CACert) > 0 && !certPool.AppendCertsFromPEM([]byte(c.CACert)) {
+		return nil, bosherr.Error("Appending Director CA to system root CAs")
+	}
+
+	return certPool, nil
+}
@@ -0,0 +1,81 @@
+package director
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+// FSUploadProgressStore keeps upload progress of each tarball in its own file
+type FSUploadProgressStore struct {
+	dirPath string
+	fs      boshsys.FileSystem
+}
+
+func NewFSUploadProgressStore(dirPath string, fs boshsys.FileSystem) FSUploadProgressStore {
+	return FSUploadProgressStore{dirPath: dirPath, fs: fs}
+}
+
+func (s FSUploadProgressStore) Load(key string) (UploadProgress, bool, error) {
+	var progress UploadProgress
+
+	path := s.path(key)
+
+	if !s.fs.FileExists(path) {
+		return progress, false, nil
+	}
+
+	bytes, err := s.fs.ReadFile(path)
+	if err != nil {
+		return progress, false, bosherr.WrapErrorf(err, "Reading upload progress '%s'", path)
+	}
+
+	err = json.Unmarshal(bytes, &progress)
+	if err != nil {
+		return progress, false, bosherr.WrapErrorf(err, "Unmarshaling upload progress '%s'", path)
+	}
+
+	return progress, true, nil
+}
+
+func (s FSUploadProgressStore) Save(key string, progress UploadProgress) error {
+	err := s.fs.MkdirAll(s.dirPath, 0700)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating upload progress directory '%s'", s.dirPath)
+	}
+
+	bytes, err := json.Marshal(progress)
+	if err != nil {
+		return bosherr.WrapError(err, "Marshaling upload progress")
+	}
+
+	path := s.path(key)
+
+	// Presigned URLs grant access to the blobstore
+	err = s.fs.WriteFileQuietly(path, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing upload progress '%s'", path)
+	}
+
+	err = s.fs.Chmod(path, 0600)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Setting permissions of upload progress '%s'", path)
+	}
+
+	return nil
+}
+
+func (s FSUploadProgressStore) Delete(key string) error {
+	err := s.fs.RemoveAll(s.path(key))
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Deleting upload progress '%s'", s.path(key))
+	}
+
+	return nil
+}
+
+func (s FSUploadProgressStore) path(key string) string {
+	return filepath.Join(s.dirPath, key+".json")
+}
@@ -0,0 +1,80 @@
+package director_test
+
+import (
+	"errors"
+	"os"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/director"
+)
+
+var _ = Describe("FSUploadProgressStore", func() {
+	var (
+		fs    *fakesys.FakeFileSystem
+		store FSUploadProgressStore
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		store = NewFSUploadProgressStore("/uploads", fs)
+	})
+
+	progress := UploadProgress{
+		DirectorUUID: "director-uuid",
+		SHA1:         "sha1",
+		Size:         7,
+		UploadID:     "upload-id",
+		Offset:       4,
+	}
+
+	It("returns saved progress", func() {
+		err := store.Save("release-sha1", progress)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fs.GetFileTestStat("/uploads").FileMode).To(Equal(os.FileMode(0700)))
+		Expect(fs.GetFileTestStat("/uploads/release-sha1.json").FileMode).To(Equal(os.FileMode(0600)))
+
+		loaded, found, err := store.Load("release-sha1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(loaded).To(Equal(progress))
+	})
+
+	It("returns not found if progress was never saved", func() {
+		_, found, err := store.Load("release-sha1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("returns not found after progress is deleted", func() {
+		err := store.Save("release-sha1", progress)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = store.Delete("release-sha1")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, found, err := store.Load("release-sha1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("returns error if progress cannot be unmarshaled", func() {
+		err := fs.WriteFileString("/uploads/release-sha1.json", "-")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = store.Load("release-sha1")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Unmarshaling upload progress '/uploads/release-sha1.json'"))
+	})
+
+	It("returns error if progress cannot be written", func() {
+		fs.WriteFileError = errors.New("fake-err")
+
+		err := store.Save("release-sha1", progress)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
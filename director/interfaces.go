@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"io"
 	"os"
 	"time"
@@ -19,6 +20,7 @@ import (
 type Director interface {
 	IsAuthenticated() (bool, error)
 	WithContext(id string) Director
+	WithRequestContext(ctx context.Context, opts RequestContextOpts) Director
 	Info() (Info, error)
 
 	Locks() ([]Lock, error)
@@ -44,6 +46,7 @@ type Director interface {
 	FindReleaseSeries(ReleaseSeriesSlug) (ReleaseSeries, error)
 	UploadReleaseURL(url, sha1 string, rebase, fix bool) error
 	UploadReleaseFile(file UploadFile, rebase, fix bool) error
+	UploadReleaseFileResumable(file UploadFile, rebase, fix bool, opts ResumableUploadOpts) error
 	MatchPackages(manifest interface{}, compiled bool) ([]string, error)
 
 	Stemcells() ([]Stemcell, error)
@@ -51,6 +54,7 @@ type Director interface {
 	FindStemcell(StemcellSlug) (Stemcell, error)
 	UploadStemcellURL(url, sha1 string, fix bool) error
 	UploadStemcellFile(file UploadFile, fix bool) error
+	UploadStemcellFileResumable(file UploadFile, fix bool, opts ResumableUploadOpts) error
 
 	LatestConfig(configType string, name string) (Config, error)
 	LatestConfigByID(configID string) (Config, error)
@@ -135,6 +139,7 @@ type FileReporter interface {
 
 type Deployment interface {
 	Name() string
+	WithRequestContext(ctx context.Context, opts RequestContextOpts) Deployment
 	Manifest() (string, error)
 	CloudConfig() (string, error)
 	Diff([]byte, bool) (DeploymentDiff, error)
@@ -269,6 +274,12 @@ type Stemcell interface {
 	Delete(force bool) error
 }
 
+// RequestContextOpts configures requests made with a context.Context
+type RequestContextOpts struct {
+	// CancelTasks cancels director task when waiting for it is cancelled
+	CancelTasks bool
+}
+
 type TasksFilter struct {
 	All        bool
 	Deployment string
@@ -278,6 +289,7 @@ type TasksFilter struct {
 
 type Task interface {
 	ID() int
+	WithRequestContext(ctx context.Context, opts RequestContextOpts) Task
 	StartedAt() time.Time
 	FinishedAt() time.Time
 
@@ -0,0 +1,312 @@
+package inventory
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Change string
+
+const (
+	Added   Change = "added"
+	Removed Change = "removed"
+	Changed Change = "changed"
+)
+
+// DirectorChange is a Director property that differs, e.g. its version or CPI
+type DirectorChange struct {
+	Property string
+	From     string
+	To       string
+}
+
+// VersionChange lists versions of a release or a stemcell
+// when they are not the same on both Directors
+type VersionChange struct {
+	Name   string
+	Change Change
+	From   []string
+	To     []string
+}
+
+type ConfigChange struct {
+	Type   string
+	Name   string
+	Change Change
+	FromID string
+	ToID   string
+}
+
+type DeploymentChange struct {
+	Name    string
+	Change  Change
+	Details []string
+}
+
+type Result struct {
+	Director    []DirectorChange
+	Releases    []VersionChange
+	Stemcells   []VersionChange
+	Configs     []ConfigChange
+	Deployments []DeploymentChange
+}
+
+func (r Result) Empty() bool {
+	return len(r.Director) == 0 && len(r.Releases) == 0 && len(r.Stemcells) == 0 &&
+		len(r.Configs) == 0 && len(r.Deployments) == 0
+}
+
+type Differ struct{}
+
+func NewDiffer() Differ {
+	return Differ{}
+}
+
+func (d Differ) Diff(from, to Inventory) Result {
+	return Result{
+		Director:    d.director(from.Director, to.Director),
+		Releases:    d.versions(releaseVersions(from.Releases), releaseVersions(to.Releases)),
+		Stemcells:   d.versions(stemcellVersions(from.Stemcells), stemcellVersions(to.Stemcells)),
+		Configs:     d.configs(from.Configs, to.Configs),
+		Deployments: d.deployments(from.Deployments, to.Deployments),
+	}
+}
+
+func (d Differ) director(from, to Director) []DirectorChange {
+	var changes []DirectorChange
+
+	props := []struct {
+		name     string
+		from, to string
+	}{
+		{"version", from.Version, to.Version},
+		{"cpi", from.CPI, to.CPI},
+	}
+
+	for _, prop := range props {
+		if prop.from != prop.to {
+			changes = append(changes, DirectorChange{Property: prop.name, From: prop.from, To: prop.to})
+		}
+	}
+
+	for _, name := range sortedKeys(from.Features, to.Features) {
+		fromVal, fromFound := from.Features[name]
+		toVal, toFound := to.Features[name]
+
+		if fromVal != toVal || fromFound != toFound {
+			changes = append(changes, DirectorChange{
+				Property: "feature " + name,
+				From:     featureStatus(fromVal, fromFound),
+				To:       featureStatus(toVal, toFound),
+			})
+		}
+	}
+
+	return changes
+}
+
+func (d Differ) versions(from, to map[string][]string) []VersionChange {
+	var changes []VersionChange
+
+	for _, name := range sortedKeys(from, to) {
+		fromVersions, fromFound := from[name]
+		toVersions, toFound := to[name]
+
+		switch {
+		case !fromFound:
+			changes = append(changes, VersionChange{Name: name, Change: Added, To: toVersions})
+		case !toFound:
+			changes = append(changes, VersionChange{Name: name, Change: Removed, From: fromVersions})
+		case !reflect.DeepEqual(fromVersions, toVersions):
+			changes = append(changes, VersionChange{Name: name, Change: Changed, From: fromVersions, To: toVersions})
+		}
+	}
+
+	return changes
+}
+
+// configs compares contents since IDs are specific to each Director
+func (d Differ) configs(from, to []Config) []ConfigChange {
+	fromConfigs := map[string]Config{}
+	toConfigs := map[string]Config{}
+
+	for _, config := range from {
+		fromConfigs[config.Type+"/"+config.Name] = config
+	}
+
+	for _, config := range to {
+		toConfigs[config.Type+"/"+config.Name] = config
+	}
+
+	var changes []ConfigChange
+
+	for _, key := range sortedKeys(fromConfigs, toConfigs) {
+		fromConfig, fromFound := fromConfigs[key]
+		toConfig, toFound := toConfigs[key]
+
+		switch {
+		case !fromFound:
+			changes = append(changes, ConfigChange{Type: toConfig.Type, Name: toConfig.Name, Change: Added, ToID: toConfig.ID})
+		case !toFound:
+			changes = append(changes, ConfigChange{Type: fromConfig.Type, Name: fromConfig.Name, Change: Removed, FromID: fromConfig.ID})
+		case !yamlEqual(fromConfig.Content, toConfig.Content):
+			changes = append(changes, ConfigChange{
+				Type: fromConfig.Type, Name: fromConfig.Name, Change: Changed, FromID: fromConfig.ID, ToID: toConfig.ID})
+		}
+	}
+
+	return changes
+}
+
+func (d Differ) deployments(from, to []Deployment) []DeploymentChange {
+	fromDeps := map[string]Deployment{}
+	toDeps := map[string]Deployment{}
+
+	for _, dep := range from {
+		fromDeps[dep.Name] = dep
+	}
+
+	for _, dep := range to {
+		toDeps[dep.Name] = dep
+	}
+
+	var changes []DeploymentChange
+
+	for _, name := range sortedKeys(fromDeps, toDeps) {
+		fromDep, fromFound := fromDeps[name]
+		toDep, toFound := toDeps[name]
+
+		switch {
+		case !fromFound:
+			changes = append(changes, DeploymentChange{Name: name, Change: Added})
+		case !toFound:
+			changes = append(changes, DeploymentChange{Name: name, Change: Removed})
+		default:
+			details := d.deploymentDetails(fromDep, toDep)
+			if len(details) > 0 {
+				changes = append(changes, DeploymentChange{Name: name, Change: Changed, Details: details})
+			}
+		}
+	}
+
+	return changes
+}
+
+func (d Differ) deploymentDetails(from, to Deployment) []string {
+	var details []string
+
+	for _, change := range d.versions(nameVersions(from.Releases), nameVersions(to.Releases)) {
+		details = append(details, fmt.Sprintf("release '%s' %s", change.Name, versionsChange(change)))
+	}
+
+	for _, change := range d.versions(nameVersions(from.Stemcells), nameVersions(to.Stemcells)) {
+		details = append(details, fmt.Sprintf("stemcell '%s' %s", change.Name, versionsChange(change)))
+	}
+
+	if !reflect.DeepEqual(sortedStrings(from.Teams), sortedStrings(to.Teams)) {
+		details = append(details, fmt.Sprintf("teams %s -> %s", formatList(from.Teams), formatList(to.Teams)))
+	}
+
+	if !yamlEqual(from.Manifest, to.Manifest) {
+		details = append(details, "manifest differs")
+	}
+
+	return details
+}
+
+func versionsChange(change VersionChange) string {
+	return fmt.Sprintf("%s -> %s", formatList(change.From), formatList(change.To))
+}
+
+func formatList(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
+
+func releaseVersions(releases []Release) map[string][]string {
+	versions := map[string][]string{}
+
+	for _, rel := range releases {
+		versions[rel.Name] = append(versions[rel.Name], rel.Version)
+	}
+
+	return sortedValues(versions)
+}
+
+func stemcellVersions(stemcells []Stemcell) map[string][]string {
+	versions := map[string][]string{}
+
+	for _, stemcell := range stemcells {
+		versions[stemcell.Name] = append(versions[stemcell.Name], stemcell.Version)
+	}
+
+	return sortedValues(versions)
+}
+
+func nameVersions(nvs []NameVersion) map[string][]string {
+	versions := map[string][]string{}
+
+	for _, nv := range nvs {
+		versions[nv.Name] = append(versions[nv.Name], nv.Version)
+	}
+
+	return sortedValues(versions)
+}
+
+func sortedValues(m map[string][]string) map[string][]string {
+	for k, v := range m {
+		m[k] = sortedStrings(v)
+	}
+	return m
+}
+
+func sortedStrings(items []string) []string {
+	sorted := append([]string{}, items...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func sortedKeys[V any](from, to map[string]V) []string {
+	var keys []string
+
+	for k := range from {
+		keys = append(keys, k)
+	}
+
+	for k := range to {
+		if _, found := from[k]; !found {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func featureStatus(enabled, found bool) string {
+	if !found {
+		return "none"
+	}
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// yamlEqual compares YAML documents ignoring formatting and key order
+func yamlEqual(a, b string) bool {
+	var aObj, bObj interface{}
+
+	if yaml.Unmarshal([]byte(a), &aObj) != nil || yaml.Unmarshal([]byte(b), &bObj) != nil {
+		return a == b
+	}
+
+	return reflect.DeepEqual(aObj, bObj)
+}
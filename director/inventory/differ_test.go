@@ -0,0 +1,130 @@
+package inventory_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/director/inventory"
+)
+
+var _ = Describe("Differ", func() {
+	var (
+		from Inventory
+		to   Inventory
+	)
+
+	BeforeEach(func() {
+		from = Inventory{
+			Director: Director{Name: "a", Version: "280.0.0", CPI: "aws_cpi", Features: map[string]bool{"dns": true}},
+			Deployments: []Deployment{
+				{
+					Name:      "dep",
+					Manifest:  "name: dep\ninstances: 1\n",
+					Teams:     []string{"team1"},
+					Releases:  []NameVersion{{Name: "rel", Version: "1"}},
+					Stemcells: []NameVersion{{Name: "stemcell", Version: "1.1"}},
+				},
+				{Name: "only-from"},
+			},
+			Releases:  []Release{{Name: "rel", Version: "1"}, {Name: "old-rel", Version: "1"}},
+			Stemcells: []Stemcell{{Name: "stemcell", Version: "1.1"}},
+			Configs: []Config{
+				{ID: "1", Type: "cloud", Name: "default", Content: "vm_types: []\nnetworks: []\n"},
+				{ID: "2", Type: "runtime", Name: "dns", Content: "addons: []"},
+			},
+		}
+
+		to = Inventory{
+			Director: Director{Name: "b", Version: "280.0.0", CPI: "aws_cpi", Features: map[string]bool{"dns": true}},
+			Deployments: []Deployment{
+				{
+					Name:      "dep",
+					Manifest:  "instances: 1\nname: dep\n",
+					Teams:     []string{"team1"},
+					Releases:  []NameVersion{{Name: "rel", Version: "1"}},
+					Stemcells: []NameVersion{{Name: "stemcell", Version: "1.1"}},
+				},
+				{Name: "only-from"},
+			},
+			Releases:  []Release{{Name: "old-rel", Version: "1"}, {Name: "rel", Version: "1"}},
+			Stemcells: []Stemcell{{Name: "stemcell", Version: "1.1"}},
+			Configs: []Config{
+				{ID: "20", Type: "runtime", Name: "dns", Content: "addons: []"},
+				{ID: "10", Type: "cloud", Name: "default", Content: "networks: []\nvm_types: []\n"},
+			},
+		}
+	})
+
+	It("returns no differences for equivalent inventories of different directors", func() {
+		result := NewDiffer().Diff(from, to)
+		Expect(result.Empty()).To(BeTrue())
+	})
+
+	It("returns director changes", func() {
+		to.Director.Version = "281.0.0"
+		to.Director.Features = map[string]bool{"dns": false, "snapshots": true}
+
+		Expect(NewDiffer().Diff(from, to).Director).To(Equal([]DirectorChange{
+			{Property: "version", From: "280.0.0", To: "281.0.0"},
+			{Property: "feature dns", From: "enabled", To: "disabled"},
+			{Property: "feature snapshots", From: "none", To: "enabled"},
+		}))
+	})
+
+	It("returns release and stemcell version skew", func() {
+		to.Releases = []Release{{Name: "rel", Version: "2"}, {Name: "rel", Version: "1"}, {Name: "new-rel", Version: "1"}}
+		to.Stemcells = []Stemcell{{Name: "stemcell", Version: "1.2"}}
+
+		result := NewDiffer().Diff(from, to)
+
+		Expect(result.Releases).To(Equal([]VersionChange{
+			{Name: "new-rel", Change: Added, To: []string{"1"}},
+			{Name: "old-rel", Change: Removed, From: []string{"1"}},
+			{Name: "rel", Change: Changed, From: []string{"1"}, To: []string{"1", "2"}},
+		}))
+
+		Expect(result.Stemcells).To(Equal([]VersionChange{
+			{Name: "stemcell", Change: Changed, From: []string{"1.1"}, To: []string{"1.2"}},
+		}))
+	})
+
+	It("returns configs with different contents", func() {
+		to.Configs = []Config{
+			{ID: "10", Type: "cloud", Name: "default", Content: "vm_types: [small]"},
+			{ID: "30", Type: "cpi", Name: "default", Content: "cpis: []"},
+		}
+
+		Expect(NewDiffer().Diff(from, to).Configs).To(Equal([]ConfigChange{
+			{Type: "cloud", Name: "default", Change: Changed, FromID: "1", ToID: "10"},
+			{Type: "cpi", Name: "default", Change: Added, ToID: "30"},
+			{Type: "runtime", Name: "dns", Change: Removed, FromID: "2"},
+		}))
+	})
+
+	It("returns deployments missing on one side and changed deployments", func() {
+		to.Deployments = []Deployment{
+			{
+				Name:      "dep",
+				Manifest:  "name: dep\ninstances: 2\n",
+				Teams:     []string{"team2"},
+				Releases:  []NameVersion{{Name: "rel", Version: "2"}},
+				Stemcells: []NameVersion{{Name: "stemcell", Version: "1.1"}},
+			},
+			{Name: "only-to"},
+		}
+
+		Expect(NewDiffer().Diff(from, to).Deployments).To(Equal([]DeploymentChange{
+			{
+				Name:   "dep",
+				Change: Changed,
+				Details: []string{
+					"release 'rel' 1 -> 2",
+					"teams team1 -> team2",
+					"manifest differs",
+				},
+			},
+			{Name: "only-from", Change: Removed},
+			{Name: "only-to", Change: Added},
+		}))
+	})
+})
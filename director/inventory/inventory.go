@@ -0,0 +1,254 @@
+package inventory
+
+import (
+	"sort"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+)
+
+// Inventory captures Director's state so that it can be compared with another Director
+type Inventory struct {
+	Director    Director     `json:"director"`
+	Deployments []Deployment `json:"deployments"`
+	Releases    []Release    `json:"releases"`
+	Stemcells   []Stemcell   `json:"stemcells"`
+	Configs     []Config     `json:"configs"`
+}
+
+type Director struct {
+	Name    string `json:"name"`
+	UUID    string `json:"uuid"`
+	Version string `json:"version"`
+	CPI     string `json:"cpi"`
+
+	Features map[string]bool `json:"features"`
+}
+
+type Deployment struct {
+	Name     string `json:"name"`
+	Manifest string `json:"manifest"`
+
+	Teams     []string      `json:"teams"`
+	Releases  []NameVersion `json:"releases"`
+	Stemcells []NameVersion `json:"stemcells"`
+
+	// Configs that deployment was last deployed with
+	Configs []ConfigRef `json:"configs"`
+}
+
+type NameVersion struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type ConfigRef struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type Release struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	CommitHash string `json:"commit_hash"`
+}
+
+type Stemcell struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	CPI     string `json:"cpi"`
+}
+
+// Config is the latest version of each config type and name
+type Config struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Team      string `json:"team"`
+	CreatedAt string `json:"created_at"`
+	Content   string `json:"content"`
+}
+
+type Collector struct {
+	director boshdir.Director
+}
+
+func NewCollector(director boshdir.Director) Collector {
+	return Collector{director: director}
+}
+
+func (c Collector) Collect() (Inventory, error) {
+	var inv Inventory
+
+	info, err := c.director.Info()
+	if err != nil {
+		return inv, bosherr.WrapError(err, "Fetching director info")
+	}
+
+	inv.Director = Director{
+		Name:     info.Name,
+		UUID:     info.UUID,
+		Version:  info.Version,
+		CPI:      info.CPI,
+		Features: info.Features,
+	}
+
+	inv.Deployments, err = c.deployments()
+	if err != nil {
+		return inv, err
+	}
+
+	inv.Releases, err = c.releases()
+	if err != nil {
+		return inv, err
+	}
+
+	inv.Stemcells, err = c.stemcells()
+	if err != nil {
+		return inv, err
+	}
+
+	inv.Configs, err = c.configs()
+	if err != nil {
+		return inv, err
+	}
+
+	return inv, nil
+}
+
+func (c Collector) deployments() ([]Deployment, error) {
+	resps, err := c.director.ListDeployments()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Listing deployments")
+	}
+
+	deployments := []Deployment{}
+
+	for _, resp := range resps {
+		dep := Deployment{
+			Name:      resp.Name,
+			Teams:     resp.Teams,
+			Releases:  []NameVersion{},
+			Stemcells: []NameVersion{},
+			Configs:   []ConfigRef{},
+		}
+
+		for _, rel := range resp.Releases {
+			dep.Releases = append(dep.Releases, NameVersion{Name: rel.Name, Version: rel.Version})
+		}
+
+		for _, stemcell := range resp.Stemcells {
+			dep.Stemcells = append(dep.Stemcells, NameVersion{Name: stemcell.Name, Version: stemcell.Version})
+		}
+
+		deployment, err := c.director.FindDeployment(resp.Name)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Finding deployment '%s'", resp.Name)
+		}
+
+		dep.Manifest, err = deployment.Manifest()
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Fetching manifest of deployment '%s'", resp.Name)
+		}
+
+		configs, err := c.director.ListDeploymentConfigs(resp.Name)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Listing configs of deployment '%s'", resp.Name)
+		}
+
+		for _, config := range configs.GetConfigs() {
+			dep.Configs = append(dep.Configs, ConfigRef{ID: config.Id, Type: config.Type, Name: config.Name})
+		}
+
+		deployments = append(deployments, dep)
+	}
+
+	sort.Slice(deployments, func(i, j int) bool { return deployments[i].Name < deployments[j].Name })
+
+	return deployments, nil
+}
+
+func (c Collector) releases() ([]Release, error) {
+	rels, err := c.director.Releases()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Listing releases")
+	}
+
+	releases := []Release{}
+
+	for _, rel := range rels {
+		releases = append(releases, Release{
+			Name:       rel.Name(),
+			Version:    rel.Version().String(),
+			CommitHash: rel.CommitHashWithMark(""),
+		})
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		if releases[i].Name == releases[j].Name {
+			return releases[i].Version < releases[j].Version
+		}
+		return releases[i].Name < releases[j].Name
+	})
+
+	return releases, nil
+}
+
+func (c Collector) stemcells() ([]Stemcell, error) {
+	scs, err := c.director.Stemcells()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Listing stemcells")
+	}
+
+	stemcells := []Stemcell{}
+
+	for _, sc := range scs {
+		stemcells = append(stemcells, Stemcell{
+			Name:    sc.Name(),
+			Version: sc.Version().String(),
+			OS:      sc.OSName(),
+			CPI:     sc.CPI(),
+		})
+	}
+
+	sort.Slice(stemcells, func(i, j int) bool {
+		if stemcells[i].Name == stemcells[j].Name {
+			return stemcells[i].Version < stemcells[j].Version
+		}
+		return stemcells[i].Name < stemcells[j].Name
+	})
+
+	return stemcells, nil
+}
+
+func (c Collector) configs() ([]Config, error) {
+	cfgs, err := c.director.ListConfigs(1, boshdir.ConfigsFilter{})
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Listing configs")
+	}
+
+	configs := []Config{}
+
+	for _, cfg := range cfgs {
+		configs = append(configs, Config{
+			ID:        cfg.ID,
+			Type:      cfg.Type,
+			Name:      cfg.Name,
+			Team:      cfg.Team,
+			CreatedAt: cfg.CreatedAt,
+			Content:   cfg.Content,
+		})
+	}
+
+	sort.Slice(configs, func(i, j int) bool {
+		if configs[i].Type == configs[j].Type {
+			return configs[i].Name < configs[j].Name
+		}
+		return configs[i].Type < configs[j].Type
+	})
+
+	return configs, nil
+}
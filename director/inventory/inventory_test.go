@@ -0,0 +1,155 @@
+package inventory_test
+
+import (
+	"errors"
+
+	semver "github.com/cppforlife/go-semi-semantic/version"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+	. "github.com/cloudfoundry/bosh-cli/v7/director/inventory"
+)
+
+var _ = Describe("Collector", func() {
+	var (
+		director   *fakedir.FakeDirector
+		deployment *fakedir.FakeDeployment
+		collector  Collector
+	)
+
+	BeforeEach(func() {
+		director = &fakedir.FakeDirector{}
+		deployment = &fakedir.FakeDeployment{}
+
+		director.InfoReturns(boshdir.Info{
+			Name:     "fake-director",
+			UUID:     "fake-uuid",
+			Version:  "280.0.0",
+			CPI:      "aws_cpi",
+			Features: map[string]bool{"dns": true},
+		}, nil)
+
+		director.ListDeploymentsReturns([]boshdir.DeploymentResp{
+			{
+				Name:      "dep2",
+				Releases:  []boshdir.DeploymentReleaseResp{{Name: "rel", Version: "2"}},
+				Stemcells: []boshdir.DeploymentStemcellResp{{Name: "stemcell", Version: "1.1"}},
+				Teams:     []string{"team1"},
+			},
+			{Name: "dep1"},
+		}, nil)
+
+		director.FindDeploymentReturns(deployment, nil)
+		deployment.ManifestReturns("name: dep\n", nil)
+
+		director.ListDeploymentConfigsStub = func(name string) (boshdir.DeploymentConfigs, error) {
+			if name == "dep2" {
+				return boshdir.DeploymentConfigs{
+					Configs: []boshdir.DeploymentConfig{
+						{Config: boshdir.DeploymentConfigProperties{Id: 3, Type: "cloud", Name: "default"}},
+					},
+				}, nil
+			}
+			return boshdir.DeploymentConfigs{}, nil
+		}
+
+		rel2 := &fakedir.FakeRelease{}
+		rel2.NameReturns("rel")
+		rel2.VersionReturns(semver.MustNewVersionFromString("2"))
+		rel2.CommitHashWithMarkReturns("abc")
+
+		rel1 := &fakedir.FakeRelease{}
+		rel1.NameReturns("rel")
+		rel1.VersionReturns(semver.MustNewVersionFromString("1"))
+
+		director.ReleasesReturns([]boshdir.Release{rel2, rel1}, nil)
+
+		stemcell := &fakedir.FakeStemcell{}
+		stemcell.NameReturns("stemcell")
+		stemcell.VersionReturns(semver.MustNewVersionFromString("1.1"))
+		stemcell.OSNameReturns("ubuntu")
+		stemcell.CPIReturns("aws")
+
+		director.StemcellsReturns([]boshdir.Stemcell{stemcell}, nil)
+
+		director.ListConfigsReturns([]boshdir.Config{
+			{ID: "4", Type: "runtime", Name: "dns", Content: "addons: []"},
+			{ID: "3", Type: "cloud", Name: "default", Team: "team1", CreatedAt: "today", Content: "vm_types: []"},
+		}, nil)
+
+		collector = NewCollector(director)
+	})
+
+	It("collects director state", func() {
+		inv, err := collector.Collect()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(inv).To(Equal(Inventory{
+			Director: Director{
+				Name:     "fake-director",
+				UUID:     "fake-uuid",
+				Version:  "280.0.0",
+				CPI:      "aws_cpi",
+				Features: map[string]bool{"dns": true},
+			},
+			Deployments: []Deployment{
+				{
+					Name:      "dep1",
+					Manifest:  "name: dep\n",
+					Releases:  []NameVersion{},
+					Stemcells: []NameVersion{},
+					Configs:   []ConfigRef{},
+				},
+				{
+					Name:      "dep2",
+					Manifest:  "name: dep\n",
+					Teams:     []string{"team1"},
+					Releases:  []NameVersion{{Name: "rel", Version: "2"}},
+					Stemcells: []NameVersion{{Name: "stemcell", Version: "1.1"}},
+					Configs:   []ConfigRef{{ID: 3, Type: "cloud", Name: "default"}},
+				},
+			},
+			Releases: []Release{
+				{Name: "rel", Version: "1"},
+				{Name: "rel", Version: "2", CommitHash: "abc"},
+			},
+			Stemcells: []Stemcell{
+				{Name: "stemcell", Version: "1.1", OS: "ubuntu", CPI: "aws"},
+			},
+			Configs: []Config{
+				{ID: "3", Type: "cloud", Name: "default", Team: "team1", CreatedAt: "today", Content: "vm_types: []"},
+				{ID: "4", Type: "runtime", Name: "dns", Content: "addons: []"},
+			},
+		}))
+
+		limit, filter := director.ListConfigsArgsForCall(0)
+		Expect(limit).To(Equal(1))
+		Expect(filter).To(Equal(boshdir.ConfigsFilter{}))
+	})
+
+	It("returns error if director info cannot be fetched", func() {
+		director.InfoReturns(boshdir.Info{}, errors.New("fake-err"))
+
+		_, err := collector.Collect()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Fetching director info: fake-err"))
+	})
+
+	It("returns error if deployment manifest cannot be fetched", func() {
+		deployment.ManifestReturns("", errors.New("fake-err"))
+
+		_, err := collector.Collect()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Fetching manifest of deployment 'dep2': fake-err"))
+	})
+
+	It("returns error if configs cannot be listed", func() {
+		director.ListConfigsReturns(nil, errors.New("fake-err"))
+
+		_, err := collector.Collect()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Listing configs: fake-err"))
+	})
+})
@@ -2,6 +2,8 @@ package director_test
 
 import (
 	"crypto/tls"
+	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -14,6 +16,9 @@ var (
 	cert        tls.Certificate
 	cacertBytes []byte
 	validCACert string
+
+	// blobstoreCert is signed by CA that is only trusted as system root CA
+	blobstoreCert tls.Certificate
 )
 var _ = BeforeSuite(func() {
 	var err error
@@ -25,5 +30,22 @@ var _ = BeforeSuite(func() {
 func TestReg(t *testing.T) {
 	RegisterFailHandler(Fail)
 
+	var systemCACertBytes []byte
+	var err error
+
+	blobstoreCert, systemCACertBytes, err = testutils.CertSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// System root CAs are loaded once hence are configured before any spec runs
+	systemCACertPath := filepath.Join(t.TempDir(), "system-ca.pem")
+	if err := os.WriteFile(systemCACertPath, systemCACertBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("SSL_CERT_FILE", systemCACertPath)
+	t.Setenv("SSL_CERT_DIR", "")
+
 	RunSpecs(t, "director")
 }
@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,6 +13,9 @@ type TaskClientRequest struct {
 	clientRequest         ClientRequest
 	taskReporter          TaskReporter
 	taskCheckStepDuration time.Duration
+
+	// cancelTasks cancels director task when waiting for it is cancelled
+	cancelTasks bool
 }
 
 func NewTaskClientRequest(
@@ -26,6 +30,14 @@ func NewTaskClientRequest(
 	}
 }
 
+// WithRequestContext returns a copy of the TaskClientRequest whose
+// requests and task waits stop when ctx is done
+func (r TaskClientRequest) WithRequestContext(ctx context.Context, opts RequestContextOpts) TaskClientRequest {
+	r.clientRequest = r.clientRequest.WithRequestContext(ctx)
+	r.cancelTasks = opts.CancelTasks
+	return r
+}
+
 type taskShortResp struct {
 	ID        int    `json:"id"`
 	State     string `json:"state"`      // e.g. "queued", "processing", "done", "error", "cancelled"
@@ -98,9 +110,14 @@ func (r TaskClientRequest) WaitForCompletion(id int, type_ string, taskReporter
 
 	taskPath := fmt.Sprintf("/tasks/%d", id)
 
+	ctx := r.clientRequest.RequestContext()
+
 	for {
 		err := r.clientRequest.Get(taskPath, &taskResp)
 		if err != nil {
+			if ctx.Err() != nil {
+				return r.stopWaiting(ctx, id)
+			}
 			return bosherr.WrapError(err, "Getting task state")
 		}
 
@@ -108,12 +125,21 @@ func (r TaskClientRequest) WaitForCompletion(id int, type_ string, taskReporter
 		// it's complete in case of task being finished
 		outputOffset, err = r.reportOutputChunk(taskResp.ID, outputOffset, type_, taskReporter)
 		if err != nil {
+			if ctx.Err() != nil {
+				return r.stopWaiting(ctx, id)
+			}
 			return bosherr.WrapError(err, "Getting task output")
 		}
 
 		if taskResp.IsRunning() {
 			taskReporter.TaskHeartbeat(taskResp.ID, taskResp.State, taskResp.StartedAt)
-			time.Sleep(r.taskCheckStepDuration)
+
+			select {
+			case <-ctx.Done():
+				return r.stopWaiting(ctx, id)
+			case <-time.After(r.taskCheckStepDuration):
+			}
+
 			continue
 		}
 
@@ -127,6 +153,23 @@ func (r TaskClientRequest) WaitForCompletion(id int, type_ string, taskReporter
 	}
 }
 
+// stopWaiting optionally cancels task whose wait was cancelled by ctx
+func (r TaskClientRequest) stopWaiting(ctx context.Context, id int) error {
+	if !r.cancelTasks {
+		return bosherr.WrapErrorf(ctx.Err(), "Waiting for task '%d'", id)
+	}
+
+	// Cancellation request itself must outlive cancelled context
+	clientRequest := r.clientRequest.WithRequestContext(context.WithoutCancel(ctx))
+
+	_, _, err := clientRequest.RawDelete(fmt.Sprintf("/task/%d", id))
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Cancelling task '%d' after waiting for it was cancelled", id)
+	}
+
+	return bosherr.WrapErrorf(ctx.Err(), "Waiting for task '%d' (task was cancelled)", id)
+}
+
 func (r TaskClientRequest) waitForResult(taskResp taskShortResp) ([]byte, error) {
 	err := r.WaitForCompletion(taskResp.ID, "event", r.taskReporter)
 	if err != nil {
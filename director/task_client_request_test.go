@@ -1,6 +1,7 @@
 package director_test
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
 	"time"
@@ -21,11 +22,15 @@ var _ = Describe("TaskClientRequest", func() {
 
 		buildReq func(TaskReporter) TaskClientRequest
 		req      TaskClientRequest
+
+		taskCheckStepDuration time.Duration
 	)
 
 	BeforeEach(func() {
 		_, server = BuildServer()
 
+		taskCheckStepDuration = 0 * time.Second
+
 		buildReq = func(taskReporter TaskReporter) TaskClientRequest {
 			httpTransport := &http.Transport{
 				TLSClientConfig:     &tls.Config{InsecureSkipVerify: true},
@@ -37,7 +42,7 @@ var _ = Describe("TaskClientRequest", func() {
 			httpClient := boshhttp.NewHTTPClient(rawClient, logger)
 			fileReporter := NewNoopFileReporter()
 			clientReq := NewClientRequest(server.URL(), httpClient, fileReporter, logger)
-			return NewTaskClientRequest(clientReq, taskReporter, 0*time.Second)
+			return NewTaskClientRequest(clientReq, taskReporter, taskCheckStepDuration)
 		}
 
 		req = buildReq(NewNoopTaskReporter())
@@ -509,5 +514,102 @@ var _ = Describe("TaskClientRequest", func() {
 			Expect(taskReporter.TaskStartedCallCount()).To(Equal(1))
 			Expect(taskReporter.TaskFinishedCallCount()).To(Equal(1))
 		})
+
+		Context("when request context is cancelled", func() {
+			var (
+				ctx    context.Context
+				cancel context.CancelFunc
+				opts   RequestContextOpts
+			)
+
+			BeforeEach(func() {
+				ctx, cancel = context.WithCancel(context.Background())
+				opts = RequestContextOpts{}
+
+				// Long check interval makes sure that waiting is interrupted by cancellation
+				taskCheckStepDuration = time.Minute
+				req = buildReq(NewNoopTaskReporter())
+
+				taskReporter.TaskHeartbeatStub = func(int, string, int64) { cancel() }
+			})
+
+			AfterEach(func() { cancel() })
+
+			act := func() error {
+				return req.WithRequestContext(ctx, opts).WaitForCompletion(123, "event", taskReporter)
+			}
+
+			processingTaskHandlers := []http.HandlerFunc{
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123"),
+					ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"processing"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123/output", "type=event"),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			}
+
+			It("stops waiting for task without cancelling it", func() {
+				server.AppendHandlers(processingTaskHandlers...)
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Waiting for task '123': context canceled"))
+
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+				Expect(taskReporter.TaskFinishedCallCount()).To(Equal(1))
+			})
+
+			It("cancels task when asked to", func() {
+				opts.CancelTasks = true
+
+				server.AppendHandlers(append(processingTaskHandlers,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/task/123"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)...)
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Waiting for task '123' (task was cancelled): context canceled"))
+
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+
+			It("cancels task when context is cancelled before getting task state", func() {
+				opts.CancelTasks = true
+				cancel()
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/task/123"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("context canceled"))
+
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			It("returns an error if cancelling task fails", func() {
+				opts.CancelTasks = true
+
+				server.AppendHandlers(append(processingTaskHandlers,
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/task/123"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)...)
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Cancelling task '123' after waiting for it was cancelled"))
+			})
+		})
 	})
 })
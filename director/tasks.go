@@ -1,6 +1,7 @@
 package director
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -31,6 +32,11 @@ func (t TaskImpl) ContextID() string     { return t.contextId }
 func (t TaskImpl) StartedAt() time.Time  { return t.startedAt }
 func (t TaskImpl) FinishedAt() time.Time { return t.finishedAt }
 
+func (t TaskImpl) WithRequestContext(ctx context.Context, opts RequestContextOpts) Task {
+	t.client = t.client.WithRequestContext(ctx, opts)
+	return t
+}
+
 func (t TaskImpl) State() string { return t.state }
 
 func (t TaskImpl) IsError() bool {
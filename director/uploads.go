@@ -0,0 +1,429 @@
+package director
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	gourl "net/url"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+/*
+Experimental: no released Director implements the API below yet.
+Tarballs are uploaded the regular way unless Director advertises one of its features.
+
+Resumable uploads send release and stemcell tarballs to the Director
+in verified chunks when it advertises 'chunked_uploads' feature:
+
+  POST /uploads {"size": 123, "sha1": "..."} => {"id": "upload-id", "offset": 0}
+  GET  /uploads/upload-id                    => {"id": "upload-id", "offset": 64}
+  PUT  /uploads/upload-id (Content-Range: bytes 64-127/123, X-Bosh-Chunk-Sha1: ...)
+
+Otherwise when it advertises 'presigned_uploads' feature tarballs are staged
+in the Director's blobstore via presigned URL and then imported the same way
+as remote releases and stemcells:
+
+  POST /uploads/presigned_urls {"sha1": "..."} => {"upload_url": "...", "download_url": "..."}
+*/
+
+const (
+	ChunkedUploadsFeature   = "chunked_uploads"
+	PresignedUploadsFeature = "presigned_uploads"
+
+	DefaultUploadChunkSize = 32 * 1024 * 1024
+
+	uploadChunkAttempts = 3
+)
+
+type ResumableUploadOpts struct {
+	// Resume continues upload recorded in Progress instead of starting over
+	Resume bool
+
+	ChunkSize int64
+	Progress  UploadProgressStore
+}
+
+//counterfeiter:generate . UploadProgressStore
+
+// UploadProgressStore persists upload progress so that later uploads of the same tarball can resume
+type UploadProgressStore interface {
+	Load(key string) (UploadProgress, bool, error)
+	Save(key string, progress UploadProgress) error
+	Delete(key string) error
+}
+
+type UploadProgress struct {
+	DirectorUUID string `json:"director_uuid"`
+	SHA1         string `json:"sha1"`
+	Size         int64  `json:"size"`
+
+	// Set for chunked uploads
+	UploadID string `json:"upload_id,omitempty"`
+	Offset   int64  `json:"offset,omitempty"`
+
+	// Set for uploads staged in the blobstore
+	UploadURL   string `json:"upload_url,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+	Staged      bool   `json:"staged,omitempty"`
+}
+
+type uploadResp struct {
+	ID     string `json:"id"`
+	Offset int64  `json:"offset"`
+}
+
+type presignedURLsResp struct {
+	UploadURL   string `json:"upload_url"`
+	DownloadURL string `json:"download_url"`
+}
+
+func (d DirectorImpl) UploadReleaseFileResumable(file UploadFile, rebase, fix bool, opts ResumableUploadOpts) error {
+	return d.client.UploadReleaseFileResumable(file, rebase, fix, opts)
+}
+
+func (d DirectorImpl) UploadStemcellFileResumable(file UploadFile, fix bool, opts ResumableUploadOpts) error {
+	return d.client.UploadStemcellFileResumable(file, fix, opts)
+}
+
+func (c Client) UploadReleaseFileResumable(file UploadFile, rebase, fix bool, opts ResumableUploadOpts) error {
+	info, err := c.Info()
+	if err != nil {
+		return err
+	}
+
+	if !resumableUploadsSupported(info) {
+		return c.UploadReleaseFile(file, rebase, fix)
+	}
+
+	query := gourl.Values{}
+
+	if rebase {
+		query.Add("rebase", "true")
+	}
+
+	if fix {
+		query.Add("fix", "true")
+	}
+
+	err = c.uploadResumable(file, info, "release", "/releases?"+query.Encode(), opts)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Uploading release file")
+	}
+
+	return nil
+}
+
+func (c Client) UploadStemcellFileResumable(file UploadFile, fix bool, opts ResumableUploadOpts) error {
+	info, err := c.Info()
+	if err != nil {
+		return err
+	}
+
+	if !resumableUploadsSupported(info) {
+		return c.UploadStemcellFile(file, fix)
+	}
+
+	query := gourl.Values{}
+
+	if fix {
+		query.Add("fix", "true")
+	}
+
+	err = c.uploadResumable(file, info, "stemcell", "/stemcells?"+query.Encode(), opts)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Uploading stemcell file")
+	}
+
+	return nil
+}
+
+// resumableUploadsSupported returns false for Directors that only accept regular uploads
+func resumableUploadsSupported(info InfoResp) bool {
+	return info.Features[ChunkedUploadsFeature].Status || info.Features[PresignedUploadsFeature].Status
+}
+
+func (c Client) uploadResumable(file UploadFile, info InfoResp, type_, importPath string, opts ResumableUploadOpts) error {
+	defer file.Close() //nolint:errcheck
+
+	seekableFile, ok := file.(io.ReadSeeker)
+	if !ok {
+		return bosherr.Error("Expected upload file to be seekable")
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Determining %s file size", type_)
+	}
+
+	sha1, err := c.uploadFileSHA1(seekableFile)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%s-%s", type_, sha1)
+
+	progress, err := c.loadUploadProgress(key, opts)
+	if err != nil {
+		return err
+	}
+
+	if !progress.matches(info.UUID, sha1, fileInfo.Size()) {
+		progress = UploadProgress{DirectorUUID: info.UUID, SHA1: sha1, Size: fileInfo.Size()}
+	}
+
+	var body map[string]interface{}
+
+	if info.Features[ChunkedUploadsFeature].Status {
+		body, err = c.uploadChunks(seekableFile, key, &progress, opts)
+	} else {
+		body, err = c.stageInBlobstore(seekableFile, key, &progress, opts)
+	}
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Uploading %s (run again with '--resume' to continue)", type_)
+	}
+
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Marshaling request body")
+	}
+
+	setHeaders := func(req *http.Request) {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	_, err = c.taskClientRequest.PostResult(importPath, reqBody, setHeaders)
+	if err != nil {
+		return err
+	}
+
+	err = opts.Progress.Delete(key)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Deleting upload progress")
+	}
+
+	return nil
+}
+
+func (c Client) uploadChunks(file io.ReadSeeker, key string, progress *UploadProgress, opts ResumableUploadOpts) (map[string]interface{}, error) {
+	if len(progress.UploadID) == 0 {
+		reqBody, err := json.Marshal(map[string]interface{}{"size": progress.Size, "sha1": progress.SHA1})
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Marshaling request body")
+		}
+
+		setHeaders := func(req *http.Request) {
+			req.Header.Add("Content-Type", "application/json")
+		}
+
+		var resp uploadResp
+
+		err = c.clientRequest.Post("/uploads", reqBody, setHeaders, &resp)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Starting chunked upload")
+		}
+
+		progress.UploadID = resp.ID
+		progress.Offset = resp.Offset
+
+		err = opts.Progress.Save(key, *progress)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Saving upload progress")
+		}
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultUploadChunkSize
+	}
+
+	// Director knows best how much of the file it has verified
+	offset, err := c.uploadOffset(progress.UploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Seeking to offset '%d'", offset)
+	}
+
+	reader := c.clientRequest.fileReporter.TrackUpload(progress.Size-offset, io.NopCloser(file))
+	defer reader.Close() //nolint:errcheck
+
+	chunk := make([]byte, chunkSize)
+
+	for offset < progress.Size {
+		n, err := io.ReadFull(reader, chunk[:min(chunkSize, progress.Size-offset)])
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading chunk at offset '%d'", offset)
+		}
+
+		offset, err = c.uploadChunk(progress, offset, chunk[:n])
+		if err != nil {
+			return nil, err
+		}
+
+		progress.Offset = offset
+
+		err = opts.Progress.Save(key, *progress)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Saving upload progress")
+		}
+	}
+
+	return map[string]interface{}{"upload_id": progress.UploadID, "sha1": progress.SHA1}, nil
+}
+
+// uploadChunk sends chunk starting at offset and returns offset verified by the Director
+func (c Client) uploadChunk(progress *UploadProgress, offset int64, chunk []byte) (int64, error) {
+	path := fmt.Sprintf("/uploads/%s", progress.UploadID)
+	digest := sha1.Sum(chunk)
+
+	setHeaders := func(req *http.Request) {
+		req.Header.Add("Content-Type", "application/octet-stream")
+		req.Header.Add("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(len(chunk))-1, progress.Size))
+		req.Header.Add("X-Bosh-Chunk-Sha1", hex.EncodeToString(digest[:]))
+	}
+
+	var err error
+
+	for i := 0; i < uploadChunkAttempts; i++ {
+		_, _, err = c.clientRequest.RawPut(path, chunk, setHeaders)
+		if err == nil {
+			return offset + int64(len(chunk)), nil
+		}
+
+		// Chunk may have been received even though response was lost
+		verified, offsetErr := c.uploadOffset(progress.UploadID)
+		if offsetErr == nil && verified == offset+int64(len(chunk)) {
+			return verified, nil
+		}
+	}
+
+	return 0, bosherr.WrapErrorf(err, "Uploading chunk at offset '%d'", offset)
+}
+
+func (c Client) uploadOffset(id string) (int64, error) {
+	var resp uploadResp
+
+	err := c.clientRequest.Get(fmt.Sprintf("/uploads/%s", id), &resp)
+	if err != nil {
+		return 0, bosherr.WrapErrorf(err, "Fetching upload '%s'", id)
+	}
+
+	return resp.Offset, nil
+}
+
+func (c Client) stageInBlobstore(file io.ReadSeeker, key string, progress *UploadProgress, opts ResumableUploadOpts) (map[string]interface{}, error) {
+	if len(progress.UploadURL) == 0 {
+		reqBody, err := json.Marshal(map[string]interface{}{"sha1": progress.SHA1})
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Marshaling request body")
+		}
+
+		setHeaders := func(req *http.Request) {
+			req.Header.Add("Content-Type", "application/json")
+		}
+
+		var resp presignedURLsResp
+
+		err = c.clientRequest.Post("/uploads/presigned_urls", reqBody, setHeaders, &resp)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Requesting presigned blobstore URLs")
+		}
+
+		progress.UploadURL = resp.UploadURL
+		progress.DownloadURL = resp.DownloadURL
+
+		err = opts.Progress.Save(key, *progress)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Saving upload progress")
+		}
+	}
+
+	if !progress.Staged {
+		_, err := file.Seek(0, io.SeekStart)
+		if err != nil {
+			return nil, bosherr.WrapError(err, "Seeking to beginning of file")
+		}
+
+		req, err := http.NewRequestWithContext(c.clientRequest.RequestContext(), "PUT", progress.UploadURL, nil)
+		if err != nil {
+			return nil, bosherr.WrapError(err, "Creating blobstore upload request")
+		}
+
+		req.ContentLength = progress.Size
+		req.Body = c.clientRequest.fileReporter.TrackUpload(progress.Size, io.NopCloser(file))
+
+		// Presigned URL carries its own credentials hence Director's auth is not used
+		resp, err := c.stagingClient().Do(req)
+		if err != nil {
+			return nil, bosherr.WrapError(err, "Staging file in blobstore")
+		}
+
+		defer resp.Body.Close() //nolint:errcheck
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			respBody, _ := io.ReadAll(resp.Body) //nolint:errcheck
+			return nil, bosherr.Errorf("Blobstore responded with non-successful status code '%d' response '%s'", resp.StatusCode, respBody)
+		}
+
+		progress.Staged = true
+
+		err = opts.Progress.Save(key, *progress)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Saving upload progress")
+		}
+	}
+
+	return map[string]interface{}{"location": progress.DownloadURL, "sha1": progress.SHA1}, nil
+}
+
+func (c Client) stagingClient() *http.Client {
+	if c.blobstoreClient != nil {
+		return c.blobstoreClient
+	}
+	return http.DefaultClient
+}
+
+func (c Client) loadUploadProgress(key string, opts ResumableUploadOpts) (UploadProgress, error) {
+	if !opts.Resume {
+		err := opts.Progress.Delete(key)
+		if err != nil {
+			return UploadProgress{}, bosherr.WrapErrorf(err, "Deleting upload progress")
+		}
+
+		return UploadProgress{}, nil
+	}
+
+	progress, _, err := opts.Progress.Load(key)
+	if err != nil {
+		return UploadProgress{}, bosherr.WrapErrorf(err, "Loading upload progress")
+	}
+
+	return progress, nil
+}
+
+func (c Client) uploadFileSHA1(file io.ReadSeeker) (string, error) {
+	hash := sha1.New()
+
+	_, err := io.Copy(hash, file)
+	if err != nil {
+		return "", bosherr.WrapError(err, "Calculating file SHA1")
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", bosherr.WrapError(err, "Seeking to beginning of file")
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (p UploadProgress) matches(directorUUID, sha1 string, size int64) bool {
+	return p.DirectorUUID == directorUUID && p.SHA1 == sha1 && p.Size == size
+}
@@ -0,0 +1,459 @@
+package director_test
+
+import (
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/hex"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/v7/director/directorfakes"
+)
+
+var _ = Describe("Resumable uploads", func() {
+	var (
+		director Director
+		server   *ghttp.Server
+
+		file          UploadFile
+		fileSHA1      string
+		progressStore *fakedir.FakeUploadProgressStore
+		uploadOpts    ResumableUploadOpts
+	)
+
+	BeforeEach(func() {
+		director, server = BuildServer()
+
+		tmpFile, err := os.CreateTemp("", "bosh-upload")
+		Expect(err).ToNot(HaveOccurred())
+
+		DeferCleanup(os.Remove, tmpFile.Name())
+
+		_, err = tmpFile.WriteString("content")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = tmpFile.Seek(0, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		file = tmpFile
+
+		digest := sha1.Sum([]byte("content"))
+		fileSHA1 = hex.EncodeToString(digest[:])
+
+		progressStore = &fakedir.FakeUploadProgressStore{}
+		uploadOpts = ResumableUploadOpts{ChunkSize: 4, Progress: progressStore}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	appendInfo := func(chunked bool) {
+		feature := "presigned_uploads"
+		if chunked {
+			feature = "chunked_uploads"
+		}
+
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/info"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+					"uuid":     "director-uuid",
+					"features": map[string]interface{}{feature: map[string]bool{"status": true}},
+				}),
+			),
+		)
+	}
+
+	appendChunk := func(contentRange, body string) {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/uploads/upload-id"),
+				ghttp.VerifyBasicAuth("username", "password"),
+				ghttp.VerifyHeader(http.Header{
+					"Content-Type":      []string{"application/octet-stream"},
+					"Content-Range":     []string{contentRange},
+					"X-Bosh-Chunk-Sha1": []string{sha1Hex(body)},
+				}),
+				ghttp.VerifyBody([]byte(body)),
+				ghttp.RespondWith(http.StatusOK, ""),
+			),
+		)
+	}
+
+	appendOffset := func(offset int) {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/uploads/upload-id"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{"id": "upload-id", "offset": offset}),
+			),
+		)
+	}
+
+	Context("when director supports neither chunked uploads nor blobstore staging", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/info"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"uuid":     "director-uuid",
+						"features": map[string]interface{}{"chunked_uploads": map[string]bool{"status": false}},
+					}),
+				),
+			)
+		})
+
+		It("uploads release file regularly", func() {
+			ConfigureTaskResult(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/releases", "rebase=true"),
+					ghttp.VerifyHeader(http.Header{"Content-Type": []string{"application/x-compressed"}}),
+					ghttp.VerifyBody([]byte("content")),
+				),
+				"",
+				server,
+			)
+
+			err := director.UploadReleaseFileResumable(file, true, false, uploadOpts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(progressStore.LoadCallCount()).To(Equal(0))
+			Expect(progressStore.SaveCallCount()).To(Equal(0))
+		})
+
+		It("uploads stemcell file regularly", func() {
+			ConfigureTaskResult(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/stemcells", "fix=true"),
+					ghttp.VerifyHeader(http.Header{"Content-Type": []string{"application/x-compressed"}}),
+					ghttp.VerifyBody([]byte("content")),
+				),
+				"",
+				server,
+			)
+
+			err := director.UploadStemcellFileResumable(file, true, uploadOpts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(progressStore.SaveCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when director supports chunked uploads", func() {
+		It("uploads release file in chunks and imports it", func() {
+			appendInfo(true)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/uploads"),
+					ghttp.VerifyBasicAuth("username", "password"),
+					ghttp.VerifyJSONRepresenting(map[string]interface{}{"size": 7, "sha1": fileSHA1}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{"id": "upload-id", "offset": 0}),
+				),
+			)
+
+			appendOffset(0)
+			appendChunk("bytes 0-3/7", "cont")
+			appendChunk("bytes 4-6/7", "ent")
+
+			ConfigureTaskResult(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/releases", "rebase=true"),
+					ghttp.VerifyHeader(http.Header{"Content-Type": []string{"application/json"}}),
+					ghttp.VerifyJSONRepresenting(map[string]interface{}{"upload_id": "upload-id", "sha1": fileSHA1}),
+				),
+				"",
+				server,
+			)
+
+			err := director.UploadReleaseFileResumable(file, true, false, uploadOpts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(progressStore.LoadCallCount()).To(Equal(0))
+			Expect(progressStore.SaveCallCount()).To(Equal(3))
+
+			key, progress := progressStore.SaveArgsForCall(2)
+			Expect(key).To(Equal("release-" + fileSHA1))
+			Expect(progress).To(Equal(UploadProgress{
+				DirectorUUID: "director-uuid",
+				SHA1:         fileSHA1,
+				Size:         7,
+				UploadID:     "upload-id",
+				Offset:       7,
+			}))
+
+			Expect(progressStore.DeleteCallCount()).To(Equal(2))
+			Expect(progressStore.DeleteArgsForCall(1)).To(Equal("release-" + fileSHA1))
+		})
+
+		It("resumes upload from offset verified by the director", func() {
+			uploadOpts.Resume = true
+
+			progressStore.LoadReturns(UploadProgress{
+				DirectorUUID: "director-uuid",
+				SHA1:         fileSHA1,
+				Size:         7,
+				UploadID:     "upload-id",
+				Offset:       0,
+			}, true, nil)
+
+			appendInfo(true)
+			appendOffset(4)
+			appendChunk("bytes 4-6/7", "ent")
+
+			ConfigureTaskResult(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/stemcells", ""),
+					ghttp.VerifyJSONRepresenting(map[string]interface{}{"upload_id": "upload-id", "sha1": fileSHA1}),
+				),
+				"",
+				server,
+			)
+
+			err := director.UploadStemcellFileResumable(file, false, uploadOpts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(progressStore.LoadCallCount()).To(Equal(1))
+			Expect(progressStore.LoadArgsForCall(0)).To(Equal("stemcell-" + fileSHA1))
+			Expect(progressStore.DeleteCallCount()).To(Equal(1))
+		})
+
+		It("starts over when recorded progress belongs to another director", func() {
+			uploadOpts.Resume = true
+
+			progressStore.LoadReturns(UploadProgress{
+				DirectorUUID: "other-uuid",
+				SHA1:         fileSHA1,
+				Size:         7,
+				UploadID:     "other-upload-id",
+			}, true, nil)
+
+			appendInfo(true)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/uploads"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{"id": "upload-id", "offset": 0}),
+				),
+			)
+
+			appendOffset(0)
+			appendChunk("bytes 0-3/7", "cont")
+			appendChunk("bytes 4-6/7", "ent")
+
+			ConfigureTaskResult(ghttp.VerifyRequest("POST", "/stemcells"), "", server)
+
+			err := director.UploadStemcellFileResumable(file, false, uploadOpts)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("considers chunk uploaded if director verified it even though response was lost", func() {
+			appendInfo(true)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/uploads"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{"id": "upload-id", "offset": 0}),
+				),
+			)
+
+			appendOffset(4)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/uploads/upload-id"),
+					ghttp.RespondWith(http.StatusInternalServerError, ""),
+				),
+			)
+
+			appendOffset(7)
+
+			ConfigureTaskResult(ghttp.VerifyRequest("POST", "/releases"), "", server)
+
+			err := director.UploadReleaseFileResumable(file, false, false, uploadOpts)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns error suggesting to resume if chunk cannot be uploaded", func() {
+			appendInfo(true)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/uploads"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{"id": "upload-id", "offset": 0}),
+				),
+			)
+
+			appendOffset(4)
+
+			for i := 0; i < 3; i++ {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/uploads/upload-id"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+				appendOffset(4)
+			}
+
+			err := director.UploadReleaseFileResumable(file, false, false, uploadOpts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Uploading chunk at offset '4'"))
+			Expect(err.Error()).To(ContainSubstring("run again with '--resume' to continue"))
+
+			Expect(progressStore.DeleteCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when director supports blobstore staging", func() {
+		It("stages file in the blobstore and imports it from there", func() {
+			appendInfo(false)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/uploads/presigned_urls"),
+					ghttp.VerifyJSONRepresenting(map[string]interface{}{"sha1": fileSHA1}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"upload_url":   server.URL() + "/blobstore/object?signature=sig",
+						"download_url": "https://blobstore/object?signature=sig",
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/blobstore/object", "signature=sig"),
+					func(w http.ResponseWriter, req *http.Request) {
+						Expect(req.Header.Get("Authorization")).To(BeEmpty())
+					},
+					ghttp.VerifyBody([]byte("content")),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+
+			ConfigureTaskResult(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/stemcells", "fix=true"),
+					ghttp.VerifyJSONRepresenting(map[string]interface{}{
+						"location": "https://blobstore/object?signature=sig",
+						"sha1":     fileSHA1,
+					}),
+				),
+				"",
+				server,
+			)
+
+			err := director.UploadStemcellFileResumable(file, true, uploadOpts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(progressStore.SaveCallCount()).To(Equal(2))
+
+			_, progress := progressStore.SaveArgsForCall(1)
+			Expect(progress.Staged).To(BeTrue())
+		})
+
+		It("stages file in blobstore that is signed by system root CA instead of Director CA", func() {
+			blobstore := ghttp.NewUnstartedServer()
+			blobstore.HTTPTestServer.TLS = &tls.Config{
+				Certificates: []tls.Certificate{blobstoreCert},
+			}
+			blobstore.HTTPTestServer.StartTLS()
+			DeferCleanup(blobstore.Close)
+
+			appendInfo(false)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/uploads/presigned_urls"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"upload_url":   blobstore.URL() + "/object?signature=sig",
+						"download_url": blobstore.URL() + "/object",
+					}),
+				),
+			)
+
+			blobstore.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/object", "signature=sig"),
+					ghttp.VerifyBody([]byte("content")),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+
+			ConfigureTaskResult(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/releases"),
+					ghttp.VerifyJSONRepresenting(map[string]interface{}{
+						"location": blobstore.URL() + "/object",
+						"sha1":     fileSHA1,
+					}),
+				),
+				"",
+				server,
+			)
+
+			err := director.UploadReleaseFileResumable(file, false, false, uploadOpts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(blobstore.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("skips staging when resumed upload was already staged", func() {
+			uploadOpts.Resume = true
+
+			progressStore.LoadReturns(UploadProgress{
+				DirectorUUID: "director-uuid",
+				SHA1:         fileSHA1,
+				Size:         7,
+				UploadURL:    "https://blobstore/upload",
+				DownloadURL:  "https://blobstore/download",
+				Staged:       true,
+			}, true, nil)
+
+			appendInfo(false)
+
+			ConfigureTaskResult(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/releases"),
+					ghttp.VerifyJSONRepresenting(map[string]interface{}{
+						"location": "https://blobstore/download",
+						"sha1":     fileSHA1,
+					}),
+				),
+				"",
+				server,
+			)
+
+			err := director.UploadReleaseFileResumable(file, false, false, uploadOpts)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns error if blobstore rejects the file", func() {
+			appendInfo(false)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/uploads/presigned_urls"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"upload_url":   server.URL() + "/blobstore/object",
+						"download_url": "https://blobstore/object",
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/blobstore/object"),
+					ghttp.RespondWith(http.StatusForbidden, "denied"),
+				),
+			)
+
+			err := director.UploadReleaseFileResumable(file, false, false, uploadOpts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Blobstore responded with non-successful status code '403' response 'denied'"))
+		})
+	})
+})
+
+func sha1Hex(content string) string {
+	digest := sha1.Sum([]byte(content))
+	return hex.EncodeToString(digest[:])
+}
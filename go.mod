@@ -21,6 +21,7 @@ require (
 	github.com/gopacket/gopacket v1.6.1
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/jessevdk/go-flags v1.6.1
+	github.com/klauspost/compress v1.18.0
 	github.com/mattn/go-isatty v0.0.22
 	github.com/maxbrunsfeld/counterfeiter/v6 v6.12.2
 	github.com/onsi/ginkgo/v2 v2.32.0
@@ -7,6 +7,7 @@ import (
 type Installation interface {
 	Target() Target
 	Jobs() []InstalledJob
+	Manifest() biinstallmanifest.Manifest
 }
 
 type installation struct {
@@ -34,3 +35,7 @@ func (i *installation) Target() Target {
 func (i *installation) Jobs() []InstalledJob {
 	return i.jobs
 }
+
+func (i *installation) Manifest() biinstallmanifest.Manifest {
+	return i.manifest
+}
@@ -7,8 +7,16 @@ import (
 	biproperty "github.com/cloudfoundry/bosh-utils/property"
 )
 
+// FakeCPI selects the built-in CPI and agent which only keep state locally
+const FakeCPI = "fake"
+
+// FakeMbus is the mbus URL used to reach the built-in fake agent
+const FakeMbus = "fake://agent"
+
 type Manifest struct {
 	Name string
+	// CPI selects a built-in CPI instead of a CPI release (optional)
+	CPI string
 	// Deprecated: use Templates instead
 	Template   ReleaseJobRef
 	Templates  []ReleaseJobRef
@@ -35,6 +35,7 @@ type manifest struct {
 }
 
 type installation struct {
+	CPI        string `yaml:"cpi"`
 	Template   template
 	Templates  []template
 	Properties map[interface{}]interface{}
@@ -114,10 +115,16 @@ func (p *parser) Parse(path string, vars boshtpl.Variables, op patch.Op, release
 
 	installationManifest := Manifest{
 		Name: comboManifest.Name,
+		CPI:  comboManifest.CloudProvider.CPI,
 		Mbus: comboManifest.CloudProvider.Mbus,
 		Cert: comboManifest.CloudProvider.Cert,
 	}
 
+	// Fake agent is only reachable in-process
+	if installationManifest.CPI == FakeCPI {
+		installationManifest.Mbus = FakeMbus
+	}
+
 	templateList := []ReleaseJobRef{}
 	templateNameCount := make(map[string]int)
 	if len(comboManifest.CloudProvider.Templates) != 0 {
@@ -128,6 +135,8 @@ func (p *parser) Parse(path string, vars boshtpl.Variables, op patch.Op, release
 			templateNameCount[templateName] = templateNameCount[templateName] + 1
 		}
 
+		installationManifest.Templates = templateList
+	} else if installationManifest.CPI != "" && comboManifest.CloudProvider.Template == (template{}) {
 		installationManifest.Templates = templateList
 	} else {
 		templateList = append(templateList, ReleaseJobRef{
@@ -27,6 +27,15 @@ func NewValidator(logger boshlog.Logger) Validator {
 func (v *validator) Validate(manifest Manifest, releaseSetManifest birelsetmanifest.Manifest) error {
 	var errs []error
 
+	if manifest.CPI != "" {
+		if manifest.CPI != FakeCPI {
+			return bosherr.Errorf("cloud_provider.cpi must be '%s' if provided", FakeCPI)
+		}
+
+		// Built-in CPI does not need to be installed from a release
+		return nil
+	}
+
 	// When there is nothing in templates, return an error. It should have a CPI release.
 	if len(manifest.Templates) == 0 {
 		return fmt.Errorf("either cloud_provider.templates or cloud_provider.template must be provided and must contain at least one release")
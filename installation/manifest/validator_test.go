@@ -119,5 +119,20 @@ var _ = Describe("Validator", func() {
 			err := validator.Validate(validManifest, releaseSetManifest)
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("does not require templates when fake CPI is used", func() {
+			manifest := Manifest{CPI: "fake"}
+
+			err := validator.Validate(manifest, releaseSetManifest)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("validates cpi is a known built-in CPI", func() {
+			manifest := Manifest{CPI: "unknown"}
+
+			err := validator.Validate(manifest, releaseSetManifest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cloud_provider.cpi must be 'fake' if provided"))
+		})
 	})
 })
@@ -517,6 +517,53 @@ cloud_provider:
 			)
 		}
 
+		var expectDeployWithNativeDiskResize = func() {
+			agentID := "fake-uuid-1"
+			oldVMCID := "fake-vm-cid-1"
+			newVMCID := "fake-vm-cid-2"
+			diskCID := "fake-disk-cid-1"
+			newDiskSize := 2048
+
+			gomock.InOrder(
+				mockCloud.EXPECT().Info().Return(bicloud.CpiInfo{ApiVersion: cpiApiVersion}, nil),
+				mockCloud.EXPECT().HasVM(oldVMCID).Return(true, nil),
+
+				// shutdown old vm
+				mockAgentClient.EXPECT().Ping().Return("any-state", nil),
+				mockAgentClient.EXPECT().RunScript("pre-stop", map[string]interface{}{}),
+				mockAgentClient.EXPECT().Drain("shutdown"),
+				mockAgentClient.EXPECT().Stop(),
+				mockAgentClient.EXPECT().RunScript("post-stop", map[string]interface{}{}),
+				mockAgentClient.EXPECT().ListDisk().Return([]string{diskCID}, nil),
+				mockAgentClient.EXPECT().UnmountDisk(diskCID),
+				mockCloud.EXPECT().DeleteVM(oldVMCID),
+
+				// create new vm
+				mockCloud.EXPECT().CreateVM(agentID, stemcellCID, vmCloudProperties, []string{diskCID}, networkInterfaces, vmEnv).Return(newVMCID, nil),
+				mockCloud.EXPECT().SetVMMetadata(newVMCID, gomock.Any()).Return(nil),
+				mockAgentClient.EXPECT().Ping().Return("any-state", nil),
+
+				// resize the detached disk, then attach it
+				mockCloud.EXPECT().Info().Return(bicloud.CpiInfo{ApiVersion: bicloud.DiskUpdateAsOfCpiApiVersion}, nil),
+				mockCloud.EXPECT().ResizeDisk(diskCID, newDiskSize),
+				mockCloud.EXPECT().AttachDisk(newVMCID, diskCID).Return("/dev/xyz", nil),
+				mockCloud.EXPECT().SetDiskMetadata(diskCID, gomock.Any()).Return(nil),
+				mockAgentClient.EXPECT().Ping().Return("any-state", nil),
+				mockAgentClient.EXPECT().AddPersistentDisk(diskCID, "/dev/xyz"),
+				mockAgentClient.EXPECT().MountDisk(diskCID),
+
+				// start jobs & wait for running
+				mockAgentClient.EXPECT().Apply(applySpec),
+				mockAgentClient.EXPECT().GetState(),
+				mockAgentClient.EXPECT().Stop(),
+				mockAgentClient.EXPECT().Apply(applySpec),
+				mockAgentClient.EXPECT().RunScript("pre-start", map[string]interface{}{}),
+				mockAgentClient.EXPECT().Start(),
+				mockAgentClient.EXPECT().GetState().Return(agentRunningState, nil),
+				mockAgentClient.EXPECT().RunScript("post-start", map[string]interface{}{}),
+			)
+		}
+
 		var expectDeployWithDiskMigration = func() {
 			agentID := "fake-uuid-1"
 			oldVMCID := "fake-vm-cid-1"
@@ -546,6 +593,9 @@ cloud_provider:
 				mockCloud.EXPECT().SetVMMetadata(newVMCID, gomock.Any()).Return(nil),
 				mockAgentClient.EXPECT().Ping().Return("any-state", nil),
 
+				// the CPI does not advertise native disk updates
+				mockCloud.EXPECT().Info().Return(bicloud.CpiInfo{ApiVersion: cpiApiVersion}, nil),
+
 				// attach both disks and migrate
 				mockCloud.EXPECT().AttachDisk(newVMCID, oldDiskCID).Return("/dev/xyz", nil),
 				mockCloud.EXPECT().SetDiskMetadata(oldDiskCID, gomock.Any()).Return(nil),
@@ -598,6 +648,9 @@ cloud_provider:
 				mockCloud.EXPECT().SetVMMetadata(newVMCID, gomock.Any()).Return(nil),
 				mockAgentClient.EXPECT().Ping().Return("any-state", nil),
 
+				// the CPI does not advertise native disk updates
+				mockCloud.EXPECT().Info().Return(bicloud.CpiInfo{ApiVersion: cpiApiVersion}, nil),
+
 				// attach both disks and migrate
 				mockCloud.EXPECT().AttachDisk(newVMCID, oldDiskCID).Return("/dev/xyz", nil),
 				mockCloud.EXPECT().SetDiskMetadata(oldDiskCID, gomock.Any()).Return(nil),
@@ -653,6 +706,9 @@ cloud_provider:
 				mockCloud.EXPECT().SetVMMetadata(newVMCID, gomock.Any()).Return(nil),
 				mockAgentClient.EXPECT().Ping().Return("any-state", nil),
 
+				// the CPI does not advertise native disk updates
+				mockCloud.EXPECT().Info().Return(bicloud.CpiInfo{ApiVersion: cpiApiVersion}, nil),
+
 				// attaching a missing disk will fail
 				mockCloud.EXPECT().AttachDisk(newVMCID, oldDiskCID).Return(
 					"",
@@ -691,6 +747,9 @@ cloud_provider:
 				mockCloud.EXPECT().SetVMMetadata(newVMCID, gomock.Any()).Return(nil),
 				mockAgentClient.EXPECT().Ping().Return("any-state", nil),
 
+				// the CPI does not advertise native disk updates
+				mockCloud.EXPECT().Info().Return(bicloud.CpiInfo{ApiVersion: cpiApiVersion}, nil),
+
 				// attach both disks and migrate (with error)
 				mockCloud.EXPECT().AttachDisk(newVMCID, oldDiskCID).Return("/dev/xyz", nil),
 				mockCloud.EXPECT().SetDiskMetadata(oldDiskCID, gomock.Any()).Return(nil),
@@ -735,6 +794,9 @@ cloud_provider:
 				mockCloud.EXPECT().SetVMMetadata(newVMCID, gomock.Any()).Return(nil),
 				mockAgentClient.EXPECT().Ping().Return("any-state", nil),
 
+				// the CPI does not advertise native disk updates
+				mockCloud.EXPECT().Info().Return(bicloud.CpiInfo{ApiVersion: cpiApiVersion}, nil),
+
 				// attach both disks and migrate
 				mockCloud.EXPECT().AttachDisk(newVMCID, oldDiskCID).Return("/dev/xyz", nil),
 				mockCloud.EXPECT().SetDiskMetadata(oldDiskCID, gomock.Any()).Return(nil),
@@ -934,6 +996,19 @@ cloud_provider:
 					Expect(err).ToNot(HaveOccurred())
 				})
 
+				It("resizes the disk in place when the CPI supports it", func() {
+					expectDeployWithNativeDiskResize()
+
+					err := newCreateEnvCmd().Run(fakeStage, newDeployOpts(deploymentManifestPath, ""))
+					Expect(err).ToNot(HaveOccurred())
+
+					diskRecord, found, err := diskRepo.FindCurrent()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(diskRecord.CID).To(Equal("fake-disk-cid-1"))
+					Expect(diskRecord.Size).To(Equal(2048))
+				})
+
 				Context("when current VM has been deleted manually (outside of bosh)", func() {
 					It("migrates the disk content, but does not shutdown the old VM", func() {
 						expectDeployWithDiskMigrationMissingVM()
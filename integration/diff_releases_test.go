@@ -0,0 +1,70 @@
+package integration_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	boshtbl "github.com/cloudfoundry/bosh-cli/v7/ui/table"
+)
+
+var _ = Describe("diff-releases command", func() {
+	findTable := func(content string) boshtbl.Table {
+		for _, table := range ui.Tables {
+			if table.Content == content {
+				return table
+			}
+		}
+		Fail("Expected to find table '" + content + "'")
+		return boshtbl.Table{}
+	}
+
+	It("compares last final release with changed release directory", func() {
+		testRootDir, err := os.Getwd()
+		Expect(err).ToNot(HaveOccurred())
+
+		releaseDir, err := fs.TempDir("bosh-diff-releases-int-test")
+		Expect(err).ToNot(HaveOccurred())
+
+		DeferCleanup(func() {
+			Expect(os.Chdir(testRootDir)).To(Succeed())
+			Expect(fs.RemoveAll(releaseDir)).To(Succeed())
+		})
+
+		setupReleaseDir(releaseDir, "diff-release")
+		commitChangesToGit(releaseDir)
+
+		createAndExecCommand(cmdFactory, []string{"create-release", "--dir", releaseDir, "--final", "--force"})
+
+		By("changing job and package", func() {
+			err := fs.WriteFileString(filepath.Join(releaseDir, "jobs", "job1", "monit"), "changed")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = fs.WriteFileString(filepath.Join(releaseDir, "src", "in-src"), "changed-in-src")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		ui.Tables = nil
+
+		createAndExecCommand(cmdFactory, []string{"diff-releases", "--from-dir", releaseDir})
+
+		jobs := findTable("jobs")
+		Expect(jobs.Rows).To(HaveLen(1))
+		Expect(jobs.Rows[0][0]).To(Equal(boshtbl.NewValueString("job1")))
+		Expect(jobs.Rows[0][1]).To(Equal(boshtbl.NewValueString("changed")))
+
+		packages := findTable("packages")
+		Expect(packages.Rows).To(HaveLen(1))
+		Expect(packages.Rows[0][0]).To(Equal(boshtbl.NewValueString("pkg1")))
+		Expect(packages.Rows[0][1]).To(Equal(boshtbl.NewValueString("changed")))
+
+		packageFiles := findTable("package files")
+		Expect(packageFiles.Rows).To(ContainElement([]boshtbl.Value{
+			boshtbl.NewValueString("pkg1"),
+			boshtbl.NewValueString("in-src"),
+			boshtbl.NewValueString("changed"),
+		}))
+	})
+})
@@ -0,0 +1,107 @@
+package integration_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/v7/director"
+	"github.com/cloudfoundry/bosh-cli/v7/director/directortest"
+)
+
+var _ = Describe("director commands", func() {
+	var (
+		director *directortest.Server
+	)
+
+	BeforeEach(func() {
+		director = directortest.NewServer()
+		director.SetBasicAuth("admin", "admin-password")
+	})
+
+	AfterEach(func() {
+		director.Close()
+	})
+
+	execCommand := func(args ...string) {
+		GinkgoHelper()
+
+		args = append(args,
+			"-e", director.URL(), "--ca-cert", director.CACert(),
+			"--client", "admin", "--client-secret", "admin-password",
+		)
+
+		createAndExecCommand(cmdFactory, args)
+	}
+
+	It("lists deployments", func() {
+		director.AddDeployment(boshdir.DeploymentResp{
+			Name:     "dep",
+			Releases: []boshdir.DeploymentReleaseResp{{Name: "rel", Version: "1"}},
+		})
+
+		execCommand("deployments")
+
+		Expect(ui.Table.Rows).To(HaveLen(1))
+		Expect(ui.Table.Rows[0][0].String()).To(Equal("dep"))
+		Expect(ui.Table.Rows[0][1].String()).To(Equal("rel/1"))
+	})
+
+	It("deploys manifest and shows task events", func() {
+		manifestPath := filepath.Join(GinkgoT().TempDir(), "manifest.yml")
+		Expect(os.WriteFile(manifestPath, []byte("name: dep\nreleases: []\n"), 0600)).To(Succeed())
+
+		director.QueueTaskOutcome(directortest.TaskOutcome{
+			Polls: 1,
+			Events: []string{
+				`{"time":1503082451,"stage":"Updating instance","tags":[],"total":1,"task":"zookeeper/0","index":1,"state":"started","progress":0}`,
+				`{"time":1503082452,"stage":"Updating instance","tags":[],"total":1,"task":"zookeeper/0","index":1,"state":"finished","progress":100}`,
+			},
+		})
+
+		execCommand("deploy", "-n", "-d", "dep", manifestPath)
+
+		Expect(director.Deployments()).To(HaveLen(1))
+		Expect(director.Deployments()[0].Manifest).To(Equal("name: dep\nreleases: []\n"))
+
+		output := strings.Join(ui.Blocks, "\n")
+		Expect(output).To(ContainSubstring("Updating instance"))
+		Expect(output).To(ContainSubstring("zookeeper/0"))
+	})
+
+	It("deletes deployment", func() {
+		director.AddDeployment(boshdir.DeploymentResp{Name: "dep"})
+
+		execCommand("delete-deployment", "-n", "-d", "dep")
+
+		Expect(director.Deployments()).To(BeEmpty())
+		Expect(director.Tasks()[0].Description).To(Equal("delete deployment dep"))
+	})
+
+	It("lists locks of running tasks", func() {
+		director.AddTask("create deployment", "dep", directortest.TaskOutcome{Polls: 10})
+
+		execCommand("locks")
+
+		Expect(ui.Table.Rows).To(HaveLen(1))
+		Expect(ui.Table.Rows[0][0].String()).To(Equal("deployment"))
+		Expect(ui.Table.Rows[0][1].String()).To(Equal("dep"))
+		Expect(ui.Table.Rows[0][2].String()).To(Equal("1"))
+	})
+
+	It("authenticates via UAA", func() {
+		director.AddUAAClient("ci", "ci-secret")
+		director.AddDeployment(boshdir.DeploymentResp{Name: "dep"})
+
+		createAndExecCommand(cmdFactory, []string{
+			"deployments", "-e", director.URL(), "--ca-cert", director.CACert(),
+			"--client", "ci", "--client-secret", "ci-secret",
+		})
+
+		Expect(ui.Table.Rows).To(HaveLen(1))
+		Expect(director.ReceivedRequests()).To(ContainElement("POST /oauth/token"))
+	})
+})
@@ -0,0 +1,236 @@
+package logs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v2"
+)
+
+const IndexFileName = "logs-index.yml"
+
+/*
+---
+instances:
+- group: web
+  id: 5d6e1a2b-...
+  files:
+  - path: nginx/access.log
+    job: nginx
+    size: 10321
+    first_time: 2024-01-02T14:59:01Z
+    last_time: 2024-01-02T15:20:44Z
+*/
+
+type Index struct {
+	Instances []IndexInstance `yaml:"instances"`
+}
+
+type IndexInstance struct {
+	Group string      `yaml:"group"`
+	ID    string      `yaml:"id"`
+	Files []IndexFile `yaml:"files"`
+}
+
+type IndexFile struct {
+	// Path is relative to the instance directory
+	Path string `yaml:"path"`
+	Job  string `yaml:"job,omitempty"`
+	Size int64  `yaml:"size"`
+
+	// Time range is only known if file contains timestamped lines
+	FirstTime *time.Time `yaml:"first_time,omitempty"`
+	LastTime  *time.Time `yaml:"last_time,omitempty"`
+}
+
+// Overlaps returns true if file may contain lines between since and until
+func (f IndexFile) Overlaps(since, until time.Time) bool {
+	if f.FirstTime == nil || f.LastTime == nil {
+		return true
+	}
+	if !since.IsZero() && f.LastTime.Before(since) {
+		return false
+	}
+	if !until.IsZero() && f.FirstTime.After(until) {
+		return false
+	}
+	return true
+}
+
+type FSIndexer struct {
+	fs boshsys.FileSystem
+}
+
+func NewFSIndexer(fs boshsys.FileSystem) FSIndexer {
+	return FSIndexer{fs: fs}
+}
+
+func (i FSIndexer) Build(dir string) (Index, error) {
+	var index Index
+
+	groupDirs, err := i.fs.Glob(filepath.Join(dir, "*", "*"))
+	if err != nil {
+		return index, bosherr.WrapErrorf(err, "Listing instance directories in '%s'", dir)
+	}
+
+	sort.Strings(groupDirs)
+
+	for _, instanceDir := range groupDirs {
+		if !i.isDir(instanceDir) {
+			continue
+		}
+
+		instance := IndexInstance{
+			Group: filepath.Base(filepath.Dir(instanceDir)),
+			ID:    filepath.Base(instanceDir),
+		}
+
+		instance.Files, err = i.indexFiles(instanceDir)
+		if err != nil {
+			return index, err
+		}
+
+		index.Instances = append(index.Instances, instance)
+	}
+
+	bytes, err := yaml.Marshal(index)
+	if err != nil {
+		return index, bosherr.WrapError(err, "Marshalling logs index")
+	}
+
+	err = i.fs.WriteFile(filepath.Join(dir, IndexFileName), bytes)
+	if err != nil {
+		return index, bosherr.WrapError(err, "Writing logs index")
+	}
+
+	return index, nil
+}
+
+func (i FSIndexer) Load(dir string) (Index, error) {
+	var index Index
+
+	indexPath := filepath.Join(dir, IndexFileName)
+
+	if !i.fs.FileExists(indexPath) {
+		return i.Build(dir)
+	}
+
+	bytes, err := i.fs.ReadFile(indexPath)
+	if err != nil {
+		return index, bosherr.WrapError(err, "Reading logs index")
+	}
+
+	err = yaml.Unmarshal(bytes, &index)
+	if err != nil {
+		return index, bosherr.WrapError(err, "Unmarshalling logs index")
+	}
+
+	return index, nil
+}
+
+func (i FSIndexer) indexFiles(instanceDir string) ([]IndexFile, error) {
+	var files []IndexFile
+
+	err := i.fs.Walk(instanceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(instanceDir, path)
+		if err != nil {
+			return err
+		}
+
+		file := IndexFile{
+			Path: filepath.ToSlash(relPath),
+			Size: info.Size(),
+		}
+
+		if pieces := strings.Split(file.Path, "/"); len(pieces) > 1 {
+			file.Job = pieces[0]
+		}
+
+		err = ScanLines(i.fs, path, func(_ int, line string) {
+			ts, found := ParseTimestamp(line)
+			if !found {
+				return
+			}
+			if file.FirstTime == nil || ts.Before(*file.FirstTime) {
+				file.FirstTime = &ts
+			}
+			if file.LastTime == nil || ts.After(*file.LastTime) {
+				file.LastTime = &ts
+			}
+		})
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Indexing log file '%s'", path)
+		}
+
+		files = append(files, file)
+
+		return nil
+	})
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Indexing instance directory '%s'", instanceDir)
+	}
+
+	return files, nil
+}
+
+func (i FSIndexer) isDir(path string) bool {
+	info, err := i.fs.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// ScanLines calls lineFunc for each line of a plain or gzipped file
+func ScanLines(fs boshsys.FileSystem, path string, lineFunc func(int, string)) error {
+	file, err := fs.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	var reader io.Reader = file
+
+	if strings.HasSuffix(path, ".gz") {
+		gzReader, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+
+		defer gzReader.Close() //nolint:errcheck
+
+		reader = gzReader
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lineNum int
+
+	for scanner.Scan() {
+		lineNum++
+		lineFunc(lineNum, scanner.Text())
+	}
+
+	// Binary files (e.g. wtmp) may not have reasonably sized lines
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		return nil
+	}
+
+	return scanner.Err()
+}
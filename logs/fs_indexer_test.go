@@ -0,0 +1,138 @@
+package logs_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"time"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/logs"
+)
+
+func writeLogFile(path, content string) {
+	Expect(os.MkdirAll(filepath.Dir(path), os.ModePerm)).To(Succeed())
+	Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+}
+
+func writeGzippedLogFile(path, content string) {
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	_, err := gzWriter.Write([]byte(content))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(gzWriter.Close()).To(Succeed())
+	writeLogFile(path, buf.String())
+}
+
+func timePtr(t time.Time) *time.Time { return &t }
+
+var _ = Describe("FSIndexer", func() {
+	var (
+		dir     string
+		fs      boshsys.FileSystem
+		indexer FSIndexer
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		fs = boshsys.NewOsFileSystem(boshlog.NewLogger(boshlog.LevelNone))
+		indexer = NewFSIndexer(fs)
+
+		writeLogFile(filepath.Join(dir, "web", "id-1", "nginx", "error.log"),
+			"2024-01-02T15:00:00Z first\n  continued\n2024-01-02T15:10:00Z last\n")
+		writeGzippedLogFile(filepath.Join(dir, "web", "id-1", "nginx", "error.log.1.gz"),
+			"2024-01-01T10:00:00Z rotated\n")
+		writeLogFile(filepath.Join(dir, "worker", "id-2", "messages"), "no timestamps\n")
+	})
+
+	Describe("Build", func() {
+		It("indexes files of every instance with their time ranges", func() {
+			index, err := indexer.Build(dir)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(index).To(Equal(Index{
+				Instances: []IndexInstance{
+					{
+						Group: "web",
+						ID:    "id-1",
+						Files: []IndexFile{
+							{
+								Path:      "nginx/error.log",
+								Job:       "nginx",
+								Size:      65,
+								FirstTime: timePtr(time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)),
+								LastTime:  timePtr(time.Date(2024, 1, 2, 15, 10, 0, 0, time.UTC)),
+							},
+							{
+								Path:      "nginx/error.log.1.gz",
+								Job:       "nginx",
+								Size:      index.Instances[0].Files[1].Size,
+								FirstTime: timePtr(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)),
+								LastTime:  timePtr(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)),
+							},
+						},
+					},
+					{
+						Group: "worker",
+						ID:    "id-2",
+						Files: []IndexFile{{Path: "messages", Size: 14}},
+					},
+				},
+			}))
+		})
+
+		It("saves index into directory", func() {
+			built, err := indexer.Build(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filepath.Join(dir, IndexFileName)).To(BeARegularFile())
+
+			Expect(os.RemoveAll(filepath.Join(dir, "worker"))).To(Succeed())
+
+			loaded, err := indexer.Load(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loaded.Instances).To(HaveLen(len(built.Instances)))
+		})
+	})
+
+	Describe("Load", func() {
+		It("builds index if it was not saved before", func() {
+			index, err := indexer.Load(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(index.Instances).To(HaveLen(2))
+			Expect(filepath.Join(dir, IndexFileName)).To(BeARegularFile())
+		})
+
+		It("returns error if index cannot be parsed", func() {
+			writeLogFile(filepath.Join(dir, IndexFileName), "-")
+
+			_, err := indexer.Load(dir)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unmarshalling logs index"))
+		})
+	})
+})
+
+var _ = Describe("IndexFile", func() {
+	Describe("Overlaps", func() {
+		file := IndexFile{
+			FirstTime: timePtr(time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)),
+			LastTime:  timePtr(time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC)),
+		}
+
+		It("returns true if time range is unknown", func() {
+			Expect(IndexFile{}.Overlaps(time.Now(), time.Time{})).To(BeTrue())
+		})
+
+		It("returns whether file time range overlaps", func() {
+			Expect(file.Overlaps(time.Time{}, time.Time{})).To(BeTrue())
+			Expect(file.Overlaps(time.Date(2024, 1, 2, 15, 30, 0, 0, time.UTC), time.Time{})).To(BeTrue())
+			Expect(file.Overlaps(time.Date(2024, 1, 2, 16, 30, 0, 0, time.UTC), time.Time{})).To(BeFalse())
+			Expect(file.Overlaps(time.Time{}, time.Date(2024, 1, 2, 14, 30, 0, 0, time.UTC))).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,68 @@
+package logs
+
+import (
+	"path/filepath"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+type FSSearcher struct {
+	fs boshsys.FileSystem
+}
+
+func NewFSSearcher(fs boshsys.FileSystem) FSSearcher {
+	return FSSearcher{fs: fs}
+}
+
+func (s FSSearcher) Search(dir string, index Index, opts SearchOpts) ([]Match, error) {
+	var matches []Match
+
+	for _, instance := range index.Instances {
+		for _, file := range instance.Files {
+			if !file.Overlaps(opts.Since, opts.Until) {
+				continue
+			}
+
+			path := filepath.Join(dir, instance.Group, instance.ID, filepath.FromSlash(file.Path))
+
+			// Lines without timestamps (e.g. stack traces) belong to the preceding line
+			var lastTime time.Time
+
+			err := ScanLines(s.fs, path, func(lineNum int, line string) {
+				if ts, found := ParseTimestamp(line); found {
+					lastTime = ts
+				}
+
+				if !lastTime.IsZero() {
+					if !opts.Since.IsZero() && lastTime.Before(opts.Since) {
+						return
+					}
+					if !opts.Until.IsZero() && lastTime.After(opts.Until) {
+						return
+					}
+				}
+
+				if opts.Pattern != nil && !opts.Pattern.MatchString(line) {
+					return
+				}
+
+				matches = append(matches, Match{
+					Group: instance.Group,
+					ID:    instance.ID,
+					Job:   file.Job,
+					Path:  file.Path,
+
+					LineNum: lineNum,
+					Line:    line,
+				})
+			})
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Searching log file '%s'", path)
+			}
+		}
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,74 @@
+package logs_test
+
+import (
+	"path/filepath"
+	"regexp"
+	"time"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/logs"
+)
+
+var _ = Describe("FSSearcher", func() {
+	var (
+		dir      string
+		index    Index
+		searcher FSSearcher
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		fs := boshsys.NewOsFileSystem(boshlog.NewLogger(boshlog.LevelNone))
+		searcher = NewFSSearcher(fs)
+
+		writeLogFile(filepath.Join(dir, "web", "id-1", "nginx", "error.log"),
+			"2024-01-02T15:00:00Z error one\n  error detail\n2024-01-02T15:10:00Z info\n2024-01-02T15:20:00Z error two\n")
+		writeGzippedLogFile(filepath.Join(dir, "web", "id-1", "nginx", "error.log.1.gz"),
+			"2024-01-01T10:00:00Z old error\n")
+		writeLogFile(filepath.Join(dir, "worker", "id-2", "messages"), "plain error\n")
+
+		var err error
+		index, err = NewFSIndexer(fs).Build(dir)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("returns matching lines of all files", func() {
+		matches, err := searcher.Search(dir, index, SearchOpts{Pattern: regexp.MustCompile("error")})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(matches).To(Equal([]Match{
+			{Group: "web", ID: "id-1", Job: "nginx", Path: "nginx/error.log", LineNum: 1, Line: "2024-01-02T15:00:00Z error one"},
+			{Group: "web", ID: "id-1", Job: "nginx", Path: "nginx/error.log", LineNum: 2, Line: "  error detail"},
+			{Group: "web", ID: "id-1", Job: "nginx", Path: "nginx/error.log", LineNum: 4, Line: "2024-01-02T15:20:00Z error two"},
+			{Group: "web", ID: "id-1", Job: "nginx", Path: "nginx/error.log.1.gz", LineNum: 1, Line: "2024-01-01T10:00:00Z old error"},
+			{Group: "worker", ID: "id-2", Job: "", Path: "messages", LineNum: 1, Line: "plain error"},
+		}))
+	})
+
+	It("only returns lines within time range", func() {
+		matches, err := searcher.Search(dir, index, SearchOpts{
+			Pattern: regexp.MustCompile("error"),
+			Since:   time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC),
+			Until:   time.Date(2024, 1, 2, 15, 15, 0, 0, time.UTC),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(matches).To(Equal([]Match{
+			{Group: "web", ID: "id-1", Job: "nginx", Path: "nginx/error.log", LineNum: 1, Line: "2024-01-02T15:00:00Z error one"},
+			{Group: "web", ID: "id-1", Job: "nginx", Path: "nginx/error.log", LineNum: 2, Line: "  error detail"},
+			{Group: "worker", ID: "id-2", Job: "", Path: "messages", LineNum: 1, Line: "plain error"},
+		}))
+	})
+
+	It("returns error if indexed file is missing", func() {
+		index.Instances[0].Files[0].Path = "missing.log"
+
+		_, err := searcher.Search(dir, index, SearchOpts{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Searching log file"))
+	})
+})
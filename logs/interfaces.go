@@ -0,0 +1,43 @@
+package logs
+
+import (
+	"regexp"
+	"time"
+)
+
+// You only need **one** of these per package!
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . Indexer
+
+type Indexer interface {
+	// Build indexes extracted log bundles laid out as DIR/<group>/<id>/
+	// and saves the index into DIR
+	Build(dir string) (Index, error)
+	// Load returns previously saved index or builds one if it's missing
+	Load(dir string) (Index, error)
+}
+
+//counterfeiter:generate . Searcher
+
+type Searcher interface {
+	Search(dir string, index Index, opts SearchOpts) ([]Match, error)
+}
+
+type SearchOpts struct {
+	Pattern *regexp.Regexp
+
+	// Since and Until are optional
+	Since time.Time
+	Until time.Time
+}
+
+type Match struct {
+	Group string
+	ID    string
+	Job   string
+	Path  string
+
+	LineNum int
+	Line    string
+}
@@ -0,0 +1,191 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package logsfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/logs"
+)
+
+type FakeIndexer struct {
+	BuildStub        func(string) (logs.Index, error)
+	buildMutex       sync.RWMutex
+	buildArgsForCall []struct {
+		arg1 string
+	}
+	buildReturns struct {
+		result1 logs.Index
+		result2 error
+	}
+	buildReturnsOnCall map[int]struct {
+		result1 logs.Index
+		result2 error
+	}
+	LoadStub        func(string) (logs.Index, error)
+	loadMutex       sync.RWMutex
+	loadArgsForCall []struct {
+		arg1 string
+	}
+	loadReturns struct {
+		result1 logs.Index
+		result2 error
+	}
+	loadReturnsOnCall map[int]struct {
+		result1 logs.Index
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeIndexer) Build(arg1 string) (logs.Index, error) {
+	fake.buildMutex.Lock()
+	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
+	fake.buildArgsForCall = append(fake.buildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.BuildStub
+	fakeReturns := fake.buildReturns
+	fake.recordInvocation("Build", []interface{}{arg1})
+	fake.buildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIndexer) BuildCallCount() int {
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	return len(fake.buildArgsForCall)
+}
+
+func (fake *FakeIndexer) BuildCalls(stub func(string) (logs.Index, error)) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = stub
+}
+
+func (fake *FakeIndexer) BuildArgsForCall(i int) string {
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	argsForCall := fake.buildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIndexer) BuildReturns(result1 logs.Index, result2 error) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = nil
+	fake.buildReturns = struct {
+		result1 logs.Index
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIndexer) BuildReturnsOnCall(i int, result1 logs.Index, result2 error) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = nil
+	if fake.buildReturnsOnCall == nil {
+		fake.buildReturnsOnCall = make(map[int]struct {
+			result1 logs.Index
+			result2 error
+		})
+	}
+	fake.buildReturnsOnCall[i] = struct {
+		result1 logs.Index
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIndexer) Load(arg1 string) (logs.Index, error) {
+	fake.loadMutex.Lock()
+	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
+	fake.loadArgsForCall = append(fake.loadArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.LoadStub
+	fakeReturns := fake.loadReturns
+	fake.recordInvocation("Load", []interface{}{arg1})
+	fake.loadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIndexer) LoadCallCount() int {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return len(fake.loadArgsForCall)
+}
+
+func (fake *FakeIndexer) LoadCalls(stub func(string) (logs.Index, error)) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = stub
+}
+
+func (fake *FakeIndexer) LoadArgsForCall(i int) string {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	argsForCall := fake.loadArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIndexer) LoadReturns(result1 logs.Index, result2 error) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = nil
+	fake.loadReturns = struct {
+		result1 logs.Index
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIndexer) LoadReturnsOnCall(i int, result1 logs.Index, result2 error) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = nil
+	if fake.loadReturnsOnCall == nil {
+		fake.loadReturnsOnCall = make(map[int]struct {
+			result1 logs.Index
+			result2 error
+		})
+	}
+	fake.loadReturnsOnCall[i] = struct {
+		result1 logs.Index
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIndexer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeIndexer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ logs.Indexer = new(FakeIndexer)
@@ -0,0 +1,118 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package logsfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/logs"
+)
+
+type FakeSearcher struct {
+	SearchStub        func(string, logs.Index, logs.SearchOpts) ([]logs.Match, error)
+	searchMutex       sync.RWMutex
+	searchArgsForCall []struct {
+		arg1 string
+		arg2 logs.Index
+		arg3 logs.SearchOpts
+	}
+	searchReturns struct {
+		result1 []logs.Match
+		result2 error
+	}
+	searchReturnsOnCall map[int]struct {
+		result1 []logs.Match
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSearcher) Search(arg1 string, arg2 logs.Index, arg3 logs.SearchOpts) ([]logs.Match, error) {
+	fake.searchMutex.Lock()
+	ret, specificReturn := fake.searchReturnsOnCall[len(fake.searchArgsForCall)]
+	fake.searchArgsForCall = append(fake.searchArgsForCall, struct {
+		arg1 string
+		arg2 logs.Index
+		arg3 logs.SearchOpts
+	}{arg1, arg2, arg3})
+	stub := fake.SearchStub
+	fakeReturns := fake.searchReturns
+	fake.recordInvocation("Search", []interface{}{arg1, arg2, arg3})
+	fake.searchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSearcher) SearchCallCount() int {
+	fake.searchMutex.RLock()
+	defer fake.searchMutex.RUnlock()
+	return len(fake.searchArgsForCall)
+}
+
+func (fake *FakeSearcher) SearchCalls(stub func(string, logs.Index, logs.SearchOpts) ([]logs.Match, error)) {
+	fake.searchMutex.Lock()
+	defer fake.searchMutex.Unlock()
+	fake.SearchStub = stub
+}
+
+func (fake *FakeSearcher) SearchArgsForCall(i int) (string, logs.Index, logs.SearchOpts) {
+	fake.searchMutex.RLock()
+	defer fake.searchMutex.RUnlock()
+	argsForCall := fake.searchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSearcher) SearchReturns(result1 []logs.Match, result2 error) {
+	fake.searchMutex.Lock()
+	defer fake.searchMutex.Unlock()
+	fake.SearchStub = nil
+	fake.searchReturns = struct {
+		result1 []logs.Match
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSearcher) SearchReturnsOnCall(i int, result1 []logs.Match, result2 error) {
+	fake.searchMutex.Lock()
+	defer fake.searchMutex.Unlock()
+	fake.SearchStub = nil
+	if fake.searchReturnsOnCall == nil {
+		fake.searchReturnsOnCall = make(map[int]struct {
+			result1 []logs.Match
+			result2 error
+		})
+	}
+	fake.searchReturnsOnCall[i] = struct {
+		result1 []logs.Match
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSearcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSearcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ logs.Searcher = new(FakeSearcher)
@@ -0,0 +1,44 @@
+package logs
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	timestampRegexp = regexp.MustCompile(
+		`(\d{4}-\d{2}-\d{2})[T _](\d{2}:\d{2}:\d{2})(\.\d+)?\s?(Z|[+-]\d{2}:?\d{2})?`)
+
+	epochTimestampRegexp = regexp.MustCompile(`"timestamp":\s*"?(\d{10}(\.\d+)?)`)
+)
+
+// ParseTimestamp finds the first timestamp in a log line.
+// Timestamps without a zone are assumed to be in UTC.
+func ParseTimestamp(line string) (time.Time, bool) {
+	if m := timestampRegexp.FindStringSubmatch(line); m != nil {
+		zone := m[4]
+
+		switch {
+		case len(zone) == 0:
+			zone = "Z"
+		case len(zone) == 5:
+			zone = zone[:3] + ":" + zone[3:]
+		}
+
+		ts, err := time.Parse(time.RFC3339Nano, m[1]+"T"+m[2]+m[3]+zone)
+		if err == nil {
+			return ts.UTC(), true
+		}
+	}
+
+	if m := epochTimestampRegexp.FindStringSubmatch(line); m != nil {
+		secs, err := strconv.ParseFloat(m[1], 64)
+		if err == nil {
+			whole := int64(secs)
+			return time.Unix(whole, int64((secs-float64(whole))*1e9)).UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,35 @@
+package logs_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/logs"
+)
+
+var _ = Describe("ParseTimestamp", func() {
+	It("parses common log timestamp formats", func() {
+		expected := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+		for _, line := range []string{
+			"2024-01-02T15:04:05Z msg",
+			"2024-01-02T17:04:05+02:00 msg",
+			"2024-01-02T10:04:05.000-0500 msg",
+			"I, [2024-01-02 15:04:05 #1234] INFO -- : msg",
+			"2024-01-02_15:04:05.00000 [agent] msg",
+			`{"timestamp":"1704207845","message":"msg"}`,
+			`{"timestamp":1704207845.0,"message":"msg"}`,
+		} {
+			ts, found := ParseTimestamp(line)
+			Expect(found).To(BeTrue(), line)
+			Expect(ts).To(Equal(expected), line)
+		}
+	})
+
+	It("returns false when line has no timestamp", func() {
+		_, found := ParseTimestamp("no time here")
+		Expect(found).To(BeFalse())
+	})
+})
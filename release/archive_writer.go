@@ -10,9 +10,12 @@ import (
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	"gopkg.in/yaml.v2"
 
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
 	boshjob "github.com/cloudfoundry/bosh-cli/v7/release/job"
 	boshlic "github.com/cloudfoundry/bosh-cli/v7/release/license"
+	birelman "github.com/cloudfoundry/bosh-cli/v7/release/manifest"
 	boshpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	boshtarball "github.com/cloudfoundry/bosh-cli/v7/release/tarball"
 )
 
 type ArchiveWriter struct {
@@ -20,6 +23,9 @@ type ArchiveWriter struct {
 	fs             boshsys.FileSystem
 	filesToInclude []string
 
+	gzipArchives     bool
+	digestCalculator bicrypto.DigestCalculator
+
 	logTag string
 	logger boshlog.Logger
 }
@@ -28,6 +34,22 @@ func NewArchiveWriter(compressor boshcmd.Compressor, fs boshsys.FileSystem, logg
 	return ArchiveWriter{compressor: compressor, fs: fs, logTag: "release.ArchiveWriter", logger: logger}
 }
 
+// NewGzipArchiveWriter returns writer that recompresses zstd compressed job,
+// package and license archives with gzip so that release can be read by
+// directors without zstd support. Digests of recompressed archives are
+// recorded in release manifest.
+func NewGzipArchiveWriter(
+	compressor boshcmd.Compressor,
+	digestCalculator bicrypto.DigestCalculator,
+	fs boshsys.FileSystem,
+	logger boshlog.Logger,
+) ArchiveWriter {
+	writer := NewArchiveWriter(compressor, fs, logger)
+	writer.gzipArchives = true
+	writer.digestCalculator = digestCalculator
+	return writer
+}
+
 func (w ArchiveWriter) Write(release Release, pkgFpsToSkip []string) (string, error) {
 	stagingDir, err := w.fs.TempDir("bosh-release")
 	if err != nil {
@@ -38,48 +60,51 @@ func (w ArchiveWriter) Write(release Release, pkgFpsToSkip []string) (string, er
 
 	w.logger.Info(w.logTag, "Writing release tarball into '%s'", stagingDir)
 
-	manifestBytes, err := yaml.Marshal(release.Manifest())
-	if err != nil {
-		return "", bosherr.WrapError(err, "Marshalling release manifest")
-	}
-
-	manifestPath := filepath.Join(stagingDir, "release.MF")
-
-	err = w.fs.WriteFile(manifestPath, manifestBytes)
-	if err != nil {
-		return "", bosherr.WrapErrorf(err, "Writing release manifest '%s'", manifestPath)
-	}
+	// Digests of archives that were recompressed while copying keyed by staged path
+	digests := map[string]string{}
 
 	w.filesToInclude = w.appendFile("release.MF")
 
-	jobsFiles, err := w.writeJobs(release.Jobs(), stagingDir)
+	jobsFiles, err := w.writeJobs(release.Jobs(), stagingDir, digests)
 	if err != nil {
 		return "", bosherr.WrapError(err, "Writing jobs")
 	}
 
 	w.filesToInclude = w.appendFiles(jobsFiles)
 
-	packagesFiles, err := w.writePackages(release.Packages(), pkgFpsToSkip, stagingDir)
+	packagesFiles, err := w.writePackages(release.Packages(), pkgFpsToSkip, stagingDir, digests)
 	if err != nil {
 		return "", bosherr.WrapError(err, "Writing packages")
 	}
 
 	w.filesToInclude = w.appendFiles(packagesFiles)
 
-	compiledPackagesFiles, err := w.writeCompiledPackages(release.CompiledPackages(), pkgFpsToSkip, stagingDir)
+	compiledPackagesFiles, err := w.writeCompiledPackages(release.CompiledPackages(), pkgFpsToSkip, stagingDir, digests)
 	if err != nil {
 		return "", bosherr.WrapError(err, "Writing compiled packages")
 	}
 
 	w.filesToInclude = w.appendFiles(compiledPackagesFiles)
 
-	licenseFiles, err := w.writeLicense(release.License(), stagingDir)
+	licenseFiles, err := w.writeLicense(release.License(), stagingDir, digests)
 	if err != nil {
 		return "", bosherr.WrapError(err, "Writing license")
 	}
 
 	w.filesToInclude = w.appendFiles(licenseFiles)
 
+	manifestBytes, err := yaml.Marshal(w.manifest(release, digests))
+	if err != nil {
+		return "", bosherr.WrapError(err, "Marshalling release manifest")
+	}
+
+	manifestPath := filepath.Join(stagingDir, "release.MF")
+
+	err = w.fs.WriteFile(manifestPath, manifestBytes)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Writing release manifest '%s'", manifestPath)
+	}
+
 	files := w.filesToInclude
 	path, err := w.compressor.CompressSpecificFilesInDir(stagingDir, files, boshcmd.CompressorOptions{NoCompression: release.NoCompression()})
 
@@ -97,7 +122,66 @@ func (w ArchiveWriter) cleanUp(stagingDir string) {
 	}
 }
 
-func (w ArchiveWriter) writeJobs(jobs []*boshjob.Job, stagingDir string) ([]string, error) {
+func (w ArchiveWriter) manifest(release Release, digests map[string]string) birelman.Manifest {
+	manifest := release.Manifest()
+
+	for i, ref := range manifest.Jobs {
+		if digest, found := digests[filepath.Join("jobs", ref.Name+".tgz")]; found {
+			manifest.Jobs[i].SHA1 = digest
+		}
+	}
+
+	for i, ref := range manifest.Packages {
+		if digest, found := digests[filepath.Join("packages", ref.Name+".tgz")]; found {
+			manifest.Packages[i].SHA1 = digest
+		}
+	}
+
+	for i, ref := range manifest.CompiledPkgs {
+		if digest, found := digests[filepath.Join("compiled_packages", ref.Name+".tgz")]; found {
+			manifest.CompiledPkgs[i].SHA1 = digest
+		}
+	}
+
+	if manifest.License != nil {
+		if digest, found := digests["license.tgz"]; found {
+			manifest.License.SHA1 = digest
+		}
+	}
+
+	return manifest
+}
+
+// copyArchive copies archive into staging dir recording digest
+// of archive in digests if it had to be recompressed
+func (w ArchiveWriter) copyArchive(srcPath, stagingDir, name string, digests map[string]string) error {
+	dstPath := filepath.Join(stagingDir, name)
+
+	if w.gzipArchives {
+		format, err := boshtarball.DetectFormat(srcPath, w.fs)
+		if err == nil && format == boshtarball.FormatZstd {
+			w.logger.Debug(w.logTag, "Recompressing zstd archive '%s' with gzip", srcPath)
+
+			err = boshtarball.Recompress(srcPath, dstPath, boshtarball.Compression{Format: boshtarball.FormatGzip}, w.fs)
+			if err != nil {
+				return err
+			}
+
+			digest, err := w.digestCalculator.Calculate(dstPath)
+			if err != nil {
+				return bosherr.WrapErrorf(err, "Calculating digest of '%s'", dstPath)
+			}
+
+			digests[name] = digest
+
+			return nil
+		}
+	}
+
+	return w.fs.CopyFile(srcPath, dstPath)
+}
+
+func (w ArchiveWriter) writeJobs(jobs []*boshjob.Job, stagingDir string, digests map[string]string) ([]string, error) {
 	var jobsFiles []string
 
 	if len(jobs) == 0 {
@@ -114,7 +198,7 @@ func (w ArchiveWriter) writeJobs(jobs []*boshjob.Job, stagingDir string) ([]stri
 	jobsFiles = append(jobsFiles, "jobs")
 
 	for _, job := range jobs {
-		err := w.fs.CopyFile(job.ArchivePath(), filepath.Join(jobsPath, job.Name()+".tgz"))
+		err := w.copyArchive(job.ArchivePath(), stagingDir, filepath.Join("jobs", job.Name()+".tgz"), digests)
 		if err != nil {
 			return jobsFiles, bosherr.WrapErrorf(err, "Copying job '%s' archive into staging dir", job.Name())
 		}
@@ -123,7 +207,7 @@ func (w ArchiveWriter) writeJobs(jobs []*boshjob.Job, stagingDir string) ([]stri
 	return jobsFiles, nil
 }
 
-func (w ArchiveWriter) writePackages(packages []*boshpkg.Package, pkgFpsToSkip []string, stagingDir string) ([]string, error) {
+func (w ArchiveWriter) writePackages(packages []*boshpkg.Package, pkgFpsToSkip []string, stagingDir string, digests map[string]string) ([]string, error) {
 	var packagesFiles []string
 
 	if len(packages) == 0 {
@@ -143,7 +227,7 @@ func (w ArchiveWriter) writePackages(packages []*boshpkg.Package, pkgFpsToSkip [
 		if w.shouldSkip(pkg.Fingerprint(), pkgFpsToSkip) {
 			w.logger.Debug(w.logTag, "Package '%s' was filtered out", pkg.Name())
 		} else {
-			err := w.fs.CopyFile(pkg.ArchivePath(), filepath.Join(pkgsPath, pkg.Name()+".tgz"))
+			err := w.copyArchive(pkg.ArchivePath(), stagingDir, filepath.Join("packages", pkg.Name()+".tgz"), digests)
 			if err != nil {
 				return packagesFiles, bosherr.WrapErrorf(err, "Copying package '%s' archive into staging dir", pkg.Name())
 			}
@@ -153,7 +237,7 @@ func (w ArchiveWriter) writePackages(packages []*boshpkg.Package, pkgFpsToSkip [
 	return packagesFiles, nil
 }
 
-func (w ArchiveWriter) writeCompiledPackages(compiledPkgs []*boshpkg.CompiledPackage, pkgFpsToSkip []string, stagingDir string) ([]string, error) {
+func (w ArchiveWriter) writeCompiledPackages(compiledPkgs []*boshpkg.CompiledPackage, pkgFpsToSkip []string, stagingDir string, digests map[string]string) ([]string, error) {
 	var compiledPackagesFiles []string
 
 	if len(compiledPkgs) == 0 {
@@ -173,7 +257,7 @@ func (w ArchiveWriter) writeCompiledPackages(compiledPkgs []*boshpkg.CompiledPac
 		if w.shouldSkip(compiledPkg.Fingerprint(), pkgFpsToSkip) {
 			w.logger.Debug(w.logTag, "Compiled package '%s' was filtered out", compiledPkg.Name())
 		} else {
-			err := w.fs.CopyFile(compiledPkg.ArchivePath(), filepath.Join(pkgsPath, compiledPkg.Name()+".tgz"))
+			err := w.copyArchive(compiledPkg.ArchivePath(), stagingDir, filepath.Join("compiled_packages", compiledPkg.Name()+".tgz"), digests)
 			if err != nil {
 				return compiledPackagesFiles, bosherr.WrapErrorf(err, "Copying compiled package '%s' archive into staging dir", compiledPkg.Name())
 			}
@@ -183,14 +267,14 @@ func (w ArchiveWriter) writeCompiledPackages(compiledPkgs []*boshpkg.CompiledPac
 	return compiledPackagesFiles, nil
 }
 
-func (w ArchiveWriter) writeLicense(license *boshlic.License, stagingDir string) ([]string, error) {
+func (w ArchiveWriter) writeLicense(license *boshlic.License, stagingDir string, digests map[string]string) ([]string, error) {
 	var licenseFiles []string
 
 	if license == nil {
 		return licenseFiles, nil
 	}
 
-	err := w.fs.CopyFile(license.ArchivePath(), filepath.Join(stagingDir, "license.tgz"))
+	err := w.copyArchive(license.ArchivePath(), stagingDir, "license.tgz", digests)
 	if err != nil {
 		return licenseFiles, bosherr.WrapError(err, "Copying license archive into staging dir")
 	}
@@ -1,16 +1,23 @@
 package release_test
 
 import (
+	"compress/gzip"
 	"errors"
+	"io"
+	"os"
 	"path/filepath"
 
+	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
 	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
 	fakecmd "github.com/cloudfoundry/bosh-utils/fileutil/fakes"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
 
+	bicrypto "github.com/cloudfoundry/bosh-cli/v7/crypto"
 	. "github.com/cloudfoundry/bosh-cli/v7/release"
 	boshjob "github.com/cloudfoundry/bosh-cli/v7/release/job"
 	boshlic "github.com/cloudfoundry/bosh-cli/v7/release/license"
@@ -18,6 +25,7 @@ import (
 	boshpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
 	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
 	. "github.com/cloudfoundry/bosh-cli/v7/release/resource"
+	boshtarball "github.com/cloudfoundry/bosh-cli/v7/release/tarball"
 )
 
 var _ = Describe("ArchiveWriter", func() {
@@ -535,5 +543,116 @@ no_compression: true
 
 			Expect(fs.FileExists(filepath.Join("/", "staging-release"))).To(BeFalse())
 		})
+
+		Context("when writer recompresses zstd archives with gzip", func() {
+			var (
+				osFs             boshsys.FileSystem
+				digestCalculator bicrypto.DigestCalculator
+				srcDir           string
+			)
+
+			writeArchive := func(name string, format boshtarball.Format, contents string) string {
+				path := filepath.Join(srcDir, name)
+
+				file, err := os.Create(path)
+				Expect(err).ToNot(HaveOccurred())
+				defer file.Close() //nolint:errcheck
+
+				w, err := boshtarball.Compression{Format: format}.NewWriter(file)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = w.Write([]byte(contents))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(w.Close()).To(Succeed())
+
+				return path
+			}
+
+			readArchive := func(path string) string {
+				file, err := os.Open(path)
+				Expect(err).ToNot(HaveOccurred())
+				defer file.Close() //nolint:errcheck
+
+				r, err := gzip.NewReader(file)
+				Expect(err).ToNot(HaveOccurred())
+
+				contents, err := io.ReadAll(r)
+				Expect(err).ToNot(HaveOccurred())
+
+				return string(contents)
+			}
+
+			BeforeEach(func() {
+				srcDir = GinkgoT().TempDir()
+
+				osFs = boshsys.NewOsFileSystem(boshlog.NewLogger(boshlog.LevelNone))
+				digestCalculator = bicrypto.NewDigestCalculator(osFs, []boshcrypto.Algorithm{boshcrypto.DigestAlgorithmSHA1})
+
+				writer = NewGzipArchiveWriter(compressor, digestCalculator, osFs, boshlog.NewLogger(boshlog.LevelNone))
+
+				release.JobsReturns([]*boshjob.Job{
+					boshjob.NewJob(NewResourceWithBuiltArchive("job1", "", writeArchive("job1.tgz", boshtarball.FormatZstd, "job1-content"), "")),
+				})
+				release.PackagesReturns([]*boshpkg.Package{
+					boshpkg.NewPackage(NewResourceWithBuiltArchive("pkg1", "pkg1-fp", writeArchive("pkg1.tgz", boshtarball.FormatZstd, "pkg1-content"), ""), nil),
+					boshpkg.NewPackage(NewResourceWithBuiltArchive("pkg2", "pkg2-fp", writeArchive("pkg2.tgz", boshtarball.FormatGzip, "pkg2-content"), ""), nil),
+				})
+				release.CompiledPackagesReturns([]*boshpkg.CompiledPackage{
+					boshpkg.NewCompiledPackageWithArchive("cp", "cp-fp", "os-slug", writeArchive("cp.tgz", boshtarball.FormatZstd, "cp-content"), "cp-sha1", nil),
+				})
+
+				release.ManifestReturns(boshman.Manifest{
+					Name:         "name",
+					Jobs:         []boshman.JobRef{{Name: "job1", SHA1: "job1-zstd-sha1"}},
+					Packages:     []boshman.PackageRef{{Name: "pkg1", SHA1: "pkg1-zstd-sha1"}, {Name: "pkg2", SHA1: "pkg2-sha1"}},
+					CompiledPkgs: []boshman.CompiledPackageRef{{Name: "cp", SHA1: "cp-zstd-sha1"}},
+				})
+			})
+
+			It("recompresses zstd archives and records their digests in release.MF", func() {
+				compressed := false
+
+				compressor.CompressSpecificFilesInDirCallBack = func() {
+					stagingDir := compressor.CompressSpecificFilesInDirDir
+
+					Expect(readArchive(filepath.Join(stagingDir, "jobs", "job1.tgz"))).To(Equal("job1-content"))
+					Expect(readArchive(filepath.Join(stagingDir, "packages", "pkg1.tgz"))).To(Equal("pkg1-content"))
+					Expect(readArchive(filepath.Join(stagingDir, "packages", "pkg2.tgz"))).To(Equal("pkg2-content"))
+					Expect(readArchive(filepath.Join(stagingDir, "compiled_packages", "cp.tgz"))).To(Equal("cp-content"))
+
+					manifestBytes, err := os.ReadFile(filepath.Join(stagingDir, "release.MF"))
+					Expect(err).ToNot(HaveOccurred())
+
+					var manifest boshman.Manifest
+					Expect(yaml.Unmarshal(manifestBytes, &manifest)).To(Succeed())
+
+					job1SHA1, err := digestCalculator.Calculate(filepath.Join(stagingDir, "jobs", "job1.tgz"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(manifest.Jobs[0].SHA1).To(Equal(job1SHA1))
+
+					pkg1SHA1, err := digestCalculator.Calculate(filepath.Join(stagingDir, "packages", "pkg1.tgz"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(manifest.Packages[0].SHA1).To(Equal(pkg1SHA1))
+					Expect(manifest.Packages[1].SHA1).To(Equal("pkg2-sha1"))
+
+					cpSHA1, err := digestCalculator.Calculate(filepath.Join(stagingDir, "compiled_packages", "cp.tgz"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(manifest.CompiledPkgs[0].SHA1).To(Equal(cpSHA1))
+
+					compressed = true
+				}
+
+				_, err := act()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(compressed).To(BeTrue())
+			})
+
+			It("keeps release.MF first in the release tarball", func() {
+				_, err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(compressor.CompressSpecificFilesInDirFiles[0]).To(Equal("release.MF"))
+			})
+		})
 	})
 })
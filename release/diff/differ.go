@@ -0,0 +1,516 @@
+package diff
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v2"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshjobman "github.com/cloudfoundry/bosh-cli/v7/release/job/manifest"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+type Change string
+
+const (
+	Added   Change = "added"
+	Removed Change = "removed"
+	Changed Change = "changed"
+)
+
+// ResourceChange is a job or a package whose fingerprint differs
+type ResourceChange struct {
+	Name            string
+	Change          Change
+	FromFingerprint string
+	ToFingerprint   string
+}
+
+type PropertyChange struct {
+	Job      string
+	Property string
+	Change   Change
+
+	// Defaults are YAML encoded; empty if property has no default
+	FromDefault string
+	ToDefault   string
+}
+
+type LinkChange struct {
+	Job string
+
+	// Link is 'consumes NAME' or 'provides NAME'
+	Link   string
+	Change Change
+
+	From string
+	To   string
+}
+
+type TemplateChange struct {
+	Job      string
+	Template string
+	Change   Change
+	Lines    []Line
+}
+
+type FileChange struct {
+	Package string
+	File    string
+	Change  Change
+}
+
+type Result struct {
+	Jobs     []ResourceChange
+	Packages []ResourceChange
+
+	Properties   []PropertyChange
+	Links        []LinkChange
+	Templates    []TemplateChange
+	PackageFiles []FileChange
+}
+
+func (r Result) Empty() bool {
+	return len(r.Jobs) == 0 && len(r.Packages) == 0
+}
+
+//counterfeiter:generate . Differ
+
+// Differ compares jobs and packages of two built releases by looking
+// into their archives since only fingerprints are kept in release manifests
+type Differ interface {
+	Diff(from, to boshrel.Release) (Result, error)
+}
+
+type differ struct {
+	compressor boshcmd.Compressor
+	fs         boshsys.FileSystem
+}
+
+func NewDiffer(compressor boshcmd.Compressor, fs boshsys.FileSystem) Differ {
+	return differ{compressor: compressor, fs: fs}
+}
+
+// resource is a job or a package with built archive
+type resource interface {
+	Name() string
+	Fingerprint() string
+	ArchivePath() string
+}
+
+func (d differ) Diff(from, to boshrel.Release) (Result, error) {
+	var result Result
+
+	fromJobs, toJobs := map[string]resource{}, map[string]resource{}
+
+	for _, job := range from.Jobs() {
+		fromJobs[job.Name()] = job
+	}
+	for _, job := range to.Jobs() {
+		toJobs[job.Name()] = job
+	}
+
+	result.Jobs = diffResources(fromJobs, toJobs)
+
+	for _, change := range result.Jobs {
+		err := d.diffJob(change, fromJobs[change.Name], toJobs[change.Name], &result)
+		if err != nil {
+			return Result{}, bosherr.WrapErrorf(err, "Comparing job '%s'", change.Name)
+		}
+	}
+
+	fromPkgs, toPkgs := releasePackages(from), releasePackages(to)
+
+	result.Packages = diffResources(fromPkgs, toPkgs)
+
+	for _, change := range result.Packages {
+		err := d.diffPackage(change, fromPkgs[change.Name], toPkgs[change.Name], &result)
+		if err != nil {
+			return Result{}, bosherr.WrapErrorf(err, "Comparing package '%s'", change.Name)
+		}
+	}
+
+	return result, nil
+}
+
+// releasePackages includes compiled packages so that compiled releases can be compared
+func releasePackages(release boshrel.Release) map[string]resource {
+	pkgs := map[string]resource{}
+
+	for _, pkg := range release.Packages() {
+		pkgs[pkg.Name()] = pkg
+	}
+	for _, pkg := range release.CompiledPackages() {
+		pkgs[pkg.Name()] = pkg
+	}
+
+	return pkgs
+}
+
+func diffResources(from, to map[string]resource) []ResourceChange {
+	var changes []ResourceChange
+
+	for name, fromRes := range from {
+		toRes, found := to[name]
+
+		switch {
+		case !found:
+			changes = append(changes, ResourceChange{Name: name, Change: Removed, FromFingerprint: fromRes.Fingerprint()})
+		case fromRes.Fingerprint() != toRes.Fingerprint():
+			changes = append(changes, ResourceChange{
+				Name: name, Change: Changed, FromFingerprint: fromRes.Fingerprint(), ToFingerprint: toRes.Fingerprint()})
+		}
+	}
+
+	for name, toRes := range to {
+		if _, found := from[name]; !found {
+			changes = append(changes, ResourceChange{Name: name, Change: Added, ToFingerprint: toRes.Fingerprint()})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+
+	return changes
+}
+
+// extractedJob keeps job spec and templates; templates are keyed by
+// their path in job archive (templates/... and monit)
+type extractedJob struct {
+	manifest  boshjobman.Manifest
+	templates map[string]string
+}
+
+func (d differ) diffJob(change ResourceChange, from, to resource, result *Result) error {
+	fromJob, err := d.extractJob(from)
+	if err != nil {
+		return err
+	}
+
+	toJob, err := d.extractJob(to)
+	if err != nil {
+		return err
+	}
+
+	result.Properties = append(result.Properties, diffProperties(change.Name, fromJob.manifest, toJob.manifest)...)
+	result.Links = append(result.Links, diffLinks(change.Name, fromJob.manifest, toJob.manifest)...)
+
+	for _, path := range unionKeys(fromJob.templates, toJob.templates) {
+		fromContents, inFrom := fromJob.templates[path]
+		toContents, inTo := toJob.templates[path]
+
+		templateChange := TemplateChange{Job: change.Name, Template: path}
+
+		switch {
+		case !inTo:
+			templateChange.Change = Removed
+		case !inFrom:
+			templateChange.Change = Added
+		case fromContents != toContents:
+			templateChange.Change = Changed
+		default:
+			continue
+		}
+
+		templateChange.Lines = diffLines(fromContents, toContents)
+
+		result.Templates = append(result.Templates, templateChange)
+	}
+
+	return nil
+}
+
+// extractJob returns empty job if resource is missing from release
+func (d differ) extractJob(res resource) (extractedJob, error) {
+	job := extractedJob{templates: map[string]string{}}
+
+	if res == nil {
+		return job, nil
+	}
+
+	extractPath, err := d.extract(res)
+	if err != nil {
+		return job, err
+	}
+
+	defer d.fs.RemoveAll(extractPath) //nolint:errcheck
+
+	job.manifest, err = boshjobman.NewManifestFromPath(filepath.Join(extractPath, "job.MF"), d.fs)
+	if err != nil {
+		return job, err
+	}
+
+	paths := []string{"monit"}
+
+	for src := range job.manifest.Templates {
+		paths = append(paths, filepath.ToSlash(filepath.Join("templates", src)))
+	}
+
+	for _, path := range paths {
+		fullPath := filepath.Join(extractPath, filepath.FromSlash(path))
+
+		if !d.fs.FileExists(fullPath) {
+			continue
+		}
+
+		contents, err := d.fs.ReadFileString(fullPath)
+		if err != nil {
+			return job, bosherr.WrapErrorf(err, "Reading job template '%s'", path)
+		}
+
+		job.templates[path] = contents
+	}
+
+	return job, nil
+}
+
+func diffProperties(jobName string, from, to boshjobman.Manifest) []PropertyChange {
+	var changes []PropertyChange
+
+	names := map[string]struct{}{}
+	for name := range from.Properties {
+		names[name] = struct{}{}
+	}
+	for name := range to.Properties {
+		names[name] = struct{}{}
+	}
+
+	for _, name := range sortedKeys(names) {
+		fromDef, inFrom := from.Properties[name]
+		toDef, inTo := to.Properties[name]
+
+		change := PropertyChange{Job: jobName, Property: name}
+
+		switch {
+		case !inTo:
+			change.Change = Removed
+		case !inFrom:
+			change.Change = Added
+		case !reflect.DeepEqual(fromDef.Default, toDef.Default):
+			change.Change = Changed
+		default:
+			continue
+		}
+
+		if inFrom {
+			change.FromDefault = encodeDefault(fromDef.Default)
+		}
+		if inTo {
+			change.ToDefault = encodeDefault(toDef.Default)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func encodeDefault(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return strings.TrimSuffix(string(bytes), "\n")
+}
+
+func diffLinks(jobName string, from, to boshjobman.Manifest) []LinkChange {
+	var changes []LinkChange
+
+	describe := func(link boshjobman.LinkDefinition) string {
+		desc := link.Type
+		if link.Optional {
+			desc += " (optional)"
+		}
+		if len(link.Properties) > 0 {
+			desc += fmt.Sprintf(" [%s]", strings.Join(link.Properties, ", "))
+		}
+		return desc
+	}
+
+	links := func(manifest boshjobman.Manifest) map[string]string {
+		result := map[string]string{}
+		for _, link := range manifest.Consumes {
+			result["consumes "+link.Name] = describe(link)
+		}
+		for _, link := range manifest.Provides {
+			result["provides "+link.Name] = describe(link)
+		}
+		return result
+	}
+
+	fromLinks, toLinks := links(from), links(to)
+
+	for _, name := range unionKeys(fromLinks, toLinks) {
+		fromDesc, inFrom := fromLinks[name]
+		toDesc, inTo := toLinks[name]
+
+		change := LinkChange{Job: jobName, Link: name, From: fromDesc, To: toDesc}
+
+		switch {
+		case !inTo:
+			change.Change = Removed
+		case !inFrom:
+			change.Change = Added
+		case fromDesc != toDesc:
+			change.Change = Changed
+		default:
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func (d differ) diffPackage(change ResourceChange, from, to resource, result *Result) error {
+	fromFiles, err := d.packageFiles(from)
+	if err != nil {
+		return err
+	}
+
+	toFiles, err := d.packageFiles(to)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range unionKeys(fromFiles, toFiles) {
+		fromDigest, inFrom := fromFiles[path]
+		toDigest, inTo := toFiles[path]
+
+		fileChange := FileChange{Package: change.Name, File: path}
+
+		switch {
+		case !inTo:
+			fileChange.Change = Removed
+		case !inFrom:
+			fileChange.Change = Added
+		case fromDigest != toDigest:
+			fileChange.Change = Changed
+		default:
+			continue
+		}
+
+		result.PackageFiles = append(result.PackageFiles, fileChange)
+	}
+
+	return nil
+}
+
+// packageFiles returns SHA256 digests of files in package archive keyed by path
+func (d differ) packageFiles(res resource) (map[string]string, error) {
+	files := map[string]string{}
+
+	if res == nil {
+		return files, nil
+	}
+
+	extractPath, err := d.extract(res)
+	if err != nil {
+		return nil, err
+	}
+
+	defer d.fs.RemoveAll(extractPath) //nolint:errcheck
+
+	err = d.fs.Walk(extractPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(extractPath, path)
+		if err != nil {
+			return err
+		}
+
+		digest, err := d.digest(path, info)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(relPath)] = digest
+
+		return nil
+	})
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Listing package files")
+	}
+
+	return files, nil
+}
+
+func (d differ) digest(path string, info os.FileInfo) (string, error) {
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := d.fs.Readlink(path)
+		if err != nil {
+			return "", err
+		}
+		return "symlink:" + target, nil
+	}
+
+	file, err := d.fs.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	hash := sha256.New()
+
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+
+	// Executable bit is significant for scripts
+	return fmt.Sprintf("%x %o", hash.Sum(nil), info.Mode().Perm()&0111), nil
+}
+
+func (d differ) extract(res resource) (string, error) {
+	extractPath, err := d.fs.TempDir("bosh-diff-releases")
+	if err != nil {
+		return "", bosherr.WrapError(err, "Creating temp directory")
+	}
+
+	err = d.compressor.DecompressFileToDir(res.ArchivePath(), extractPath, boshcmd.CompressorOptions{})
+	if err != nil {
+		d.fs.RemoveAll(extractPath) //nolint:errcheck
+		return "", bosherr.WrapErrorf(err, "Extracting archive '%s'", res.ArchivePath())
+	}
+
+	return extractPath, nil
+}
+
+func unionKeys(a, b map[string]string) []string {
+	keys := map[string]struct{}{}
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return sortedKeys(keys)
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,199 @@
+package diff_test
+
+import (
+	"os"
+	"path/filepath"
+
+	boshfu "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/release/diff"
+	bireljob "github.com/cloudfoundry/bosh-cli/v7/release/job"
+	birelpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
+	birelres "github.com/cloudfoundry/bosh-cli/v7/release/resource"
+)
+
+var _ = Describe("Differ", func() {
+	var (
+		compressor boshfu.Compressor
+		from, to   *fakerel.FakeRelease
+		differ     Differ
+	)
+
+	archive := func(files map[string]string) string {
+		dir := GinkgoT().TempDir()
+
+		for path, contents := range files {
+			fullPath := filepath.Join(dir, path)
+			Expect(os.MkdirAll(filepath.Dir(fullPath), 0755)).To(Succeed())
+			Expect(os.WriteFile(fullPath, []byte(contents), 0644)).To(Succeed())
+		}
+
+		path, err := compressor.CompressFilesInDir(dir, boshfu.CompressorOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		return path
+	}
+
+	newJob := func(name, fp string, files map[string]string) *bireljob.Job {
+		return bireljob.NewJob(birelres.NewResourceWithBuiltArchive(name, fp, archive(files), ""))
+	}
+
+	newPkg := func(name, fp string, files map[string]string) *birelpkg.Package {
+		return birelpkg.NewPackage(birelres.NewResourceWithBuiltArchive(name, fp, archive(files), ""), nil)
+	}
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		fs := boshsys.NewOsFileSystem(logger)
+		compressor = boshfu.NewTarballCompressor(boshsys.NewExecCmdRunner(logger), fs)
+
+		from = &fakerel.FakeRelease{}
+		to = &fakerel.FakeRelease{}
+
+		differ = NewDiffer(compressor, fs)
+	})
+
+	It("returns empty result for releases with the same fingerprints", func() {
+		from.JobsReturns([]*bireljob.Job{newJob("web", "fp", map[string]string{"job.MF": "name: web"})})
+		to.JobsReturns([]*bireljob.Job{newJob("web", "fp", map[string]string{"job.MF": "name: other"})})
+
+		result, err := differ.Diff(from, to)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Empty()).To(BeTrue())
+		Expect(result.Properties).To(BeEmpty())
+	})
+
+	It("compares jobs by fingerprint and reports spec and template changes", func() {
+		from.JobsReturns([]*bireljob.Job{
+			newJob("web", "web-fp1", map[string]string{
+				"job.MF": `---
+name: web
+templates: {config.erb: config/config.yml, ctl.erb: bin/ctl}
+properties:
+  port: {default: 80}
+  removed: {}
+  same: {default: [a]}
+consumes:
+- {name: db, type: postgres}
+provides:
+- {name: web, type: http}
+`,
+				"monit":                "check process web",
+				"templates/config.erb": "a\nb\nc\nd\ne\nf\ng\nh\ni\n",
+				"templates/ctl.erb":    "exec web",
+			}),
+			newJob("old", "old-fp", map[string]string{"job.MF": "name: old"}),
+		})
+
+		to.JobsReturns([]*bireljob.Job{
+			newJob("web", "web-fp2", map[string]string{
+				"job.MF": `---
+name: web
+templates: {config.erb: config/config.yml, pre-start.erb: bin/pre-start}
+properties:
+  port: {default: 8080}
+  added: {default: {tls: true}}
+  same: {default: [a]}
+consumes:
+- {name: db, type: postgres, optional: true}
+- {name: cache, type: redis}
+`,
+				"monit":                   "check process web",
+				"templates/config.erb":    "a\nb\nc\nd\nE\nf\ng\nh\ni\n",
+				"templates/pre-start.erb": "mkdir -p /var/vcap",
+			}),
+			newJob("new", "new-fp", map[string]string{"job.MF": "name: new"}),
+		})
+
+		result, err := differ.Diff(from, to)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(result.Jobs).To(Equal([]ResourceChange{
+			{Name: "new", Change: Added, ToFingerprint: "new-fp"},
+			{Name: "old", Change: Removed, FromFingerprint: "old-fp"},
+			{Name: "web", Change: Changed, FromFingerprint: "web-fp1", ToFingerprint: "web-fp2"},
+		}))
+
+		Expect(result.Properties).To(Equal([]PropertyChange{
+			{Job: "web", Property: "added", Change: Added, ToDefault: "tls: true"},
+			{Job: "web", Property: "port", Change: Changed, FromDefault: "80", ToDefault: "8080"},
+			{Job: "web", Property: "removed", Change: Removed},
+		}))
+
+		Expect(result.Links).To(Equal([]LinkChange{
+			{Job: "web", Link: "consumes cache", Change: Added, To: "redis"},
+			{Job: "web", Link: "consumes db", Change: Changed, From: "postgres", To: "postgres (optional)"},
+			{Job: "web", Link: "provides web", Change: Removed, From: "http"},
+		}))
+
+		Expect(result.Templates).To(Equal([]TemplateChange{
+			{Job: "web", Template: "templates/config.erb", Change: Changed, Lines: []Line{
+				{Text: "..."},
+				{Text: "b"},
+				{Text: "c"},
+				{Text: "d"},
+				{Text: "e", Mod: "removed"},
+				{Text: "E", Mod: "added"},
+				{Text: "f"},
+				{Text: "g"},
+				{Text: "h"},
+				{Text: "..."},
+			}},
+			{Job: "web", Template: "templates/ctl.erb", Change: Removed, Lines: []Line{
+				{Text: "exec web", Mod: "removed"},
+			}},
+			{Job: "web", Template: "templates/pre-start.erb", Change: Added, Lines: []Line{
+				{Text: "mkdir -p /var/vcap", Mod: "added"},
+			}},
+		}))
+	})
+
+	It("compares package files", func() {
+		from.PackagesReturns([]*birelpkg.Package{
+			newPkg("ruby", "ruby-fp1", map[string]string{
+				"packaging":          "make",
+				"ruby/ruby-3.1.tgz":  "old",
+				"ruby/yaml-0.2.tgz":  "yaml",
+				"ruby/patches/a.txt": "same",
+			}),
+			newPkg("same", "same-fp", map[string]string{"packaging": "a"}),
+		})
+
+		to.PackagesReturns([]*birelpkg.Package{
+			newPkg("ruby", "ruby-fp2", map[string]string{
+				"packaging":          "make install",
+				"ruby/ruby-3.2.tgz":  "new",
+				"ruby/yaml-0.2.tgz":  "yaml",
+				"ruby/patches/a.txt": "same",
+			}),
+			newPkg("same", "same-fp", map[string]string{"packaging": "b"}),
+		})
+
+		result, err := differ.Diff(from, to)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(result.Packages).To(Equal([]ResourceChange{
+			{Name: "ruby", Change: Changed, FromFingerprint: "ruby-fp1", ToFingerprint: "ruby-fp2"},
+		}))
+
+		Expect(result.PackageFiles).To(Equal([]FileChange{
+			{Package: "ruby", File: "packaging", Change: Changed},
+			{Package: "ruby", File: "ruby/ruby-3.1.tgz", Change: Removed},
+			{Package: "ruby", File: "ruby/ruby-3.2.tgz", Change: Added},
+		}))
+	})
+
+	It("returns error if job spec cannot be read", func() {
+		from.JobsReturns([]*bireljob.Job{newJob("web", "fp1", map[string]string{"monit": ""})})
+		to.JobsReturns([]*bireljob.Job{newJob("web", "fp2", map[string]string{"job.MF": "name: web"})})
+
+		_, err := differ.Diff(from, to)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Comparing job 'web'"))
+	})
+})
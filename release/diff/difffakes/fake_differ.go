@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package difffakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/release"
+	"github.com/cloudfoundry/bosh-cli/v7/release/diff"
+)
+
+type FakeDiffer struct {
+	DiffStub        func(release.Release, release.Release) (diff.Result, error)
+	diffMutex       sync.RWMutex
+	diffArgsForCall []struct {
+		arg1 release.Release
+		arg2 release.Release
+	}
+	diffReturns struct {
+		result1 diff.Result
+		result2 error
+	}
+	diffReturnsOnCall map[int]struct {
+		result1 diff.Result
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDiffer) Diff(arg1 release.Release, arg2 release.Release) (diff.Result, error) {
+	fake.diffMutex.Lock()
+	ret, specificReturn := fake.diffReturnsOnCall[len(fake.diffArgsForCall)]
+	fake.diffArgsForCall = append(fake.diffArgsForCall, struct {
+		arg1 release.Release
+		arg2 release.Release
+	}{arg1, arg2})
+	stub := fake.DiffStub
+	fakeReturns := fake.diffReturns
+	fake.recordInvocation("Diff", []interface{}{arg1, arg2})
+	fake.diffMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDiffer) DiffCallCount() int {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	return len(fake.diffArgsForCall)
+}
+
+func (fake *FakeDiffer) DiffCalls(stub func(release.Release, release.Release) (diff.Result, error)) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = stub
+}
+
+func (fake *FakeDiffer) DiffArgsForCall(i int) (release.Release, release.Release) {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	argsForCall := fake.diffArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDiffer) DiffReturns(result1 diff.Result, result2 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	fake.diffReturns = struct {
+		result1 diff.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDiffer) DiffReturnsOnCall(i int, result1 diff.Result, result2 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	if fake.diffReturnsOnCall == nil {
+		fake.diffReturnsOnCall = make(map[int]struct {
+			result1 diff.Result
+			result2 error
+		})
+	}
+	fake.diffReturnsOnCall[i] = struct {
+		result1 diff.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDiffer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDiffer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ diff.Differ = new(FakeDiffer)
@@ -0,0 +1,107 @@
+package diff
+
+import (
+	"strings"
+)
+
+const (
+	lineAdded   = "added"
+	lineRemoved = "removed"
+
+	// contextLines are kept around changed lines; the rest is collapsed
+	contextLines = 3
+
+	// maxLCSCells bounds memory used to compare large files
+	maxLCSCells = 4000000
+)
+
+// Line uses the same modification names as director's diff lines
+type Line struct {
+	Text string
+	Mod  string
+}
+
+func diffLines(from, to string) []Line {
+	fromLines := splitLines(from)
+	toLines := splitLines(to)
+
+	var lines []Line
+
+	if len(fromLines)*len(toLines) > maxLCSCells {
+		for _, text := range fromLines {
+			lines = append(lines, Line{Text: text, Mod: lineRemoved})
+		}
+		for _, text := range toLines {
+			lines = append(lines, Line{Text: text, Mod: lineAdded})
+		}
+		return lines
+	}
+
+	// lcs[i][j] is length of longest common subsequence of fromLines[i:] and toLines[j:]
+	lcs := make([][]int, len(fromLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(toLines)+1)
+	}
+
+	for i := len(fromLines) - 1; i >= 0; i-- {
+		for j := len(toLines) - 1; j >= 0; j-- {
+			if fromLines[i] == toLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+
+	for i < len(fromLines) || j < len(toLines) {
+		switch {
+		case i < len(fromLines) && j < len(toLines) && fromLines[i] == toLines[j]:
+			lines = append(lines, Line{Text: fromLines[i]})
+			i++
+			j++
+		case i < len(fromLines) && (j == len(toLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, Line{Text: fromLines[i], Mod: lineRemoved})
+			i++
+		default:
+			lines = append(lines, Line{Text: toLines[j], Mod: lineAdded})
+			j++
+		}
+	}
+
+	return collapseContext(lines)
+}
+
+// collapseContext replaces runs of unchanged lines far from changes with '...'
+func collapseContext(lines []Line) []Line {
+	keep := make([]bool, len(lines))
+
+	for i, line := range lines {
+		if len(line.Mod) == 0 {
+			continue
+		}
+		for j := max(0, i-contextLines); j <= min(len(lines)-1, i+contextLines); j++ {
+			keep[j] = true
+		}
+	}
+
+	var result []Line
+
+	for i, line := range lines {
+		if keep[i] {
+			result = append(result, line)
+		} else if len(result) == 0 || result[len(result)-1] != (Line{Text: "..."}) {
+			result = append(result, Line{Text: "..."})
+		}
+	}
+
+	return result
+}
+
+func splitLines(s string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
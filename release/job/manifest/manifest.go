@@ -11,6 +11,19 @@ type Manifest struct {
 	Templates  map[string]string             `yaml:"templates"`
 	Packages   []string                      `yaml:"packages"`
 	Properties map[string]PropertyDefinition `yaml:"properties"`
+
+	Consumes []LinkDefinition `yaml:"consumes"`
+	Provides []LinkDefinition `yaml:"provides"`
+}
+
+// LinkDefinition is a link consumed or provided by a job
+type LinkDefinition struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Optional bool   `yaml:"optional"`
+
+	// Only set for provided links
+	Properties []string `yaml:"properties"`
 }
 
 type PropertyDefinition struct {
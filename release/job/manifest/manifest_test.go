@@ -37,6 +37,16 @@ properties:
   prop1.prop2:
     description: prop2-desc
     default: prop2-default
+
+consumes:
+- name: db
+  type: database
+  optional: true
+
+provides:
+- name: api
+  type: http
+  properties: [prop1]
 `
 
 		err := fs.WriteFileString("/path", contents)
@@ -61,6 +71,14 @@ properties:
 					Default:     "prop2-default",
 				},
 			},
+
+			Consumes: []LinkDefinition{
+				{Name: "db", Type: "database", Optional: true},
+			},
+
+			Provides: []LinkDefinition{
+				{Name: "api", Type: "http", Properties: []string{"prop1"}},
+			},
 		}))
 	})
 
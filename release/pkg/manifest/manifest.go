@@ -12,6 +12,15 @@ type Manifest struct {
 	Files         []string `yaml:"files"`
 	ExcludedFiles []string `yaml:"excluded_files"`
 	NoCompression bool     `yaml:"no_compression"`
+
+	Upstreams []Upstream `yaml:"upstreams"`
+}
+
+// Upstream describes third party software a package is built from
+type Upstream struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	PURL    string `yaml:"purl"`
 }
 
 func NewManifestFromPath(path string, fs boshsys.FileSystem) (Manifest, error) {
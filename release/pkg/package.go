@@ -22,10 +22,28 @@ type Package struct {
 	Dependencies    []*Package
 	dependencyNames []string
 
+	// Sources and Upstreams are only known when annotated for a software bill of materials;
+	// they are never written into release manifest
+	Sources   []Source
+	Upstreams []Upstream
+
 	extractedPath string
 	fs            boshsys.FileSystem
 }
 
+// Source is a blob included into a package
+type Source struct {
+	Path   string
+	Digest string
+}
+
+// Upstream is third party software declared in a package spec
+type Upstream struct {
+	Name    string
+	Version string
+	PURL    string
+}
+
 func NewPackage(pkgResource resource.Resource, dependencyNames []string) *Package {
 	return &Package{
 		resource: pkgResource,
@@ -84,3 +84,7 @@ func (p Provider) NewManifestReader() ManifestReader {
 func (p Provider) NewArchiveWriter() ArchiveWriter {
 	return NewArchiveWriter(p.compressor, p.fs, p.logger)
 }
+
+func (p Provider) NewGzipArchiveWriter() ArchiveWriter {
+	return NewGzipArchiveWriter(p.compressor, p.digestCalculator, p.fs, p.logger)
+}
@@ -194,6 +194,18 @@ var _ = Describe("Release", func() {
 			}))
 		})
 
+		It("does not include package sources and upstreams annotated for software bill of materials", func() {
+			pkg := boshpkg.NewPackage(NewExistingResource("pkg", "pkg-fp", "pkg-sha1"), []string{"dep"})
+			pkg.Sources = []boshpkg.Source{{Path: "pkg/src.tgz", Digest: "sha1:abc"}}
+			pkg.Upstreams = []boshpkg.Upstream{{Name: "src", Version: "1.0"}}
+
+			release = NewRelease("name", "ver", "commit", false, nil, []*boshpkg.Package{pkg}, nil, nil, false, "", fs)
+
+			Expect(release.Manifest().Packages).To(Equal([]boshman.PackageRef{
+				{Name: "pkg", Version: "pkg-fp", Fingerprint: "pkg-fp", SHA1: "pkg-sha1", Dependencies: []string{"dep"}},
+			}))
+		})
+
 		It("does not include license if it's not set", func() {
 			release = NewRelease("name", "ver", "commit", true, nil, nil, nil, nil, false, "", fs)
 			Expect(release.Manifest().License).To(BeNil())
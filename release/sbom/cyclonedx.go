@@ -0,0 +1,160 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+const cdxReleaseRef = "release"
+
+type cdxDocument struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components"`
+	Dependencies []cdxDependency `json:"dependencies"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	BOMRef     string         `json:"bom-ref,omitempty"`
+	Type       string         `json:"type"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version,omitempty"`
+	PURL       string         `json:"purl,omitempty"`
+	Hashes     []cdxHash      `json:"hashes,omitempty"`
+	Components []cdxComponent `json:"components,omitempty"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+func newCycloneDXDocument(doc document) ([]byte, error) {
+	namespace := doc.namespace()
+
+	cdx := cdxDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		// Serial number is derived from contents so that same release produces same document
+		SerialNumber: fmt.Sprintf("urn:uuid:%s-%s-%s-%s-%s",
+			namespace[0:8], namespace[8:12], namespace[12:16], namespace[16:20], namespace[20:32]),
+		Version: 1,
+		Metadata: cdxMetadata{
+			Timestamp: doc.Created.Format(time.RFC3339),
+			Tools: cdxTools{
+				Components: []cdxComponent{{Type: "application", Name: toolName}},
+			},
+			Component: cdxComponent{
+				BOMRef:  cdxReleaseRef,
+				Type:    "application",
+				Name:    doc.Name,
+				Version: doc.Version,
+			},
+		},
+		Components:   []cdxComponent{},
+		Dependencies: []cdxDependency{},
+	}
+
+	releaseDep := cdxDependency{Ref: cdxReleaseRef, DependsOn: []string{}}
+
+	for _, pkg := range doc.Packages {
+		pkgRef := cdxPackageRef(pkg.Name)
+
+		component := cdxComponent{
+			BOMRef:  pkgRef,
+			Type:    "library",
+			Name:    pkg.Name,
+			Version: pkg.Fingerprint,
+			Hashes:  cdxHashes(pkg.Digest),
+		}
+
+		for _, upstream := range pkg.Upstreams {
+			component.Components = append(component.Components, cdxComponent{
+				BOMRef:  fmt.Sprintf("%s:upstream:%s", pkgRef, upstream.Name),
+				Type:    "library",
+				Name:    upstream.Name,
+				Version: upstream.Version,
+				PURL:    upstream.PURL,
+			})
+		}
+
+		for _, source := range pkg.Sources {
+			component.Components = append(component.Components, cdxComponent{
+				BOMRef: fmt.Sprintf("%s:source:%s", pkgRef, source.Path),
+				Type:   "file",
+				Name:   sourcesDir + source.Path,
+				Hashes: cdxHashes(source.Digest),
+			})
+		}
+
+		cdx.Components = append(cdx.Components, component)
+
+		releaseDep.DependsOn = append(releaseDep.DependsOn, pkgRef)
+
+		pkgDep := cdxDependency{Ref: pkgRef, DependsOn: []string{}}
+
+		for _, depName := range pkg.Dependencies {
+			pkgDep.DependsOn = append(pkgDep.DependsOn, cdxPackageRef(depName))
+		}
+
+		cdx.Dependencies = append(cdx.Dependencies, pkgDep)
+	}
+
+	cdx.Dependencies = append([]cdxDependency{releaseDep}, cdx.Dependencies...)
+
+	for _, license := range doc.Licenses {
+		cdx.Components = append(cdx.Components, cdxComponent{
+			BOMRef: "license:" + license.Path,
+			Type:   "file",
+			Name:   licensesName + "/" + license.Path,
+			Hashes: cdxHashes(license.Digest),
+		})
+	}
+
+	bytes, err := json.MarshalIndent(cdx, "", "  ")
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Marshalling CycloneDX document")
+	}
+
+	return bytes, nil
+}
+
+func cdxPackageRef(name string) string {
+	return "package:" + name
+}
+
+func cdxHashes(digest string) []cdxHash {
+	var result []cdxHash
+
+	for _, sum := range checksums(digest) {
+		// CycloneDX spells algorithms with a dash, e.g. SHA-256
+		alg := strings.ToUpper(sum.Algorithm)
+		alg = strings.Replace(alg, "SHA", "SHA-", 1)
+
+		result = append(result, cdxHash{Alg: alg, Content: sum.Value})
+	}
+
+	return result
+}
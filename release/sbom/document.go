@@ -0,0 +1,92 @@
+package sbom
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	boshpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+)
+
+const (
+	toolName     = "bosh-cli"
+	noAssertion  = "NOASSERTION"
+	sourcesDir   = "blobs/"
+	licensesName = "license"
+)
+
+// document is the format independent view of a release which is encoded into SPDX or CycloneDX
+type document struct {
+	Name    string
+	Version string
+	Created time.Time
+
+	Packages []documentPackage
+	Licenses []documentFile
+}
+
+type documentPackage struct {
+	Name         string
+	Fingerprint  string
+	Digest       string
+	Dependencies []string
+	Sources      []boshpkg.Source
+	Upstreams    []boshpkg.Upstream
+}
+
+type documentFile struct {
+	Path   string
+	Digest string
+}
+
+type checksum struct {
+	Algorithm string
+	Value     string
+}
+
+// checksums splits a bosh digest such as "sha1:abc;sha256:def" into individual checksums.
+// Digests without algorithm prefix are SHA1 for compatibility with older releases.
+func checksums(digest string) []checksum {
+	var result []checksum
+
+	for _, piece := range strings.Split(digest, ";") {
+		if piece == "" {
+			continue
+		}
+
+		algo, value := "sha1", piece
+
+		if i := strings.Index(piece, ":"); i >= 0 {
+			algo, value = piece[:i], piece[i+1:]
+		}
+
+		result = append(result, checksum{Algorithm: algo, Value: value})
+	}
+
+	return result
+}
+
+// namespace returns a stable identifier for the document contents
+func (d document) namespace() string {
+	hash := sha256.New()
+
+	fmt.Fprintf(hash, "%s/%s\n", d.Name, d.Version)
+
+	for _, pkg := range d.Packages {
+		fmt.Fprintf(hash, "%s/%s/%s\n", pkg.Name, pkg.Fingerprint, pkg.Digest)
+	}
+
+	for _, file := range d.Licenses {
+		fmt.Fprintf(hash, "%s/%s\n", file.Path, file.Digest)
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+var invalidIDChars = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+func idSafe(str string) string {
+	return invalidIDChars.ReplaceAllString(str, "-")
+}
@@ -0,0 +1,256 @@
+package sbom
+
+import (
+	"path/filepath"
+	"sort"
+
+	"code.cloudfoundry.org/clock"
+	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	boshpkgman "github.com/cloudfoundry/bosh-cli/v7/release/pkg/manifest"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+type Format string
+
+const (
+	SPDXFormat      Format = "spdx"
+	CycloneDXFormat Format = "cyclonedx"
+)
+
+func NewFormat(name string) (Format, error) {
+	switch Format(name) {
+	case SPDXFormat, CycloneDXFormat:
+		return Format(name), nil
+	default:
+		return "", bosherr.Errorf("Expected software bill of materials format '%s' to be one of: %s, %s",
+			name, SPDXFormat, CycloneDXFormat)
+	}
+}
+
+// FileExtension returns extension conventionally used for documents in the format
+func (f Format) FileExtension() string {
+	if f == CycloneDXFormat {
+		return ".cdx.json"
+	}
+	return ".spdx.json"
+}
+
+//counterfeiter:generate . Generator
+
+type Generator interface {
+	// AnnotateFromDir records package source blobs and upstreams
+	// declared in package specs found in the release directory
+	AnnotateFromDir(release boshrel.Release, dirPath string) error
+
+	Generate(release boshrel.Release, format Format) ([]byte, error)
+}
+
+var sourceDigestAlgorithms = []boshcrypto.Algorithm{
+	boshcrypto.DigestAlgorithmSHA1,
+	boshcrypto.DigestAlgorithmSHA256,
+}
+
+type generator struct {
+	compressor boshcmd.Compressor
+	fs         boshsys.FileSystem
+	time       clock.Clock
+}
+
+func NewGenerator(compressor boshcmd.Compressor, fs boshsys.FileSystem, time clock.Clock) Generator {
+	return generator{compressor: compressor, fs: fs, time: time}
+}
+
+func (g generator) AnnotateFromDir(release boshrel.Release, dirPath string) error {
+	for _, pkg := range release.Packages() {
+		specPath := filepath.Join(dirPath, "packages", pkg.Name(), "spec")
+
+		// Vendored packages only keep spec.lock which does not list files
+		if !g.fs.FileExists(specPath) {
+			continue
+		}
+
+		manifest, err := boshpkgman.NewManifestFromPath(specPath, g.fs)
+		if err != nil {
+			return err
+		}
+
+		sources, err := g.sources(dirPath, manifest)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Collecting sources of package '%s'", pkg.Name())
+		}
+
+		pkg.Sources = sources
+		pkg.Upstreams = nil
+
+		for _, upstream := range manifest.Upstreams {
+			pkg.Upstreams = append(pkg.Upstreams, boshpkg.Upstream{
+				Name:    upstream.Name,
+				Version: upstream.Version,
+				PURL:    upstream.PURL,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (g generator) sources(dirPath string, manifest boshpkgman.Manifest) ([]boshpkg.Source, error) {
+	blobsPath := filepath.Join(dirPath, "blobs")
+
+	excluded := map[string]struct{}{}
+
+	for _, glob := range manifest.ExcludedFiles {
+		matches, err := g.fs.RecursiveGlob(filepath.Join(blobsPath, glob))
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Listing excluded files for glob '%s'", glob)
+		}
+		for _, match := range matches {
+			excluded[match] = struct{}{}
+		}
+	}
+
+	seen := map[string]struct{}{}
+
+	var sources []boshpkg.Source
+
+	for _, glob := range manifest.Files {
+		matches, err := g.fs.RecursiveGlob(filepath.Join(blobsPath, glob))
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Listing blobs for glob '%s'", glob)
+		}
+
+		for _, match := range matches {
+			if _, found := excluded[match]; found {
+				continue
+			}
+			if _, found := seen[match]; found {
+				continue
+			}
+
+			info, err := g.fs.Stat(match)
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Checking blob '%s'", match)
+			}
+			if info.IsDir() {
+				continue
+			}
+
+			seen[match] = struct{}{}
+
+			digest, err := boshcrypto.NewMultipleDigestFromPath(match, g.fs, sourceDigestAlgorithms)
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Calculating digest of blob '%s'", match)
+			}
+
+			relPath, err := filepath.Rel(blobsPath, match)
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Determining relative path of blob '%s'", match)
+			}
+
+			sources = append(sources, boshpkg.Source{
+				Path:   filepath.ToSlash(relPath),
+				Digest: digest.String(),
+			})
+		}
+	}
+
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Path < sources[j].Path })
+
+	return sources, nil
+}
+
+func (g generator) Generate(release boshrel.Release, format Format) ([]byte, error) {
+	doc, err := g.document(release)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case SPDXFormat:
+		return newSPDXDocument(doc)
+	case CycloneDXFormat:
+		return newCycloneDXDocument(doc)
+	default:
+		_, err := NewFormat(string(format))
+		return nil, err
+	}
+}
+
+func (g generator) document(release boshrel.Release) (document, error) {
+	doc := document{
+		Name:    release.Name(),
+		Version: release.Version(),
+		Created: g.time.Now().UTC(),
+	}
+
+	for _, pkg := range release.Packages() {
+		doc.Packages = append(doc.Packages, documentPackage{
+			Name:         pkg.Name(),
+			Fingerprint:  pkg.Fingerprint(),
+			Digest:       pkg.ArchiveDigest(),
+			Dependencies: pkg.DependencyNames(),
+			Sources:      pkg.Sources,
+			Upstreams:    pkg.Upstreams,
+		})
+	}
+
+	sort.Slice(doc.Packages, func(i, j int) bool { return doc.Packages[i].Name < doc.Packages[j].Name })
+
+	if release.License() != nil {
+		licenses, err := g.licenseFiles(release.License().ArchivePath())
+		if err != nil {
+			return doc, err
+		}
+		doc.Licenses = licenses
+	}
+
+	return doc, nil
+}
+
+func (g generator) licenseFiles(archivePath string) ([]documentFile, error) {
+	if archivePath == "" {
+		return nil, nil
+	}
+
+	tmpDir, err := g.fs.TempDir("bosh-sbom-license")
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Creating temp directory for license")
+	}
+
+	defer g.fs.RemoveAll(tmpDir) //nolint:errcheck
+
+	err = g.compressor.DecompressFileToDir(archivePath, tmpDir, boshcmd.CompressorOptions{})
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Extracting license archive '%s'", archivePath)
+	}
+
+	var files []documentFile
+
+	for _, prefix := range []string{"LICENSE", "NOTICE"} {
+		matches, err := g.fs.Glob(filepath.Join(tmpDir, prefix+"*"))
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Listing '%s' files", prefix)
+		}
+
+		for _, match := range matches {
+			digest, err := boshcrypto.NewMultipleDigestFromPath(match, g.fs, sourceDigestAlgorithms)
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Calculating digest of license file '%s'", match)
+			}
+
+			files = append(files, documentFile{
+				Path:   filepath.Base(match),
+				Digest: digest.String(),
+			})
+		}
+	}
+
+	return files, nil
+}
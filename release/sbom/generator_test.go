@@ -0,0 +1,239 @@
+package sbom_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshlic "github.com/cloudfoundry/bosh-cli/v7/release/license"
+	boshpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	. "github.com/cloudfoundry/bosh-cli/v7/release/resource"
+	. "github.com/cloudfoundry/bosh-cli/v7/release/sbom"
+)
+
+var _ = Describe("Generator", func() {
+	var (
+		fs         boshsys.FileSystem
+		compressor boshcmd.Compressor
+		dirPath    string
+		pkg1       *boshpkg.Package
+		pkg2       *boshpkg.Package
+		release    boshrel.Release
+		generator  Generator
+	)
+
+	writeFile := func(path, content string) {
+		err := fs.MkdirAll(filepath.Dir(path), os.ModePerm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fs.WriteFileString(path, content)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		fs = boshsys.NewOsFileSystemWithStrictTempRoot(logger)
+		Expect(fs.ChangeTempRoot(GinkgoT().TempDir())).To(Succeed())
+		compressor = boshcmd.NewTarballCompressor(boshsys.NewExecCmdRunner(logger), fs)
+
+		dirPath = GinkgoT().TempDir()
+
+		pkg1 = boshpkg.NewPackage(NewExistingResource("pkg_1", "pkg1-fp", "sha1:pkg1-sha1;sha256:pkg1-sha256"), []string{"pkg2"})
+		pkg2 = boshpkg.NewPackage(NewExistingResource("pkg2", "pkg2-fp", "pkg2-sha1"), nil)
+		Expect(pkg1.AttachDependencies([]*boshpkg.Package{pkg2})).To(Succeed())
+
+		release = boshrel.NewRelease("rel", "1.0", "commit", false, nil,
+			[]*boshpkg.Package{pkg2, pkg1}, nil, nil, false, "", fs)
+
+		timeService := fakeclock.NewFakeClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+		generator = NewGenerator(compressor, fs, timeService)
+	})
+
+	Describe("NewFormat", func() {
+		It("accepts supported formats", func() {
+			Expect(NewFormat("spdx")).To(Equal(SPDXFormat))
+			Expect(NewFormat("cyclonedx")).To(Equal(CycloneDXFormat))
+		})
+
+		It("returns error for unknown format", func() {
+			_, err := NewFormat("xml")
+			Expect(err).To(MatchError(ContainSubstring("Expected software bill of materials format 'xml' to be one of: spdx, cyclonedx")))
+		})
+	})
+
+	Describe("AnnotateFromDir", func() {
+		BeforeEach(func() {
+			writeFile(filepath.Join(dirPath, "packages", "pkg_1", "spec"), `---
+name: pkg_1
+files:
+- pkg1/*.tgz
+- pkg1/src.tgz
+excluded_files:
+- pkg1/skip.tgz
+upstreams:
+- name: openssl
+  version: 3.0.0
+  purl: pkg:generic/openssl@3.0.0
+`)
+			writeFile(filepath.Join(dirPath, "blobs", "pkg1", "src.tgz"), "src")
+			writeFile(filepath.Join(dirPath, "blobs", "pkg1", "other.tgz"), "other")
+			writeFile(filepath.Join(dirPath, "blobs", "pkg1", "skip.tgz"), "skip")
+		})
+
+		It("records sources matching package spec files and declared upstreams", func() {
+			err := generator.AnnotateFromDir(release, dirPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pkg1.Sources).To(Equal([]boshpkg.Source{
+				{
+					Path:   "pkg1/other.tgz",
+					Digest: "d0941e68da8f38151ff86a61fc59f7c5cf9fcaa2;sha256:d9298a10d1b0735837dc4bd85dac641b0f3cef27a47e5d53a54f2f3f5b2fcffa",
+				},
+				{
+					Path:   "pkg1/src.tgz",
+					Digest: "f27fede2220bcd326aee3e86ddfd4ebd0fe58cb9;sha256:25a6634263c1b1f6fc4697a04e2b9904ea4b042a89af59dc93ec1f5d44848a26",
+				},
+			}))
+			Expect(pkg1.Upstreams).To(Equal([]boshpkg.Upstream{
+				{Name: "openssl", Version: "3.0.0", PURL: "pkg:generic/openssl@3.0.0"},
+			}))
+		})
+
+		It("skips packages without spec", func() {
+			err := generator.AnnotateFromDir(release, dirPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pkg2.Sources).To(BeNil())
+			Expect(pkg2.Upstreams).To(BeNil())
+		})
+
+		It("returns error if spec cannot be parsed", func() {
+			writeFile(filepath.Join(dirPath, "packages", "pkg_1", "spec"), "-")
+
+			err := generator.AnnotateFromDir(release, dirPath)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Generate", func() {
+		BeforeEach(func() {
+			pkg1.Sources = []boshpkg.Source{{Path: "pkg1/src.tgz", Digest: "sha1:src-sha1;sha256:src-sha256"}}
+			pkg1.Upstreams = []boshpkg.Upstream{{Name: "openssl", Version: "3.0.0", PURL: "pkg:generic/openssl@3.0.0"}}
+
+			licenseDir := GinkgoT().TempDir()
+			writeFile(filepath.Join(licenseDir, "LICENSE"), "license")
+			writeFile(filepath.Join(licenseDir, "NOTICE"), "notice")
+
+			licensePath, err := compressor.CompressFilesInDir(licenseDir, boshcmd.CompressorOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			license := boshlic.NewLicense(NewResourceWithBuiltArchive("license", "lic-fp", licensePath, "lic-sha1"))
+
+			release = boshrel.NewRelease("rel", "1.0", "commit", false, nil,
+				[]*boshpkg.Package{pkg2, pkg1}, nil, license, false, "", fs)
+		})
+
+		It("generates SPDX document", func() {
+			bytes, err := generator.Generate(release, SPDXFormat)
+			Expect(err).ToNot(HaveOccurred())
+
+			var doc map[string]interface{}
+			Expect(json.Unmarshal(bytes, &doc)).To(Succeed())
+
+			Expect(doc["spdxVersion"]).To(Equal("SPDX-2.3"))
+			Expect(doc["name"]).To(Equal("rel-1.0"))
+			Expect(doc["documentNamespace"]).To(HavePrefix("https://bosh.io/spdx/rel/1.0/"))
+			Expect(doc["creationInfo"]).To(HaveKeyWithValue("created", "2026-01-02T03:04:05Z"))
+
+			packages := doc["packages"].([]interface{})
+			Expect(packages).To(HaveLen(4))
+			Expect(packages[0]).To(HaveKeyWithValue("SPDXID", "SPDXRef-Release"))
+
+			Expect(packages[2]).To(HaveKeyWithValue("SPDXID", "SPDXRef-Package-pkg-1"))
+			Expect(packages[2]).To(HaveKeyWithValue("name", "pkg_1"))
+			Expect(packages[2]).To(HaveKeyWithValue("versionInfo", "pkg1-fp"))
+			Expect(packages[2]).To(HaveKeyWithValue("checksums", []interface{}{
+				map[string]interface{}{"algorithm": "SHA1", "checksumValue": "pkg1-sha1"},
+				map[string]interface{}{"algorithm": "SHA256", "checksumValue": "pkg1-sha256"},
+			}))
+
+			Expect(packages[3]).To(HaveKeyWithValue("name", "openssl"))
+			Expect(packages[3]).To(HaveKeyWithValue("externalRefs", []interface{}{
+				map[string]interface{}{
+					"referenceCategory": "PACKAGE-MANAGER",
+					"referenceType":     "purl",
+					"referenceLocator":  "pkg:generic/openssl@3.0.0",
+				},
+			}))
+
+			Expect(packages[1]).To(HaveKeyWithValue("name", "pkg2"))
+			Expect(packages[1]).To(HaveKeyWithValue("checksums", []interface{}{
+				map[string]interface{}{"algorithm": "SHA1", "checksumValue": "pkg2-sha1"},
+			}))
+
+			var fileNames []interface{}
+			for _, file := range doc["files"].([]interface{}) {
+				fileNames = append(fileNames, file.(map[string]interface{})["fileName"])
+			}
+			Expect(fileNames).To(Equal([]interface{}{"./blobs/pkg1/src.tgz", "./license/LICENSE", "./license/NOTICE"}))
+
+			Expect(doc["relationships"]).To(ContainElements(
+				map[string]interface{}{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Release"},
+				map[string]interface{}{"spdxElementId": "SPDXRef-Package-pkg-1", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-pkg2"},
+				map[string]interface{}{"spdxElementId": "SPDXRef-Package-pkg-1", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Source-pkg-1-0"},
+			))
+		})
+
+		It("generates CycloneDX document", func() {
+			bytes, err := generator.Generate(release, CycloneDXFormat)
+			Expect(err).ToNot(HaveOccurred())
+
+			var doc map[string]interface{}
+			Expect(json.Unmarshal(bytes, &doc)).To(Succeed())
+
+			Expect(doc["bomFormat"]).To(Equal("CycloneDX"))
+			Expect(doc["specVersion"]).To(Equal("1.5"))
+			Expect(doc["metadata"]).To(HaveKeyWithValue("timestamp", "2026-01-02T03:04:05Z"))
+
+			components := doc["components"].([]interface{})
+			Expect(components).To(HaveLen(4))
+			Expect(components[1]).To(HaveKeyWithValue("bom-ref", "package:pkg_1"))
+			Expect(components[1]).To(HaveKeyWithValue("type", "library"))
+			Expect(components[1]).To(HaveKeyWithValue("hashes", []interface{}{
+				map[string]interface{}{"alg": "SHA-1", "content": "pkg1-sha1"},
+				map[string]interface{}{"alg": "SHA-256", "content": "pkg1-sha256"},
+			}))
+			Expect(components[1]).To(HaveKeyWithValue("components", HaveLen(2)))
+			Expect(components[2]).To(HaveKeyWithValue("name", "license/LICENSE"))
+
+			Expect(doc["dependencies"]).To(Equal([]interface{}{
+				map[string]interface{}{"ref": "release", "dependsOn": []interface{}{"package:pkg2", "package:pkg_1"}},
+				map[string]interface{}{"ref": "package:pkg2", "dependsOn": []interface{}{}},
+				map[string]interface{}{"ref": "package:pkg_1", "dependsOn": []interface{}{"package:pkg2"}},
+			}))
+		})
+
+		It("generates same document for same release", func() {
+			bytes1, err := generator.Generate(release, SPDXFormat)
+			Expect(err).ToNot(HaveOccurred())
+
+			bytes2, err := generator.Generate(release, SPDXFormat)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(bytes1).To(Equal(bytes2))
+		})
+
+		It("returns error for unknown format", func() {
+			_, err := generator.Generate(release, Format("xml"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
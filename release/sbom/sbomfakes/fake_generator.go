@@ -0,0 +1,191 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package sbomfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/release"
+	"github.com/cloudfoundry/bosh-cli/v7/release/sbom"
+)
+
+type FakeGenerator struct {
+	AnnotateFromDirStub        func(release.Release, string) error
+	annotateFromDirMutex       sync.RWMutex
+	annotateFromDirArgsForCall []struct {
+		arg1 release.Release
+		arg2 string
+	}
+	annotateFromDirReturns struct {
+		result1 error
+	}
+	annotateFromDirReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GenerateStub        func(release.Release, sbom.Format) ([]byte, error)
+	generateMutex       sync.RWMutex
+	generateArgsForCall []struct {
+		arg1 release.Release
+		arg2 sbom.Format
+	}
+	generateReturns struct {
+		result1 []byte
+		result2 error
+	}
+	generateReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeGenerator) AnnotateFromDir(arg1 release.Release, arg2 string) error {
+	fake.annotateFromDirMutex.Lock()
+	ret, specificReturn := fake.annotateFromDirReturnsOnCall[len(fake.annotateFromDirArgsForCall)]
+	fake.annotateFromDirArgsForCall = append(fake.annotateFromDirArgsForCall, struct {
+		arg1 release.Release
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AnnotateFromDirStub
+	fakeReturns := fake.annotateFromDirReturns
+	fake.recordInvocation("AnnotateFromDir", []interface{}{arg1, arg2})
+	fake.annotateFromDirMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeGenerator) AnnotateFromDirCallCount() int {
+	fake.annotateFromDirMutex.RLock()
+	defer fake.annotateFromDirMutex.RUnlock()
+	return len(fake.annotateFromDirArgsForCall)
+}
+
+func (fake *FakeGenerator) AnnotateFromDirCalls(stub func(release.Release, string) error) {
+	fake.annotateFromDirMutex.Lock()
+	defer fake.annotateFromDirMutex.Unlock()
+	fake.AnnotateFromDirStub = stub
+}
+
+func (fake *FakeGenerator) AnnotateFromDirArgsForCall(i int) (release.Release, string) {
+	fake.annotateFromDirMutex.RLock()
+	defer fake.annotateFromDirMutex.RUnlock()
+	argsForCall := fake.annotateFromDirArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGenerator) AnnotateFromDirReturns(result1 error) {
+	fake.annotateFromDirMutex.Lock()
+	defer fake.annotateFromDirMutex.Unlock()
+	fake.AnnotateFromDirStub = nil
+	fake.annotateFromDirReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGenerator) AnnotateFromDirReturnsOnCall(i int, result1 error) {
+	fake.annotateFromDirMutex.Lock()
+	defer fake.annotateFromDirMutex.Unlock()
+	fake.AnnotateFromDirStub = nil
+	if fake.annotateFromDirReturnsOnCall == nil {
+		fake.annotateFromDirReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.annotateFromDirReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGenerator) Generate(arg1 release.Release, arg2 sbom.Format) ([]byte, error) {
+	fake.generateMutex.Lock()
+	ret, specificReturn := fake.generateReturnsOnCall[len(fake.generateArgsForCall)]
+	fake.generateArgsForCall = append(fake.generateArgsForCall, struct {
+		arg1 release.Release
+		arg2 sbom.Format
+	}{arg1, arg2})
+	stub := fake.GenerateStub
+	fakeReturns := fake.generateReturns
+	fake.recordInvocation("Generate", []interface{}{arg1, arg2})
+	fake.generateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGenerator) GenerateCallCount() int {
+	fake.generateMutex.RLock()
+	defer fake.generateMutex.RUnlock()
+	return len(fake.generateArgsForCall)
+}
+
+func (fake *FakeGenerator) GenerateCalls(stub func(release.Release, sbom.Format) ([]byte, error)) {
+	fake.generateMutex.Lock()
+	defer fake.generateMutex.Unlock()
+	fake.GenerateStub = stub
+}
+
+func (fake *FakeGenerator) GenerateArgsForCall(i int) (release.Release, sbom.Format) {
+	fake.generateMutex.RLock()
+	defer fake.generateMutex.RUnlock()
+	argsForCall := fake.generateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGenerator) GenerateReturns(result1 []byte, result2 error) {
+	fake.generateMutex.Lock()
+	defer fake.generateMutex.Unlock()
+	fake.GenerateStub = nil
+	fake.generateReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGenerator) GenerateReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.generateMutex.Lock()
+	defer fake.generateMutex.Unlock()
+	fake.GenerateStub = nil
+	if fake.generateReturnsOnCall == nil {
+		fake.generateReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.generateReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGenerator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeGenerator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sbom.Generator = new(FakeGenerator)
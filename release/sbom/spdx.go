@@ -0,0 +1,174 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+const spdxReleaseID = "SPDXRef-Release"
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Files             []spdxFile         `json:"files,omitempty"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxFile struct {
+	SPDXID           string         `json:"SPDXID"`
+	FileName         string         `json:"fileName"`
+	Checksums        []spdxChecksum `json:"checksums"`
+	LicenseConcluded string         `json:"licenseConcluded"`
+	CopyrightText    string         `json:"copyrightText"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+func newSPDXDocument(doc document) ([]byte, error) {
+	spdx := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              fmt.Sprintf("%s-%s", doc.Name, doc.Version),
+		DocumentNamespace: fmt.Sprintf("https://bosh.io/spdx/%s/%s/%s", doc.Name, doc.Version, doc.namespace()),
+		CreationInfo: spdxCreationInfo{
+			Created:  doc.Created.Format(time.RFC3339),
+			Creators: []string{"Tool: " + toolName},
+		},
+		Packages: []spdxPackage{newSPDXPackage(spdxReleaseID, doc.Name, doc.Version, "")},
+		Relationships: []spdxRelationship{
+			{"SPDXRef-DOCUMENT", "DESCRIBES", spdxReleaseID},
+		},
+	}
+
+	for _, pkg := range doc.Packages {
+		pkgID := spdxPackageID(pkg.Name)
+
+		spdxPkg := newSPDXPackage(pkgID, pkg.Name, pkg.Fingerprint, pkg.Digest)
+		spdx.Packages = append(spdx.Packages, spdxPkg)
+		spdx.Relationships = append(spdx.Relationships, spdxRelationship{spdxReleaseID, "CONTAINS", pkgID})
+
+		for _, depName := range pkg.Dependencies {
+			spdx.Relationships = append(spdx.Relationships, spdxRelationship{pkgID, "DEPENDS_ON", spdxPackageID(depName)})
+		}
+
+		for i, upstream := range pkg.Upstreams {
+			upstreamID := fmt.Sprintf("SPDXRef-Upstream-%s-%d", idSafe(pkg.Name), i)
+
+			upstreamPkg := newSPDXPackage(upstreamID, upstream.Name, upstream.Version, "")
+			if upstream.PURL != "" {
+				upstreamPkg.ExternalRefs = []spdxExternalRef{{
+					ReferenceCategory: "PACKAGE-MANAGER",
+					ReferenceType:     "purl",
+					ReferenceLocator:  upstream.PURL,
+				}}
+			}
+
+			spdx.Packages = append(spdx.Packages, upstreamPkg)
+			spdx.Relationships = append(spdx.Relationships, spdxRelationship{pkgID, "CONTAINS", upstreamID})
+		}
+
+		for i, source := range pkg.Sources {
+			fileID := fmt.Sprintf("SPDXRef-Source-%s-%d", idSafe(pkg.Name), i)
+
+			spdx.Files = append(spdx.Files, newSPDXFile(fileID, sourcesDir+source.Path, source.Digest))
+			spdx.Relationships = append(spdx.Relationships, spdxRelationship{pkgID, "CONTAINS", fileID})
+		}
+	}
+
+	for i, license := range doc.Licenses {
+		fileID := fmt.Sprintf("SPDXRef-License-%d", i)
+
+		spdx.Files = append(spdx.Files, newSPDXFile(fileID, licensesName+"/"+license.Path, license.Digest))
+		spdx.Relationships = append(spdx.Relationships, spdxRelationship{spdxReleaseID, "CONTAINS", fileID})
+	}
+
+	bytes, err := json.MarshalIndent(spdx, "", "  ")
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Marshalling SPDX document")
+	}
+
+	return bytes, nil
+}
+
+func newSPDXPackage(id, name, version, digest string) spdxPackage {
+	return spdxPackage{
+		SPDXID:           id,
+		Name:             name,
+		VersionInfo:      version,
+		DownloadLocation: noAssertion,
+		LicenseConcluded: noAssertion,
+		LicenseDeclared:  noAssertion,
+		CopyrightText:    noAssertion,
+		Checksums:        spdxChecksums(digest),
+	}
+}
+
+func newSPDXFile(id, name, digest string) spdxFile {
+	return spdxFile{
+		SPDXID:           id,
+		FileName:         "./" + name,
+		Checksums:        spdxChecksums(digest),
+		LicenseConcluded: noAssertion,
+		CopyrightText:    noAssertion,
+	}
+}
+
+func spdxPackageID(name string) string {
+	return "SPDXRef-Package-" + idSafe(name)
+}
+
+func spdxChecksums(digest string) []spdxChecksum {
+	var result []spdxChecksum
+
+	for _, sum := range checksums(digest) {
+		result = append(result, spdxChecksum{
+			Algorithm:     strings.ToUpper(sum.Algorithm),
+			ChecksumValue: sum.Value,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,177 @@
+package tarball
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"github.com/klauspost/compress/zstd"
+)
+
+type Format string
+
+const (
+	FormatNone Format = "none"
+	FormatGzip Format = "gzip"
+	FormatZstd Format = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Compression describes how tarballs are compressed;
+// zero Level selects default level of the format
+type Compression struct {
+	Format Format
+	Level  int
+}
+
+func NewCompression(format string, level int) (Compression, error) {
+	compression := Compression{Format: Format(format), Level: level}
+
+	switch compression.Format {
+	case "":
+		compression.Format = FormatGzip
+		fallthrough
+
+	case FormatGzip:
+		if level < 0 || level > gzip.BestCompression {
+			return Compression{}, bosherr.Errorf("Expected gzip compression level to be between 1 and 9 but was '%d'", level)
+		}
+
+	case FormatZstd:
+		if level < 0 || level > 22 {
+			return Compression{}, bosherr.Errorf("Expected zstd compression level to be between 1 and 22 but was '%d'", level)
+		}
+
+	default:
+		return Compression{}, bosherr.Errorf("Expected compression format to be 'gzip' or 'zstd' but was '%s'", format)
+	}
+
+	return compression, nil
+}
+
+// NewWriter returns writer that compresses into w; closing it does not close w
+func (c Compression) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c.Format {
+	case FormatNone:
+		return nopWriteCloser{w}, nil
+
+	case FormatZstd:
+		level := zstd.SpeedDefault
+		if c.Level > 0 {
+			level = zstd.EncoderLevelFromZstd(c.Level)
+		}
+
+		// Single goroutine keeps output independent of number of CPUs
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(level), zstd.WithEncoderConcurrency(1))
+
+	default:
+		level := gzip.DefaultCompression
+		if c.Level > 0 {
+			level = c.Level
+		}
+
+		return gzip.NewWriterLevel(w, level)
+	}
+}
+
+// DetectFormat returns format of tarball at path based on its leading bytes
+func DetectFormat(path string, fs boshsys.FileSystem) (Format, error) {
+	file, err := fs.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Opening '%s'", path)
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	header := make([]byte, len(zstdMagic))
+
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", bosherr.WrapErrorf(err, "Reading '%s'", path)
+	}
+
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, zstdMagic):
+		return FormatZstd, nil
+	case bytes.HasPrefix(header, gzipMagic):
+		return FormatGzip, nil
+	default:
+		return FormatNone, nil
+	}
+}
+
+// Recompress writes gzip or zstd compressed tarball at srcPath to dstPath using given compression
+func Recompress(srcPath, dstPath string, compression Compression, fs boshsys.FileSystem) error {
+	format, err := DetectFormat(srcPath, fs)
+	if err != nil {
+		return err
+	}
+
+	src, err := fs.OpenFile(srcPath, os.O_RDONLY, 0)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Opening '%s'", srcPath)
+	}
+
+	defer src.Close() //nolint:errcheck
+
+	var reader io.Reader = src
+
+	switch format {
+	case FormatZstd:
+		zstdReader, err := zstd.NewReader(src)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Reading zstd stream of '%s'", srcPath)
+		}
+
+		defer zstdReader.Close()
+		reader = zstdReader
+
+	case FormatGzip:
+		gzipReader, err := gzip.NewReader(src)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Reading gzip stream of '%s'", srcPath)
+		}
+
+		defer gzipReader.Close() //nolint:errcheck
+		reader = gzipReader
+	}
+
+	dst, err := fs.OpenFile(dstPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating '%s'", dstPath)
+	}
+
+	defer dst.Close() //nolint:errcheck
+
+	writer, err := compression.NewWriter(dst)
+	if err != nil {
+		return bosherr.WrapError(err, "Creating compressor")
+	}
+
+	_, err = io.Copy(writer, reader)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Recompressing '%s'", srcPath)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Closing '%s' stream", compression.Format)
+	}
+
+	return nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,125 @@
+package tarball_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/release/tarball"
+)
+
+var _ = Describe("Compression", func() {
+	Describe("NewCompression", func() {
+		It("defaults to gzip", func() {
+			Expect(NewCompression("", 0)).To(Equal(Compression{Format: FormatGzip}))
+		})
+
+		It("accepts gzip and zstd with levels", func() {
+			Expect(NewCompression("gzip", 9)).To(Equal(Compression{Format: FormatGzip, Level: 9}))
+			Expect(NewCompression("zstd", 19)).To(Equal(Compression{Format: FormatZstd, Level: 19}))
+		})
+
+		It("returns error for unknown format", func() {
+			_, err := NewCompression("bzip2", 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected compression format to be 'gzip' or 'zstd' but was 'bzip2'"))
+		})
+
+		It("returns error for levels out of range", func() {
+			_, err := NewCompression("gzip", 10)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("between 1 and 9"))
+
+			_, err = NewCompression("zstd", 23)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("between 1 and 22"))
+		})
+	})
+
+	Describe("Recompress", func() {
+		var (
+			fs  boshsys.FileSystem
+			dir string
+		)
+
+		BeforeEach(func() {
+			fs = boshsys.NewOsFileSystem(boshlog.NewLogger(boshlog.LevelNone))
+			dir = GinkgoT().TempDir()
+		})
+
+		writeTarball := func(path string, compression Compression) {
+			var buf bytes.Buffer
+
+			writer, err := compression.NewWriter(&buf)
+			Expect(err).ToNot(HaveOccurred())
+
+			tarWriter := tar.NewWriter(writer)
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: "file", Mode: 0644, Size: 4})).To(Succeed())
+			_, err = tarWriter.Write([]byte("file"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tarWriter.Close()).To(Succeed())
+			Expect(writer.Close()).To(Succeed())
+
+			Expect(fs.WriteFile(path, buf.Bytes())).To(Succeed())
+		}
+
+		It("converts zstd tarball into gzip tarball", func() {
+			srcPath := filepath.Join(dir, "src.tgz")
+			dstPath := filepath.Join(dir, "dst.tgz")
+
+			writeTarball(srcPath, Compression{Format: FormatZstd})
+			Expect(DetectFormat(srcPath, fs)).To(Equal(FormatZstd))
+
+			err := Recompress(srcPath, dstPath, Compression{Format: FormatGzip}, fs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(DetectFormat(dstPath, fs)).To(Equal(FormatGzip))
+
+			bs, err := fs.ReadFile(dstPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			gzipReader, err := gzip.NewReader(bytes.NewReader(bs))
+			Expect(err).ToNot(HaveOccurred())
+
+			tarReader := tar.NewReader(gzipReader)
+			header, err := tarReader.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(header.Name).To(Equal("file"))
+
+			contents, err := io.ReadAll(tarReader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("file"))
+		})
+
+		It("decompresses gzip tarball when no compression is requested", func() {
+			srcPath := filepath.Join(dir, "src.tgz")
+			dstPath := filepath.Join(dir, "dst.tar")
+
+			writeTarball(srcPath, Compression{Format: FormatGzip})
+
+			err := Recompress(srcPath, dstPath, Compression{Format: FormatNone}, fs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(DetectFormat(dstPath, fs)).To(Equal(FormatNone))
+		})
+
+		It("returns error if source is not readable", func() {
+			err := Recompress(filepath.Join(dir, "missing"), filepath.Join(dir, "dst"), Compression{Format: FormatGzip}, fs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Opening"))
+		})
+
+		It("returns error if source stream is corrupted", func() {
+			srcPath := filepath.Join(dir, "src.tgz")
+			Expect(fs.WriteFile(srcPath, append([]byte{0x28, 0xb5, 0x2f, 0xfd}, []byte("garbage")...))).To(Succeed())
+
+			err := Recompress(srcPath, filepath.Join(dir, "dst"), Compression{Format: FormatGzip}, fs)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,59 @@
+package tarball
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+type detectingCompressor struct {
+	compressor boshcmd.Compressor
+	fs         boshsys.FileSystem
+}
+
+// NewDetectingCompressor returns compressor that extracts zstd compressed tarballs
+// without relying on tar and zstd binaries; other tarballs and compression
+// are delegated to the given compressor.
+func NewDetectingCompressor(compressor boshcmd.Compressor, fs boshsys.FileSystem) boshcmd.Compressor {
+	return detectingCompressor{compressor: compressor, fs: fs}
+}
+
+func (c detectingCompressor) CompressFilesInDir(dir string, options boshcmd.CompressorOptions) (string, error) {
+	return c.compressor.CompressFilesInDir(dir, options)
+}
+
+func (c detectingCompressor) CompressSpecificFilesInDir(dir string, files []string, options boshcmd.CompressorOptions) (string, error) {
+	return c.compressor.CompressSpecificFilesInDir(dir, files, options)
+}
+
+func (c detectingCompressor) DecompressFileToDir(path string, dir string, options boshcmd.CompressorOptions) error {
+	format, err := DetectFormat(path, c.fs)
+	if err != nil || format != FormatZstd {
+		return c.compressor.DecompressFileToDir(path, dir, options)
+	}
+
+	file, err := c.fs.TempFile("bosh-zstd-tarball")
+	if err != nil {
+		return bosherr.WrapError(err, "Creating temporary file for tarball")
+	}
+
+	tarPath := file.Name()
+	_ = file.Close() //nolint:errcheck
+
+	defer c.fs.RemoveAll(tarPath) //nolint:errcheck
+
+	err = Recompress(path, tarPath, Compression{Format: FormatNone}, c.fs)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Decompressing zstd tarball '%s'", path)
+	}
+
+	return c.compressor.DecompressFileToDir(tarPath, dir, options)
+}
+
+func (c detectingCompressor) IsNonCompressedTarball(path string) bool {
+	return c.compressor.IsNonCompressedTarball(path)
+}
+
+func (c detectingCompressor) CleanUp(path string) error {
+	return c.compressor.CleanUp(path)
+}
@@ -0,0 +1,178 @@
+package tarball
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+// EntryModTime is recorded as modification time of every entry
+// so that tarballs do not depend on when files were staged
+var EntryModTime = time.Unix(0, 0).UTC()
+
+type reproducibleCompressor struct {
+	compressor  boshcmd.Compressor
+	compression Compression
+	fs          boshsys.FileSystem
+}
+
+// NewReproducibleCompressor returns compressor that produces bit-for-bit identical
+// tarballs for identical directory contents: entries are sorted, owners and
+// modification times are normalised and gzip header does not include a timestamp.
+// Tarballs are compressed with given compression unless NoCompression is requested.
+// Decompression is delegated to the given compressor.
+func NewReproducibleCompressor(compressor boshcmd.Compressor, compression Compression, fs boshsys.FileSystem) boshcmd.Compressor {
+	return reproducibleCompressor{compressor: compressor, compression: compression, fs: fs}
+}
+
+func (c reproducibleCompressor) CompressFilesInDir(dir string, options boshcmd.CompressorOptions) (string, error) {
+	return c.CompressSpecificFilesInDir(dir, []string{"."}, options)
+}
+
+func (c reproducibleCompressor) CompressSpecificFilesInDir(dir string, files []string, options boshcmd.CompressorOptions) (string, error) {
+	file, err := c.fs.TempFile("bosh-reproducible-tarball")
+	if err != nil {
+		return "", bosherr.WrapError(err, "Creating temporary file for tarball")
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	err = c.write(file, dir, files, options)
+	if err != nil {
+		_ = c.fs.RemoveAll(file.Name()) //nolint:errcheck
+		return "", err
+	}
+
+	return file.Name(), nil
+}
+
+func (c reproducibleCompressor) write(w io.Writer, dir string, files []string, options boshcmd.CompressorOptions) error {
+	compression := c.compression
+
+	if options.NoCompression {
+		compression = Compression{Format: FormatNone}
+	}
+
+	compressedWriter, err := compression.NewWriter(w)
+	if err != nil {
+		return bosherr.WrapError(err, "Creating compressor")
+	}
+
+	tarWriter := tar.NewWriter(compressedWriter)
+
+	for _, file := range files {
+		err := c.addTree(tarWriter, filepath.Join(dir, file), file)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return bosherr.WrapError(err, "Closing tarball")
+	}
+
+	err = compressedWriter.Close()
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Closing %s stream", compression.Format)
+	}
+
+	return nil
+}
+
+// addTree adds root and its descendants; Walk visits directory entries in lexical order
+func (c reproducibleCompressor) addTree(tarWriter *tar.Writer, root, name string) error {
+	return c.fs.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Walking '%s'", root)
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Determining relative path of '%s'", path)
+		}
+
+		entryName := name
+		if relPath != "." {
+			entryName = strings.TrimSuffix(name, "/") + "/" + filepath.ToSlash(relPath)
+		}
+
+		return c.addEntry(tarWriter, path, entryName, info)
+	})
+}
+
+func (c reproducibleCompressor) addEntry(tarWriter *tar.Writer, path, name string, info os.FileInfo) error {
+	header := &tar.Header{Name: name, ModTime: EntryModTime}
+
+	switch {
+	case info.IsDir():
+		header.Typeflag = tar.TypeDir
+		header.Name = strings.TrimSuffix(name, "/") + "/"
+		header.Mode = 0755
+
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := c.fs.Readlink(path)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Reading symlink '%s'", path)
+		}
+
+		header.Typeflag = tar.TypeSymlink
+		header.Linkname = target
+		header.Mode = 0777
+
+	case info.Mode().IsRegular():
+		header.Typeflag = tar.TypeReg
+		header.Size = info.Size()
+		header.Mode = 0644
+
+		// Only executable bit is kept since other bits depend on umask
+		if info.Mode()&0100 != 0 {
+			header.Mode = 0755
+		}
+
+	default:
+		return bosherr.Errorf("Unsupported file type '%s' of '%s'", info.Mode().Type(), path)
+	}
+
+	err := tarWriter.WriteHeader(header)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing tarball entry '%s'", name)
+	}
+
+	if header.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	file, err := c.fs.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Opening '%s'", path)
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	_, err = io.Copy(tarWriter, file)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing tarball entry '%s'", name)
+	}
+
+	return nil
+}
+
+func (c reproducibleCompressor) DecompressFileToDir(path string, dir string, options boshcmd.CompressorOptions) error {
+	return c.compressor.DecompressFileToDir(path, dir, options)
+}
+
+func (c reproducibleCompressor) IsNonCompressedTarball(path string) bool {
+	return c.compressor.IsNonCompressedTarball(path)
+}
+
+func (c reproducibleCompressor) CleanUp(path string) error {
+	return c.compressor.CleanUp(path)
+}
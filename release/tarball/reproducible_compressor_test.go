@@ -0,0 +1,223 @@
+package tarball_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/release/tarball"
+)
+
+var _ = Describe("ReproducibleCompressor", func() {
+	var (
+		fs         boshsys.FileSystem
+		dir           string
+		tarCompressor boshcmd.Compressor
+		compressor    boshcmd.Compressor
+	)
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		fs = boshsys.NewOsFileSystemWithStrictTempRoot(logger)
+
+		err := fs.ChangeTempRoot(GinkgoT().TempDir())
+		Expect(err).ToNot(HaveOccurred())
+
+		tarCompressor = NewDetectingCompressor(boshcmd.NewTarballCompressor(boshsys.NewExecCmdRunner(logger), fs), fs)
+		compressor = NewReproducibleCompressor(tarCompressor, Compression{Format: FormatGzip}, fs)
+	})
+
+	writeDir := func(modTime time.Time, perm os.FileMode) string {
+		dir, err := fs.TempDir("staging")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fs.MkdirAll(filepath.Join(dir, "b-dir"), perm|0700)).To(Succeed())
+		Expect(fs.WriteFileString(filepath.Join(dir, "b-dir", "file"), "file")).To(Succeed())
+		Expect(fs.WriteFileString(filepath.Join(dir, "a-script"), "#!/bin/bash")).To(Succeed())
+		Expect(fs.WriteFileString(filepath.Join(dir, "c-file"), "c")).To(Succeed())
+		Expect(fs.Symlink("c-file", filepath.Join(dir, "d-link"))).To(Succeed())
+
+		Expect(fs.Chmod(filepath.Join(dir, "a-script"), 0700|perm)).To(Succeed())
+		Expect(fs.Chmod(filepath.Join(dir, "c-file"), 0600|perm&0066)).To(Succeed())
+
+		for _, path := range []string{"b-dir/file", "a-script", "c-file", "b-dir"} {
+			Expect(os.Chtimes(filepath.Join(dir, path), modTime, modTime)).To(Succeed())
+		}
+
+		return dir
+	}
+
+	readHeaders := func(path string, compressed bool) []*tar.Header {
+		file, err := os.Open(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		defer file.Close() //nolint:errcheck
+
+		var reader io.Reader = file
+
+		if compressed {
+			gzipReader, err := gzip.NewReader(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gzipReader.ModTime.IsZero()).To(BeTrue())
+			reader = gzipReader
+		}
+
+		var headers []*tar.Header
+
+		tarReader := tar.NewReader(reader)
+
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			headers = append(headers, header)
+		}
+
+		return headers
+	}
+
+	It("produces identical tarballs for same contents regardless of modification times and permissions", func() {
+		dir = writeDir(time.Now().Add(-time.Hour), 0)
+		otherDir := writeDir(time.Now(), 0055)
+
+		path1, err := compressor.CompressFilesInDir(dir, boshcmd.CompressorOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		path2, err := compressor.CompressFilesInDir(otherDir, boshcmd.CompressorOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		contents1, err := fs.ReadFile(path1)
+		Expect(err).ToNot(HaveOccurred())
+
+		contents2, err := fs.ReadFile(path2)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(contents1).To(Equal(contents2))
+	})
+
+	It("writes sorted entries with normalised owners, modes and modification times", func() {
+		dir = writeDir(time.Now(), 0)
+
+		path, err := compressor.CompressFilesInDir(dir, boshcmd.CompressorOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		var names []string
+
+		for _, header := range readHeaders(path, true) {
+			names = append(names, header.Name)
+
+			Expect(header.Uid).To(Equal(0))
+			Expect(header.Gid).To(Equal(0))
+			Expect(header.Uname).To(BeEmpty())
+			Expect(header.Gname).To(BeEmpty())
+			Expect(header.ModTime.Unix()).To(Equal(EntryModTime.Unix()))
+
+			switch header.Name {
+			case "./a-script", "./", "./b-dir/":
+				Expect(header.Mode).To(Equal(int64(0755)))
+			case "./b-dir/file", "./c-file":
+				Expect(header.Mode).To(Equal(int64(0644)))
+			case "./d-link":
+				Expect(header.Typeflag).To(Equal(byte(tar.TypeSymlink)))
+				Expect(header.Linkname).To(Equal("c-file"))
+			}
+		}
+
+		Expect(names).To(Equal([]string{"./", "./a-script", "./b-dir/", "./b-dir/file", "./c-file", "./d-link"}))
+	})
+
+	It("writes only specific files in given order without compression when requested", func() {
+		dir = writeDir(time.Now(), 0)
+
+		path, err := compressor.CompressSpecificFilesInDir(
+			dir, []string{"c-file", "b-dir"}, boshcmd.CompressorOptions{NoCompression: true})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(compressor.IsNonCompressedTarball(path)).To(BeTrue())
+
+		var names []string
+		for _, header := range readHeaders(path, false) {
+			names = append(names, header.Name)
+		}
+
+		Expect(names).To(Equal([]string{"c-file", "b-dir/", "b-dir/file"}))
+	})
+
+	It("produces tarballs that can be decompressed", func() {
+		dir = writeDir(time.Now(), 0)
+
+		path, err := compressor.CompressFilesInDir(dir, boshcmd.CompressorOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		dstDir, err := fs.TempDir("dst")
+		Expect(err).ToNot(HaveOccurred())
+
+		err = compressor.DecompressFileToDir(path, dstDir, boshcmd.CompressorOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fs.ReadFileString(filepath.Join(dstDir, "b-dir", "file"))).To(Equal("file"))
+		Expect(fs.Readlink(filepath.Join(dstDir, "d-link"))).To(Equal("c-file"))
+	})
+
+	Context("when zstd compression is configured", func() {
+		BeforeEach(func() {
+			compressor = NewReproducibleCompressor(tarCompressor, Compression{Format: FormatZstd, Level: 19}, fs)
+		})
+
+		It("produces identical zstd compressed tarballs that can be decompressed", func() {
+			dir = writeDir(time.Now().Add(-time.Hour), 0)
+			otherDir := writeDir(time.Now(), 0055)
+
+			path1, err := compressor.CompressFilesInDir(dir, boshcmd.CompressorOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			path2, err := compressor.CompressFilesInDir(otherDir, boshcmd.CompressorOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(DetectFormat(path1, fs)).To(Equal(FormatZstd))
+			contents1, err := fs.ReadFile(path1)
+			Expect(err).ToNot(HaveOccurred())
+
+			contents2, err := fs.ReadFile(path2)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(contents1).To(Equal(contents2))
+
+			dstDir, err := fs.TempDir("dst")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = compressor.DecompressFileToDir(path1, dstDir, boshcmd.CompressorOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.ReadFileString(filepath.Join(dstDir, "b-dir", "file"))).To(Equal("file"))
+		})
+
+		It("does not compress when requested", func() {
+			dir = writeDir(time.Now(), 0)
+
+			path, err := compressor.CompressFilesInDir(dir, boshcmd.CompressorOptions{NoCompression: true})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(compressor.IsNonCompressedTarball(path)).To(BeTrue())
+		})
+	})
+
+	It("returns error if files do not exist", func() {
+		dir = writeDir(time.Now(), 0)
+
+		_, err := compressor.CompressSpecificFilesInDir(dir, []string{"missing"}, boshcmd.CompressorOptions{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Walking"))
+	})
+})
@@ -0,0 +1,257 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"gopkg.in/yaml.v3"
+
+	boshjobman "github.com/cloudfoundry/bosh-cli/v7/release/job/manifest"
+)
+
+type jobSpec struct {
+	specPath string
+	node     *yaml.Node
+	manifest boshjobman.Manifest
+}
+
+func (r *run) validateJobs(pkgNames map[string]struct{}) ([]jobSpec, error) {
+	dirs, err := r.subDirs("jobs")
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []jobSpec
+
+	for _, dir := range dirs {
+		spec, found, err := r.readJobSpec(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+
+		specs = append(specs, spec)
+
+		r.validateJobPackages(spec, pkgNames)
+
+		err = r.validateJobTemplates(dir, spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return specs, nil
+}
+
+func (r *run) readJobSpec(dir string) (jobSpec, bool, error) {
+	specPath := filepath.Join(dir, "spec")
+	dirName := filepath.Base(dir)
+
+	if !r.fs.FileExists(specPath) {
+		r.errorf(dir, 0, "Expected job '%s' to have spec", dirName)
+		return jobSpec{}, false, nil
+	}
+
+	bytes, err := r.fs.ReadFile(specPath)
+	if err != nil {
+		return jobSpec{}, false, bosherr.WrapErrorf(err, "Reading job spec '%s'", specPath)
+	}
+
+	node, err := specNode(bytes)
+	if err != nil {
+		r.errorf(specPath, yamlErrLine(err), "Expected job spec to be valid YAML: %s", err)
+		return jobSpec{}, false, nil
+	}
+
+	manifest, err := boshjobman.NewManifestFromPath(specPath, r.fs)
+	if err != nil {
+		r.errorf(specPath, yamlErrLine(err), "%s", err)
+		return jobSpec{}, false, nil
+	}
+
+	if manifest.Name != dirName {
+		r.errorf(specPath, keyLine(node, "name"), "Job name '%s' does not match directory '%s'", manifest.Name, dirName)
+	}
+
+	return jobSpec{specPath: specPath, node: node, manifest: manifest}, true, nil
+}
+
+func (r *run) validateJobPackages(spec jobSpec, pkgNames map[string]struct{}) {
+	pkgsNode := valueNode(spec.node, "packages")
+
+	for i, pkg := range spec.manifest.Packages {
+		if _, found := pkgNames[pkg]; !found {
+			r.errorf(spec.specPath, itemLine(pkgsNode, i), "Package '%s' is not in release", pkg)
+		}
+	}
+}
+
+func (r *run) validateJobTemplates(dir string, spec jobSpec) error {
+	templatesDir := filepath.Join(dir, "templates")
+	templatesNode := valueNode(spec.node, "templates")
+
+	var srcs []string
+	for src := range spec.manifest.Templates {
+		srcs = append(srcs, src)
+	}
+
+	sort.Strings(srcs)
+
+	var usages []templateUsage
+
+	for _, src := range srcs {
+		path := filepath.Join(templatesDir, src)
+
+		if !r.fs.FileExists(path) {
+			r.errorf(spec.specPath, keyLine(templatesNode, src), "Template '%s' does not exist in '%s'", src, r.relPath(templatesDir))
+			continue
+		}
+
+		fileUsages, err := r.scanTemplate(path)
+		if err != nil {
+			return err
+		}
+
+		usages = append(usages, fileUsages...)
+	}
+
+	err := r.warnUnlistedTemplates(templatesDir, spec)
+	if err != nil {
+		return err
+	}
+
+	monitPath := filepath.Join(dir, "monit")
+
+	if r.fs.FileExists(monitPath) {
+		contents, err := r.fs.ReadFileString(monitPath)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Reading monit file '%s'", monitPath)
+		}
+
+		r.validateMonit(monitPath, contents)
+
+		usages = append(usages, scanTemplate(monitPath, contents)...)
+	}
+
+	r.validateUsages(spec, usages)
+
+	return nil
+}
+
+func (r *run) scanTemplate(path string) ([]templateUsage, error) {
+	contents, err := r.fs.ReadFileString(path)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Reading template '%s'", path)
+	}
+
+	return scanTemplate(path, contents), nil
+}
+
+func (r *run) warnUnlistedTemplates(templatesDir string, spec jobSpec) error {
+	if !r.fs.FileExists(templatesDir) {
+		return nil
+	}
+
+	return r.fs.Walk(templatesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Listing templates in '%s'", templatesDir)
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(templatesDir, path)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Determining relative path of '%s'", path)
+		}
+
+		if _, found := spec.manifest.Templates[filepath.ToSlash(relPath)]; !found {
+			r.warnf(path, 0, "Template '%s' is not listed in job spec", filepath.ToSlash(relPath))
+		}
+
+		return nil
+	})
+}
+
+func (r *run) validateUsages(spec jobSpec, usages []templateUsage) {
+	props := spec.manifest.Properties
+	propsNode := valueNode(spec.node, "properties")
+
+	consumed := map[string]struct{}{}
+	for _, link := range spec.manifest.Consumes {
+		consumed[link.Name] = struct{}{}
+	}
+
+	var usedProps []string
+
+	for _, usage := range usages {
+		switch usage.kind {
+		case propertyUsage:
+			usedProps = append(usedProps, usage.name)
+
+			if !propertyDeclared(usage.name, props) {
+				r.errorf(usage.path, usage.line, "Property '%s' is not declared in job spec", usage.name)
+			}
+
+		case linkUsage:
+			if _, found := consumed[usage.name]; !found {
+				r.errorf(usage.path, usage.line, "Link '%s' is not declared in consumes of job spec", usage.name)
+			}
+		}
+	}
+
+	providesNode := valueNode(spec.node, "provides")
+
+	for i, link := range spec.manifest.Provides {
+		linkPropsNode := valueNode(itemNode(providesNode, i), "properties")
+
+		for j, name := range link.Properties {
+			usedProps = append(usedProps, name)
+
+			if _, found := props[name]; !found {
+				r.errorf(spec.specPath, itemLine(linkPropsNode, j),
+					"Property '%s' of provided link '%s' is not declared in job spec", name, link.Name)
+			}
+		}
+	}
+
+	var declared []string
+	for name := range props {
+		declared = append(declared, name)
+	}
+
+	sort.Strings(declared)
+
+	for _, name := range declared {
+		if !propertyUsed(name, usedProps) {
+			r.warnf(spec.specPath, keyLine(propsNode, name), "Property '%s' is not used in templates", name)
+		}
+	}
+}
+
+// propertyDeclared allows using parent of declared properties
+// (e.g. p('a') when 'a.b' is declared) and children of declared hashes
+func propertyDeclared(name string, props map[string]boshjobman.PropertyDefinition) bool {
+	for declared := range props {
+		if declared == name || strings.HasPrefix(declared, name+".") || strings.HasPrefix(name, declared+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func propertyUsed(name string, used []string) bool {
+	for _, usedName := range used {
+		if usedName == name || strings.HasPrefix(name, usedName+".") || strings.HasPrefix(usedName, name+".") {
+			return true
+		}
+	}
+	return false
+}
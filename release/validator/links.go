@@ -0,0 +1,29 @@
+package validator
+
+// validateLinks warns about required links that no job of the release provides;
+// such links have to be provided by another release in a deployment
+func (r *run) validateLinks(specs []jobSpec) {
+	provided := map[string]struct{}{}
+
+	for _, spec := range specs {
+		for _, link := range spec.manifest.Provides {
+			provided[link.Type] = struct{}{}
+		}
+	}
+
+	for _, spec := range specs {
+		consumesNode := valueNode(spec.node, "consumes")
+
+		for i, link := range spec.manifest.Consumes {
+			if len(link.Type) == 0 {
+				r.errorf(spec.specPath, itemLine(consumesNode, i), "Expected consumed link '%s' to specify type", link.Name)
+				continue
+			}
+
+			if _, found := provided[link.Type]; !found && !link.Optional {
+				r.warnf(spec.specPath, itemLine(consumesNode, i),
+					"Link '%s' of type '%s' is consumed but not provided by any job in release", link.Name, link.Type)
+			}
+		}
+	}
+}
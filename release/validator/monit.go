@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	erbOutputTagRegexp  = regexp.MustCompile(`<%=.*?%>`)
+	erbControlTagRegexp = regexp.MustCompile(`<%.*?%>`)
+
+	monitCheckTypes = map[string]struct{}{
+		"process": {}, "file": {}, "fifo": {}, "filesystem": {}, "directory": {},
+		"host": {}, "system": {}, "program": {}, "network": {},
+	}
+)
+
+// validateMonit checks structure of monit control file: statements belong to
+// 'check' blocks, check types are known and process checks are able to find a process
+func (r *run) validateMonit(path, contents string) {
+	var (
+		checkLine int
+		checkType string
+		found     bool
+	)
+
+	finishCheck := func() {
+		if checkType == "process" && !found {
+			r.errorf(path, checkLine, "Expected monit process check to specify 'with pidfile' or 'matching'")
+		}
+	}
+
+	for i, line := range strings.Split(contents, "\n") {
+		// Rendered values are replaced so that control-only lines become empty
+		line = erbOutputTagRegexp.ReplaceAllString(line, "erb")
+		line = erbControlTagRegexp.ReplaceAllString(line, "")
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if strings.Count(line, `"`)%2 != 0 || strings.Count(line, `'`)%2 != 0 {
+			r.errorf(path, i+1, "Expected quotes to be closed in monit statement")
+		}
+
+		switch fields[0] {
+		case "check":
+			finishCheck()
+
+			checkLine, checkType, found = i+1, "", false
+
+			if len(fields) < 3 {
+				r.errorf(path, i+1, "Expected monit check to specify type and name")
+				continue
+			}
+
+			checkType = fields[1]
+
+			if _, known := monitCheckTypes[checkType]; !known {
+				r.errorf(path, i+1, "Unknown monit check type '%s'", checkType)
+			}
+
+		case "set", "include":
+
+		default:
+			if checkLine == 0 {
+				r.errorf(path, i+1, "Expected monit statement '%s' to be inside of a check", fields[0])
+				continue
+			}
+
+			if strings.Contains(line, "pidfile") || strings.Contains(line, "matching") {
+				found = true
+			}
+		}
+	}
+
+	finishCheck()
+}
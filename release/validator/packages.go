@@ -0,0 +1,207 @@
+package validator
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"gopkg.in/yaml.v3"
+
+	boshpkgman "github.com/cloudfoundry/bosh-cli/v7/release/pkg/manifest"
+)
+
+type packageSpec struct {
+	specPath string
+	node     *yaml.Node
+	manifest boshpkgman.Manifest
+}
+
+// validatePackages returns names of all packages in the release
+func (r *run) validatePackages() (map[string]struct{}, error) {
+	dirs, err := r.subDirs("packages")
+	if err != nil {
+		return nil, err
+	}
+
+	blobPaths, err := r.blobPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]struct{}{}
+
+	var specs []packageSpec
+
+	for _, dir := range dirs {
+		names[filepath.Base(dir)] = struct{}{}
+
+		spec, found, err := r.readPackageSpec(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			specs = append(specs, spec)
+			r.validatePackageFiles(spec, blobPaths)
+		}
+	}
+
+	// Dependencies are checked once all package names are known
+	for _, spec := range specs {
+		depsNode := valueNode(spec.node, "dependencies")
+
+		for i, dep := range spec.manifest.Dependencies {
+			if _, found := names[dep]; !found {
+				r.errorf(spec.specPath, itemLine(depsNode, i), "Package dependency '%s' is not in release", dep)
+			}
+		}
+	}
+
+	return names, nil
+}
+
+func (r *run) readPackageSpec(dir string) (packageSpec, bool, error) {
+	specPath := filepath.Join(dir, "spec")
+	dirName := filepath.Base(dir)
+
+	if !r.fs.FileExists(specPath) {
+		// Vendored packages only keep spec.lock
+		if !r.fs.FileExists(filepath.Join(dir, "spec.lock")) {
+			r.errorf(dir, 0, "Expected package '%s' to have spec", dirName)
+		}
+		return packageSpec{}, false, nil
+	}
+
+	bytes, err := r.fs.ReadFile(specPath)
+	if err != nil {
+		return packageSpec{}, false, bosherr.WrapErrorf(err, "Reading package spec '%s'", specPath)
+	}
+
+	node, err := specNode(bytes)
+	if err != nil {
+		r.errorf(specPath, yamlErrLine(err), "Expected package spec to be valid YAML: %s", err)
+		return packageSpec{}, false, nil
+	}
+
+	manifest, err := boshpkgman.NewManifestFromPath(specPath, r.fs)
+	if err != nil {
+		r.errorf(specPath, yamlErrLine(err), "%s", err)
+		return packageSpec{}, false, nil
+	}
+
+	if manifest.Name != dirName {
+		r.errorf(specPath, keyLine(node, "name"), "Package name '%s' does not match directory '%s'", manifest.Name, dirName)
+	}
+
+	if !r.fs.FileExists(filepath.Join(dir, "packaging")) {
+		r.errorf(specPath, 0, "Expected package '%s' to have packaging script", dirName)
+	}
+
+	return packageSpec{specPath: specPath, node: node, manifest: manifest}, true, nil
+}
+
+func (r *run) validatePackageFiles(spec packageSpec, blobPaths []string) {
+	filesNode := valueNode(spec.node, "files")
+
+	for i, glob := range spec.manifest.Files {
+		found, err := r.globHasFiles(filepath.Join(r.dirPath, "src", glob))
+		if err == nil && !found {
+			found, err = r.globHasFiles(filepath.Join(r.dirPath, "blobs", glob))
+		}
+		if err == nil && !found {
+			// Blobs are not necessarily synced locally
+			found = matchesAny(glob, blobPaths)
+		}
+
+		if err != nil {
+			r.errorf(spec.specPath, itemLine(filesNode, i), "Listing files for pattern '%s': %s", glob, err)
+		} else if !found {
+			r.errorf(spec.specPath, itemLine(filesNode, i), "Missing files for pattern '%s'", glob)
+		}
+	}
+}
+
+func (r *run) globHasFiles(pattern string) (bool, error) {
+	matches, err := r.fs.RecursiveGlob(pattern)
+	if err != nil {
+		return false, err
+	}
+
+	for _, match := range matches {
+		info, err := r.fs.Stat(match)
+		if err == nil && !info.IsDir() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *run) blobPaths() ([]string, error) {
+	path := filepath.Join(r.dirPath, "config", "blobs.yml")
+
+	if !r.fs.FileExists(path) {
+		return nil, nil
+	}
+
+	bytes, err := r.fs.ReadFile(path)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Reading blobs index '%s'", path)
+	}
+
+	var blobs map[string]interface{}
+
+	err = yaml.Unmarshal(bytes, &blobs)
+	if err != nil {
+		r.errorf(path, yamlErrLine(err), "Expected blobs index to be valid YAML: %s", err)
+		return nil, nil
+	}
+
+	var paths []string
+
+	for blobPath := range blobs {
+		paths = append(paths, blobPath)
+	}
+
+	return paths, nil
+}
+
+// matchesAny matches paths against package spec file glob where '**' spans directories
+func matchesAny(glob string, paths []string) bool {
+	var expr strings.Builder
+
+	expr.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expr.WriteString("[^/]*")
+		case glob[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(glob[i])))
+		}
+	}
+
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return false
+	}
+
+	for _, path := range paths {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
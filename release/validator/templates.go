@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+)
+
+type usageKind int
+
+const (
+	propertyUsage usageKind = iota
+	linkUsage
+)
+
+// templateUsage is a property or link referenced from a job template
+type templateUsage struct {
+	kind usageKind
+	name string
+	path string
+	line int
+}
+
+var (
+	// Calls of link(...).p(...) refer to link properties hence preceding '.' is excluded
+	propertyCallRegexp = regexp.MustCompile(
+		`(?:^|[^.\w])(if_p|p)\(\s*(\[[^\]]*\]|"[^"]*"|'[^']*')((?:\s*,\s*(?:"[^"]*"|'[^']*'))*)`)
+
+	linkCallRegexp = regexp.MustCompile(`(?:^|[^.\w])(?:if_link|link)\(\s*(?:"([^"]*)"|'([^']*)')`)
+
+	quotedRegexp = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+)
+
+// scanTemplate finds statically known property and link names used in ERB template
+func scanTemplate(path, contents string) []templateUsage {
+	var usages []templateUsage
+
+	lineAt := func(offset int) int {
+		return strings.Count(contents[:offset], "\n") + 1
+	}
+
+	for _, match := range propertyCallRegexp.FindAllStringSubmatchIndex(contents, -1) {
+		line := lineAt(match[2])
+		fn := contents[match[2]:match[3]]
+
+		names := quotedStrings(contents[match[4]:match[5]])
+
+		// if_p() takes any number of property names, while
+		// remaining arguments of p() are default values
+		if fn == "if_p" {
+			names = append(names, quotedStrings(contents[match[6]:match[7]])...)
+		}
+
+		for _, name := range names {
+			if isStaticName(name) {
+				usages = append(usages, templateUsage{kind: propertyUsage, name: name, path: path, line: line})
+			}
+		}
+	}
+
+	for _, match := range linkCallRegexp.FindAllStringSubmatchIndex(contents, -1) {
+		var name string
+
+		if match[2] >= 0 {
+			name = contents[match[2]:match[3]]
+		} else {
+			name = contents[match[4]:match[5]]
+		}
+
+		if isStaticName(name) {
+			usages = append(usages, templateUsage{kind: linkUsage, name: name, path: path, line: lineAt(match[1])})
+		}
+	}
+
+	return usages
+}
+
+func quotedStrings(s string) []string {
+	var result []string
+	for _, match := range quotedRegexp.FindAllStringSubmatch(s, -1) {
+		result = append(result, match[1]+match[2])
+	}
+	return result
+}
+
+// isStaticName excludes interpolated names such as "#{prefix}.port"
+func isStaticName(name string) bool {
+	return len(name) > 0 && !strings.Contains(name, "#{")
+}
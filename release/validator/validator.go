@@ -0,0 +1,146 @@
+package validator
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+type Severity string
+
+const (
+	ErrorSeverity   Severity = "error"
+	WarningSeverity Severity = "warning"
+)
+
+// Problem is a finding in a file of release directory;
+// path is relative to release directory and line is 0 when unknown
+type Problem struct {
+	Severity Severity
+	Path     string
+	Line     int
+	Message  string
+}
+
+func (p Problem) Position() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d", p.Path, p.Line)
+	}
+	return p.Path
+}
+
+type Result struct {
+	Problems []Problem
+}
+
+func (r Result) Count(severity Severity) int {
+	var count int
+	for _, problem := range r.Problems {
+		if problem.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+//counterfeiter:generate . Validator
+
+// Validator statically checks job specs, package specs and job templates
+// of a release directory for mistakes usually found only at deploy time
+type Validator interface {
+	Validate(dirPath string) (Result, error)
+}
+
+type validator struct {
+	fs boshsys.FileSystem
+}
+
+func NewValidator(fs boshsys.FileSystem) Validator {
+	return validator{fs: fs}
+}
+
+func (v validator) Validate(dirPath string) (Result, error) {
+	r := &run{dirPath: dirPath, fs: v.fs}
+
+	pkgNames, err := r.validatePackages()
+	if err != nil {
+		return Result{}, bosherr.WrapError(err, "Validating packages")
+	}
+
+	jobs, err := r.validateJobs(pkgNames)
+	if err != nil {
+		return Result{}, bosherr.WrapError(err, "Validating jobs")
+	}
+
+	r.validateLinks(jobs)
+
+	sort.SliceStable(r.problems, func(i, j int) bool {
+		if r.problems[i].Path != r.problems[j].Path {
+			return r.problems[i].Path < r.problems[j].Path
+		}
+		return r.problems[i].Line < r.problems[j].Line
+	})
+
+	return Result{Problems: r.problems}, nil
+}
+
+// run keeps state of a single validation
+type run struct {
+	dirPath  string
+	fs       boshsys.FileSystem
+	problems []Problem
+}
+
+func (r *run) errorf(path string, line int, msg string, args ...interface{}) {
+	r.add(ErrorSeverity, path, line, msg, args...)
+}
+
+func (r *run) warnf(path string, line int, msg string, args ...interface{}) {
+	r.add(WarningSeverity, path, line, msg, args...)
+}
+
+func (r *run) add(severity Severity, path string, line int, msg string, args ...interface{}) {
+	r.problems = append(r.problems, Problem{
+		Severity: severity,
+		Path:     r.relPath(path),
+		Line:     line,
+		Message:  fmt.Sprintf(msg, args...),
+	})
+}
+
+func (r *run) relPath(path string) string {
+	relPath, err := filepath.Rel(r.dirPath, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// subDirs returns sorted directories directly inside of release directory's subdirectory
+func (r *run) subDirs(name string) ([]string, error) {
+	matches, err := r.fs.Glob(filepath.Join(r.dirPath, name, "*"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing '%s'", name)
+	}
+
+	var dirs []string
+
+	for _, match := range matches {
+		info, err := r.fs.Stat(match)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Checking '%s'", match)
+		}
+		if info.IsDir() {
+			dirs = append(dirs, match)
+		}
+	}
+
+	sort.Strings(dirs)
+
+	return dirs, nil
+}
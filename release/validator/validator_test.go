@@ -0,0 +1,231 @@
+package validator_test
+
+import (
+	"path/filepath"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/release/validator"
+)
+
+var _ = Describe("Validator", func() {
+	var (
+		fs        boshsys.FileSystem
+		dirPath   string
+		validator Validator
+	)
+
+	write := func(relPath, contents string) {
+		path := filepath.Join(dirPath, relPath)
+		Expect(fs.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(fs.WriteFileString(path, contents)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		fs = boshsys.NewOsFileSystem(boshlog.NewLogger(boshlog.LevelNone))
+		dirPath = GinkgoT().TempDir()
+		validator = NewValidator(fs)
+
+		write("packages/ruby/spec", "---\nname: ruby\nfiles:\n- ruby/*.tgz\n")
+		write("packages/ruby/packaging", "")
+		write("blobs/ruby/ruby-3.2.tgz", "ruby")
+
+		write("packages/app/spec", "---\nname: app\ndependencies:\n- ruby\nfiles:\n- app/**/*\n")
+		write("packages/app/packaging", "")
+		write("src/app/lib/app.rb", "app")
+
+		write("jobs/web/spec", `---
+name: web
+templates:
+  config.yml.erb: config/config.yml
+packages:
+- app
+properties:
+  port:
+    description: Port
+  tls.cert:
+    description: Certificate
+consumes:
+- name: db
+  type: database
+provides:
+- name: web
+  type: http
+  properties: [port]
+`)
+		write("jobs/web/templates/config.yml.erb", `port: <%= p("port") %>
+<% if_p("tls.cert") do |cert| %>
+cert: <%= cert %>
+<% end %>
+db: <%= link("db").p("address") %>
+`)
+		write("jobs/web/monit", `check process web
+  with pidfile /var/vcap/sys/run/web/web.pid
+  start program "/var/vcap/jobs/web/bin/ctl start"
+  stop program "/var/vcap/jobs/web/bin/ctl stop"
+  group vcap
+`)
+
+		write("jobs/db/spec", "---\nname: db\ntemplates: {}\nprovides:\n- name: db\n  type: database\n")
+	})
+
+	It("returns no problems for valid release", func() {
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Problems).To(BeEmpty())
+	})
+
+	It("reports templates that do not exist and templates that are not listed", func() {
+		write("jobs/web/spec", `---
+name: web
+templates:
+  config.yml.erb: config/config.yml
+  missing.erb: bin/missing
+packages: [app]
+properties:
+  port: {}
+  tls.cert: {}
+consumes:
+- {name: db, type: database}
+`)
+		write("jobs/web/templates/unlisted.erb", "")
+
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Problems).To(Equal([]Problem{
+			{Severity: ErrorSeverity, Path: "jobs/web/spec", Line: 5, Message: "Template 'missing.erb' does not exist in 'jobs/web/templates'"},
+			{Severity: WarningSeverity, Path: "jobs/web/templates/unlisted.erb", Message: "Template 'unlisted.erb' is not listed in job spec"},
+		}))
+	})
+
+	It("reports packages and dependencies that are not in release", func() {
+		write("jobs/db/spec", "---\nname: db\ntemplates: {}\npackages:\n- postgres\nprovides:\n- name: db\n  type: database\n")
+		write("packages/app/spec", "---\nname: app\ndependencies:\n- ruby\n- golang\nfiles:\n- app/**/*\n")
+
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Problems).To(Equal([]Problem{
+			{Severity: ErrorSeverity, Path: "jobs/db/spec", Line: 5, Message: "Package 'postgres' is not in release"},
+			{Severity: ErrorSeverity, Path: "packages/app/spec", Line: 5, Message: "Package dependency 'golang' is not in release"},
+		}))
+	})
+
+	It("reports properties used in templates but not declared and declared but not used", func() {
+		write("jobs/web/templates/config.yml.erb", `port: <%= p("port") %>
+host: <%= p('host', 'localhost') %>
+<%= p(["listen.address", "bind"]) %>
+<% if_p("tls.cert", "tls.key") do |cert, key| %><% end %>
+name: <%= p("prefix.#{spec.name}") %>
+db: <%= link("db").p("address") %>
+`)
+
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Problems).To(Equal([]Problem{
+			{Severity: ErrorSeverity, Path: "jobs/web/templates/config.yml.erb", Line: 2, Message: "Property 'host' is not declared in job spec"},
+			{Severity: ErrorSeverity, Path: "jobs/web/templates/config.yml.erb", Line: 3, Message: "Property 'listen.address' is not declared in job spec"},
+			{Severity: ErrorSeverity, Path: "jobs/web/templates/config.yml.erb", Line: 3, Message: "Property 'bind' is not declared in job spec"},
+			{Severity: ErrorSeverity, Path: "jobs/web/templates/config.yml.erb", Line: 4, Message: "Property 'tls.key' is not declared in job spec"},
+		}))
+	})
+
+	It("allows using parents and children of declared properties", func() {
+		write("jobs/web/templates/config.yml.erb", `<%= p("port") %> <%= p("tls").to_json %> <%= p("tls.cert.pem") %>
+<%= link("db").address %>`)
+
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Problems).To(BeEmpty())
+	})
+
+	It("warns about declared properties that are not used", func() {
+		write("jobs/web/templates/config.yml.erb", `<%= link("db").address %>`)
+
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Problems).To(Equal([]Problem{
+			{Severity: WarningSeverity, Path: "jobs/web/spec", Line: 10, Message: "Property 'tls.cert' is not used in templates"},
+		}))
+	})
+
+	It("reports links that are used but not consumed and consumed but not provided", func() {
+		write("jobs/web/templates/config.yml.erb", `<%= p("port") %> <%= p("tls.cert") %>
+<% if_link('cache') do |cache| %><% end %>
+<%= link("db").address %>`)
+		write("jobs/db/spec", "---\nname: db\ntemplates: {}\nprovides:\n- name: db\n  type: postgres\n  properties: [missing]\n")
+
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Problems).To(Equal([]Problem{
+			{Severity: ErrorSeverity, Path: "jobs/db/spec", Line: 7, Message: "Property 'missing' of provided link 'db' is not declared in job spec"},
+			{Severity: WarningSeverity, Path: "jobs/web/spec", Line: 13, Message: "Link 'db' of type 'database' is consumed but not provided by any job in release"},
+			{Severity: ErrorSeverity, Path: "jobs/web/templates/config.yml.erb", Line: 2, Message: "Link 'cache' is not declared in consumes of job spec"},
+		}))
+	})
+
+	It("reports monit syntax problems", func() {
+		write("jobs/web/monit", `set daemon 30
+group vcap
+check process web
+  start program "/var/vcap/jobs/web/bin/ctl start
+<% if p("port") > 0 %>
+check service other
+  with pidfile <%= p("tls.cert") %>
+<% end %>
+`)
+
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Problems).To(Equal([]Problem{
+			{Severity: ErrorSeverity, Path: "jobs/web/monit", Line: 2, Message: "Expected monit statement 'group' to be inside of a check"},
+			{Severity: ErrorSeverity, Path: "jobs/web/monit", Line: 3, Message: "Expected monit process check to specify 'with pidfile' or 'matching'"},
+			{Severity: ErrorSeverity, Path: "jobs/web/monit", Line: 4, Message: "Expected quotes to be closed in monit statement"},
+			{Severity: ErrorSeverity, Path: "jobs/web/monit", Line: 6, Message: "Unknown monit check type 'service'"},
+		}))
+	})
+
+	It("reports mismatched names, missing files and invalid specs", func() {
+		write("jobs/web/spec", "---\nname: api\n")
+		write("jobs/broken/spec", "name: [broken\n")
+		write("packages/ruby/spec", "---\nname: ruby\nfiles:\n- ruby/*.zip\n")
+		write("packages/empty/packaging", "")
+
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+
+		var positions, messages []string
+		for _, problem := range result.Problems {
+			positions = append(positions, problem.Position())
+			messages = append(messages, problem.Message)
+		}
+
+		Expect(positions).To(Equal([]string{
+			"jobs/broken/spec:1",
+			"jobs/web/spec:2",
+			"jobs/web/templates/config.yml.erb",
+			"packages/empty",
+			"packages/ruby/spec:4",
+		}))
+		Expect(messages[0]).To(ContainSubstring("Expected job spec to be valid YAML"))
+		Expect(messages[1:]).To(Equal([]string{
+			"Job name 'api' does not match directory 'web'",
+			"Template 'config.yml.erb' is not listed in job spec",
+			"Expected package 'empty' to have spec",
+			"Missing files for pattern 'ruby/*.zip'",
+		}))
+		Expect(result.Count(ErrorSeverity)).To(Equal(4))
+		Expect(result.Count(WarningSeverity)).To(Equal(1))
+	})
+
+	It("matches package files against blobs that are not synced", func() {
+		Expect(fs.RemoveAll(filepath.Join(dirPath, "blobs"))).To(Succeed())
+		write("config/blobs.yml", "ruby/ruby-3.2.tgz:\n  size: 4\n  sha: abc\n")
+
+		result, err := validator.Validate(dirPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Problems).To(BeEmpty())
+	})
+})
@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package validatorfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/release/validator"
+)
+
+type FakeValidator struct {
+	ValidateStub        func(string) (validator.Result, error)
+	validateMutex       sync.RWMutex
+	validateArgsForCall []struct {
+		arg1 string
+	}
+	validateReturns struct {
+		result1 validator.Result
+		result2 error
+	}
+	validateReturnsOnCall map[int]struct {
+		result1 validator.Result
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeValidator) Validate(arg1 string) (validator.Result, error) {
+	fake.validateMutex.Lock()
+	ret, specificReturn := fake.validateReturnsOnCall[len(fake.validateArgsForCall)]
+	fake.validateArgsForCall = append(fake.validateArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ValidateStub
+	fakeReturns := fake.validateReturns
+	fake.recordInvocation("Validate", []interface{}{arg1})
+	fake.validateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeValidator) ValidateCallCount() int {
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	return len(fake.validateArgsForCall)
+}
+
+func (fake *FakeValidator) ValidateCalls(stub func(string) (validator.Result, error)) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = stub
+}
+
+func (fake *FakeValidator) ValidateArgsForCall(i int) string {
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	argsForCall := fake.validateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeValidator) ValidateReturns(result1 validator.Result, result2 error) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = nil
+	fake.validateReturns = struct {
+		result1 validator.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeValidator) ValidateReturnsOnCall(i int, result1 validator.Result, result2 error) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = nil
+	if fake.validateReturnsOnCall == nil {
+		fake.validateReturnsOnCall = make(map[int]struct {
+			result1 validator.Result
+			result2 error
+		})
+	}
+	fake.validateReturnsOnCall[i] = struct {
+		result1 validator.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ validator.Validator = new(FakeValidator)
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+var yamlErrLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+// specNode parses spec only to find positions of its keys and items;
+// values are read from typed manifests
+func specNode(bytes []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(bytes, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0], nil
+	}
+
+	return &doc, nil
+}
+
+func yamlErrLine(err error) int {
+	matches := yamlErrLineRegexp.FindStringSubmatch(err.Error())
+	if len(matches) < 2 {
+		return 0
+	}
+
+	line, _ := strconv.Atoi(matches[1]) //nolint:errcheck
+
+	return line
+}
+
+func keyNodes(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
+
+func valueNode(node *yaml.Node, key string) *yaml.Node {
+	_, value := keyNodes(node, key)
+	return value
+}
+
+func keyLine(node *yaml.Node, key string) int {
+	keyNode, _ := keyNodes(node, key)
+	if keyNode == nil {
+		return 0
+	}
+	return keyNode.Line
+}
+
+func itemLine(node *yaml.Node, index int) int {
+	if node == nil || node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+		return 0
+	}
+	return node.Content[index].Line
+}
+
+func itemNode(node *yaml.Node, index int) *yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+		return nil
+	}
+	return node.Content[index]
+}
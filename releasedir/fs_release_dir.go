@@ -327,6 +327,10 @@ func (d FSReleaseDir) FinalizeRelease(release boshrel.Release, force bool) error
 	return d.finalReleases.Add(release.Manifest())
 }
 
+func (d FSReleaseDir) RecordFinalReleaseSignature(release boshrel.Release, keyFingerprint, signature string) error {
+	return d.finalReleases.AddSignature(release.Name(), release.Version(), keyFingerprint, signature)
+}
+
 func (d FSReleaseDir) CheckNoCompressionMismatch() (bool, []string, error) {
 	releaseNoCompression := d.config.NoCompression()
 
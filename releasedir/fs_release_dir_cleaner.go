@@ -0,0 +1,344 @@
+package releasedir
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	boshblob "github.com/cloudfoundry/bosh-utils/blobstore"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	boshrelman "github.com/cloudfoundry/bosh-cli/v7/release/manifest"
+	boshidx "github.com/cloudfoundry/bosh-cli/v7/releasedir/index"
+)
+
+type FSReleaseDirCleaner struct {
+	devReleases   FSReleaseIndex
+	finalReleases FSReleaseIndex
+
+	devIndicies   boshidx.FSIndicies
+	finalIndicies boshidx.FSIndicies
+
+	// cachePath is local cache of blobs shared with other release directories
+	cachePath string
+
+	blobstore boshblob.DigestBlobstore
+	fs        boshsys.FileSystem
+}
+
+type cleanerIndex struct {
+	type_ string
+	index boshidx.FSIndex
+}
+
+// cleanerBuilds keeps SHA1s of builds that are kept and removed
+// to find out which blobs in local cache become unreferenced
+type cleanerBuilds struct {
+	kept    map[string]bool
+	removed map[int]string // artifact index -> SHA1
+}
+
+func NewFSReleaseDirCleaner(
+	devReleases FSReleaseIndex,
+	finalReleases FSReleaseIndex,
+	devIndicies boshidx.FSIndicies,
+	finalIndicies boshidx.FSIndicies,
+	cachePath string,
+	blobstore boshblob.DigestBlobstore,
+	fs boshsys.FileSystem,
+) FSReleaseDirCleaner {
+	return FSReleaseDirCleaner{
+		devReleases:   devReleases,
+		finalReleases: finalReleases,
+
+		devIndicies:   devIndicies,
+		finalIndicies: finalIndicies,
+
+		cachePath: cachePath,
+
+		blobstore: blobstore,
+		fs:        fs,
+	}
+}
+
+func (c FSReleaseDirCleaner) Clean(keepDev int, finalBlobs, cacheBlobs, dryRun bool) ([]CleanedArtifact, error) {
+	if keepDev < 0 {
+		return nil, bosherr.Error("Expected number of dev releases to keep to be non-negative")
+	}
+
+	refs := map[string]bool{}
+
+	_, err := c.collectReleases(c.finalReleases, -1, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := c.collectReleases(c.devReleases, keepDev, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	builds := cleanerBuilds{kept: map[string]bool{}, removed: map[int]string{}}
+
+	artifacts, err = c.collectBuilds("dev", c.devIndicies, refs, true, artifacts, builds)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err = c.collectBuilds("final", c.finalIndicies, refs, finalBlobs, artifacts, builds)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, cachedPaths, err := c.collectCache(artifacts, builds, cacheBlobs)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return artifacts, nil
+	}
+
+	err = c.remove(artifacts)
+	if err != nil {
+		return artifacts, err
+	}
+
+	for _, path := range cachedPaths {
+		err := c.fs.RemoveAll(path)
+		if err != nil {
+			return artifacts, bosherr.WrapErrorf(err, "Removing cache blob '%s'", path)
+		}
+	}
+
+	return artifacts, nil
+}
+
+// collectReleases records builds referenced by releases that are kept
+// and returns releases that are not; all releases are kept when keep is negative
+func (c FSReleaseDirCleaner) collectReleases(index FSReleaseIndex, keep int, refs map[string]bool) ([]CleanedArtifact, error) {
+	names, err := index.Names()
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []CleanedArtifact
+
+	for _, name := range names {
+		versions, err := index.Versions(name)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading %s release '%s' versions", index.name, name)
+		}
+
+		for i, version := range versions {
+			if keep >= 0 && i < len(versions)-keep {
+				artifacts = append(artifacts, CleanedArtifact{
+					Type:    index.name + " release",
+					Name:    name,
+					Version: version.AsString(),
+				})
+				continue
+			}
+
+			manifest, err := boshrelman.NewManifestFromPath(index.ManifestPath(name, version.AsString()), c.fs)
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Reading %s release '%s/%s'", index.name, name, version.AsString())
+			}
+
+			for _, job := range manifest.Jobs {
+				refs[c.refKey("job", job.Name, job.Fingerprint)] = true
+			}
+
+			for _, pkg := range manifest.Packages {
+				refs[c.refKey("package", pkg.Name, pkg.Fingerprint)] = true
+			}
+
+			if manifest.License != nil {
+				refs[c.refKey("license", "license", manifest.License.Fingerprint)] = true
+			}
+		}
+	}
+
+	return artifacts, nil
+}
+
+// collectBuilds appends unreferenced builds to artifacts if they are removable
+// and records SHA1s of kept and removed builds
+func (c FSReleaseDirCleaner) collectBuilds(
+	prefix string,
+	indicies boshidx.FSIndicies,
+	refs map[string]bool,
+	removable bool,
+	artifacts []CleanedArtifact,
+	builds cleanerBuilds,
+) ([]CleanedArtifact, error) {
+	for _, idx := range c.cleanerIndicies(indicies) {
+		entries, err := idx.index.Entries()
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading %s %s builds", prefix, idx.type_)
+		}
+
+		for _, entry := range entries {
+			if !removable || refs[c.refKey(idx.type_, entry.Name, entry.Fingerprint)] {
+				builds.kept[c.cacheName(entry.SHA1)] = true
+				continue
+			}
+
+			builds.removed[len(artifacts)] = c.cacheName(entry.SHA1)
+
+			artifacts = append(artifacts, CleanedArtifact{
+				Type:        prefix + " " + idx.type_,
+				Name:        entry.Name,
+				Version:     entry.Fingerprint,
+				BlobstoreID: entry.BlobstoreID,
+			})
+		}
+	}
+
+	return artifacts, nil
+}
+
+// collectCache sets sizes of cached blobs of removed builds and returns paths of cache blobs to remove;
+// when cacheBlobs is set all cache blobs not referenced by kept builds are removed
+// even though they may be used by other release directories, which then rebuild or download them
+func (c FSReleaseDirCleaner) collectCache(
+	artifacts []CleanedArtifact,
+	builds cleanerBuilds,
+	cacheBlobs bool,
+) ([]CleanedArtifact, []string, error) {
+	cachePath, err := c.fs.ExpandPath(c.cachePath)
+	if err != nil {
+		return nil, nil, bosherr.WrapError(err, "Expanding cache directory")
+	}
+
+	var removedPaths []string
+
+	accounted := map[string]bool{}
+
+	for i := range artifacts {
+		name, found := builds.removed[i]
+		if !found || len(name) == 0 || builds.kept[name] || accounted[name] {
+			continue
+		}
+
+		path := filepath.Join(cachePath, name)
+
+		info, err := c.fs.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		artifacts[i].Size = uint64(info.Size())
+		accounted[name] = true
+
+		if cacheBlobs {
+			removedPaths = append(removedPaths, path)
+		}
+	}
+
+	if !cacheBlobs {
+		return artifacts, removedPaths, nil
+	}
+
+	paths, err := c.fs.Glob(filepath.Join(cachePath, "*"))
+	if err != nil {
+		return nil, nil, bosherr.WrapErrorf(err, "Listing cache blobs in '%s'", cachePath)
+	}
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+
+		if builds.kept[name] || accounted[name] {
+			continue
+		}
+
+		info, err := c.fs.Stat(path)
+		if err != nil {
+			return nil, nil, bosherr.WrapErrorf(err, "Checking cache blob '%s'", path)
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		artifacts = append(artifacts, CleanedArtifact{Type: "cache blob", Name: name, Size: uint64(info.Size())})
+		removedPaths = append(removedPaths, path)
+	}
+
+	return artifacts, removedPaths, nil
+}
+
+func (c FSReleaseDirCleaner) remove(artifacts []CleanedArtifact) error {
+	indicies := map[string]boshidx.FSIndex{}
+
+	for _, idx := range c.cleanerIndicies(c.devIndicies) {
+		indicies["dev "+idx.type_] = idx.index
+	}
+
+	for _, idx := range c.cleanerIndicies(c.finalIndicies) {
+		indicies["final "+idx.type_] = idx.index
+	}
+
+	builds := map[string]map[string][]string{}
+
+	for _, artifact := range artifacts {
+		switch artifact.Type {
+		case "cache blob":
+			continue
+
+		case c.devReleases.name + " release":
+			err := c.devReleases.Remove(artifact.Name, artifact.Version)
+			if err != nil {
+				return bosherr.WrapErrorf(err, "Removing dev release '%s/%s'", artifact.Name, artifact.Version)
+			}
+
+		default:
+			if len(artifact.BlobstoreID) > 0 {
+				err := c.blobstore.Delete(artifact.BlobstoreID)
+				if err != nil {
+					return bosherr.WrapErrorf(err, "Deleting blob '%s' of %s '%s/%s'",
+						artifact.BlobstoreID, artifact.Type, artifact.Name, artifact.Version)
+				}
+			}
+
+			if builds[artifact.Type] == nil {
+				builds[artifact.Type] = map[string][]string{}
+			}
+
+			builds[artifact.Type][artifact.Name] = append(builds[artifact.Type][artifact.Name], artifact.Version)
+		}
+	}
+
+	for type_, names := range builds {
+		for name, fingerprints := range names {
+			err := indicies[type_].Remove(name, fingerprints)
+			if err != nil {
+				return bosherr.WrapErrorf(err, "Removing %s '%s' builds", type_, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c FSReleaseDirCleaner) cleanerIndicies(indicies boshidx.FSIndicies) []cleanerIndex {
+	return []cleanerIndex{
+		{type_: "job", index: indicies.Jobs},
+		{type_: "package", index: indicies.Packages},
+		{type_: "license", index: indicies.Licenses},
+	}
+}
+
+// cacheName is file name of blob with given SHA1 in local cache
+func (c FSReleaseDirCleaner) cacheName(sha1 string) string {
+	if runtime.GOOS == "windows" {
+		return strings.ReplaceAll(sha1, ":", "_")
+	}
+	return sha1
+}
+
+func (c FSReleaseDirCleaner) refKey(type_, name, fingerprint string) string {
+	return fmt.Sprintf("%s/%s/%s", type_, name, fingerprint)
+}
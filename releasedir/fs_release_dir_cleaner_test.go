@@ -0,0 +1,298 @@
+package releasedir_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	boshidx "github.com/cloudfoundry/bosh-cli/v7/releasedir/index"
+	fakeidx "github.com/cloudfoundry/bosh-cli/v7/releasedir/index/indexfakes"
+	fakereldir "github.com/cloudfoundry/bosh-cli/v7/releasedir/releasedirfakes"
+)
+
+var _ = Describe("FSReleaseDirCleaner", func() {
+	var (
+		dirPath   string
+		cachePath string
+		blobstore *fakereldir.FakeDigestBlobstore
+		cleaner   FSReleaseDirCleaner
+	)
+
+	writeFile := func(path, contents string) {
+		fullPath := filepath.Join(dirPath, path)
+		Expect(os.MkdirAll(filepath.Dir(fullPath), 0755)).To(Succeed())
+		Expect(os.WriteFile(fullPath, []byte(contents), 0644)).To(Succeed())
+	}
+
+	exists := func(path string) bool {
+		_, err := os.Stat(filepath.Join(dirPath, path))
+		return err == nil
+	}
+
+	readFile := func(path string) string {
+		bytes, err := os.ReadFile(filepath.Join(dirPath, path))
+		Expect(err).ToNot(HaveOccurred())
+		return string(bytes)
+	}
+
+	BeforeEach(func() {
+		dirPath = GinkgoT().TempDir()
+		cachePath = GinkgoT().TempDir()
+		blobstore = &fakereldir.FakeDigestBlobstore{}
+
+		fs := boshsys.NewOsFileSystem(boshlog.NewLogger(boshlog.LevelNone))
+		reporter := &fakereldir.FakeReleaseIndexReporter{}
+		uuidGen := &fakeuuid.FakeGenerator{}
+		idxReporter := &fakeidx.FakeReporter{}
+		idxBlobs := &fakeidx.FakeIndexBlobs{}
+
+		newIndicies := func(prefix string, blobIDs bool) boshidx.FSIndicies {
+			return boshidx.FSIndicies{
+				Jobs:     boshidx.NewFSIndex("job", filepath.Join(dirPath, prefix, "jobs"), true, blobIDs, idxReporter, idxBlobs, fs),
+				Packages: boshidx.NewFSIndex("package", filepath.Join(dirPath, prefix, "packages"), true, blobIDs, idxReporter, idxBlobs, fs),
+				Licenses: boshidx.NewFSIndex("license", filepath.Join(dirPath, prefix, "license"), false, blobIDs, idxReporter, idxBlobs, fs),
+			}
+		}
+
+		cleaner = NewFSReleaseDirCleaner(
+			NewFSReleaseIndex("dev", filepath.Join(dirPath, "dev_releases"), reporter, uuidGen, fs),
+			NewFSReleaseIndex("final", filepath.Join(dirPath, "releases"), reporter, uuidGen, fs),
+			newIndicies(".dev_builds", false),
+			newIndicies(".final_builds", true),
+			cachePath,
+			blobstore,
+			fs,
+		)
+
+		writeFile("releases/rel/index.yml", `---
+builds:
+  uuid1: {version: "1"}
+format-version: "2"`)
+
+		writeFile("releases/rel/rel-1.yml", `---
+name: rel
+version: "1"
+jobs:
+- {name: job1, fingerprint: job1-fp1, sha1: job1-sha1}
+packages:
+- {name: pkg1, fingerprint: pkg1-fp1, sha1: pkg1-sha1}
+license: {fingerprint: lic-fp1, sha1: lic-sha1}`)
+
+		writeFile("dev_releases/rel/index.yml", `---
+builds:
+  uuid1: {version: "1+dev.1"}
+  uuid2: {version: "1+dev.2"}
+  uuid3: {version: "1+dev.10"}
+format-version: "2"`)
+
+		writeFile("dev_releases/rel/rel-1+dev.1.yml", "name: rel")
+		writeFile("dev_releases/rel/rel-1+dev.2.yml", `---
+name: rel
+version: "1+dev.2"
+jobs:
+- {name: job1, fingerprint: job1-fp2, sha1: job1-sha1}`)
+		writeFile("dev_releases/rel/rel-1+dev.10.yml", `---
+name: rel
+version: "1+dev.10"
+jobs:
+- {name: job1, fingerprint: job1-fp3, sha1: job1-sha1}`)
+
+		writeFile(".dev_builds/jobs/job1/index.yml", `---
+builds:
+  job1-fp1: {version: job1-fp1, sha1: sha1}
+  job1-fp2: {version: job1-fp2, sha1: sha1}
+  job1-fp3: {version: job1-fp3, sha1: sha1}
+  job1-fp4: {version: job1-fp4, sha1: sha1}
+format-version: "2"`)
+		writeFile(".dev_builds/jobs/job1/job1-fp4.tgz", "legacy")
+
+		writeFile(".dev_builds/packages/pkg2/index.yml", `---
+builds:
+  pkg2-fp1: {version: pkg2-fp1, sha1: sha1}
+format-version: "2"`)
+
+		writeFile(".final_builds/jobs/job1/index.yml", `---
+builds:
+  job1-fp1: {version: job1-fp1, sha1: sha1, blobstore_id: job1-fp1-blob}
+  job1-fp0: {version: job1-fp0, sha1: sha1, blobstore_id: job1-fp0-blob}
+format-version: "2"`)
+
+		writeFile(".final_builds/license/index.yml", `---
+builds:
+  lic-fp1: {version: lic-fp1, sha1: sha1, blobstore_id: lic-fp1-blob}
+  lic-fp0: {version: lic-fp0, sha1: sha1, blobstore_id: lic-fp0-blob}
+format-version: "2"`)
+	})
+
+	It("removes old dev releases and unreferenced dev builds", func() {
+		artifacts, err := cleaner.Clean(2, false, false, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(artifacts).To(Equal([]CleanedArtifact{
+			{Type: "dev release", Name: "rel", Version: "1+dev.1"},
+			{Type: "dev job", Name: "job1", Version: "job1-fp4"},
+			{Type: "dev package", Name: "pkg2", Version: "pkg2-fp1"},
+		}))
+
+		Expect(exists("dev_releases/rel/rel-1+dev.1.yml")).To(BeFalse())
+		Expect(exists("dev_releases/rel/rel-1+dev.2.yml")).To(BeTrue())
+		Expect(readFile("dev_releases/rel/index.yml")).ToNot(ContainSubstring("1+dev.1\""))
+
+		Expect(exists(".dev_builds/jobs/job1/job1-fp4.tgz")).To(BeFalse())
+		Expect(readFile(".dev_builds/jobs/job1/index.yml")).ToNot(ContainSubstring("job1-fp4"))
+		Expect(readFile(".dev_builds/jobs/job1/index.yml")).To(ContainSubstring("job1-fp3"))
+		Expect(exists(".dev_builds/packages/pkg2")).To(BeFalse())
+
+		Expect(readFile(".final_builds/jobs/job1/index.yml")).To(ContainSubstring("job1-fp0"))
+		Expect(blobstore.DeleteCallCount()).To(Equal(0))
+	})
+
+	It("treats builds referenced only by removed dev releases as unreferenced", func() {
+		artifacts, err := cleaner.Clean(1, false, false, true)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(artifacts).To(Equal([]CleanedArtifact{
+			{Type: "dev release", Name: "rel", Version: "1+dev.1"},
+			{Type: "dev release", Name: "rel", Version: "1+dev.2"},
+			{Type: "dev job", Name: "job1", Version: "job1-fp2"},
+			{Type: "dev job", Name: "job1", Version: "job1-fp4"},
+			{Type: "dev package", Name: "pkg2", Version: "pkg2-fp1"},
+		}))
+	})
+
+	It("removes unreferenced final builds and deletes their blobs when requested", func() {
+		artifacts, err := cleaner.Clean(2, true, false, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(artifacts).To(ContainElements(
+			CleanedArtifact{Type: "final job", Name: "job1", Version: "job1-fp0", BlobstoreID: "job1-fp0-blob"},
+			CleanedArtifact{Type: "final license", Name: "license", Version: "lic-fp0", BlobstoreID: "lic-fp0-blob"},
+		))
+		Expect(artifacts).To(HaveLen(5))
+
+		Expect(blobstore.DeleteCallCount()).To(Equal(2))
+		Expect(blobstore.DeleteArgsForCall(0)).To(Equal("job1-fp0-blob"))
+		Expect(blobstore.DeleteArgsForCall(1)).To(Equal("lic-fp0-blob"))
+
+		Expect(readFile(".final_builds/jobs/job1/index.yml")).ToNot(ContainSubstring("job1-fp0"))
+		Expect(readFile(".final_builds/jobs/job1/index.yml")).To(ContainSubstring("job1-fp1"))
+		Expect(readFile(".final_builds/license/index.yml")).ToNot(ContainSubstring("lic-fp0"))
+	})
+
+	It("does not remove anything during dry run", func() {
+		artifacts, err := cleaner.Clean(0, true, false, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(artifacts).To(HaveLen(9))
+
+		Expect(exists("dev_releases/rel/rel-1+dev.1.yml")).To(BeTrue())
+		Expect(exists(".dev_builds/packages/pkg2/index.yml")).To(BeTrue())
+		Expect(readFile(".final_builds/jobs/job1/index.yml")).To(ContainSubstring("job1-fp0"))
+		Expect(blobstore.DeleteCallCount()).To(Equal(0))
+	})
+
+	It("does not remove final build from index if deleting its blob fails", func() {
+		blobstore.DeleteReturns(errors.New("fake-err"))
+
+		_, err := cleaner.Clean(2, true, false, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+		Expect(readFile(".final_builds/jobs/job1/index.yml")).To(ContainSubstring("job1-fp0"))
+	})
+
+	Context("when blobs are in local cache", func() {
+		writeCacheFile := func(name, contents string) {
+			Expect(os.WriteFile(filepath.Join(cachePath, name), []byte(contents), 0644)).To(Succeed())
+		}
+
+		cacheExists := func(name string) bool {
+			_, err := os.Stat(filepath.Join(cachePath, name))
+			return err == nil
+		}
+
+		BeforeEach(func() {
+			writeFile(".dev_builds/packages/pkg2/index.yml", `---
+builds:
+  pkg2-fp1: {version: pkg2-fp1, sha1: pkg2-sha1}
+format-version: "2"`)
+
+			writeFile(".dev_builds/packages/pkg3/index.yml", `---
+builds:
+  pkg3-fp1: {version: pkg3-fp1, sha1: shared-sha1}
+format-version: "2"`)
+
+			writeFile(".final_builds/packages/pkg3/index.yml", `---
+builds:
+  pkg3-fp1: {version: pkg3-fp1, sha1: shared-sha1, blobstore_id: pkg3-blob}
+format-version: "2"`)
+
+			writeCacheFile("pkg2-sha1", "pkg2-contents")
+			writeCacheFile("shared-sha1", "shared")
+			writeCacheFile("sha1", "kept")
+			writeCacheFile("other-sha1", "other-release")
+		})
+
+		It("reports size of cached blobs of removed builds but keeps them", func() {
+			artifacts, err := cleaner.Clean(2, false, false, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(artifacts).To(ContainElements(
+				CleanedArtifact{Type: "dev package", Name: "pkg2", Version: "pkg2-fp1", Size: 13},
+				// Blob is still referenced by final build
+				CleanedArtifact{Type: "dev package", Name: "pkg3", Version: "pkg3-fp1"},
+			))
+
+			Expect(cacheExists("pkg2-sha1")).To(BeTrue())
+			Expect(cacheExists("other-sha1")).To(BeTrue())
+		})
+
+		It("removes cache blobs not referenced by kept builds when requested", func() {
+			artifacts, err := cleaner.Clean(2, false, true, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(artifacts).To(ContainElements(
+				CleanedArtifact{Type: "dev package", Name: "pkg2", Version: "pkg2-fp1", Size: 13},
+				CleanedArtifact{Type: "cache blob", Name: "other-sha1", Size: 13},
+			))
+			Expect(artifacts).ToNot(ContainElement(HaveField("Name", "shared-sha1")))
+			Expect(artifacts).ToNot(ContainElement(HaveField("Name", "sha1")))
+
+			Expect(cacheExists("pkg2-sha1")).To(BeFalse())
+			Expect(cacheExists("other-sha1")).To(BeFalse())
+			Expect(cacheExists("shared-sha1")).To(BeTrue())
+			Expect(cacheExists("sha1")).To(BeTrue())
+		})
+
+		It("does not remove cache blobs during dry run", func() {
+			artifacts, err := cleaner.Clean(2, false, true, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(artifacts).To(ContainElement(CleanedArtifact{Type: "cache blob", Name: "other-sha1", Size: 13}))
+
+			Expect(cacheExists("pkg2-sha1")).To(BeTrue())
+			Expect(cacheExists("other-sha1")).To(BeTrue())
+		})
+	})
+
+	It("returns error if kept release manifest cannot be read", func() {
+		Expect(os.Remove(filepath.Join(dirPath, "dev_releases/rel/rel-1+dev.10.yml"))).To(Succeed())
+
+		_, err := cleaner.Clean(2, false, false, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Reading dev release 'rel/1+dev.10'"))
+
+		Expect(exists("dev_releases/rel/rel-1+dev.1.yml")).To(BeTrue())
+	})
+
+	It("returns error if number of dev releases to keep is negative", func() {
+		_, err := cleaner.Clean(-1, false, false, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected number of dev releases to keep to be non-negative"))
+	})
+})
@@ -923,6 +923,22 @@ fingerprint: pkg1-fp
 		})
 	})
 
+	Describe("RecordFinalReleaseSignature", func() {
+		It("adds signature to final release index", func() {
+			release := &fakerel.FakeRelease{}
+			release.NameReturns("rel1")
+			release.VersionReturns("ver1")
+
+			finalReleases.AddSignatureReturns(errors.New("fake-err"))
+
+			err := releaseDir.RecordFinalReleaseSignature(release, "SHA256:fp", "sig")
+			Expect(err).To(Equal(errors.New("fake-err")))
+
+			name, version, fingerprint, sig := finalReleases.AddSignatureArgsForCall(0)
+			Expect([]string{name, version, fingerprint, sig}).To(Equal([]string{"rel1", "ver1", "SHA256:fp", "sig"}))
+		})
+	})
+
 	Describe("CheckNoCompressionMismatch", func() {
 		It("returns no mismatch when release has no_compression: true", func() {
 			config.NoCompressionReturns(true)
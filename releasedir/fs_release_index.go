@@ -29,6 +29,9 @@ type FSReleaseIndex struct {
 builds:
   70b9ea8efb83b882021792517b1164550b41bc27:
     version: "1"
+    signature:
+      key_fingerprint: SHA256:...
+      value: MEUCIQ...
 format-version: "2"
 */
 
@@ -56,7 +59,13 @@ func (e fsReleaseIndexSchema_SortedEntries) MarshalYAML() (interface{}, error) {
 }
 
 type fsReleaseIndexSchema_Entry struct {
-	Version string `yaml:"version"`
+	Version   string                          `yaml:"version"`
+	Signature *fsReleaseIndexSchema_Signature `yaml:"signature,omitempty"`
+}
+
+type fsReleaseIndexSchema_Signature struct {
+	KeyFingerprint string `yaml:"key_fingerprint"`
+	Value          string `yaml:"value"`
 }
 
 type releaseIndexEntry struct { //nolint:unused
@@ -178,6 +187,95 @@ func (i FSReleaseIndex) Add(manifest boshrelman.Manifest) error {
 	return nil
 }
 
+func (i FSReleaseIndex) AddSignature(name, version, keyFingerprint, signature string) error {
+	schema, err := i.read(name)
+	if err != nil {
+		return err
+	}
+
+	for key, entry := range schema.Builds {
+		if entry.Version == version {
+			entry.Signature = &fsReleaseIndexSchema_Signature{
+				KeyFingerprint: keyFingerprint,
+				Value:          signature,
+			}
+			schema.Builds[key] = entry
+
+			return i.save(name, schema)
+		}
+	}
+
+	return bosherr.Errorf("Expected release version '%s' to exist", version)
+}
+
+// Names returns names of releases recorded in the index
+func (i FSReleaseIndex) Names() ([]string, error) {
+	indexPaths, err := i.fs.Glob(filepath.Join(i.dirPath, "*", "index.yml"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing release indices in '%s'", i.dirPath)
+	}
+
+	var names []string
+
+	for _, indexPath := range indexPaths {
+		names = append(names, filepath.Base(filepath.Dir(indexPath)))
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Versions returns all versions of a release in ascending order
+func (i FSReleaseIndex) Versions(name string) ([]semver.Version, error) {
+	if len(name) == 0 {
+		return nil, bosherr.Error("Expected non-empty release name")
+	}
+
+	schema, err := i.read(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []semver.Version
+
+	for _, entry := range schema.Builds {
+		ver, err := semver.NewVersionFromString(entry.Version)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Parsing release versions")
+		}
+
+		versions = append(versions, ver)
+	}
+
+	sort.Sort(semver.AscSorting(versions))
+
+	return versions, nil
+}
+
+// Remove drops release version entry and its manifest
+func (i FSReleaseIndex) Remove(name, version string) error {
+	schema, err := i.read(name)
+	if err != nil {
+		return err
+	}
+
+	for key, entry := range schema.Builds {
+		if entry.Version == version {
+			delete(schema.Builds, key)
+		}
+	}
+
+	manifestPath := i.ManifestPath(name, version)
+
+	err = i.fs.RemoveAll(manifestPath)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Removing manifest '%s'", manifestPath)
+	}
+
+	return i.save(name, schema)
+}
+
 func (i FSReleaseIndex) ManifestPath(name, version string) string {
 	fileName := fmt.Sprintf("%s-%s.yml", name, version)
 
@@ -361,6 +361,121 @@ format-version: "2"`)
 		})
 	})
 
+	Describe("AddSignature", func() {
+		BeforeEach(func() {
+			err := fs.WriteFileString(filepath.Join("/", "dir", "name", "index.yml"), `---
+builds:
+  uuid1: {version: "1"}
+  uuid2: {version: "2"}
+format-version: "2"
+`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("records signature for release version", func() {
+			err := index.AddSignature("name", "2", "SHA256:fp", "sig")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.ReadFileString(filepath.Join("/", "dir", "name", "index.yml"))).To(Equal(`builds:
+  uuid1:
+    version: "1"
+  uuid2:
+    version: "2"
+    signature:
+      key_fingerprint: SHA256:fp
+      value: sig
+format-version: "2"
+`))
+		})
+
+		It("returns error if release version is not in the index", func() {
+			err := index.AddSignature("name", "3", "SHA256:fp", "sig")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected release version '3' to exist"))
+		})
+	})
+
+	Describe("Names", func() {
+		It("returns sorted release names that have index files", func() {
+			fs.SetGlob(filepath.Join("/", "dir", "*", "index.yml"), []string{
+				filepath.Join("/", "dir", "rel2", "index.yml"),
+				filepath.Join("/", "dir", "rel1", "index.yml"),
+			})
+
+			names, err := index.Names()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{"rel1", "rel2"}))
+		})
+
+		It("returns error if listing index files fails", func() {
+			fs.GlobErr = errors.New("fake-err")
+
+			_, err := index.Names()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
+	Describe("Versions", func() {
+		It("returns versions in ascending order", func() {
+			err := fs.WriteFileString(filepath.Join("/", "dir", "name", "index.yml"), `---
+builds:
+  uuid1: {version: "1.10"}
+  uuid2: {version: "1.2"}
+  uuid3: {version: "1"}
+format-version: "2"`)
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err := index.Versions("name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]semver.Version{
+				semver.MustNewVersionFromString("1"),
+				semver.MustNewVersionFromString("1.2"),
+				semver.MustNewVersionFromString("1.10"),
+			}))
+		})
+
+		It("returns error if name is empty", func() {
+			_, err := index.Versions("")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected non-empty release name"))
+		})
+	})
+
+	Describe("Remove", func() {
+		BeforeEach(func() {
+			err := fs.WriteFileString(filepath.Join("/", "dir", "name", "index.yml"), `---
+builds:
+  uuid1: {version: "1"}
+  uuid2: {version: "2"}
+format-version: "2"`)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = fs.WriteFileString(filepath.Join("/", "dir", "name", "name-1.yml"), "manifest")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("removes version entry and its manifest", func() {
+			err := index.Remove("name", "1")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.FileExists(filepath.Join("/", "dir", "name", "name-1.yml"))).To(BeFalse())
+			Expect(fs.ReadFileString(filepath.Join("/", "dir", "name", "index.yml"))).To(Equal(`builds:
+  uuid2:
+    version: "2"
+format-version: "2"
+`))
+		})
+
+		It("returns error if removing manifest fails", func() {
+			fs.RemoveAllStub = func(string) error { return errors.New("fake-err") }
+
+			err := index.Remove("name", "1")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
 	Describe("ManifestPath", func() {
 		It("returns path to a manifest", func() {
 			Expect(index.ManifestPath("name", "ver1")).To(Equal(filepath.Join("/", "dir", "name", "name-ver1.yml")))
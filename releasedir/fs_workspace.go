@@ -0,0 +1,318 @@
+package releasedir
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v2"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshjobman "github.com/cloudfoundry/bosh-cli/v7/release/job/manifest"
+	boshpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	boshpkgman "github.com/cloudfoundry/bosh-cli/v7/release/pkg/manifest"
+)
+
+/*
+# workspace.yml
+---
+releases:
+- releases/api
+- releases/worker
+shared_packages:
+- shared
+*/
+
+type fsWorkspaceSchema struct {
+	Releases       []string `yaml:"releases"`
+	SharedPackages []string `yaml:"shared_packages"`
+}
+
+// FSWorkspace groups release directories that vendor packages
+// from shared package roots. Each shared root is laid out like
+// a release directory (packages/, src/, blobs/) and its packages
+// are built once into the workspace's dev index which release
+// directories of the workspace resolve vendored packages against.
+type FSWorkspace struct {
+	path string
+
+	// releaseReaderFactory reads shared package roots
+	releaseReaderFactory func(string) boshrel.Reader
+	releaseDirFactory    func(string) (boshrel.Reader, ReleaseDir)
+
+	fs boshsys.FileSystem
+
+	schema     *fsWorkspaceSchema
+	sharedPkgs []*boshpkg.Package
+}
+
+func NewFSWorkspace(
+	path string,
+	releaseReaderFactory func(string) boshrel.Reader,
+	releaseDirFactory func(string) (boshrel.Reader, ReleaseDir),
+	fs boshsys.FileSystem,
+) *FSWorkspace {
+	return &FSWorkspace{
+		path: path,
+
+		releaseReaderFactory: releaseReaderFactory,
+		releaseDirFactory:    releaseDirFactory,
+
+		fs: fs,
+	}
+}
+
+func (w *FSWorkspace) ReleaseDirPaths() ([]string, error) {
+	schema, err := w.read()
+	if err != nil {
+		return nil, err
+	}
+
+	return w.absPaths(schema.Releases), nil
+}
+
+func (w *FSWorkspace) FindReleaseDir(name string) (ReleaseDir, error) {
+	paths, err := w.ReleaseDirPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		_, releaseDir := w.releaseDirFactory(path)
+
+		defaultName, err := releaseDir.DefaultName()
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading release name of '%s'", path)
+		}
+
+		if defaultName == name {
+			return releaseDir, nil
+		}
+	}
+
+	return nil, bosherr.Errorf("Expected to find release directory for release '%s' in workspace '%s'", name, w.path)
+}
+
+func (w *FSWorkspace) ReleaseDir(path string) (boshrel.Reader, ReleaseDir) {
+	return w.releaseDirFactory(path)
+}
+
+func (w *FSWorkspace) VendorSharedPackages(releaseDirPath string) ([]string, error) {
+	sharedPkgs, err := w.sharedPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := w.requiredPackageNames(releaseDirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	vendorPkgs := map[string]*boshpkg.Package{}
+
+	for _, pkg := range sharedPkgs {
+		if required[pkg.Name()] {
+			w.collectDependentPackages(pkg, vendorPkgs)
+		}
+	}
+
+	var vendored []string
+
+	for name, pkg := range vendorPkgs {
+		changed, err := w.writeSpecLock(releaseDirPath, pkg)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Vendoring shared package '%s' into '%s'", name, releaseDirPath)
+		}
+
+		if changed {
+			vendored = append(vendored, name)
+		}
+	}
+
+	sort.Strings(vendored)
+
+	return vendored, nil
+}
+
+func (w *FSWorkspace) collectDependentPackages(pkg *boshpkg.Package, pkgs map[string]*boshpkg.Package) {
+	pkgs[pkg.Name()] = pkg
+	for _, dep := range pkg.Dependencies {
+		w.collectDependentPackages(dep, pkgs)
+	}
+}
+
+// writeSpecLock makes release directory refer to shared package build by its fingerprint;
+// spec lock is only rewritten when fingerprint or dependencies change
+func (w *FSWorkspace) writeSpecLock(releaseDirPath string, pkg *boshpkg.Package) (bool, error) {
+	pkgDirPath := filepath.Join(releaseDirPath, "packages", pkg.Name())
+
+	if w.fs.FileExists(filepath.Join(pkgDirPath, "spec")) {
+		return false, bosherr.Errorf("Expected package '%s' not to be defined in release directory", pkg.Name())
+	}
+
+	manifestLock := boshpkgman.ManifestLock{Name: pkg.Name(), Fingerprint: pkg.Fingerprint()}
+
+	for _, dep := range pkg.Dependencies {
+		manifestLock.Dependencies = append(manifestLock.Dependencies, dep.Name())
+	}
+
+	manifestLockBytes, err := manifestLock.AsBytes()
+	if err != nil {
+		return false, bosherr.WrapError(err, "Marshaling spec lock")
+	}
+
+	lockPath := filepath.Join(pkgDirPath, "spec.lock")
+
+	if w.fs.FileExists(lockPath) {
+		existingBytes, err := w.fs.ReadFile(lockPath)
+		if err != nil {
+			return false, bosherr.WrapError(err, "Reading spec lock")
+		}
+
+		if string(existingBytes) == string(manifestLockBytes) {
+			return false, nil
+		}
+	}
+
+	err = w.fs.RemoveAll(pkgDirPath)
+	if err != nil {
+		return false, bosherr.WrapError(err, "Removing package dir")
+	}
+
+	err = w.fs.MkdirAll(pkgDirPath, os.ModePerm)
+	if err != nil {
+		return false, bosherr.WrapError(err, "Creating package dir")
+	}
+
+	err = w.fs.WriteFile(lockPath, manifestLockBytes)
+	if err != nil {
+		return false, bosherr.WrapError(err, "Writing spec lock")
+	}
+
+	return true, nil
+}
+
+// requiredPackageNames returns names of packages used by jobs and
+// packages of the release directory that are not defined in it
+func (w *FSWorkspace) requiredPackageNames(releaseDirPath string) (map[string]bool, error) {
+	required := map[string]bool{}
+
+	jobSpecPaths, err := w.fs.Glob(filepath.Join(releaseDirPath, "jobs", "*", "spec"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Globbing job specs")
+	}
+
+	for _, path := range jobSpecPaths {
+		manifest, err := boshjobman.NewManifestFromPath(path, w.fs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range manifest.Packages {
+			required[name] = true
+		}
+	}
+
+	pkgSpecPaths, err := w.fs.Glob(filepath.Join(releaseDirPath, "packages", "*", "spec"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Globbing package specs")
+	}
+
+	var local []string
+
+	for _, path := range pkgSpecPaths {
+		manifest, err := boshpkgman.NewManifestFromPath(path, w.fs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range manifest.Dependencies {
+			required[name] = true
+		}
+
+		local = append(local, filepath.Base(filepath.Dir(path)))
+	}
+
+	for _, name := range local {
+		delete(required, name)
+	}
+
+	return required, nil
+}
+
+func (w *FSWorkspace) sharedPackages() ([]*boshpkg.Package, error) {
+	if w.sharedPkgs != nil {
+		return w.sharedPkgs, nil
+	}
+
+	schema, err := w.read()
+	if err != nil {
+		return nil, err
+	}
+
+	roots := map[string]string{}
+	sharedPkgs := []*boshpkg.Package{}
+
+	for _, path := range w.absPaths(schema.SharedPackages) {
+		release, err := w.releaseReaderFactory(path).Read(path)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Building shared packages from '%s'", path)
+		}
+
+		for _, pkg := range release.Packages() {
+			if otherPath, found := roots[pkg.Name()]; found {
+				return nil, bosherr.Errorf("Expected shared package '%s' to be defined only once but found it in '%s' and '%s'",
+					pkg.Name(), otherPath, path)
+			}
+
+			roots[pkg.Name()] = path
+			sharedPkgs = append(sharedPkgs, pkg)
+		}
+	}
+
+	w.sharedPkgs = sharedPkgs
+
+	return sharedPkgs, nil
+}
+
+func (w *FSWorkspace) read() (fsWorkspaceSchema, error) {
+	if w.schema != nil {
+		return *w.schema, nil
+	}
+
+	var schema fsWorkspaceSchema
+
+	bytes, err := w.fs.ReadFile(w.path)
+	if err != nil {
+		return schema, bosherr.WrapErrorf(err, "Reading workspace '%s'", w.path)
+	}
+
+	err = yaml.UnmarshalStrict(bytes, &schema)
+	if err != nil {
+		return schema, bosherr.WrapErrorf(err, "Unmarshalling workspace '%s'", w.path)
+	}
+
+	if len(schema.Releases) == 0 {
+		return schema, bosherr.Errorf("Expected workspace '%s' to list at least one release directory", w.path)
+	}
+
+	w.schema = &schema
+
+	return schema, nil
+}
+
+func (w *FSWorkspace) absPaths(paths []string) []string {
+	var absPaths []string
+
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(w.path), path)
+		}
+
+		absPaths = append(absPaths, path)
+	}
+
+	return absPaths
+}
@@ -0,0 +1,276 @@
+package releasedir_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
+	boshpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	fakerel "github.com/cloudfoundry/bosh-cli/v7/release/releasefakes"
+	fakeres "github.com/cloudfoundry/bosh-cli/v7/release/resource/resourcefakes"
+	. "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/v7/releasedir/releasedirfakes"
+)
+
+var _ = Describe("FSWorkspace", func() {
+	var (
+		dirPath       string
+		releaseReader *fakerel.FakeReader
+		releaseDirs   map[string]*fakereldir.FakeReleaseDir
+		workspace     *FSWorkspace
+	)
+
+	writeFile := func(path, contents string) {
+		fullPath := filepath.Join(dirPath, path)
+		Expect(os.MkdirAll(filepath.Dir(fullPath), 0755)).To(Succeed())
+		Expect(os.WriteFile(fullPath, []byte(contents), 0644)).To(Succeed())
+	}
+
+	newPackage := func(name string, deps ...*boshpkg.Package) *boshpkg.Package {
+		res := &fakeres.FakeResource{
+			NameStub:        func() string { return name },
+			FingerprintStub: func() string { return name + "-fp" },
+		}
+
+		var depNames []string
+		for _, dep := range deps {
+			depNames = append(depNames, dep.Name())
+		}
+
+		pkg := boshpkg.NewPackage(res, depNames)
+		Expect(pkg.AttachDependencies(deps)).To(Succeed())
+
+		return pkg
+	}
+
+	readFile := func(path string) string {
+		bytes, err := os.ReadFile(filepath.Join(dirPath, path))
+		Expect(err).ToNot(HaveOccurred())
+		return string(bytes)
+	}
+
+	BeforeEach(func() {
+		dirPath = GinkgoT().TempDir()
+		releaseReader = &fakerel.FakeReader{}
+		releaseDirs = map[string]*fakereldir.FakeReleaseDir{}
+
+		releaseReaderFactory := func(path string) boshrel.Reader {
+			return releaseReader
+		}
+
+		releaseDirFactory := func(path string) (boshrel.Reader, ReleaseDir) {
+			if releaseDirs[path] == nil {
+				releaseDirs[path] = &fakereldir.FakeReleaseDir{}
+			}
+			return releaseReader, releaseDirs[path]
+		}
+
+		fs := boshsys.NewOsFileSystem(boshlog.NewLogger(boshlog.LevelNone))
+		workspace = NewFSWorkspace(filepath.Join(dirPath, "workspace.yml"), releaseReaderFactory, releaseDirFactory, fs)
+
+		writeFile("workspace.yml", `---
+releases:
+- api
+- /abs/worker
+shared_packages:
+- shared
+- shared2`)
+	})
+
+	Describe("ReleaseDirPaths", func() {
+		It("returns release directory paths relative to workspace file", func() {
+			paths, err := workspace.ReleaseDirPaths()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(paths).To(Equal([]string{filepath.Join(dirPath, "api"), "/abs/worker"}))
+		})
+
+		It("returns error if workspace does not list release directories", func() {
+			writeFile("workspace.yml", "shared_packages: [shared]")
+
+			_, err := workspace.ReleaseDirPaths()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("to list at least one release directory"))
+		})
+
+		It("returns error if workspace contains unknown keys", func() {
+			writeFile("workspace.yml", "releases: [api]\nunknown: true")
+
+			_, err := workspace.ReleaseDirPaths()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unmarshalling workspace"))
+		})
+
+		It("returns error if workspace cannot be read", func() {
+			Expect(os.Remove(filepath.Join(dirPath, "workspace.yml"))).To(Succeed())
+
+			_, err := workspace.ReleaseDirPaths()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Reading workspace"))
+		})
+	})
+
+	Describe("FindReleaseDir", func() {
+		It("returns release directory with matching release name", func() {
+			apiDir := &fakereldir.FakeReleaseDir{}
+			apiDir.DefaultNameReturns("api", nil)
+			releaseDirs[filepath.Join(dirPath, "api")] = apiDir
+
+			workerDir := &fakereldir.FakeReleaseDir{}
+			workerDir.DefaultNameReturns("worker", nil)
+			releaseDirs["/abs/worker"] = workerDir
+
+			releaseDir, err := workspace.FindReleaseDir("worker")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(releaseDir).To(BeIdenticalTo(workerDir))
+		})
+
+		It("returns error if no release directory matches", func() {
+			_, err := workspace.FindReleaseDir("other")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected to find release directory for release 'other'"))
+		})
+
+		It("returns error if release name cannot be determined", func() {
+			apiDir := &fakereldir.FakeReleaseDir{}
+			apiDir.DefaultNameReturns("", errors.New("fake-err"))
+			releaseDirs[filepath.Join(dirPath, "api")] = apiDir
+
+			_, err := workspace.FindReleaseDir("api")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
+	Describe("ReleaseDir", func() {
+		It("returns reader and release directory for path", func() {
+			reader, releaseDir := workspace.ReleaseDir("/abs/worker")
+			Expect(reader).To(BeIdenticalTo(releaseReader))
+			Expect(releaseDir).To(BeIdenticalTo(releaseDirs["/abs/worker"]))
+		})
+	})
+
+	Describe("VendorSharedPackages", func() {
+		var (
+			apiPath         string
+			golang, openssl *boshpkg.Package
+			gcc, ruby       *boshpkg.Package
+			unrelated       *boshpkg.Package
+			sharedRelease   *fakerel.FakeRelease
+			sharedRelease2  *fakerel.FakeRelease
+		)
+
+		BeforeEach(func() {
+			apiPath = filepath.Join(dirPath, "api")
+
+			gcc = newPackage("gcc")
+			golang = newPackage("golang", gcc)
+			openssl = newPackage("openssl")
+			ruby = newPackage("ruby")
+			unrelated = newPackage("unrelated")
+
+			sharedRelease = &fakerel.FakeRelease{}
+			sharedRelease.PackagesReturns([]*boshpkg.Package{gcc, golang, openssl, ruby})
+
+			sharedRelease2 = &fakerel.FakeRelease{}
+			sharedRelease2.PackagesReturns([]*boshpkg.Package{unrelated})
+
+			releaseReader.ReadStub = func(path string) (boshrel.Release, error) {
+				if path == filepath.Join(dirPath, "shared") {
+					return sharedRelease, nil
+				}
+				return sharedRelease2, nil
+			}
+
+			writeFile("api/jobs/web/spec", "name: web\npackages: [api, golang]")
+			writeFile("api/packages/api/spec", "name: api\ndependencies: [openssl]")
+			writeFile("api/packages/ruby/spec", "name: ruby")
+			writeFile("api/jobs/ruby-job/spec", "name: ruby-job\npackages: [ruby]")
+		})
+
+		It("records shared packages required by release and not defined in it with their dependencies", func() {
+			vendored, err := workspace.VendorSharedPackages(apiPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vendored).To(Equal([]string{"gcc", "golang", "openssl"}))
+
+			Expect(readFile("api/packages/golang/spec.lock")).To(Equal("name: golang\nfingerprint: golang-fp\ndependencies:\n- gcc\n"))
+			Expect(readFile("api/packages/gcc/spec.lock")).To(Equal("name: gcc\nfingerprint: gcc-fp\n"))
+			Expect(readFile("api/packages/openssl/spec.lock")).To(Equal("name: openssl\nfingerprint: openssl-fp\n"))
+			Expect(readFile("api/packages/ruby/spec")).To(Equal("name: ruby"))
+
+			// Shared packages are not finalized into release directory
+			Expect(releaseDirs[apiPath]).To(BeNil())
+		})
+
+		It("does not rewrite records of shared packages that did not change", func() {
+			writeFile("api/packages/golang/spec.lock", "name: golang\nfingerprint: old-fp\ndependencies:\n- gcc\n")
+
+			_, err := workspace.VendorSharedPackages(apiPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			vendored, err := workspace.VendorSharedPackages(apiPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vendored).To(BeEmpty())
+
+			Expect(readFile("api/packages/golang/spec.lock")).To(ContainSubstring("fingerprint: golang-fp"))
+		})
+
+		It("returns error if shared package dependency is defined in release directory", func() {
+			writeFile("api/packages/gcc/spec", "name: gcc")
+
+			_, err := workspace.VendorSharedPackages(apiPath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Vendoring shared package 'gcc'"))
+			Expect(err.Error()).To(ContainSubstring("Expected package 'gcc' not to be defined in release directory"))
+		})
+
+		It("builds shared packages only once", func() {
+			_, err := workspace.VendorSharedPackages(apiPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = workspace.VendorSharedPackages(apiPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(releaseReader.ReadCallCount()).To(Equal(2))
+			Expect(releaseReader.ReadArgsForCall(0)).To(Equal(filepath.Join(dirPath, "shared")))
+			Expect(releaseReader.ReadArgsForCall(1)).To(Equal(filepath.Join(dirPath, "shared2")))
+		})
+
+		It("returns error if shared package is defined in multiple roots", func() {
+			sharedRelease2.PackagesReturns([]*boshpkg.Package{newPackage("golang")})
+
+			_, err := workspace.VendorSharedPackages(apiPath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected shared package 'golang' to be defined only once"))
+		})
+
+		It("returns error if building shared packages fails", func() {
+			releaseReader.ReadStub = nil
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			_, err := workspace.VendorSharedPackages(apiPath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns error if job spec cannot be parsed", func() {
+			writeFile("api/jobs/web/spec", "-")
+
+			_, err := workspace.VendorSharedPackages(apiPath)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns error if recording shared package fails", func() {
+			writeFile("api/packages/openssl", "not-a-dir")
+
+			_, err := workspace.VendorSharedPackages(apiPath)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Vendoring shared package"))
+		})
+	})
+})
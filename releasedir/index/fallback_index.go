@@ -0,0 +1,29 @@
+package index
+
+import (
+	boshres "github.com/cloudfoundry/bosh-cli/v7/release/resource"
+)
+
+// FallbackIndex finds builds in index and then in fallback index;
+// new builds are only added to index
+type FallbackIndex struct {
+	index    boshres.ArchiveIndex
+	fallback boshres.ArchiveIndex
+}
+
+func NewFallbackIndex(index, fallback boshres.ArchiveIndex) FallbackIndex {
+	return FallbackIndex{index: index, fallback: fallback}
+}
+
+func (i FallbackIndex) Find(name, fingerprint string) (string, string, error) {
+	path, sha1, err := i.index.Find(name, fingerprint)
+	if err != nil || len(path) > 0 {
+		return path, sha1, err
+	}
+
+	return i.fallback.Find(name, fingerprint)
+}
+
+func (i FallbackIndex) Add(name, fingerprint, path, sha1 string) (string, string, error) {
+	return i.index.Add(name, fingerprint, path, sha1)
+}
@@ -0,0 +1,69 @@
+package index_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fakeres "github.com/cloudfoundry/bosh-cli/v7/release/resource/resourcefakes"
+	boshidx "github.com/cloudfoundry/bosh-cli/v7/releasedir/index"
+)
+
+var _ = Describe("FallbackIndex", func() {
+	var (
+		index, fallback *fakeres.FakeArchiveIndex
+		fallbackIndex   boshidx.FallbackIndex
+	)
+
+	BeforeEach(func() {
+		index = &fakeres.FakeArchiveIndex{}
+		fallback = &fakeres.FakeArchiveIndex{}
+		fallbackIndex = boshidx.NewFallbackIndex(index, fallback)
+	})
+
+	Describe("Find", func() {
+		It("returns build from index if found", func() {
+			index.FindReturns("path", "sha1", nil)
+
+			path, sha1, err := fallbackIndex.Find("name", "fp")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("path"))
+			Expect(sha1).To(Equal("sha1"))
+			Expect(fallback.FindCallCount()).To(Equal(0))
+		})
+
+		It("returns build from fallback index if not found in index", func() {
+			fallback.FindReturns("fallback-path", "fallback-sha1", nil)
+
+			path, sha1, err := fallbackIndex.Find("name", "fp")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("fallback-path"))
+			Expect(sha1).To(Equal("fallback-sha1"))
+
+			name, fp := fallback.FindArgsForCall(0)
+			Expect(name).To(Equal("name"))
+			Expect(fp).To(Equal("fp"))
+		})
+
+		It("returns error if finding in index fails", func() {
+			index.FindReturns("", "", errors.New("fake-err"))
+
+			_, _, err := fallbackIndex.Find("name", "fp")
+			Expect(err).To(Equal(errors.New("fake-err")))
+			Expect(fallback.FindCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("Add", func() {
+		It("adds build only to index", func() {
+			index.AddReturns("path", "sha1", nil)
+
+			path, sha1, err := fallbackIndex.Add("name", "fp", "src", "sha1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("path"))
+			Expect(sha1).To(Equal("sha1"))
+			Expect(fallback.AddCallCount()).To(Equal(0))
+		})
+	})
+})
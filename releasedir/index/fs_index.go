@@ -204,6 +204,129 @@ func (i FSIndex) Add(name, fingerprint, path, sha1 string) (string, string, erro
 	return blobPath, sha1, nil
 }
 
+// Entry is a build recorded in the index
+type Entry struct {
+	Name        string
+	Fingerprint string
+
+	BlobstoreID string
+	SHA1        string
+}
+
+// Entries returns builds recorded for all names sorted by name and fingerprint
+func (i FSIndex) Entries() ([]Entry, error) {
+	names := []string{i.name}
+
+	if i.useSubdir {
+		indexPaths, err := i.fs.Glob(filepath.Join(i.dirPath, "*", "index.yml"))
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Listing indices in '%s'", i.dirPath)
+		}
+
+		names = nil
+
+		for _, indexPath := range indexPaths {
+			names = append(names, filepath.Base(filepath.Dir(indexPath)))
+		}
+	}
+
+	var result []Entry
+
+	for _, name := range names {
+		entries, err := i.entries(name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			result = append(result, Entry{
+				Name:        name,
+				Fingerprint: entry.Version,
+				BlobstoreID: entry.BlobstoreID,
+				SHA1:        entry.SHA1,
+			})
+		}
+	}
+
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Name != result[b].Name {
+			return result[a].Name < result[b].Name
+		}
+		return result[a].Fingerprint < result[b].Fingerprint
+	})
+
+	return result, nil
+}
+
+// Remove drops builds with given fingerprints and their legacy tarballs
+// kept next to the index file; index file is removed once it's empty.
+// Blobs in the local cache are not removed here since they are shared between release directories.
+func (i FSIndex) Remove(name string, fingerprints []string) error {
+	if len(name) == 0 {
+		return bosherr.Error("Expected non-empty name")
+	}
+
+	entries, err := i.entries(name)
+	if err != nil {
+		return err
+	}
+
+	removed := map[string]bool{}
+
+	for _, fingerprint := range fingerprints {
+		removed[fingerprint] = true
+	}
+
+	var remaining []indexEntry
+
+	for _, entry := range entries {
+		if !removed[entry.Version] {
+			remaining = append(remaining, entry)
+		}
+	}
+
+	indexPath := i.indexPath(name)
+
+	for fingerprint := range removed {
+		tarballPath := filepath.Join(filepath.Dir(indexPath), fingerprint+".tgz")
+
+		err := i.fs.RemoveAll(tarballPath)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Removing build tarball '%s'", tarballPath)
+		}
+	}
+
+	if len(remaining) > 0 {
+		return i.save(name, remaining)
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	err = i.fs.RemoveAll(indexPath)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Removing index '%s'", indexPath)
+	}
+
+	if i.useSubdir {
+		dirPath := filepath.Dir(indexPath)
+
+		leftovers, err := i.fs.Glob(filepath.Join(dirPath, "*"))
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Listing '%s'", dirPath)
+		}
+
+		if len(leftovers) == 0 {
+			err = i.fs.RemoveAll(dirPath)
+			if err != nil {
+				return bosherr.WrapErrorf(err, "Removing '%s'", dirPath)
+			}
+		}
+	}
+
+	return nil
+}
+
 var (
 	// Ruby CLI for some reason produces invalid annotations
 	invalidBinaryAnnotationReplacer = strings.NewReplacer(" !binary ", " !!binary ")
@@ -198,6 +198,115 @@ format-version: "2"`)
 		})
 	})
 
+	Describe("Entries", func() {
+		It("returns entries of all names sorted by name and fingerprint", func() {
+			fs.SetGlob(filepath.Join("/", "dir", "*", "index.yml"), []string{
+				filepath.Join("/", "dir", "name2", "index.yml"),
+				filepath.Join("/", "dir", "name1", "index.yml"),
+			})
+
+			err := fs.WriteFileString(filepath.Join("/", "dir", "name1", "index.yml"), `---
+builds:
+  fp2: {version: fp2, sha1: fp2-sha1, blobstore_id: fp2-blob-id}
+  fp1: {version: fp1, sha1: fp1-sha1, blobstore_id: fp1-blob-id}
+format-version: "2"`)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = fs.WriteFileString(filepath.Join("/", "dir", "name2", "index.yml"), `---
+builds:
+  fp3: {version: fp3, sha1: fp3-sha1, blobstore_id: fp3-blob-id}
+format-version: "2"`)
+			Expect(err).ToNot(HaveOccurred())
+
+			entries, err := index.Entries()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(Equal([]boshidx.Entry{
+				{Name: "name1", Fingerprint: "fp1", BlobstoreID: "fp1-blob-id", SHA1: "fp1-sha1"},
+				{Name: "name1", Fingerprint: "fp2", BlobstoreID: "fp2-blob-id", SHA1: "fp2-sha1"},
+				{Name: "name2", Fingerprint: "fp3", BlobstoreID: "fp3-blob-id", SHA1: "fp3-sha1"},
+			}))
+		})
+
+		It("returns entries of non-prefixed index file under index name", func() {
+			index = boshidx.NewFSIndex("index-name", filepath.Join("/", "dir"), false, true, reporter, blobs, fs)
+
+			err := fs.WriteFileString(filepath.Join("/", "dir", "index.yml"), `---
+builds:
+  fp: {version: fp, sha1: fp-sha1, blobstore_id: fp-blob-id}
+format-version: "2"`)
+			Expect(err).ToNot(HaveOccurred())
+
+			entries, err := index.Entries()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(Equal([]boshidx.Entry{
+				{Name: "index-name", Fingerprint: "fp", BlobstoreID: "fp-blob-id", SHA1: "fp-sha1"},
+			}))
+		})
+
+		It("returns error if listing index files fails", func() {
+			fs.GlobErr = errors.New("fake-err")
+
+			_, err := index.Entries()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
+	Describe("Remove", func() {
+		BeforeEach(func() {
+			err := fs.WriteFileString(filepath.Join("/", "dir", "name", "index.yml"), `---
+builds:
+  fp2: {version: fp2, sha1: fp2-sha1, blobstore_id: fp2-blob-id}
+  fp1: {version: fp1, sha1: fp1-sha1, blobstore_id: fp1-blob-id}
+format-version: "2"`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("removes entries and their legacy tarballs", func() {
+			err := fs.WriteFileString(filepath.Join("/", "dir", "name", "fp1.tgz"), "tarball")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = index.Remove("name", []string{"fp1"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.FileExists(filepath.Join("/", "dir", "name", "fp1.tgz"))).To(BeFalse())
+			Expect(fs.ReadFileString(filepath.Join("/", "dir", "name", "index.yml"))).To(Equal(`builds:
+  fp2:
+    version: fp2
+    blobstore_id: fp2-blob-id
+    sha1: fp2-sha1
+format-version: "2"
+`))
+		})
+
+		It("removes index file and its directory when no entries remain", func() {
+			err := index.Remove("name", []string{"fp1", "fp2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.FileExists(filepath.Join("/", "dir", "name", "index.yml"))).To(BeFalse())
+			Expect(fs.FileExists(filepath.Join("/", "dir", "name"))).To(BeFalse())
+		})
+
+		It("returns error if name is empty", func() {
+			err := index.Remove("", []string{"fp1"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected non-empty name"))
+		})
+
+		It("returns error if removing index file fails", func() {
+			fs.RemoveAllStub = func(path string) error {
+				if path == filepath.Join("/", "dir", "name", "index.yml") {
+					return errors.New("fake-err")
+				}
+				return nil
+			}
+
+			err := index.Remove("name", []string{"fp1", "fp2"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
 	Describe("Add", func() {
 		It("adds new entry when no index file exists", func() {
 			blobs.AddStub = func(name, path, sha1 string) (string, string, error) {
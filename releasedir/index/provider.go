@@ -9,6 +9,9 @@ import (
 	boshrel "github.com/cloudfoundry/bosh-cli/v7/release"
 )
 
+// CachePath is local cache of built archives shared by all release directories
+var CachePath = filepath.Join("~", ".bosh", "cache")
+
 type Provider struct {
 	reporter  Reporter
 	blobstore boshblob.DigestBlobstore
@@ -27,11 +30,25 @@ func NewProvider(
 	}
 }
 
+// FSIndicies keeps indices of jobs, packages and license built in release directory
+type FSIndicies struct {
+	Jobs     FSIndex
+	Packages FSIndex
+	Licenses FSIndex
+}
+
+func (i FSIndicies) ArchiveIndicies() boshrel.ArchiveIndicies {
+	return boshrel.ArchiveIndicies{Jobs: i.Jobs, Packages: i.Packages, Licenses: i.Licenses}
+}
+
 func (p Provider) DevAndFinalIndicies(dirPath string) (boshrel.ArchiveIndicies, boshrel.ArchiveIndicies) {
-	cachePath := filepath.Join("~", ".bosh", "cache")
+	devIndicies, finalIndicies := p.DevAndFinalFSIndicies(dirPath)
+	return devIndicies.ArchiveIndicies(), finalIndicies.ArchiveIndicies()
+}
 
-	devBlobsCache := NewFSIndexBlobs(cachePath, p.reporter, nil, p.fs)
-	finalBlobsCache := NewFSIndexBlobs(cachePath, p.reporter, p.blobstore, p.fs)
+func (p Provider) DevAndFinalFSIndicies(dirPath string) (FSIndicies, FSIndicies) {
+	devBlobsCache := NewFSIndexBlobs(CachePath, p.reporter, nil, p.fs)
+	finalBlobsCache := NewFSIndexBlobs(CachePath, p.reporter, p.blobstore, p.fs)
 
 	devJobsPath := filepath.Join(dirPath, ".dev_builds", "jobs")
 	devPkgsPath := filepath.Join(dirPath, ".dev_builds", "packages")
@@ -41,13 +58,13 @@ func (p Provider) DevAndFinalIndicies(dirPath string) (boshrel.ArchiveIndicies,
 	finalPkgsPath := filepath.Join(dirPath, ".final_builds", "packages")
 	finalLicPath := filepath.Join(dirPath, ".final_builds", "license")
 
-	devIndicies := boshrel.ArchiveIndicies{
+	devIndicies := FSIndicies{
 		Jobs:     NewFSIndex("job", devJobsPath, true, false, p.reporter, devBlobsCache, p.fs),
 		Packages: NewFSIndex("package", devPkgsPath, true, false, p.reporter, devBlobsCache, p.fs),
 		Licenses: NewFSIndex("license", devLicPath, false, false, p.reporter, devBlobsCache, p.fs),
 	}
 
-	finalIndicies := boshrel.ArchiveIndicies{
+	finalIndicies := FSIndicies{
 		Jobs:     NewFSIndex("job", finalJobsPath, true, true, p.reporter, finalBlobsCache, p.fs),
 		Packages: NewFSIndex("package", finalPkgsPath, true, true, p.reporter, finalBlobsCache, p.fs),
 		Licenses: NewFSIndex("license", finalLicPath, false, true, p.reporter, finalBlobsCache, p.fs),
@@ -55,3 +72,19 @@ func (p Provider) DevAndFinalIndicies(dirPath string) (boshrel.ArchiveIndicies,
 
 	return devIndicies, finalIndicies
 }
+
+// ScratchIndicies returns empty indices kept under scratchPath
+// so that all archives get built from source
+func (p Provider) ScratchIndicies(scratchPath string) (boshrel.ArchiveIndicies, boshrel.ArchiveIndicies) {
+	blobsCache := NewFSIndexBlobs(filepath.Join(scratchPath, "cache"), p.reporter, nil, p.fs)
+
+	newIndicies := func(prefix string) boshrel.ArchiveIndicies {
+		return boshrel.ArchiveIndicies{
+			Jobs:     NewFSIndex("job", filepath.Join(scratchPath, prefix, "jobs"), true, false, p.reporter, blobsCache, p.fs),
+			Packages: NewFSIndex("package", filepath.Join(scratchPath, prefix, "packages"), true, false, p.reporter, blobsCache, p.fs),
+			Licenses: NewFSIndex("license", filepath.Join(scratchPath, prefix, "license"), false, false, p.reporter, blobsCache, p.fs),
+		}
+	}
+
+	return newIndicies("dev"), newIndicies("final")
+}
@@ -46,6 +46,9 @@ type ReleaseDir interface {
 	// FinalizeRelease adds the Release to the final list so that it's consumable by others.
 	FinalizeRelease(release boshrel.Release, force bool) error
 
+	// RecordFinalReleaseSignature keeps tarball signature of a final release in the final release index.
+	RecordFinalReleaseSignature(release boshrel.Release, keyFingerprint, signature string) error
+
 	// CheckNoCompressionMismatch checks if any packages have no_compression: true in their spec files
 	// but final.yml does not have no_compression: true. Returns true if there's a mismatch,
 	// along with a list of package names that have no_compression: true.
@@ -115,10 +118,34 @@ type ReleaseIndex interface {
 
 	Contains(boshrel.Release) (bool, error)
 	Add(boshrelman.Manifest) error
+	AddSignature(name, version, keyFingerprint, signature string) error
 
 	ManifestPath(name, version string) string
 }
 
+//counterfeiter:generate . ReleaseDirCleaner
+
+type ReleaseDirCleaner interface {
+	// Clean removes dev releases except keepDev latest versions of each release
+	// and dev builds that are not referenced by remaining dev or final releases.
+	// Unreferenced final builds and their blobs are only removed when finalBlobs is set.
+	// Blobs in local cache that are not referenced by remaining builds are only removed
+	// when cacheBlobs is set. Nothing is removed when dryRun is set.
+	Clean(keepDev int, finalBlobs, cacheBlobs, dryRun bool) ([]CleanedArtifact, error)
+}
+
+type CleanedArtifact struct {
+	Type    string // e.g. 'dev release', 'dev job', 'final package', 'cache blob'
+	Name    string
+	Version string
+
+	BlobstoreID string
+
+	// Size is number of bytes held by artifact in local cache
+	// which is only freed when cache blobs are removed
+	Size uint64
+}
+
 //counterfeiter:generate . ReleaseIndexReporter
 
 type ReleaseIndexReporter interface {
@@ -134,3 +161,23 @@ type DigestBlobstore interface {
 	Validate() (err error)
 	Delete(blobId string) (err error)
 }
+
+//counterfeiter:generate . Workspace
+
+type Workspace interface {
+	// ReleaseDirPaths returns paths of release directories listed in the workspace.
+	ReleaseDirPaths() ([]string, error)
+
+	// FindReleaseDir returns release directory whose default release name matches name.
+	FindReleaseDir(name string) (ReleaseDir, error)
+
+	// ReleaseDir returns reader and release directory for path which find
+	// builds of vendored shared packages in the workspace's dev index.
+	ReleaseDir(path string) (boshrel.Reader, ReleaseDir)
+
+	// VendorSharedPackages builds shared packages required by jobs and packages
+	// of the release directory into the workspace's dev index and records them
+	// in the release directory. Nothing is uploaded to the release blobstore.
+	// Returns names of packages whose records changed.
+	VendorSharedPackages(releaseDirPath string) ([]string, error)
+}
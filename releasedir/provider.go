@@ -60,6 +60,10 @@ func NewProvider(
 }
 
 func (p Provider) NewFSReleaseDir(dirPath string, parallel int) FSReleaseDir {
+	return p.newFSReleaseDir(dirPath, p.NewReleaseReader(dirPath, parallel), parallel)
+}
+
+func (p Provider) newFSReleaseDir(dirPath string, releaseReader boshrel.Reader, parallel int) FSReleaseDir {
 	gitRepo := NewFSGitRepo(dirPath, p.cmdRunner, p.fs)
 	blobsDir := p.NewFSBlobsDir(dirPath)
 	generator := NewFSGenerator(dirPath, p.fs)
@@ -73,8 +77,6 @@ func (p Provider) NewFSReleaseDir(dirPath string, parallel int) FSReleaseDir {
 	indiciesProvider := boshidx.NewProvider(p.indexReporter, p.newBlobstore(dirPath), p.fs)
 	_, finalIndex := indiciesProvider.DevAndFinalIndicies(dirPath)
 
-	releaseReader := p.NewReleaseReader(dirPath, parallel)
-
 	return NewFSReleaseDir(
 		dirPath,
 		p.newConfig(dirPath),
@@ -91,14 +93,68 @@ func (p Provider) NewFSReleaseDir(dirPath string, parallel int) FSReleaseDir {
 	)
 }
 
+func (p Provider) NewFSReleaseDirCleaner(dirPath string) FSReleaseDirCleaner {
+	devRelsPath := filepath.Join(dirPath, "dev_releases")
+	devReleases := NewFSReleaseIndex("dev", devRelsPath, p.releaseIndexReporter, p.uuidGen, p.fs)
+
+	finalRelsPath := filepath.Join(dirPath, "releases")
+	finalReleases := NewFSReleaseIndex("final", finalRelsPath, p.releaseIndexReporter, p.uuidGen, p.fs)
+
+	blobstore := p.newBlobstore(dirPath)
+
+	indiciesProvider := boshidx.NewProvider(p.indexReporter, blobstore, p.fs)
+	devIndicies, finalIndicies := indiciesProvider.DevAndFinalFSIndicies(dirPath)
+
+	return NewFSReleaseDirCleaner(devReleases, finalReleases, devIndicies, finalIndicies, boshidx.CachePath, blobstore, p.fs)
+}
+
+func (p Provider) NewFSWorkspace(path string, parallel int) *FSWorkspace {
+	// Shared packages are built into workspace's own dev index and are never uploaded
+	sharedIndiciesProvider := boshidx.NewProvider(p.indexReporter, nil, p.fs)
+	sharedDevIndicies, sharedFinalIndicies := sharedIndiciesProvider.DevAndFinalIndicies(filepath.Dir(path))
+
+	releaseReaderFactory := func(dirPath string) boshrel.Reader {
+		multiReader := p.releaseProvider.NewMultiReader(dirPath)
+		return boshrel.NewBuiltReader(multiReader, sharedDevIndicies, sharedFinalIndicies, parallel)
+	}
+
+	releaseDirFactory := func(dirPath string) (boshrel.Reader, ReleaseDir) {
+		multiReader := p.releaseProvider.NewMultiReader(dirPath)
+		indiciesProvider := boshidx.NewProvider(p.indexReporter, p.newBlobstore(dirPath), p.fs)
+		devIndex, finalIndex := indiciesProvider.DevAndFinalIndicies(dirPath)
+
+		devIndex.Packages = boshidx.NewFallbackIndex(devIndex.Packages, sharedDevIndicies.Packages)
+
+		releaseReader := boshrel.NewBuiltReader(multiReader, devIndex, finalIndex, parallel)
+
+		return releaseReader, p.newFSReleaseDir(dirPath, releaseReader, parallel)
+	}
+
+	return NewFSWorkspace(path, releaseReaderFactory, releaseDirFactory, p.fs)
+}
+
 func (p Provider) NewFSBlobsDir(dirPath string) FSBlobsDir {
 	return NewFSBlobsDir(dirPath, p.blobsReporter, p.newBlobstore(dirPath), p.digestCalculator, p.fs, p.logger)
 }
 
+// DevAndFinalIndicies returns indices of builds in release directory;
+// final builds are downloaded from release blobstore
+func (p Provider) DevAndFinalIndicies(dirPath string) (boshrel.ArchiveIndicies, boshrel.ArchiveIndicies) {
+	return boshidx.NewProvider(p.indexReporter, p.newBlobstore(dirPath), p.fs).DevAndFinalIndicies(dirPath)
+}
+
 func (p Provider) NewReleaseReader(dirPath string, parallel int) boshrel.BuiltReader {
 	multiReader := p.releaseProvider.NewMultiReader(dirPath)
-	indiciesProvider := boshidx.NewProvider(p.indexReporter, p.newBlobstore(dirPath), p.fs)
-	devIndex, finalIndex := indiciesProvider.DevAndFinalIndicies(dirPath)
+	devIndex, finalIndex := p.DevAndFinalIndicies(dirPath)
+	return boshrel.NewBuiltReader(multiReader, devIndex, finalIndex, parallel)
+}
+
+// NewScratchReleaseReader returns reader that builds all jobs, packages
+// and license from source ignoring existing dev and final builds
+func (p Provider) NewScratchReleaseReader(dirPath, scratchPath string, parallel int) boshrel.BuiltReader {
+	multiReader := p.releaseProvider.NewMultiReader(dirPath)
+	indiciesProvider := boshidx.NewProvider(p.indexReporter, nil, p.fs)
+	devIndex, finalIndex := indiciesProvider.ScratchIndicies(scratchPath)
 	return boshrel.NewBuiltReader(multiReader, devIndex, finalIndex, parallel)
 }
 
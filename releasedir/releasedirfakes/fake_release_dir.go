@@ -148,6 +148,19 @@ type FakeReleaseDir struct {
 	noCompressionReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RecordFinalReleaseSignatureStub        func(release.Release, string, string) error
+	recordFinalReleaseSignatureMutex       sync.RWMutex
+	recordFinalReleaseSignatureArgsForCall []struct {
+		arg1 release.Release
+		arg2 string
+		arg3 string
+	}
+	recordFinalReleaseSignatureReturns struct {
+		result1 error
+	}
+	recordFinalReleaseSignatureReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ResetStub        func() error
 	resetMutex       sync.RWMutex
 	resetArgsForCall []struct {
@@ -847,6 +860,69 @@ func (fake *FakeReleaseDir) NoCompressionReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeReleaseDir) RecordFinalReleaseSignature(arg1 release.Release, arg2 string, arg3 string) error {
+	fake.recordFinalReleaseSignatureMutex.Lock()
+	ret, specificReturn := fake.recordFinalReleaseSignatureReturnsOnCall[len(fake.recordFinalReleaseSignatureArgsForCall)]
+	fake.recordFinalReleaseSignatureArgsForCall = append(fake.recordFinalReleaseSignatureArgsForCall, struct {
+		arg1 release.Release
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RecordFinalReleaseSignatureStub
+	fakeReturns := fake.recordFinalReleaseSignatureReturns
+	fake.recordInvocation("RecordFinalReleaseSignature", []interface{}{arg1, arg2, arg3})
+	fake.recordFinalReleaseSignatureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseDir) RecordFinalReleaseSignatureCallCount() int {
+	fake.recordFinalReleaseSignatureMutex.RLock()
+	defer fake.recordFinalReleaseSignatureMutex.RUnlock()
+	return len(fake.recordFinalReleaseSignatureArgsForCall)
+}
+
+func (fake *FakeReleaseDir) RecordFinalReleaseSignatureCalls(stub func(release.Release, string, string) error) {
+	fake.recordFinalReleaseSignatureMutex.Lock()
+	defer fake.recordFinalReleaseSignatureMutex.Unlock()
+	fake.RecordFinalReleaseSignatureStub = stub
+}
+
+func (fake *FakeReleaseDir) RecordFinalReleaseSignatureArgsForCall(i int) (release.Release, string, string) {
+	fake.recordFinalReleaseSignatureMutex.RLock()
+	defer fake.recordFinalReleaseSignatureMutex.RUnlock()
+	argsForCall := fake.recordFinalReleaseSignatureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReleaseDir) RecordFinalReleaseSignatureReturns(result1 error) {
+	fake.recordFinalReleaseSignatureMutex.Lock()
+	defer fake.recordFinalReleaseSignatureMutex.Unlock()
+	fake.RecordFinalReleaseSignatureStub = nil
+	fake.recordFinalReleaseSignatureReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseDir) RecordFinalReleaseSignatureReturnsOnCall(i int, result1 error) {
+	fake.recordFinalReleaseSignatureMutex.Lock()
+	defer fake.recordFinalReleaseSignatureMutex.Unlock()
+	fake.RecordFinalReleaseSignatureStub = nil
+	if fake.recordFinalReleaseSignatureReturnsOnCall == nil {
+		fake.recordFinalReleaseSignatureReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordFinalReleaseSignatureReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseDir) Reset() error {
 	fake.resetMutex.Lock()
 	ret, specificReturn := fake.resetReturnsOnCall[len(fake.resetArgsForCall)]
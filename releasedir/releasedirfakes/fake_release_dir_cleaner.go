@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package releasedirfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/releasedir"
+)
+
+type FakeReleaseDirCleaner struct {
+	CleanStub        func(int, bool, bool, bool) ([]releasedir.CleanedArtifact, error)
+	cleanMutex       sync.RWMutex
+	cleanArgsForCall []struct {
+		arg1 int
+		arg2 bool
+		arg3 bool
+		arg4 bool
+	}
+	cleanReturns struct {
+		result1 []releasedir.CleanedArtifact
+		result2 error
+	}
+	cleanReturnsOnCall map[int]struct {
+		result1 []releasedir.CleanedArtifact
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeReleaseDirCleaner) Clean(arg1 int, arg2 bool, arg3 bool, arg4 bool) ([]releasedir.CleanedArtifact, error) {
+	fake.cleanMutex.Lock()
+	ret, specificReturn := fake.cleanReturnsOnCall[len(fake.cleanArgsForCall)]
+	fake.cleanArgsForCall = append(fake.cleanArgsForCall, struct {
+		arg1 int
+		arg2 bool
+		arg3 bool
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CleanStub
+	fakeReturns := fake.cleanReturns
+	fake.recordInvocation("Clean", []interface{}{arg1, arg2, arg3, arg4})
+	fake.cleanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReleaseDirCleaner) CleanCallCount() int {
+	fake.cleanMutex.RLock()
+	defer fake.cleanMutex.RUnlock()
+	return len(fake.cleanArgsForCall)
+}
+
+func (fake *FakeReleaseDirCleaner) CleanCalls(stub func(int, bool, bool, bool) ([]releasedir.CleanedArtifact, error)) {
+	fake.cleanMutex.Lock()
+	defer fake.cleanMutex.Unlock()
+	fake.CleanStub = stub
+}
+
+func (fake *FakeReleaseDirCleaner) CleanArgsForCall(i int) (int, bool, bool, bool) {
+	fake.cleanMutex.RLock()
+	defer fake.cleanMutex.RUnlock()
+	argsForCall := fake.cleanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeReleaseDirCleaner) CleanReturns(result1 []releasedir.CleanedArtifact, result2 error) {
+	fake.cleanMutex.Lock()
+	defer fake.cleanMutex.Unlock()
+	fake.CleanStub = nil
+	fake.cleanReturns = struct {
+		result1 []releasedir.CleanedArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReleaseDirCleaner) CleanReturnsOnCall(i int, result1 []releasedir.CleanedArtifact, result2 error) {
+	fake.cleanMutex.Lock()
+	defer fake.cleanMutex.Unlock()
+	fake.CleanStub = nil
+	if fake.cleanReturnsOnCall == nil {
+		fake.cleanReturnsOnCall = make(map[int]struct {
+			result1 []releasedir.CleanedArtifact
+			result2 error
+		})
+	}
+	fake.cleanReturnsOnCall[i] = struct {
+		result1 []releasedir.CleanedArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReleaseDirCleaner) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeReleaseDirCleaner) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ releasedir.ReleaseDirCleaner = new(FakeReleaseDirCleaner)
@@ -22,6 +22,20 @@ type FakeReleaseIndex struct {
 	addReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AddSignatureStub        func(string, string, string, string) error
+	addSignatureMutex       sync.RWMutex
+	addSignatureArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	addSignatureReturns struct {
+		result1 error
+	}
+	addSignatureReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ContainsStub        func(release.Release) (bool, error)
 	containsMutex       sync.RWMutex
 	containsArgsForCall []struct {
@@ -125,6 +139,70 @@ func (fake *FakeReleaseIndex) AddReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeReleaseIndex) AddSignature(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.addSignatureMutex.Lock()
+	ret, specificReturn := fake.addSignatureReturnsOnCall[len(fake.addSignatureArgsForCall)]
+	fake.addSignatureArgsForCall = append(fake.addSignatureArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AddSignatureStub
+	fakeReturns := fake.addSignatureReturns
+	fake.recordInvocation("AddSignature", []interface{}{arg1, arg2, arg3, arg4})
+	fake.addSignatureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseIndex) AddSignatureCallCount() int {
+	fake.addSignatureMutex.RLock()
+	defer fake.addSignatureMutex.RUnlock()
+	return len(fake.addSignatureArgsForCall)
+}
+
+func (fake *FakeReleaseIndex) AddSignatureCalls(stub func(string, string, string, string) error) {
+	fake.addSignatureMutex.Lock()
+	defer fake.addSignatureMutex.Unlock()
+	fake.AddSignatureStub = stub
+}
+
+func (fake *FakeReleaseIndex) AddSignatureArgsForCall(i int) (string, string, string, string) {
+	fake.addSignatureMutex.RLock()
+	defer fake.addSignatureMutex.RUnlock()
+	argsForCall := fake.addSignatureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeReleaseIndex) AddSignatureReturns(result1 error) {
+	fake.addSignatureMutex.Lock()
+	defer fake.addSignatureMutex.Unlock()
+	fake.AddSignatureStub = nil
+	fake.addSignatureReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseIndex) AddSignatureReturnsOnCall(i int, result1 error) {
+	fake.addSignatureMutex.Lock()
+	defer fake.addSignatureMutex.Unlock()
+	fake.AddSignatureStub = nil
+	if fake.addSignatureReturnsOnCall == nil {
+		fake.addSignatureReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addSignatureReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseIndex) Contains(arg1 release.Release) (bool, error) {
 	fake.containsMutex.Lock()
 	ret, specificReturn := fake.containsReturnsOnCall[len(fake.containsArgsForCall)]
@@ -0,0 +1,337 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package releasedirfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/release"
+	"github.com/cloudfoundry/bosh-cli/v7/releasedir"
+)
+
+type FakeWorkspace struct {
+	FindReleaseDirStub        func(string) (releasedir.ReleaseDir, error)
+	findReleaseDirMutex       sync.RWMutex
+	findReleaseDirArgsForCall []struct {
+		arg1 string
+	}
+	findReleaseDirReturns struct {
+		result1 releasedir.ReleaseDir
+		result2 error
+	}
+	findReleaseDirReturnsOnCall map[int]struct {
+		result1 releasedir.ReleaseDir
+		result2 error
+	}
+	ReleaseDirStub        func(string) (release.Reader, releasedir.ReleaseDir)
+	releaseDirMutex       sync.RWMutex
+	releaseDirArgsForCall []struct {
+		arg1 string
+	}
+	releaseDirReturns struct {
+		result1 release.Reader
+		result2 releasedir.ReleaseDir
+	}
+	releaseDirReturnsOnCall map[int]struct {
+		result1 release.Reader
+		result2 releasedir.ReleaseDir
+	}
+	ReleaseDirPathsStub        func() ([]string, error)
+	releaseDirPathsMutex       sync.RWMutex
+	releaseDirPathsArgsForCall []struct {
+	}
+	releaseDirPathsReturns struct {
+		result1 []string
+		result2 error
+	}
+	releaseDirPathsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	VendorSharedPackagesStub        func(string) ([]string, error)
+	vendorSharedPackagesMutex       sync.RWMutex
+	vendorSharedPackagesArgsForCall []struct {
+		arg1 string
+	}
+	vendorSharedPackagesReturns struct {
+		result1 []string
+		result2 error
+	}
+	vendorSharedPackagesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWorkspace) FindReleaseDir(arg1 string) (releasedir.ReleaseDir, error) {
+	fake.findReleaseDirMutex.Lock()
+	ret, specificReturn := fake.findReleaseDirReturnsOnCall[len(fake.findReleaseDirArgsForCall)]
+	fake.findReleaseDirArgsForCall = append(fake.findReleaseDirArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FindReleaseDirStub
+	fakeReturns := fake.findReleaseDirReturns
+	fake.recordInvocation("FindReleaseDir", []interface{}{arg1})
+	fake.findReleaseDirMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkspace) FindReleaseDirCallCount() int {
+	fake.findReleaseDirMutex.RLock()
+	defer fake.findReleaseDirMutex.RUnlock()
+	return len(fake.findReleaseDirArgsForCall)
+}
+
+func (fake *FakeWorkspace) FindReleaseDirCalls(stub func(string) (releasedir.ReleaseDir, error)) {
+	fake.findReleaseDirMutex.Lock()
+	defer fake.findReleaseDirMutex.Unlock()
+	fake.FindReleaseDirStub = stub
+}
+
+func (fake *FakeWorkspace) FindReleaseDirArgsForCall(i int) string {
+	fake.findReleaseDirMutex.RLock()
+	defer fake.findReleaseDirMutex.RUnlock()
+	argsForCall := fake.findReleaseDirArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkspace) FindReleaseDirReturns(result1 releasedir.ReleaseDir, result2 error) {
+	fake.findReleaseDirMutex.Lock()
+	defer fake.findReleaseDirMutex.Unlock()
+	fake.FindReleaseDirStub = nil
+	fake.findReleaseDirReturns = struct {
+		result1 releasedir.ReleaseDir
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkspace) FindReleaseDirReturnsOnCall(i int, result1 releasedir.ReleaseDir, result2 error) {
+	fake.findReleaseDirMutex.Lock()
+	defer fake.findReleaseDirMutex.Unlock()
+	fake.FindReleaseDirStub = nil
+	if fake.findReleaseDirReturnsOnCall == nil {
+		fake.findReleaseDirReturnsOnCall = make(map[int]struct {
+			result1 releasedir.ReleaseDir
+			result2 error
+		})
+	}
+	fake.findReleaseDirReturnsOnCall[i] = struct {
+		result1 releasedir.ReleaseDir
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkspace) ReleaseDir(arg1 string) (release.Reader, releasedir.ReleaseDir) {
+	fake.releaseDirMutex.Lock()
+	ret, specificReturn := fake.releaseDirReturnsOnCall[len(fake.releaseDirArgsForCall)]
+	fake.releaseDirArgsForCall = append(fake.releaseDirArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReleaseDirStub
+	fakeReturns := fake.releaseDirReturns
+	fake.recordInvocation("ReleaseDir", []interface{}{arg1})
+	fake.releaseDirMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkspace) ReleaseDirCallCount() int {
+	fake.releaseDirMutex.RLock()
+	defer fake.releaseDirMutex.RUnlock()
+	return len(fake.releaseDirArgsForCall)
+}
+
+func (fake *FakeWorkspace) ReleaseDirCalls(stub func(string) (release.Reader, releasedir.ReleaseDir)) {
+	fake.releaseDirMutex.Lock()
+	defer fake.releaseDirMutex.Unlock()
+	fake.ReleaseDirStub = stub
+}
+
+func (fake *FakeWorkspace) ReleaseDirArgsForCall(i int) string {
+	fake.releaseDirMutex.RLock()
+	defer fake.releaseDirMutex.RUnlock()
+	argsForCall := fake.releaseDirArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkspace) ReleaseDirReturns(result1 release.Reader, result2 releasedir.ReleaseDir) {
+	fake.releaseDirMutex.Lock()
+	defer fake.releaseDirMutex.Unlock()
+	fake.ReleaseDirStub = nil
+	fake.releaseDirReturns = struct {
+		result1 release.Reader
+		result2 releasedir.ReleaseDir
+	}{result1, result2}
+}
+
+func (fake *FakeWorkspace) ReleaseDirReturnsOnCall(i int, result1 release.Reader, result2 releasedir.ReleaseDir) {
+	fake.releaseDirMutex.Lock()
+	defer fake.releaseDirMutex.Unlock()
+	fake.ReleaseDirStub = nil
+	if fake.releaseDirReturnsOnCall == nil {
+		fake.releaseDirReturnsOnCall = make(map[int]struct {
+			result1 release.Reader
+			result2 releasedir.ReleaseDir
+		})
+	}
+	fake.releaseDirReturnsOnCall[i] = struct {
+		result1 release.Reader
+		result2 releasedir.ReleaseDir
+	}{result1, result2}
+}
+
+func (fake *FakeWorkspace) ReleaseDirPaths() ([]string, error) {
+	fake.releaseDirPathsMutex.Lock()
+	ret, specificReturn := fake.releaseDirPathsReturnsOnCall[len(fake.releaseDirPathsArgsForCall)]
+	fake.releaseDirPathsArgsForCall = append(fake.releaseDirPathsArgsForCall, struct {
+	}{})
+	stub := fake.ReleaseDirPathsStub
+	fakeReturns := fake.releaseDirPathsReturns
+	fake.recordInvocation("ReleaseDirPaths", []interface{}{})
+	fake.releaseDirPathsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkspace) ReleaseDirPathsCallCount() int {
+	fake.releaseDirPathsMutex.RLock()
+	defer fake.releaseDirPathsMutex.RUnlock()
+	return len(fake.releaseDirPathsArgsForCall)
+}
+
+func (fake *FakeWorkspace) ReleaseDirPathsCalls(stub func() ([]string, error)) {
+	fake.releaseDirPathsMutex.Lock()
+	defer fake.releaseDirPathsMutex.Unlock()
+	fake.ReleaseDirPathsStub = stub
+}
+
+func (fake *FakeWorkspace) ReleaseDirPathsReturns(result1 []string, result2 error) {
+	fake.releaseDirPathsMutex.Lock()
+	defer fake.releaseDirPathsMutex.Unlock()
+	fake.ReleaseDirPathsStub = nil
+	fake.releaseDirPathsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkspace) ReleaseDirPathsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.releaseDirPathsMutex.Lock()
+	defer fake.releaseDirPathsMutex.Unlock()
+	fake.ReleaseDirPathsStub = nil
+	if fake.releaseDirPathsReturnsOnCall == nil {
+		fake.releaseDirPathsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.releaseDirPathsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkspace) VendorSharedPackages(arg1 string) ([]string, error) {
+	fake.vendorSharedPackagesMutex.Lock()
+	ret, specificReturn := fake.vendorSharedPackagesReturnsOnCall[len(fake.vendorSharedPackagesArgsForCall)]
+	fake.vendorSharedPackagesArgsForCall = append(fake.vendorSharedPackagesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.VendorSharedPackagesStub
+	fakeReturns := fake.vendorSharedPackagesReturns
+	fake.recordInvocation("VendorSharedPackages", []interface{}{arg1})
+	fake.vendorSharedPackagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkspace) VendorSharedPackagesCallCount() int {
+	fake.vendorSharedPackagesMutex.RLock()
+	defer fake.vendorSharedPackagesMutex.RUnlock()
+	return len(fake.vendorSharedPackagesArgsForCall)
+}
+
+func (fake *FakeWorkspace) VendorSharedPackagesCalls(stub func(string) ([]string, error)) {
+	fake.vendorSharedPackagesMutex.Lock()
+	defer fake.vendorSharedPackagesMutex.Unlock()
+	fake.VendorSharedPackagesStub = stub
+}
+
+func (fake *FakeWorkspace) VendorSharedPackagesArgsForCall(i int) string {
+	fake.vendorSharedPackagesMutex.RLock()
+	defer fake.vendorSharedPackagesMutex.RUnlock()
+	argsForCall := fake.vendorSharedPackagesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorkspace) VendorSharedPackagesReturns(result1 []string, result2 error) {
+	fake.vendorSharedPackagesMutex.Lock()
+	defer fake.vendorSharedPackagesMutex.Unlock()
+	fake.VendorSharedPackagesStub = nil
+	fake.vendorSharedPackagesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkspace) VendorSharedPackagesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.vendorSharedPackagesMutex.Lock()
+	defer fake.vendorSharedPackagesMutex.Unlock()
+	fake.VendorSharedPackagesStub = nil
+	if fake.vendorSharedPackagesReturnsOnCall == nil {
+		fake.vendorSharedPackagesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.vendorSharedPackagesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkspace) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWorkspace) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ releasedir.Workspace = new(FakeWorkspace)
@@ -0,0 +1,342 @@
+package sandbox
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshfu "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	birelpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	bistatepkg "github.com/cloudfoundry/bosh-cli/v7/state/pkg"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . Builder
+
+// Builder runs packaging scripts of a package and its dependencies outside
+// of a director so that they can be iterated on locally
+type Builder interface {
+	Build(pkg *birelpkg.Package, out io.Writer) ([]Result, error)
+}
+
+type Result struct {
+	Name        string
+	Fingerprint string
+
+	// Path is install target of the package on the host
+	Path string
+
+	// Cached is true if package was installed from a previous build
+	Cached bool
+}
+
+// Sandbox mirrors agent's layout under root path: packages are installed into
+// var/vcap/packages/<name> and compiled in var/vcap/data/compile/<name>
+const (
+	packagesDir = "var/vcap/packages"
+	compileDir  = "var/vcap/data/compile"
+)
+
+// rootfsVersionFiles identify contents of stemcell root filesystem;
+// only the first one is required to be present
+var rootfsVersionFiles = []string{
+	"etc/os-release",
+	"var/vcap/bosh/etc/stemcell_version",
+	"var/vcap/bosh/etc/stemcell_git_sha1",
+}
+
+type builder struct {
+	devBuildsPath string
+
+	rootPath  string
+	cachePath string
+
+	// rootfsPath is stemcell root filesystem which is copied into
+	// sandbox so that packaging scripts do not modify it
+	rootfsPath string
+
+	// chroot runs packaging scripts with root path as root directory
+	chroot bool
+
+	compressor boshfu.Compressor
+	runner     boshsys.CmdRunner
+	fs         boshsys.FileSystem
+
+	logTag string
+	logger boshlog.Logger
+}
+
+// NewBuilder returns builder which keeps its sandbox and cache in release's .dev_builds;
+// if rootfs path is given, packaging scripts are run chrooted into a copy of it instead
+func NewBuilder(
+	dirPath string,
+	rootfsPath string,
+	compressor boshfu.Compressor,
+	runner boshsys.CmdRunner,
+	fs boshsys.FileSystem,
+	logger boshlog.Logger,
+) Builder {
+	devBuildsPath := filepath.Join(dirPath, ".dev_builds")
+
+	return builder{
+		devBuildsPath: devBuildsPath,
+
+		rootPath:  filepath.Join(devBuildsPath, "sandbox"),
+		cachePath: filepath.Join(devBuildsPath, "compiled_packages", "host"),
+
+		rootfsPath: rootfsPath,
+		chroot:     len(rootfsPath) > 0,
+
+		compressor: compressor,
+		runner:     runner,
+		fs:         fs,
+
+		logTag: "sandbox.Builder",
+		logger: logger,
+	}
+}
+
+// Build installs dependencies bottom-up and then the package itself
+func (b builder) Build(pkg *birelpkg.Package, out io.Writer) ([]Result, error) {
+	b, err := b.prepareRootfs()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+
+	cacheKeys := map[string]string{}
+
+	for _, compilable := range append(bistatepkg.ResolveDependencies(pkg), pkg) {
+		depPkg, ok := compilable.(*birelpkg.Package)
+		if !ok {
+			return nil, bosherr.Errorf("Expected package '%s' to be built from source", compilable.Name())
+		}
+
+		cacheKey := b.cacheKey(depPkg, cacheKeys)
+		cacheKeys[depPkg.Name()] = cacheKey
+
+		result, err := b.install(depPkg, cacheKey, out)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Building package '%s'", depPkg.Name())
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// prepareRootfs points sandbox at a copy of stemcell root filesystem;
+// copies and builds are kept per rootfs contents so that updated rootfs is not mixed with stale builds
+func (b builder) prepareRootfs() (builder, error) {
+	if len(b.rootfsPath) == 0 {
+		return b, nil
+	}
+
+	rootfsKey, err := b.rootfsKey()
+	if err != nil {
+		return b, err
+	}
+
+	b.rootPath = filepath.Join(b.devBuildsPath, "sandbox", "rootfs-"+rootfsKey)
+	b.cachePath = filepath.Join(b.devBuildsPath, "compiled_packages", "rootfs-"+rootfsKey)
+
+	if b.fs.FileExists(b.rootPath) {
+		return b, nil
+	}
+
+	// Copy is made next to its final location so that interrupted copies are not used
+	copyPath := b.rootPath + ".tmp"
+
+	err = b.fs.RemoveAll(copyPath)
+	if err != nil {
+		return b, bosherr.WrapError(err, "Removing previous stemcell root filesystem copy")
+	}
+
+	err = b.fs.MkdirAll(copyPath, os.ModePerm)
+	if err != nil {
+		return b, bosherr.WrapError(err, "Creating stemcell root filesystem copy")
+	}
+
+	// cp preserves ownership, special files and symlinks which chrooted scripts rely on
+	_, _, _, err = b.runner.RunCommand("cp", "-a", b.rootfsPath+"/.", copyPath)
+	if err != nil {
+		return b, bosherr.WrapErrorf(err, "Copying stemcell root filesystem '%s'", b.rootfsPath)
+	}
+
+	err = b.fs.Rename(copyPath, b.rootPath)
+	if err != nil {
+		return b, bosherr.WrapError(err, "Moving stemcell root filesystem copy")
+	}
+
+	return b, nil
+}
+
+// rootfsKey is derived from OS release and stemcell version of root filesystem
+func (b builder) rootfsKey() (string, error) {
+	hash := sha1.New() //nolint:gosec
+
+	for i, name := range rootfsVersionFiles {
+		path := filepath.Join(b.rootfsPath, name)
+
+		if !b.fs.FileExists(path) {
+			if i == 0 {
+				return "", bosherr.Errorf("Expected stemcell root filesystem '%s' to contain '/%s'", b.rootfsPath, name)
+			}
+			continue
+		}
+
+		contents, err := b.fs.ReadFile(path)
+		if err != nil {
+			return "", bosherr.WrapErrorf(err, "Reading '%s'", path)
+		}
+
+		fmt.Fprintf(hash, "%s\n%s\n", name, contents)
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))[:12], nil
+}
+
+// cacheKey changes when package or any of its dependencies change
+// since package fingerprint only includes names of dependencies
+func (b builder) cacheKey(pkg *birelpkg.Package, cacheKeys map[string]string) string {
+	var depNames []string
+
+	for _, dep := range pkg.Deps() {
+		depNames = append(depNames, dep.Name())
+	}
+
+	sort.Strings(depNames)
+
+	hash := sha1.New() //nolint:gosec
+
+	fmt.Fprint(hash, pkg.Fingerprint())
+
+	for _, name := range depNames {
+		fmt.Fprintf(hash, "\n%s:%s", name, cacheKeys[name])
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+func (b builder) install(pkg *birelpkg.Package, cacheKey string, out io.Writer) (Result, error) {
+	result := Result{
+		Name:        pkg.Name(),
+		Fingerprint: pkg.Fingerprint(),
+		Path:        filepath.Join(b.rootPath, packagesDir, pkg.Name()),
+	}
+
+	err := b.fs.RemoveAll(result.Path)
+	if err != nil {
+		return Result{}, bosherr.WrapError(err, "Removing previous install target")
+	}
+
+	err = b.fs.MkdirAll(result.Path, os.ModePerm)
+	if err != nil {
+		return Result{}, bosherr.WrapError(err, "Creating install target")
+	}
+
+	cachedPath := filepath.Join(b.cachePath, pkg.Name(), cacheKey+".tgz")
+
+	if b.fs.FileExists(cachedPath) {
+		b.logger.Debug(b.logTag, "Using cached build '%s' of package '%s'", cachedPath, pkg.Name())
+
+		err = b.compressor.DecompressFileToDir(cachedPath, result.Path, boshfu.CompressorOptions{})
+		if err != nil {
+			return Result{}, bosherr.WrapErrorf(err, "Extracting cached build '%s'", cachedPath)
+		}
+
+		result.Cached = true
+
+		return result, nil
+	}
+
+	err = b.compile(pkg, out)
+	if err != nil {
+		return Result{}, err
+	}
+
+	tarball, err := b.compressor.CompressFilesInDir(result.Path, boshfu.CompressorOptions{})
+	if err != nil {
+		return Result{}, bosherr.WrapError(err, "Compressing built package")
+	}
+
+	err = b.fs.MkdirAll(filepath.Dir(cachedPath), os.ModePerm)
+	if err != nil {
+		return Result{}, bosherr.WrapError(err, "Creating build cache")
+	}
+
+	err = boshfu.NewFileMover(b.fs).Move(tarball, cachedPath)
+	if err != nil {
+		return Result{}, bosherr.WrapError(err, "Caching built package")
+	}
+
+	return result, nil
+}
+
+// compile leaves extracted sources in compile target for inspection
+func (b builder) compile(pkg *birelpkg.Package, out io.Writer) error {
+	hostCompilePath := filepath.Join(b.rootPath, compileDir, pkg.Name())
+
+	err := b.fs.RemoveAll(hostCompilePath)
+	if err != nil {
+		return bosherr.WrapError(err, "Removing previous compile target")
+	}
+
+	err = b.fs.MkdirAll(hostCompilePath, os.ModePerm)
+	if err != nil {
+		return bosherr.WrapError(err, "Creating compile target")
+	}
+
+	err = b.compressor.DecompressFileToDir(pkg.ArchivePath(), hostCompilePath, boshfu.CompressorOptions{})
+	if err != nil {
+		return bosherr.WrapError(err, "Extracting package sources")
+	}
+
+	if !b.fs.FileExists(filepath.Join(hostCompilePath, "packaging")) {
+		return bosherr.Errorf("Packaging script for package '%s' not found", pkg.Name())
+	}
+
+	// Paths as seen by packaging script
+	scriptRootPath := b.rootPath
+	if b.chroot {
+		scriptRootPath = "/"
+	}
+
+	cmd := boshsys.Command{
+		Name: "bash",
+		Args: []string{"-x", "packaging"},
+		Env: map[string]string{
+			"BOSH_COMPILE_TARGET":  filepath.Join(scriptRootPath, compileDir, pkg.Name()),
+			"BOSH_INSTALL_TARGET":  filepath.Join(scriptRootPath, packagesDir, pkg.Name()),
+			"BOSH_PACKAGE_NAME":    pkg.Name(),
+			"BOSH_PACKAGE_VERSION": pkg.Fingerprint(),
+			"BOSH_PACKAGES_DIR":    filepath.Join(scriptRootPath, packagesDir),
+		},
+		WorkingDir: hostCompilePath,
+		Stdout:     out,
+		Stderr:     out,
+	}
+
+	if b.chroot {
+		// Working directory has to be changed after chroot
+		cmd.Name = "chroot"
+		cmd.Args = []string{b.rootPath, "/bin/bash", "-c", `cd "$BOSH_COMPILE_TARGET" && exec bash -x packaging`}
+	}
+
+	_, _, _, err = b.runner.RunComplexCommand(cmd)
+	if err != nil {
+		return bosherr.WrapError(err, "Running packaging script")
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package sandbox_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	boshfu "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	birelpkg "github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	birelres "github.com/cloudfoundry/bosh-cli/v7/release/resource"
+	. "github.com/cloudfoundry/bosh-cli/v7/releasedir/sandbox"
+)
+
+var _ = Describe("Builder", func() {
+	var (
+		dirPath    string
+		srcPath    string
+		fs         boshsys.FileSystem
+		runner     boshsys.CmdRunner
+		compressor boshfu.Compressor
+		out        *bytes.Buffer
+		builder    Builder
+	)
+
+	newPackage := func(name, fp, packaging string, deps ...*birelpkg.Package) *birelpkg.Package {
+		pkgSrcPath := filepath.Join(srcPath, name+"-"+fp)
+		Expect(os.MkdirAll(pkgSrcPath, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(pkgSrcPath, "packaging"), []byte(packaging), 0644)).To(Succeed())
+
+		archivePath, err := compressor.CompressFilesInDir(pkgSrcPath, boshfu.CompressorOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		var depNames []string
+		for _, dep := range deps {
+			depNames = append(depNames, dep.Name())
+		}
+
+		pkg := birelpkg.NewPackage(birelres.NewResourceWithBuiltArchive(name, fp, archivePath, ""), depNames)
+		Expect(pkg.AttachDependencies(deps)).To(Succeed())
+
+		return pkg
+	}
+
+	readFile := func(path string) string {
+		bytes, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		return string(bytes)
+	}
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+
+		dirPath = GinkgoT().TempDir()
+		srcPath = GinkgoT().TempDir()
+		fs = boshsys.NewOsFileSystem(logger)
+		runner = boshsys.NewExecCmdRunner(logger)
+		compressor = boshfu.NewTarballCompressor(runner, fs)
+		out = &bytes.Buffer{}
+
+		builder = NewBuilder(dirPath, "", compressor, runner, fs, logger)
+	})
+
+	Describe("Build", func() {
+		var base, app *birelpkg.Package
+
+		BeforeEach(func() {
+			base = newPackage("base", "base-fp", `
+echo base > $BOSH_INSTALL_TARGET/base.txt
+ln -s base.txt $BOSH_INSTALL_TARGET/link
+`)
+
+			app = newPackage("app", "app-fp", `
+echo app-output
+cat $BOSH_PACKAGES_DIR/base/base.txt > $BOSH_INSTALL_TARGET/app.txt
+echo "$BOSH_PACKAGE_NAME $BOSH_PACKAGE_VERSION $PWD" >> $BOSH_INSTALL_TARGET/app.txt
+`, base)
+		})
+
+		It("builds dependencies before package into sandbox tree", func() {
+			results, err := builder.Build(app, out)
+			Expect(err).ToNot(HaveOccurred())
+
+			sandboxPath := filepath.Join(dirPath, ".dev_builds", "sandbox")
+
+			Expect(results).To(Equal([]Result{
+				{Name: "base", Fingerprint: "base-fp", Path: filepath.Join(sandboxPath, "var/vcap/packages/base")},
+				{Name: "app", Fingerprint: "app-fp", Path: filepath.Join(sandboxPath, "var/vcap/packages/app")},
+			}))
+
+			Expect(readFile(filepath.Join(results[1].Path, "app.txt"))).To(Equal(
+				"base\napp app-fp " + filepath.Join(sandboxPath, "var/vcap/data/compile/app") + "\n"))
+
+			target, err := os.Readlink(filepath.Join(results[0].Path, "link"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(target).To(Equal("base.txt"))
+
+			Expect(out.String()).To(ContainSubstring("app-output"))
+
+			// Sources are left for inspection
+			Expect(filepath.Join(sandboxPath, "var/vcap/data/compile/app/packaging")).To(BeAnExistingFile())
+		})
+
+		It("installs previously built packages from cache", func() {
+			_, err := builder.Build(app, out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(os.RemoveAll(filepath.Join(dirPath, ".dev_builds", "sandbox"))).To(Succeed())
+			out.Reset()
+
+			results, err := builder.Build(app, out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(results[0].Cached).To(BeTrue())
+			Expect(results[1].Cached).To(BeTrue())
+			Expect(out.String()).To(BeEmpty())
+
+			Expect(readFile(filepath.Join(results[1].Path, "app.txt"))).To(HavePrefix("base\n"))
+
+			target, err := os.Readlink(filepath.Join(results[0].Path, "link"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(target).To(Equal("base.txt"))
+		})
+
+		It("rebuilds package when its dependency changes", func() {
+			_, err := builder.Build(app, out)
+			Expect(err).ToNot(HaveOccurred())
+
+			newBase := newPackage("base", "base-fp2", "echo base2 > $BOSH_INSTALL_TARGET/base.txt")
+			newApp := newPackage("app", "app-fp", "cat $BOSH_PACKAGES_DIR/base/base.txt > $BOSH_INSTALL_TARGET/app.txt", newBase)
+
+			results, err := builder.Build(newApp, out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(results[0].Cached).To(BeFalse())
+			Expect(results[1].Cached).To(BeFalse())
+			Expect(readFile(filepath.Join(results[1].Path, "app.txt"))).To(Equal("base2\n"))
+		})
+
+		It("returns error if packaging script fails", func() {
+			broken := newPackage("broken", "broken-fp", "echo broken-output\nexit 1", base)
+
+			_, err := builder.Build(broken, out)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Building package 'broken'"))
+			Expect(err.Error()).To(ContainSubstring("Running packaging script"))
+			Expect(out.String()).To(ContainSubstring("broken-output"))
+
+			// Failed builds are not cached
+			_, err = builder.Build(broken, out)
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("when stemcell root filesystem is given", func() {
+			var (
+				rootfsPath string
+				fakeRunner *fakesys.FakeCmdRunner
+			)
+
+			writeRootfsFile := func(name, contents string) {
+				path := filepath.Join(rootfsPath, name)
+				Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+				Expect(os.WriteFile(path, []byte(contents), 0644)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				rootfsPath = GinkgoT().TempDir()
+				writeRootfsFile("etc/os-release", "ID=ubuntu\nVERSION_CODENAME=jammy\n")
+				writeRootfsFile("var/vcap/bosh/etc/stemcell_version", "1.10")
+
+				fakeRunner = fakesys.NewFakeCmdRunner()
+
+				builder = NewBuilder(dirPath, rootfsPath, compressor, fakeRunner, fs, boshlog.NewLogger(boshlog.LevelNone))
+			})
+
+			copiedRootfsPaths := func() []string {
+				paths, err := filepath.Glob(filepath.Join(dirPath, ".dev_builds", "sandbox", "rootfs-*"))
+				Expect(err).ToNot(HaveOccurred())
+				return paths
+			}
+
+			It("runs packaging scripts chrooted into copy of it with agent's paths", func() {
+				results, err := builder.Build(base, out)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(copiedRootfsPaths()).To(HaveLen(1))
+				copyPath := copiedRootfsPaths()[0]
+
+				Expect(fakeRunner.RunCommands).To(Equal([][]string{{"cp", "-a", rootfsPath + "/.", copyPath + ".tmp"}}))
+
+				Expect(results[0].Path).To(Equal(filepath.Join(copyPath, "var/vcap/packages/base")))
+				Expect(filepath.Join(copyPath, "var/vcap/data/compile/base/packaging")).To(BeAnExistingFile())
+
+				// Stemcell root filesystem is left untouched
+				Expect(filepath.Join(rootfsPath, "var/vcap/packages")).ToNot(BeADirectory())
+				Expect(filepath.Join(rootfsPath, "var/vcap/data")).ToNot(BeADirectory())
+
+				Expect(fakeRunner.RunComplexCommands).To(HaveLen(1))
+
+				cmd := fakeRunner.RunComplexCommands[0]
+				Expect(cmd.Name).To(Equal("chroot"))
+				Expect(cmd.Args).To(Equal([]string{copyPath, "/bin/bash", "-c", `cd "$BOSH_COMPILE_TARGET" && exec bash -x packaging`}))
+				Expect(cmd.Env).To(Equal(map[string]string{
+					"BOSH_COMPILE_TARGET":  "/var/vcap/data/compile/base",
+					"BOSH_INSTALL_TARGET":  "/var/vcap/packages/base",
+					"BOSH_PACKAGE_NAME":    "base",
+					"BOSH_PACKAGE_VERSION": "base-fp",
+					"BOSH_PACKAGES_DIR":    "/var/vcap/packages",
+				}))
+			})
+
+			It("reuses copy and builds while root filesystem contents are unchanged", func() {
+				_, err := builder.Build(base, out)
+				Expect(err).ToNot(HaveOccurred())
+
+				results, err := builder.Build(base, out)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(results[0].Cached).To(BeTrue())
+				Expect(fakeRunner.RunCommands).To(HaveLen(1))
+				Expect(copiedRootfsPaths()).To(HaveLen(1))
+			})
+
+			It("copies root filesystem and rebuilds packages when stemcell version changes", func() {
+				_, err := builder.Build(base, out)
+				Expect(err).ToNot(HaveOccurred())
+
+				writeRootfsFile("var/vcap/bosh/etc/stemcell_version", "1.20")
+
+				results, err := builder.Build(base, out)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(results[0].Cached).To(BeFalse())
+				Expect(fakeRunner.RunCommands).To(HaveLen(2))
+				Expect(copiedRootfsPaths()).To(HaveLen(2))
+			})
+
+			It("returns error if root filesystem has no OS release file", func() {
+				Expect(os.Remove(filepath.Join(rootfsPath, "etc/os-release"))).To(Succeed())
+
+				_, err := builder.Build(base, out)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("to contain '/etc/os-release'"))
+			})
+		})
+	})
+})
@@ -0,0 +1,118 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package sandboxfakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/release/pkg"
+	"github.com/cloudfoundry/bosh-cli/v7/releasedir/sandbox"
+)
+
+type FakeBuilder struct {
+	BuildStub        func(*pkg.Package, io.Writer) ([]sandbox.Result, error)
+	buildMutex       sync.RWMutex
+	buildArgsForCall []struct {
+		arg1 *pkg.Package
+		arg2 io.Writer
+	}
+	buildReturns struct {
+		result1 []sandbox.Result
+		result2 error
+	}
+	buildReturnsOnCall map[int]struct {
+		result1 []sandbox.Result
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuilder) Build(arg1 *pkg.Package, arg2 io.Writer) ([]sandbox.Result, error) {
+	fake.buildMutex.Lock()
+	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
+	fake.buildArgsForCall = append(fake.buildArgsForCall, struct {
+		arg1 *pkg.Package
+		arg2 io.Writer
+	}{arg1, arg2})
+	stub := fake.BuildStub
+	fakeReturns := fake.buildReturns
+	fake.recordInvocation("Build", []interface{}{arg1, arg2})
+	fake.buildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuilder) BuildCallCount() int {
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	return len(fake.buildArgsForCall)
+}
+
+func (fake *FakeBuilder) BuildCalls(stub func(*pkg.Package, io.Writer) ([]sandbox.Result, error)) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = stub
+}
+
+func (fake *FakeBuilder) BuildArgsForCall(i int) (*pkg.Package, io.Writer) {
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	argsForCall := fake.buildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuilder) BuildReturns(result1 []sandbox.Result, result2 error) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = nil
+	fake.buildReturns = struct {
+		result1 []sandbox.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuilder) BuildReturnsOnCall(i int, result1 []sandbox.Result, result2 error) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = nil
+	if fake.buildReturnsOnCall == nil {
+		fake.buildReturnsOnCall = make(map[int]struct {
+			result1 []sandbox.Result
+			result2 error
+		})
+	}
+	fake.buildReturnsOnCall[i] = struct {
+		result1 []sandbox.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuilder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBuilder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sandbox.Builder = new(FakeBuilder)
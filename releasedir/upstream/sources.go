@@ -0,0 +1,219 @@
+package upstream
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v3"
+)
+
+// VersionPlaceholder is replaced in blob paths and URLs with source version
+const VersionPlaceholder = "((version))"
+
+// Source describes where a blob comes from upstream. It is kept in
+// config/blob_sources.yml next to config/blobs.yml, keyed by source name:
+//
+//	golang:
+//	  version: 1.21.0
+//	  blob: golang/go((version)).linux-amd64.tar.gz
+//	  url: https://dl.google.com/go/go((version)).linux-amd64.tar.gz
+//	  checksum_url: https://dl.google.com/go/go((version)).linux-amd64.tar.gz.sha256
+//	  versions_url: https://go.dev/dl/
+//	  versions_regexp: go(\d+\.\d+\.\d+)\.linux-amd64\.tar\.gz
+type Source struct {
+	Name string `yaml:"-"`
+
+	Version string `yaml:"version"`
+	Blob    string `yaml:"blob"`
+	URL     string `yaml:"url"`
+
+	// Checksum is either SHA1 hex digest or prefixed with algorithm (e.g. sha256:...)
+	Checksum    string `yaml:"checksum"`
+	ChecksumURL string `yaml:"checksum_url"`
+
+	// VersionsURL page is searched with VersionsRegexp whose first
+	// capture group is a version; the highest version is the latest
+	VersionsURL    string `yaml:"versions_url"`
+	VersionsRegexp string `yaml:"versions_regexp"`
+
+	// versionLine and versionColumn locate version value in sources file
+	versionLine   int
+	versionColumn int
+}
+
+func (s Source) BlobPath(version string) string {
+	return strings.ReplaceAll(s.Blob, VersionPlaceholder, version)
+}
+
+func (s Source) BlobURL(version string) string {
+	return strings.ReplaceAll(s.URL, VersionPlaceholder, version)
+}
+
+func (s Source) ChecksumURLFor(version string) string {
+	return strings.ReplaceAll(s.ChecksumURL, VersionPlaceholder, version)
+}
+
+// MatchesBlobPath returns true if blob path is rendered from source's blob path with any version
+func (s Source) MatchesBlobPath(path string) bool {
+	parts := strings.Split(s.Blob, VersionPlaceholder)
+
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	re, err := regexp.Compile("^" + strings.Join(parts, `[^/]+`) + "$")
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(path)
+}
+
+func (s Source) validate() error {
+	var errs []error
+
+	if len(s.Version) == 0 {
+		errs = append(errs, bosherr.Error("Missing 'version'"))
+	}
+
+	if len(s.Blob) == 0 {
+		errs = append(errs, bosherr.Error("Missing 'blob'"))
+	} else if !strings.Contains(s.Blob, VersionPlaceholder) {
+		errs = append(errs, bosherr.Errorf("Expected 'blob' to include '%s'", VersionPlaceholder))
+	}
+
+	if len(s.URL) == 0 {
+		errs = append(errs, bosherr.Error("Missing 'url'"))
+	}
+
+	if len(s.Checksum) == 0 && len(s.ChecksumURL) == 0 {
+		errs = append(errs, bosherr.Error("Expected 'checksum' or 'checksum_url' to be specified"))
+	} else if len(s.Checksum) > 0 && len(s.ChecksumURL) > 0 {
+		errs = append(errs, bosherr.Error("Expected only one of 'checksum' or 'checksum_url' to be specified"))
+	}
+
+	if len(s.VersionsURL) > 0 {
+		if len(s.Checksum) > 0 {
+			// Checksum value only applies to a single version
+			errs = append(errs, bosherr.Error("Expected 'checksum_url' instead of 'checksum' when 'versions_url' is specified"))
+		}
+
+		if len(s.VersionsRegexp) == 0 {
+			errs = append(errs, bosherr.Error("Expected 'versions_regexp' when 'versions_url' is specified"))
+		} else if re, err := regexp.Compile(s.VersionsRegexp); err != nil {
+			errs = append(errs, bosherr.WrapError(err, "Compiling 'versions_regexp'"))
+		} else if re.NumSubexp() < 1 {
+			errs = append(errs, bosherr.Error("Expected 'versions_regexp' to have a capture group for version"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return bosherr.NewMultiError(errs...)
+	}
+
+	return nil
+}
+
+// Sources represents blob sources file of a release directory
+type Sources struct {
+	path string
+	fs   boshsys.FileSystem
+}
+
+func NewSources(path string, fs boshsys.FileSystem) Sources {
+	return Sources{path: path, fs: fs}
+}
+
+func (s Sources) Path() string { return s.path }
+
+// List returns sources sorted by name
+func (s Sources) List() ([]Source, error) {
+	if !s.fs.FileExists(s.path) {
+		return nil, bosherr.Errorf("Expected blob sources file '%s' to exist", s.path)
+	}
+
+	bytes, err := s.fs.ReadFile(s.path)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Reading blob sources '%s'", s.path)
+	}
+
+	var doc yaml.Node
+
+	err = yaml.Unmarshal(bytes, &doc)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Unmarshalling blob sources '%s'", s.path)
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, bosherr.Errorf("Expected blob sources '%s' to be a map of source names", s.path)
+	}
+
+	var sources []Source
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		var source Source
+
+		err := root.Content[i+1].Decode(&source)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Unmarshalling blob source '%s'", root.Content[i].Value)
+		}
+
+		source.Name = root.Content[i].Value
+
+		for j := 0; j+1 < len(root.Content[i+1].Content); j += 2 {
+			if root.Content[i+1].Content[j].Value == "version" {
+				source.versionLine = root.Content[i+1].Content[j+1].Line
+				source.versionColumn = root.Content[i+1].Content[j+1].Column
+			}
+		}
+
+		err = source.validate()
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Validating blob source '%s'", source.Name)
+		}
+
+		sources = append(sources, source)
+	}
+
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+
+	return sources, nil
+}
+
+// SetVersion changes version of a source in place keeping the rest of the file intact
+func (s Sources) SetVersion(source Source, version string) error {
+	bytes, err := s.fs.ReadFile(s.path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading blob sources '%s'", s.path)
+	}
+
+	lines := strings.Split(string(bytes), "\n")
+
+	if source.versionLine < 1 || source.versionLine > len(lines) {
+		return bosherr.Errorf("Locating version of blob source '%s'", source.Name)
+	}
+
+	line := lines[source.versionLine-1]
+	col := source.versionColumn - 1
+
+	if col < 0 || col > len(line) || !strings.Contains(line[col:], source.Version) {
+		return bosherr.Errorf("Locating version of blob source '%s'", source.Name)
+	}
+
+	lines[source.versionLine-1] = line[:col] + strings.Replace(line[col:], source.Version, version, 1)
+
+	err = s.fs.WriteFileString(s.path, strings.Join(lines, "\n"))
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing blob sources '%s'", s.path)
+	}
+
+	return nil
+}
@@ -0,0 +1,411 @@
+package upstream
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	semver "github.com/cppforlife/go-semi-semantic/version"
+	"gopkg.in/yaml.v3"
+
+	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . Updater
+
+// Updater brings blobs of a release directory up to date with their upstream sources
+type Updater interface {
+	Check() ([]Status, error)
+	Update(Status) (Result, error)
+}
+
+type HTTPClient interface {
+	Get(endpoint string) (*http.Response, error)
+}
+
+type Status struct {
+	Source Source
+
+	CurrentVersion string
+	LatestVersion  string
+
+	// Path is blob path of the latest version and Tracked is true if it is in blobs.yml
+	Path    string
+	Tracked bool
+
+	// StalePaths are tracked blobs of other versions of the same source
+	StalePaths []string
+}
+
+func (s Status) UpToDate() bool {
+	return s.Tracked && s.CurrentVersion == s.LatestVersion && len(s.StalePaths) == 0
+}
+
+type Result struct {
+	Blob boshreldir.Blob
+
+	RemovedPaths []string
+
+	// SpecPaths are package specs whose file references were rewritten
+	SpecPaths []string
+}
+
+type updater struct {
+	dirPath    string
+	sources    Sources
+	blobsDir   boshreldir.BlobsDir
+	httpClient HTTPClient
+	fs         boshsys.FileSystem
+}
+
+func NewUpdater(
+	dirPath string,
+	blobsDir boshreldir.BlobsDir,
+	httpClient HTTPClient,
+	fs boshsys.FileSystem,
+) Updater {
+	return updater{
+		dirPath:    dirPath,
+		sources:    NewSources(filepath.Join(dirPath, "config", "blob_sources.yml"), fs),
+		blobsDir:   blobsDir,
+		httpClient: httpClient,
+		fs:         fs,
+	}
+}
+
+func (u updater) Check() ([]Status, error) {
+	sources, err := u.sources.List()
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, err := u.blobsDir.Blobs()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+
+	for _, source := range sources {
+		latest, err := u.latestVersion(source)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Finding latest version of blob source '%s'", source.Name)
+		}
+
+		status := Status{
+			Source:         source,
+			CurrentVersion: source.Version,
+			LatestVersion:  latest,
+			Path:           source.BlobPath(latest),
+		}
+
+		for _, blob := range blobs {
+			if blob.Path == status.Path {
+				status.Tracked = true
+			} else if source.MatchesBlobPath(blob.Path) {
+				status.StalePaths = append(status.StalePaths, blob.Path)
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (u updater) Update(status Status) (Result, error) {
+	source := status.Source
+
+	result := Result{RemovedPaths: status.StalePaths}
+
+	if !status.Tracked {
+		blob, err := u.download(source, status.LatestVersion, status.Path)
+		if err != nil {
+			return Result{}, bosherr.WrapErrorf(err, "Downloading blob '%s'", status.Path)
+		}
+
+		result.Blob = blob
+	}
+
+	for _, stalePath := range status.StalePaths {
+		err := u.blobsDir.UntrackBlob(stalePath)
+		if err != nil {
+			return Result{}, bosherr.WrapErrorf(err, "Removing blob '%s'", stalePath)
+		}
+	}
+
+	specPaths, err := u.rewriteSpecs(status.StalePaths, status.Path)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result.SpecPaths = specPaths
+
+	if status.CurrentVersion != status.LatestVersion {
+		err = u.sources.SetVersion(source, status.LatestVersion)
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// latestVersion returns the highest of current and upstream versions
+func (u updater) latestVersion(source Source) (string, error) {
+	if len(source.VersionsURL) == 0 {
+		return source.Version, nil
+	}
+
+	latestStr := source.Version
+
+	latest, err := semver.NewVersionFromString(latestStr)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Parsing current version")
+	}
+
+	body, err := u.get(source.VersionsURL)
+	if err != nil {
+		return "", err
+	}
+
+	// Matches that do not look like versions are skipped
+	re := regexp.MustCompile(source.VersionsRegexp)
+
+	for _, match := range re.FindAllStringSubmatch(string(body), -1) {
+		ver, err := semver.NewVersionFromString(match[1])
+		if err == nil && ver.IsGt(latest) {
+			latest, latestStr = ver, match[1]
+		}
+	}
+
+	return latestStr, nil
+}
+
+func (u updater) download(source Source, version, blobPath string) (boshreldir.Blob, error) {
+	digest, err := u.expectedDigest(source, version)
+	if err != nil {
+		return boshreldir.Blob{}, err
+	}
+
+	url := source.BlobURL(version)
+
+	resp, err := u.httpClient.Get(url)
+	if err != nil {
+		return boshreldir.Blob{}, bosherr.WrapErrorf(err, "Requesting '%s'", url)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return boshreldir.Blob{}, bosherr.Errorf("Requesting '%s': %s", url, resp.Status)
+	}
+
+	tempFile, err := u.fs.TempFile("update-blob")
+	if err != nil {
+		return boshreldir.Blob{}, bosherr.WrapError(err, "Creating temp blob")
+	}
+
+	defer u.fs.RemoveAll(tempFile.Name()) //nolint:errcheck
+
+	_, err = io.Copy(tempFile, resp.Body)
+	if err != nil {
+		tempFile.Close() //nolint:errcheck
+		return boshreldir.Blob{}, bosherr.WrapErrorf(err, "Saving '%s'", url)
+	}
+
+	tempFile.Close() //nolint:errcheck
+
+	err = digest.VerifyFilePath(tempFile.Name(), u.fs)
+	if err != nil {
+		return boshreldir.Blob{}, bosherr.WrapErrorf(err, "Verifying checksum of '%s'", url)
+	}
+
+	file, err := u.fs.OpenFile(tempFile.Name(), os.O_RDONLY, 0)
+	if err != nil {
+		return boshreldir.Blob{}, bosherr.WrapError(err, "Opening temp blob")
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	return u.blobsDir.TrackBlob(blobPath, file)
+}
+
+func (u updater) expectedDigest(source Source, version string) (boshcrypto.MultipleDigest, error) {
+	checksum := source.Checksum
+
+	if len(source.ChecksumURL) > 0 {
+		body, err := u.get(source.ChecksumURLFor(version))
+		if err != nil {
+			return boshcrypto.MultipleDigest{}, err
+		}
+
+		checksum, err = parseChecksumFile(string(body), path.Base(source.BlobURL(version)))
+		if err != nil {
+			return boshcrypto.MultipleDigest{}, bosherr.WrapErrorf(err, "Parsing checksum from '%s'", source.ChecksumURLFor(version))
+		}
+	}
+
+	digest, err := boshcrypto.ParseMultipleDigest(checksum)
+	if err != nil {
+		return boshcrypto.MultipleDigest{}, bosherr.WrapErrorf(err, "Parsing checksum '%s'", checksum)
+	}
+
+	return digest, nil
+}
+
+func (u updater) get(url string) ([]byte, error) {
+	resp, err := u.httpClient.Get(url)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Requesting '%s'", url)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, bosherr.Errorf("Requesting '%s': %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Reading '%s'", url)
+	}
+
+	return body, nil
+}
+
+// parseChecksumFile accepts either a bare hex digest or sha*sum output listing file names
+func parseChecksumFile(contents, fileName string) (string, error) {
+	var hex string
+
+	lines := strings.FieldsFunc(contents, func(r rune) bool { return r == '\n' || r == '\r' })
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 1 && len(lines) == 1:
+			hex = fields[0]
+		case len(fields) >= 2 && strings.TrimPrefix(fields[len(fields)-1], "*") == fileName:
+			hex = fields[0]
+		}
+
+		if len(hex) > 0 {
+			break
+		}
+	}
+
+	switch len(hex) {
+	case 40:
+		return hex, nil
+	case 64:
+		return fmt.Sprintf("%s:%s", boshcrypto.DigestAlgorithmSHA256.Name(), hex), nil
+	case 128:
+		return fmt.Sprintf("%s:%s", boshcrypto.DigestAlgorithmSHA512.Name(), hex), nil
+	case 0:
+		return "", bosherr.Errorf("Expected to find checksum for '%s'", fileName)
+	default:
+		return "", bosherr.Errorf("Expected checksum '%s' to be SHA1, SHA256 or SHA512 hex digest", hex)
+	}
+}
+
+// rewriteSpecs replaces stale blob paths listed in package spec files with new path
+func (u updater) rewriteSpecs(stalePaths []string, newPath string) ([]string, error) {
+	if len(stalePaths) == 0 {
+		return nil, nil
+	}
+
+	stale := map[string]struct{}{}
+	for _, p := range stalePaths {
+		stale[p] = struct{}{}
+	}
+
+	specPaths, err := u.fs.Glob(filepath.Join(u.dirPath, "packages", "*", "spec"))
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Listing package specs")
+	}
+
+	var rewritten []string
+
+	for _, specPath := range specPaths {
+		changed, err := u.rewriteSpec(specPath, stale, newPath)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Rewriting package spec '%s'", specPath)
+		}
+
+		if changed {
+			relPath, err := filepath.Rel(u.dirPath, specPath)
+			if err != nil {
+				relPath = specPath
+			}
+			rewritten = append(rewritten, filepath.ToSlash(relPath))
+		}
+	}
+
+	return rewritten, nil
+}
+
+// rewriteSpec only touches lines of matching 'files' entries to keep formatting and comments
+func (u updater) rewriteSpec(specPath string, stale map[string]struct{}, newPath string) (bool, error) {
+	bytes, err := u.fs.ReadFile(specPath)
+	if err != nil {
+		return false, err
+	}
+
+	var doc yaml.Node
+
+	err = yaml.Unmarshal(bytes, &doc)
+	if err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		// Invalid specs are reported when release is created
+		return false, nil
+	}
+
+	var filesNode *yaml.Node
+
+	root := doc.Content[0]
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "files" {
+			filesNode = root.Content[i+1]
+		}
+	}
+
+	if filesNode == nil || filesNode.Kind != yaml.SequenceNode {
+		return false, nil
+	}
+
+	lines := strings.Split(string(bytes), "\n")
+	changed := false
+
+	for _, item := range filesNode.Content {
+		if _, found := stale[item.Value]; !found || item.Line < 1 || item.Line > len(lines) {
+			continue
+		}
+
+		line := lines[item.Line-1]
+		col := item.Column - 1
+
+		if col < 0 || col > len(line) {
+			continue
+		}
+
+		lines[item.Line-1] = line[:col] + strings.Replace(line[col:], item.Value, newPath, 1)
+		changed = true
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, u.fs.WriteFileString(specPath, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,258 @@
+package upstream_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	boshreldir "github.com/cloudfoundry/bosh-cli/v7/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/v7/releasedir/releasedirfakes"
+	. "github.com/cloudfoundry/bosh-cli/v7/releasedir/upstream"
+)
+
+var _ = Describe("Updater", func() {
+	var (
+		server   *ghttp.Server
+		dirPath  string
+		fs       boshsys.FileSystem
+		blobsDir *fakereldir.FakeBlobsDir
+		updater  Updater
+	)
+
+	writeFile := func(path, contents string) {
+		path = filepath.Join(dirPath, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(contents), 0644)).To(Succeed())
+	}
+
+	readFile := func(path string) string {
+		bytes, err := os.ReadFile(filepath.Join(dirPath, path))
+		Expect(err).ToNot(HaveOccurred())
+		return string(bytes)
+	}
+
+	sha256Hex := func(s string) string {
+		return fmt.Sprintf("%x", sha256.Sum256([]byte(s)))
+	}
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		server.SetAllowUnhandledRequests(true)
+		server.SetUnhandledRequestStatusCode(http.StatusNotFound)
+
+		dirPath = GinkgoT().TempDir()
+		fs = boshsys.NewOsFileSystem(boshlog.NewLogger(boshlog.LevelNone))
+		blobsDir = &fakereldir.FakeBlobsDir{}
+
+		updater = NewUpdater(dirPath, blobsDir, http.DefaultClient, fs)
+
+		writeFile("config/blob_sources.yml", fmt.Sprintf(`---
+# Go toolchain
+golang:
+  version: "1.2.0" # pinned by update-blobs
+  blob: golang/go((version)).tar.gz
+  url: %[1]s/dl/go((version)).tar.gz
+  checksum_url: %[1]s/dl/go((version)).tar.gz.sha256
+  versions_url: %[1]s/dl/
+  versions_regexp: go(\d+\.\d+\.\d+)\.tar\.gz
+`, server.URL()))
+
+		writeFile("packages/golang/spec", `---
+name: golang
+files:
+# toolchain
+- golang/go1.2.0.tar.gz
+- golang/other.tar.gz
+`)
+
+		server.RouteToHandler("GET", "/dl/", ghttp.RespondWith(http.StatusOK,
+			`<a href="go1.10.0.tar.gz">go1.10.0.tar.gz</a> <a href="go1.9.1.tar.gz">go1.9.1.tar.gz</a> go1.1.0.tar.gz go-rc.tar.gz`))
+
+		server.RouteToHandler("GET", "/dl/go1.10.0.tar.gz", ghttp.RespondWith(http.StatusOK, "new-tarball"))
+		server.RouteToHandler("GET", "/dl/go1.10.0.tar.gz.sha256", ghttp.RespondWith(http.StatusOK,
+			fmt.Sprintf("%s  go1.10.0.linux.tar.gz\n%s *go1.10.0.tar.gz\n", sha256Hex("other"), sha256Hex("new-tarball"))))
+
+		blobsDir.BlobsReturns([]boshreldir.Blob{
+			{Path: "golang/go1.2.0.tar.gz"},
+			{Path: "golang/other.tar.gz"},
+		}, nil)
+
+		blobsDir.TrackBlobStub = func(path string, src io.ReadCloser) (boshreldir.Blob, error) {
+			bytes, err := io.ReadAll(src)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(bytes)).To(Equal("new-tarball"))
+			return boshreldir.Blob{Path: path, Size: int64(len(bytes))}, nil
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Check", func() {
+		It("returns latest upstream version and stale blobs of each source", func() {
+			statuses, err := updater.Check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statuses).To(HaveLen(1))
+
+			status := statuses[0]
+			Expect(status.Source.Name).To(Equal("golang"))
+			Expect(status.CurrentVersion).To(Equal("1.2.0"))
+			Expect(status.LatestVersion).To(Equal("1.10.0"))
+			Expect(status.Path).To(Equal("golang/go1.10.0.tar.gz"))
+			Expect(status.Tracked).To(BeFalse())
+			Expect(status.StalePaths).To(Equal([]string{"golang/go1.2.0.tar.gz"}))
+			Expect(status.UpToDate()).To(BeFalse())
+		})
+
+		It("reports source as up to date when latest version is tracked", func() {
+			blobsDir.BlobsReturns([]boshreldir.Blob{{Path: "golang/go1.2.0.tar.gz"}}, nil)
+
+			server.RouteToHandler("GET", "/dl/", ghttp.RespondWith(http.StatusOK, "go1.1.0.tar.gz go1.2.0.tar.gz"))
+
+			statuses, err := updater.Check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statuses[0].LatestVersion).To(Equal("1.2.0"))
+			Expect(statuses[0].UpToDate()).To(BeTrue())
+		})
+
+		It("uses pinned version when source does not discover versions", func() {
+			writeFile("config/blob_sources.yml", fmt.Sprintf(`---
+golang:
+  version: 1.3.0
+  blob: golang/go((version)).tar.gz
+  url: %s/dl/go((version)).tar.gz
+  checksum: sha256:abc
+`, server.URL()))
+
+			statuses, err := updater.Check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statuses[0].LatestVersion).To(Equal("1.3.0"))
+			Expect(statuses[0].Path).To(Equal("golang/go1.3.0.tar.gz"))
+			Expect(statuses[0].StalePaths).To(Equal([]string{"golang/go1.2.0.tar.gz"}))
+		})
+
+		It("returns error if source is invalid", func() {
+			writeFile("config/blob_sources.yml", `---
+golang:
+  version: 1.3.0
+  blob: golang/go.tar.gz
+  url: https://example.com/go.tar.gz
+  checksum: sha256:abc
+  versions_url: https://example.com/
+`)
+
+			_, err := updater.Check()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Validating blob source 'golang'"))
+			Expect(err.Error()).To(ContainSubstring("Expected 'blob' to include '((version))'"))
+			Expect(err.Error()).To(ContainSubstring("Expected 'checksum_url' instead of 'checksum'"))
+			Expect(err.Error()).To(ContainSubstring("Expected 'versions_regexp'"))
+		})
+
+		It("returns error if sources file is missing", func() {
+			Expect(os.Remove(filepath.Join(dirPath, "config", "blob_sources.yml"))).To(Succeed())
+
+			_, err := updater.Check()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected blob sources file"))
+		})
+
+		It("returns error if versions cannot be fetched", func() {
+			server.RouteToHandler("GET", "/dl/", ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+			_, err := updater.Check()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Finding latest version of blob source 'golang'"))
+			Expect(err.Error()).To(ContainSubstring("500"))
+		})
+	})
+
+	Describe("Update", func() {
+		It("tracks new blob, untracks stale blobs and rewrites package specs and version", func() {
+			statuses, err := updater.Check()
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := updater.Update(statuses[0])
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(result.Blob).To(Equal(boshreldir.Blob{Path: "golang/go1.10.0.tar.gz", Size: 11}))
+			Expect(result.RemovedPaths).To(Equal([]string{"golang/go1.2.0.tar.gz"}))
+			Expect(result.SpecPaths).To(Equal([]string{"packages/golang/spec"}))
+
+			Expect(blobsDir.TrackBlobCallCount()).To(Equal(1))
+			Expect(blobsDir.UntrackBlobCallCount()).To(Equal(1))
+			Expect(blobsDir.UntrackBlobArgsForCall(0)).To(Equal("golang/go1.2.0.tar.gz"))
+
+			Expect(readFile("packages/golang/spec")).To(Equal(`---
+name: golang
+files:
+# toolchain
+- golang/go1.10.0.tar.gz
+- golang/other.tar.gz
+`))
+
+			Expect(readFile("config/blob_sources.yml")).To(ContainSubstring(`
+# Go toolchain
+golang:
+  version: "1.10.0" # pinned by update-blobs
+  blob: golang/go((version)).tar.gz
+`))
+		})
+
+		It("verifies checksum value from sources file", func() {
+			writeFile("config/blob_sources.yml", fmt.Sprintf(`---
+golang:
+  version: 1.10.0
+  blob: golang/go((version)).tar.gz
+  url: %s/dl/go((version)).tar.gz
+  checksum: sha256:%s
+`, server.URL(), sha256Hex("new-tarball")))
+
+			statuses, err := updater.Check()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = updater.Update(statuses[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(blobsDir.TrackBlobCallCount()).To(Equal(1))
+			Expect(readFile("config/blob_sources.yml")).To(ContainSubstring("version: 1.10.0"))
+		})
+
+		It("returns error without changing release if checksum does not match", func() {
+			server.RouteToHandler("GET", "/dl/go1.10.0.tar.gz", ghttp.RespondWith(http.StatusOK, "tampered"))
+
+			statuses, err := updater.Check()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = updater.Update(statuses[0])
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Verifying checksum"))
+
+			Expect(blobsDir.TrackBlobCallCount()).To(Equal(0))
+			Expect(blobsDir.UntrackBlobCallCount()).To(Equal(0))
+			Expect(readFile("packages/golang/spec")).To(ContainSubstring("golang/go1.2.0.tar.gz"))
+			Expect(readFile("config/blob_sources.yml")).To(ContainSubstring(`version: "1.2.0"`))
+		})
+
+		It("returns error if checksum file does not list blob", func() {
+			server.RouteToHandler("GET", "/dl/go1.10.0.tar.gz.sha256", ghttp.RespondWith(http.StatusOK,
+				fmt.Sprintf("%s  go1.10.0.linux.tar.gz\n", sha256Hex("other"))))
+
+			statuses, err := updater.Check()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = updater.Update(statuses[0])
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected to find checksum for 'go1.10.0.tar.gz'"))
+		})
+	})
+})
@@ -0,0 +1,182 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package upstreamfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/v7/releasedir/upstream"
+)
+
+type FakeUpdater struct {
+	CheckStub        func() ([]upstream.Status, error)
+	checkMutex       sync.RWMutex
+	checkArgsForCall []struct {
+	}
+	checkReturns struct {
+		result1 []upstream.Status
+		result2 error
+	}
+	checkReturnsOnCall map[int]struct {
+		result1 []upstream.Status
+		result2 error
+	}
+	UpdateStub        func(upstream.Status) (upstream.Result, error)
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		arg1 upstream.Status
+	}
+	updateReturns struct {
+		result1 upstream.Result
+		result2 error
+	}
+	updateReturnsOnCall map[int]struct {
+		result1 upstream.Result
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUpdater) Check() ([]upstream.Status, error) {
+	fake.checkMutex.Lock()
+	ret, specificReturn := fake.checkReturnsOnCall[len(fake.checkArgsForCall)]
+	fake.checkArgsForCall = append(fake.checkArgsForCall, struct {
+	}{})
+	stub := fake.CheckStub
+	fakeReturns := fake.checkReturns
+	fake.recordInvocation("Check", []interface{}{})
+	fake.checkMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUpdater) CheckCallCount() int {
+	fake.checkMutex.RLock()
+	defer fake.checkMutex.RUnlock()
+	return len(fake.checkArgsForCall)
+}
+
+func (fake *FakeUpdater) CheckCalls(stub func() ([]upstream.Status, error)) {
+	fake.checkMutex.Lock()
+	defer fake.checkMutex.Unlock()
+	fake.CheckStub = stub
+}
+
+func (fake *FakeUpdater) CheckReturns(result1 []upstream.Status, result2 error) {
+	fake.checkMutex.Lock()
+	defer fake.checkMutex.Unlock()
+	fake.CheckStub = nil
+	fake.checkReturns = struct {
+		result1 []upstream.Status
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpdater) CheckReturnsOnCall(i int, result1 []upstream.Status, result2 error) {
+	fake.checkMutex.Lock()
+	defer fake.checkMutex.Unlock()
+	fake.CheckStub = nil
+	if fake.checkReturnsOnCall == nil {
+		fake.checkReturnsOnCall = make(map[int]struct {
+			result1 []upstream.Status
+			result2 error
+		})
+	}
+	fake.checkReturnsOnCall[i] = struct {
+		result1 []upstream.Status
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpdater) Update(arg1 upstream.Status) (upstream.Result, error) {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		arg1 upstream.Status
+	}{arg1})
+	stub := fake.UpdateStub
+	fakeReturns := fake.updateReturns
+	fake.recordInvocation("Update", []interface{}{arg1})
+	fake.updateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUpdater) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *FakeUpdater) UpdateCalls(stub func(upstream.Status) (upstream.Result, error)) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = stub
+}
+
+func (fake *FakeUpdater) UpdateArgsForCall(i int) upstream.Status {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	argsForCall := fake.updateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUpdater) UpdateReturns(result1 upstream.Result, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 upstream.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpdater) UpdateReturnsOnCall(i int, result1 upstream.Result, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 upstream.Result
+			result2 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 upstream.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpdater) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeUpdater) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ upstream.Updater = new(FakeUpdater)
@@ -0,0 +1,261 @@
+package ssh
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/fatih/color"
+
+	boshlogs "github.com/cloudfoundry/bosh-cli/v7/logs"
+	boshui "github.com/cloudfoundry/bosh-cli/v7/ui"
+)
+
+const defaultMergeWindow = 1 * time.Second
+
+var mergedPrefixColors = []color.Attribute{
+	color.FgCyan, color.FgGreen, color.FgYellow, color.FgBlue, color.FgMagenta, color.FgRed,
+}
+
+type MergingWriterOpts struct {
+	// Grep drops lines that do not match (optional)
+	Grep *regexp.Regexp
+
+	// Since drops lines with timestamps older than now-Since (optional)
+	Since time.Duration
+
+	// JSONLines prints every line as a JSON object instead of prefixed text
+	JSONLines bool
+
+	// Window is how long lines are held back to be ordered by timestamp
+	Window time.Duration
+}
+
+type MergedLine struct {
+	Time     time.Time `json:"time"`
+	Instance string    `json:"instance"`
+	Group    string    `json:"group"`
+	ID       string    `json:"id"`
+	Stream   string    `json:"stream"`
+	Line     string    `json:"line"`
+
+	arrivedAt time.Time
+}
+
+// MergingWriter interleaves lines from multiple instances by their
+// timestamps before printing them with an instance prefix.
+type MergingWriter struct {
+	ui          boshui.UI
+	timeService clock.Clock
+	opts        MergingWriterOpts
+	since       time.Time
+
+	lock      sync.Mutex
+	pending   []MergedLine
+	lastTimes map[string]time.Time
+	prefixes  map[string]func(a ...interface{}) string
+
+	started bool
+	stopCh  chan struct{}
+}
+
+func NewMergingWriter(ui boshui.UI, timeService clock.Clock, opts MergingWriterOpts) *MergingWriter {
+	if opts.Window == 0 {
+		opts.Window = defaultMergeWindow
+	}
+
+	var since time.Time
+
+	if opts.Since > 0 {
+		since = timeService.Now().Add(-opts.Since)
+	}
+
+	return &MergingWriter{
+		ui:          ui,
+		timeService: timeService,
+		opts:        opts,
+		since:       since,
+
+		lastTimes: map[string]time.Time{},
+		prefixes:  map[string]func(a ...interface{}) string{},
+	}
+}
+
+func (w *MergingWriter) ForInstance(jobName, indexOrID string) InstanceWriter {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	instance := fmt.Sprintf("%s/%s", jobName, indexOrID)
+
+	if _, found := w.prefixes[instance]; !found {
+		attr := mergedPrefixColors[len(w.prefixes)%len(mergedPrefixColors)]
+		w.prefixes[instance] = color.New(attr).SprintFunc()
+	}
+
+	if !w.started {
+		w.started = true
+		w.stopCh = make(chan struct{})
+		go w.emitPeriodically(w.stopCh)
+	}
+
+	newStream := func(stream string) *mergingStreamWriter {
+		return &mergingStreamWriter{w: w, group: jobName, id: indexOrID, stream: stream}
+	}
+
+	return mergingInstanceWriter{stdout: newStream("stdout"), stderr: newStream("stderr")}
+}
+
+func (w *MergingWriter) Flush() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.started {
+		w.started = false
+		close(w.stopCh)
+	}
+
+	w.emit(func(MergedLine) bool { return true })
+}
+
+func (w *MergingWriter) emitPeriodically(stopCh chan struct{}) {
+	ticker := w.timeService.NewTicker(w.opts.Window / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			w.lock.Lock()
+			cutoff := w.timeService.Now().Add(-w.opts.Window)
+			w.emit(func(l MergedLine) bool { return !l.arrivedAt.After(cutoff) })
+			w.lock.Unlock()
+
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+func (w *MergingWriter) add(group, id, stream, text string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	instance := fmt.Sprintf("%s/%s", group, id)
+	now := w.timeService.Now()
+
+	ts, found := boshlogs.ParseTimestamp(text)
+	if found {
+		w.lastTimes[instance] = ts
+	} else if lastTime, found := w.lastTimes[instance]; found {
+		// Continuation lines (e.g. stack traces) stay with their preceding line
+		ts = lastTime
+	} else {
+		ts = now
+	}
+
+	if !w.since.IsZero() && ts.Before(w.since) {
+		return
+	}
+
+	if w.opts.Grep != nil && !w.opts.Grep.MatchString(text) {
+		return
+	}
+
+	w.pending = append(w.pending, MergedLine{
+		Time:     ts,
+		Instance: instance,
+		Group:    group,
+		ID:       id,
+		Stream:   stream,
+		Line:     text,
+
+		arrivedAt: now,
+	})
+}
+
+// emit prints pending lines in timestamp order up to the first line that is not yet ready
+func (w *MergingWriter) emit(ready func(MergedLine) bool) {
+	sort.SliceStable(w.pending, func(i, j int) bool {
+		return w.pending[i].Time.Before(w.pending[j].Time)
+	})
+
+	var n int
+
+	for n < len(w.pending) && ready(w.pending[n]) {
+		w.print(w.pending[n])
+		n++
+	}
+
+	w.pending = w.pending[n:]
+}
+
+func (w *MergingWriter) print(line MergedLine) {
+	if w.opts.JSONLines {
+		bytes, err := json.Marshal(line)
+		if err != nil {
+			return
+		}
+		w.ui.PrintBlock(append(bytes, '\n'))
+		return
+	}
+
+	w.ui.PrintBlock([]byte(fmt.Sprintf("%s | %s\n", w.prefixes[line.Instance](line.Instance), line.Line)))
+}
+
+type mergingInstanceWriter struct {
+	stdout *mergingStreamWriter
+	stderr *mergingStreamWriter
+}
+
+func (w mergingInstanceWriter) Stdout() io.Writer { return w.stdout }
+func (w mergingInstanceWriter) Stderr() io.Writer { return w.stderr }
+
+func (w mergingInstanceWriter) End(exitStatus int, err error) {
+	w.stdout.flushPartial()
+	w.stderr.flushPartial()
+}
+
+type mergingStreamWriter struct {
+	w      *MergingWriter
+	group  string
+	id     string
+	stream string
+
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (s *mergingStreamWriter) Write(p []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.buf.Write(p)
+
+	for {
+		idx := bytes.IndexByte(s.buf.Bytes(), '\n')
+		if idx < 0 {
+			break
+		}
+
+		line := string(s.buf.Next(idx + 1))
+		s.w.add(s.group, s.id, s.stream, strings.TrimRight(line, "\r\n"))
+	}
+
+	return len(p), nil
+}
+
+func (s *mergingStreamWriter) flushPartial() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.buf.Len() > 0 {
+		s.w.add(s.group, s.id, s.stream, strings.TrimRight(s.buf.String(), "\r"))
+		s.buf.Reset()
+	}
+}
@@ -0,0 +1,126 @@
+package ssh_test
+
+import (
+	"regexp"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/v7/ssh"
+	fakeui "github.com/cloudfoundry/bosh-cli/v7/ui/fakes"
+)
+
+var _ = Describe("MergingWriter", func() {
+	var (
+		ui          *fakeui.FakeUI
+		timeService *fakeclock.FakeClock
+		opts        MergingWriterOpts
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		timeService = fakeclock.NewFakeClock(time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC))
+		opts = MergingWriterOpts{}
+	})
+
+	write := func(w InstanceWriter, lines string) {
+		_, err := w.Stdout().Write([]byte(lines))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("interleaves lines from multiple instances by timestamp", func() {
+		writer := NewMergingWriter(ui, timeService, opts)
+		inst1 := writer.ForInstance("web", "0")
+		inst2 := writer.ForInstance("worker", "1")
+
+		write(inst1, "2024-01-02T14:59:01Z first\n2024-01-02T14:59:03Z third\n")
+		write(inst2, "[2024-01-02 14:59:02 #1] second\n2024-01-02_14:59:04.5 fourth\n")
+		writer.Flush()
+
+		Expect(ui.Blocks).To(Equal([]string{
+			"web/0 | 2024-01-02T14:59:01Z first\n",
+			"worker/1 | [2024-01-02 14:59:02 #1] second\n",
+			"web/0 | 2024-01-02T14:59:03Z third\n",
+			"worker/1 | 2024-01-02_14:59:04.5 fourth\n",
+		}))
+	})
+
+	It("keeps lines without timestamps after their preceding line", func() {
+		writer := NewMergingWriter(ui, timeService, opts)
+		inst1 := writer.ForInstance("web", "0")
+		inst2 := writer.ForInstance("worker", "1")
+
+		write(inst1, "2024-01-02T14:59:01Z panic\n  stack frame\n")
+		write(inst2, "2024-01-02T14:59:00Z before\n2024-01-02T14:59:02Z after\n")
+		writer.Flush()
+
+		Expect(ui.Blocks).To(Equal([]string{
+			"worker/1 | 2024-01-02T14:59:00Z before\n",
+			"web/0 | 2024-01-02T14:59:01Z panic\n",
+			"web/0 |   stack frame\n",
+			"worker/1 | 2024-01-02T14:59:02Z after\n",
+		}))
+	})
+
+	It("buffers partial lines until they are complete or instance ends", func() {
+		writer := NewMergingWriter(ui, timeService, opts)
+		inst := writer.ForInstance("web", "0")
+
+		write(inst, "2024-01-02T14:59:01Z par")
+		write(inst, "tial\n2024-01-02T14:59:02Z unterminated")
+		inst.End(0, nil)
+		writer.Flush()
+
+		Expect(ui.Blocks).To(Equal([]string{
+			"web/0 | 2024-01-02T14:59:01Z partial\n",
+			"web/0 | 2024-01-02T14:59:02Z unterminated\n",
+		}))
+	})
+
+	It("only includes lines matching grep", func() {
+		opts.Grep = regexp.MustCompile("ERR")
+
+		writer := NewMergingWriter(ui, timeService, opts)
+		inst := writer.ForInstance("web", "0")
+
+		write(inst, "2024-01-02T14:59:01Z INFO ok\n2024-01-02T14:59:02Z ERR failed\n")
+		writer.Flush()
+
+		Expect(ui.Blocks).To(Equal([]string{"web/0 | 2024-01-02T14:59:02Z ERR failed\n"}))
+	})
+
+	It("only includes lines newer than since", func() {
+		opts.Since = 10 * time.Minute
+
+		writer := NewMergingWriter(ui, timeService, opts)
+		inst := writer.ForInstance("web", "0")
+
+		write(inst, "2024-01-02T14:49:00Z old\n  old continuation\n2024-01-02T14:51:00Z new\n")
+		writer.Flush()
+
+		Expect(ui.Blocks).To(Equal([]string{"web/0 | 2024-01-02T14:51:00Z new\n"}))
+	})
+
+	It("prints JSON lines if requested", func() {
+		opts.JSONLines = true
+
+		writer := NewMergingWriter(ui, timeService, opts)
+		inst := writer.ForInstance("web", "0")
+
+		_, err := inst.Stderr().Write([]byte(`{"timestamp":"1704207540.5","message":"hi"}` + "\n"))
+		Expect(err).ToNot(HaveOccurred())
+		writer.Flush()
+
+		Expect(ui.Blocks).To(HaveLen(1))
+		Expect(ui.Blocks[0]).To(MatchJSON(`{
+			"time": "2024-01-02T14:59:00.5Z",
+			"instance": "web/0",
+			"group": "web",
+			"id": "0",
+			"stream": "stderr",
+			"line": "{\"timestamp\":\"1704207540.5\",\"message\":\"hi\"}"
+		}`))
+	})
+})
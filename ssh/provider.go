@@ -14,6 +14,8 @@ type Provider struct {
 	streamingSSH ComboRunner
 	resultsSSH   ComboRunner
 	scp          ComboRunner
+
+	newSSHRunner func(Writer) ComboRunner
 }
 
 func NewProvider(cmdRunner boshsys.CmdRunner, fs boshsys.FileSystem, ui boshui.UI, logger boshlog.Logger) Provider {
@@ -23,11 +25,13 @@ func NewProvider(cmdRunner boshsys.CmdRunner, fs boshsys.FileSystem, ui boshui.U
 
 	streamingWriter := NewStreamingWriter(boshui.NewComboWriter(ui))
 
-	streamingSSH := NewComboRunner(
-		cmdRunner, sshSessionFactory, signal.Notify, streamingWriter, fs, ui, logger)
+	newSSHRunner := func(writer Writer) ComboRunner {
+		return NewComboRunner(cmdRunner, sshSessionFactory, signal.Notify, writer, fs, ui, logger)
+	}
+
+	streamingSSH := newSSHRunner(streamingWriter)
 
-	resultsSSH := NewComboRunner(
-		cmdRunner, sshSessionFactory, signal.Notify, NewResultsWriter(ui), fs, ui, logger)
+	resultsSSH := newSSHRunner(NewResultsWriter(ui))
 
 	scpSessionFactory := func(connOpts ConnectionOpts, result boshdir.SSHResult) Session {
 		return NewSessionImpl(connOpts, SessionImplOpts{}, result, fs)
@@ -35,7 +39,13 @@ func NewProvider(cmdRunner boshsys.CmdRunner, fs boshsys.FileSystem, ui boshui.U
 
 	scp := NewComboRunner(cmdRunner, scpSessionFactory, signal.Notify, streamingWriter, fs, ui, logger)
 
-	return Provider{streamingSSH: streamingSSH, resultsSSH: resultsSSH, scp: scp}
+	return Provider{
+		streamingSSH: streamingSSH,
+		resultsSSH:   resultsSSH,
+		scp:          scp,
+
+		newSSHRunner: newSSHRunner,
+	}
 }
 
 func (p Provider) NewResultsSSHRunner(interactive bool) Runner {
@@ -49,4 +59,8 @@ func (p Provider) NewSSHRunner(interactive bool) Runner {
 	return NewNonInteractiveRunner(p.streamingSSH)
 }
 
+func (p Provider) NewMergedSSHRunner(writer *MergingWriter) Runner {
+	return NewNonInteractiveRunner(p.newSSHRunner(writer))
+}
+
 func (p Provider) NewSCPRunner() SCPRunner { return NewSCPRunner(p.scp) }